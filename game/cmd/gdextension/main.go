@@ -102,8 +102,14 @@ func advance_day() {
 }
 
 //export save_game
-func save_game() C.int {
-	err := gameManager.SaveGame(0)
+func save_game(profile *C.char) C.int {
+	name := C.GoString(profile)
+	var err error
+	if name == "" {
+		err = gameManager.SaveGame(0)
+	} else {
+		err = gameManager.SaveGameNamed(name)
+	}
 	if err != nil {
 		fmt.Printf("Failed to save game: %v\n", err)
 		return 0
@@ -113,14 +119,59 @@ func save_game() C.int {
 }
 
 //export load_game
-func load_game() C.int {
-	err := gameManager.LoadGame(0)
+func load_game(profile *C.char) C.int {
+	name := C.GoString(profile)
+	var err error
+	if name == "" {
+		err = gameManager.LoadGame(0)
+	} else {
+		err = gameManager.LoadGameNamed(name)
+	}
 	if err != nil {
 		fmt.Printf("Failed to load game: %v\n", err)
 		return 0
 	}
 
-	// Load game state from manager
+	syncLoadedState()
+	fmt.Println("Game loaded successfully")
+	return 1
+}
+
+//export list_save_profiles_json
+func list_save_profiles_json() *C.char {
+	saves, err := gameManager.ListNamedSaves()
+	if err != nil {
+		fmt.Printf("Failed to list save profiles: %v\n", err)
+	}
+	return C.CString(saves)
+}
+
+//export quick_save
+func quick_save() C.int {
+	err := gameManager.QuickSave()
+	if err != nil {
+		fmt.Printf("Failed to quick save: %v\n", err)
+		return 0
+	}
+	fmt.Println("Quick saved")
+	return 1
+}
+
+//export quick_load
+func quick_load() C.int {
+	err := gameManager.QuickLoad()
+	if err != nil {
+		fmt.Printf("Failed to quick load: %v\n", err)
+		return 0
+	}
+
+	syncLoadedState()
+	fmt.Println("Quick save loaded")
+	return 1
+}
+
+// syncLoadedState copies gold and day from a freshly loaded game
+func syncLoadedState() {
 	if stateJSON, err := gameManager.GetGameState(); err == nil {
 		var state map[string]interface{}
 		if err := json.Unmarshal([]byte(stateJSON), &state); err == nil {
@@ -132,9 +183,6 @@ func load_game() C.int {
 			}
 		}
 	}
-
-	fmt.Println("Game loaded successfully")
-	return 1
 }
 
 //export buy_item
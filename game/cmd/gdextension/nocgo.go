@@ -21,8 +21,11 @@ func start_new_game(playerName *byte) int        { return 0 }
 func get_player_gold() float64                   { return 0 }
 func get_current_day() int32                     { return 0 }
 func advance_day()                               {}
-func save_game() int                             { return 0 }
-func load_game() int                             { return 0 }
+func save_game(profile *byte) int                { return 0 }
+func load_game(profile *byte) int                { return 0 }
+func list_save_profiles_json() *byte             { return nil }
+func quick_save() int                            { return 0 }
+func quick_load() int                            { return 0 }
 func buy_item(itemID *byte, quantity int32) int  { return 0 }
 func sell_item(itemID *byte, quantity int32) int { return 0 }
 func get_market_price(itemID *byte) float64      { return 0 }
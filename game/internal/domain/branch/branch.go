@@ -0,0 +1,330 @@
+// Package branch runs the extra shops a merchant opens once the first one
+// prospers. Each branch has its own stock and capacity and trades at its
+// location's local prices.
+package branch
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/inventory"
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// Branch network defaults
+const (
+	MaxBranches       = 3   // Branches beyond the main shop
+	ShopCapacity      = 10  // Shop space of a new branch
+	WarehouseCapacity = 50  // Warehouse space of a new branch
+	SpecialtyBonus    = 1.1 // Price multiplier on a branch's specialty category
+)
+
+var (
+	// ErrUnknownBranch is returned for a branch ID that is not open
+	ErrUnknownBranch = errors.New("unknown branch")
+	// ErrUnknownLocation is returned for a location branches cannot open in
+	ErrUnknownLocation = errors.New("unknown location")
+	// ErrTooManyBranches is returned once MaxBranches are open
+	ErrTooManyBranches = fmt.Errorf("cannot run more than %d branches", MaxBranches)
+)
+
+// Location is a town a branch can open in. Local prices differ from the
+// main market's by the location's price modifier.
+type Location struct {
+	ID            string
+	Name          string
+	PriceModifier float64
+	OpeningCost   int
+}
+
+var locations = []Location{
+	{ID: "village", Name: "Hillside Village", PriceModifier: 0.9, OpeningCost: 2000},
+	{ID: "harbor", Name: "Harbor Town", PriceModifier: 1.0, OpeningCost: 4000},
+	{ID: "capital", Name: "Royal Capital", PriceModifier: 1.2, OpeningCost: 8000},
+}
+
+// Locations returns every location a branch can open in, cheapest first
+func Locations() []Location {
+	return append([]Location(nil), locations...)
+}
+
+// GetLocation returns a location by ID
+func GetLocation(id string) (Location, error) {
+	for _, location := range locations {
+		if location.ID == id {
+			return location, nil
+		}
+	}
+	return Location{}, fmt.Errorf("%w: %s", ErrUnknownLocation, id)
+}
+
+func isCategory(category item.Category) bool {
+	for _, known := range item.AllCategories() {
+		if known == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Branch is a shop beyond the main one
+type Branch struct {
+	ID        string
+	Name      string
+	Location  Location
+	Specialty item.Category // Empty for a general store
+	OpenedDay int
+	Inventory *inventory.InventoryManager
+}
+
+// PriceModifier returns the multiplier on market prices for a category sold
+// at this branch: the location's modifier, plus the specialty bonus
+func (b *Branch) PriceModifier(category item.Category) float64 {
+	modifier := b.Location.PriceModifier
+	if b.Specialty != "" && category == b.Specialty {
+		modifier *= SpecialtyBonus
+	}
+	return modifier
+}
+
+// SortedStock returns a branch's shop and warehouse item IDs in order
+func (b *Branch) SortedStock() []string {
+	seen := make(map[string]bool)
+	for _, stock := range []map[string]int{b.Inventory.ShopInventory.GetAll(), b.Inventory.WarehouseInventory.GetAll()} {
+		for itemID := range stock {
+			seen[itemID] = true
+		}
+	}
+	itemIDs := make([]string, 0, len(seen))
+	for itemID := range seen {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+	return itemIDs
+}
+
+// Network is every branch the player has opened, in opening order
+type Network struct {
+	branches []*Branch
+	nextID   int
+	mu       sync.RWMutex
+}
+
+// NewNetwork creates a network with no branches
+func NewNetwork() *Network {
+	return &Network{
+		branches: make([]*Branch, 0),
+		nextID:   1,
+	}
+}
+
+// Open opens a branch at a location, optionally specializing in a category
+func (n *Network) Open(name, locationID string, specialty item.Category, day int) (*Branch, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("branch needs a name")
+	}
+	location, err := GetLocation(locationID)
+	if err != nil {
+		return nil, err
+	}
+	if specialty != "" && !isCategory(specialty) {
+		return nil, fmt.Errorf("unknown specialty: %s", specialty)
+	}
+	stock, err := inventory.NewInventoryManager(ShopCapacity, WarehouseCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.branches) >= MaxBranches {
+		return nil, ErrTooManyBranches
+	}
+
+	b := &Branch{
+		ID:        fmt.Sprintf("branch-%d", n.nextID),
+		Name:      name,
+		Location:  location,
+		Specialty: specialty,
+		OpenedDay: day,
+		Inventory: stock,
+	}
+	n.nextID++
+	n.branches = append(n.branches, b)
+	return b, nil
+}
+
+// Get returns an open branch by ID
+func (n *Network) Get(id string) (*Branch, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, b := range n.branches {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBranch, id)
+}
+
+// All returns every open branch in opening order
+func (n *Network) All() []*Branch {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return append([]*Branch(nil), n.branches...)
+}
+
+// Count returns how many branches are open
+func (n *Network) Count() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.branches)
+}
+
+// Reset closes every branch
+func (n *Network) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.branches = make([]*Branch, 0)
+	n.nextID = 1
+}
+
+// Snapshot is a copy of the network, including each branch's stock
+type Snapshot struct {
+	branches []branchCopy
+	nextID   int
+}
+
+type branchCopy struct {
+	branch            Branch
+	stock             *inventory.InventorySnapshot
+	shopCapacity      int
+	warehouseCapacity int
+}
+
+// CreateSnapshot copies every branch and its stock
+func (n *Network) CreateSnapshot() *Snapshot {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	snapshot := &Snapshot{nextID: n.nextID}
+	for _, b := range n.branches {
+		snapshot.branches = append(snapshot.branches, branchCopy{
+			branch:            *b,
+			stock:             b.Inventory.CreateSnapshot(),
+			shopCapacity:      b.Inventory.ShopCapacity,
+			warehouseCapacity: b.Inventory.WarehouseCapacity,
+		})
+	}
+	return snapshot
+}
+
+// RestoreFromSnapshot reopens the snapshot's branches with their stock,
+// closing any opened since
+func (n *Network) RestoreFromSnapshot(snapshot *Snapshot) error {
+	branches := make([]*Branch, 0, len(snapshot.branches))
+	for _, copied := range snapshot.branches {
+		stock, err := inventory.NewInventoryManager(copied.shopCapacity, copied.warehouseCapacity)
+		if err != nil {
+			return err
+		}
+		if err := stock.RestoreFromSnapshot(copied.stock); err != nil {
+			return err
+		}
+		b := copied.branch
+		b.Inventory = stock
+		branches = append(branches, &b)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.branches = branches
+	n.nextID = snapshot.nextID
+	return nil
+}
+
+// Record is a branch as kept in a save
+type Record struct {
+	ID                string                  `json:"id"`
+	Name              string                  `json:"name"`
+	Location          string                  `json:"location"`
+	Specialty         item.Category           `json:"specialty,omitempty"`
+	OpenedDay         int                     `json:"openedDay"`
+	ShopCapacity      int                     `json:"shopCapacity"`
+	WarehouseCapacity int                     `json:"warehouseCapacity"`
+	Stock             []inventory.StockRecord `json:"stock"`
+}
+
+// NetworkRecord is the branch network as kept in a save
+type NetworkRecord struct {
+	Branches []Record `json:"branches"`
+	NextID   int      `json:"nextId"`
+}
+
+// Record returns every branch and its stock for a save
+func (n *Network) Record() NetworkRecord {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	record := NetworkRecord{Branches: make([]Record, 0, len(n.branches)), NextID: n.nextID}
+	for _, b := range n.branches {
+		record.Branches = append(record.Branches, Record{
+			ID:                b.ID,
+			Name:              b.Name,
+			Location:          b.Location.ID,
+			Specialty:         b.Specialty,
+			OpenedDay:         b.OpenedDay,
+			ShopCapacity:      b.Inventory.ShopCapacity,
+			WarehouseCapacity: b.Inventory.WarehouseCapacity,
+			Stock:             b.Inventory.StockRecords(),
+		})
+	}
+	return record
+}
+
+// RestoreRecord reopens the branches in a save with their stock, replacing
+// any open now. Stock that no longer fits is reported but the rest is kept.
+func (n *Network) RestoreRecord(record NetworkRecord) error {
+	branches := make([]*Branch, 0, len(record.Branches))
+	var errs []error
+	for _, saved := range record.Branches {
+		location, err := GetLocation(saved.Location)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		shopCapacity, warehouseCapacity := saved.ShopCapacity, saved.WarehouseCapacity
+		if shopCapacity <= 0 || warehouseCapacity <= 0 {
+			shopCapacity, warehouseCapacity = ShopCapacity, WarehouseCapacity
+		}
+		stock, err := inventory.NewInventoryManager(shopCapacity, warehouseCapacity)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, lot := range saved.Stock {
+			if err := stock.RestoreStock(lot); err != nil {
+				errs = append(errs, fmt.Errorf("branch %s: %w", saved.ID, err))
+			}
+		}
+		branches = append(branches, &Branch{
+			ID:        saved.ID,
+			Name:      saved.Name,
+			Location:  location,
+			Specialty: saved.Specialty,
+			OpenedDay: saved.OpenedDay,
+			Inventory: stock,
+		})
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.branches = branches
+	n.nextID = max(record.NextID, 1)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package branch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+func TestNetwork_Open(t *testing.T) {
+	n := NewNetwork()
+
+	_, err := n.Open("Nowhere", "atlantis", "", 1)
+	assert.ErrorIs(t, err, ErrUnknownLocation)
+	_, err = n.Open("  ", "village", "", 1)
+	assert.Error(t, err)
+	_, err = n.Open("Odd Shop", "village", item.Category("SHOES"), 1)
+	assert.ErrorContains(t, err, "unknown specialty")
+
+	smithy, err := n.Open("Capital Smithy", "capital", item.CategoryWeapon, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "branch-1", smithy.ID)
+	assert.Equal(t, 4, smithy.OpenedDay)
+	assert.Equal(t, ShopCapacity, smithy.Inventory.ShopCapacity)
+
+	// Local prices carry the location's modifier, and more for the specialty
+	assert.InDelta(t, 1.2, smithy.PriceModifier(item.CategoryFruit), 1e-9)
+	assert.InDelta(t, 1.2*SpecialtyBonus, smithy.PriceModifier(item.CategoryWeapon), 1e-9)
+
+	for len(n.All()) < MaxBranches {
+		_, err := n.Open("Stall", "village", "", 5)
+		require.NoError(t, err)
+	}
+	_, err = n.Open("One Too Many", "harbor", "", 6)
+	assert.ErrorIs(t, err, ErrTooManyBranches)
+
+	found, err := n.Get(smithy.ID)
+	require.NoError(t, err)
+	assert.True(t, smithy == found, "Get returned a different branch")
+	_, err = n.Get("branch-99")
+	assert.ErrorIs(t, err, ErrUnknownBranch)
+
+	n.Reset()
+	assert.Zero(t, n.Count())
+}
+
+func TestNetwork_Snapshot(t *testing.T) {
+	n := NewNetwork()
+	stall, err := n.Open("Stall", "village", item.CategoryFruit, 1)
+	require.NoError(t, err)
+	require.NoError(t, stall.Inventory.AddToWarehouseByID("apple", 10, 8))
+	snapshot := n.CreateSnapshot()
+
+	require.NoError(t, stall.Inventory.TransferToShop("apple", 4))
+	_, err = n.Open("Later", "harbor", "", 2)
+	require.NoError(t, err)
+
+	require.NoError(t, n.RestoreFromSnapshot(snapshot))
+	require.Equal(t, 1, n.Count())
+	restored, err := n.Get(stall.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, restored.Inventory.GetWarehouseQuantity("apple"))
+	assert.Zero(t, restored.Inventory.GetShopQuantity("apple"))
+	assert.Equal(t, 8, restored.Inventory.GetPurchasePrice("apple"))
+
+	// Branch IDs keep counting from where the snapshot left off
+	next, err := n.Open("Again", "harbor", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "branch-2", next.ID)
+}
+
+func TestNetwork_Record(t *testing.T) {
+	n := NewNetwork()
+	stall, err := n.Open("Stall", "village", item.CategoryFruit, 3)
+	require.NoError(t, err)
+	require.NoError(t, stall.Inventory.AddToWarehouseByID("apple", 10, 8))
+	require.NoError(t, stall.Inventory.TransferToShop("apple", 4))
+
+	restored := NewNetwork()
+	require.NoError(t, restored.RestoreRecord(n.Record()))
+	require.Equal(t, 1, restored.Count())
+	b, err := restored.Get(stall.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Stall", b.Name)
+	assert.Equal(t, "village", b.Location.ID)
+	assert.Equal(t, item.CategoryFruit, b.Specialty)
+	assert.Equal(t, 3, b.OpenedDay)
+	assert.Equal(t, 6, b.Inventory.GetWarehouseQuantity("apple"))
+	assert.Equal(t, 4, b.Inventory.GetShopQuantity("apple"))
+	assert.Equal(t, 8, b.Inventory.GetPurchasePrice("apple"))
+
+	next, err := restored.Open("Again", "harbor", "", 4)
+	require.NoError(t, err)
+	assert.Equal(t, "branch-2", next.ID)
+
+	// A branch in a town that no longer exists is dropped
+	record := n.Record()
+	record.Branches[0].Location = "atlantis"
+	assert.ErrorIs(t, restored.RestoreRecord(record), ErrUnknownLocation)
+	assert.Zero(t, restored.Count())
+}
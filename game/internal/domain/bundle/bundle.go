@@ -0,0 +1,362 @@
+// Package bundle keeps the products a merchant assembles from their own
+// stock, such as an adventurer's kit of sword, potion and shield, and sells
+// as a single item at a markup over what the components cost.
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MinComponentUnits is the fewest item units a bundle can hold
+const MinComponentUnits = 2
+
+var (
+	// ErrUnknownBundle is returned for a bundle ID that does not exist
+	ErrUnknownBundle = errors.New("unknown bundle")
+	// ErrNotAssembled is returned when fewer bundles are assembled than asked for
+	ErrNotAssembled = errors.New("not enough bundles assembled")
+)
+
+// Component is one item that goes into a bundle
+type Component struct {
+	ItemID   string `json:"itemId"`
+	Quantity int    `json:"quantity"` // Units per bundle
+}
+
+// Bundle is a product made of several items, sold as one
+type Bundle struct {
+	ID         string
+	Name       string
+	Components []Component // Sorted by item ID
+	Price      int         // Asking price of one bundle
+	Assembled  int         // Bundles ready to sell
+	CreatedDay int
+
+	// Purchase cost of the components held in assembled bundles, per item
+	componentCost map[string]int
+}
+
+// UnitCost returns what the components of one assembled bundle cost on
+// average, or zero when none are assembled
+func (b *Bundle) UnitCost() int {
+	if b.Assembled == 0 {
+		return 0
+	}
+	total := 0
+	for _, cost := range b.componentCost {
+		total += cost
+	}
+	return total / b.Assembled
+}
+
+// Markup returns the bundle's price over its unit cost as a fraction, or
+// zero when none are assembled
+func (b *Bundle) Markup() float64 {
+	cost := b.UnitCost()
+	if cost <= 0 {
+		return 0
+	}
+	return float64(b.Price-cost) / float64(cost)
+}
+
+// Catalog is every bundle the player has created, in creation order
+type Catalog struct {
+	bundles []*Bundle
+	nextID  int
+	mu      sync.RWMutex
+}
+
+// NewCatalog creates a catalog with no bundles
+func NewCatalog() *Catalog {
+	return &Catalog{
+		bundles: make([]*Bundle, 0),
+		nextID:  1,
+	}
+}
+
+// Create adds a bundle of the given item quantities and returns a copy of
+// it. Nothing is assembled until Assemble is called.
+func (c *Catalog) Create(name string, components map[string]int, price, day int) (Bundle, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Bundle{}, errors.New("bundle needs a name")
+	}
+	if price <= 0 {
+		return Bundle{}, errors.New("bundle price must be positive")
+	}
+
+	parts := make([]Component, 0, len(components))
+	units := 0
+	for itemID, quantity := range components {
+		if quantity <= 0 {
+			return Bundle{}, fmt.Errorf("invalid quantity %d of %s", quantity, itemID)
+		}
+		parts = append(parts, Component{ItemID: itemID, Quantity: quantity})
+		units += quantity
+	}
+	if units < MinComponentUnits {
+		return Bundle{}, fmt.Errorf("bundle needs at least %d item units", MinComponentUnits)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].ItemID < parts[j].ItemID })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, existing := range c.bundles {
+		if strings.EqualFold(existing.Name, name) {
+			return Bundle{}, fmt.Errorf("a bundle named %q already exists", existing.Name)
+		}
+	}
+
+	b := &Bundle{
+		ID:            fmt.Sprintf("bundle-%d", c.nextID),
+		Name:          name,
+		Components:    parts,
+		Price:         price,
+		CreatedDay:    day,
+		componentCost: make(map[string]int),
+	}
+	c.nextID++
+	c.bundles = append(c.bundles, b)
+	return copyBundle(b), nil
+}
+
+// Assemble records quantity more bundles as ready to sell. unitCosts is the
+// purchase price of one unit of each component item.
+func (c *Catalog) Assemble(id string, quantity int, unitCosts map[string]int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("invalid quantity: %d", quantity)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.getUnsafe(id)
+	if err != nil {
+		return err
+	}
+	for _, part := range b.Components {
+		b.componentCost[part.ItemID] += unitCosts[part.ItemID] * part.Quantity * quantity
+	}
+	b.Assembled += quantity
+	return nil
+}
+
+// Take removes quantity assembled bundles, to be sold or taken apart, and
+// returns the purchase cost of their components per item
+func (c *Catalog) Take(id string, quantity int) (map[string]int, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("invalid quantity: %d", quantity)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.getUnsafe(id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Assembled < quantity {
+		return nil, fmt.Errorf("%w: %s has %d", ErrNotAssembled, b.Name, b.Assembled)
+	}
+
+	costs := make(map[string]int, len(b.Components))
+	for itemID, total := range b.componentCost {
+		cost := total * quantity / b.Assembled
+		costs[itemID] = cost
+		b.componentCost[itemID] -= cost
+	}
+	b.Assembled -= quantity
+	return costs, nil
+}
+
+// Restore puts back quantity bundles taken with Take, with the component
+// costs Take returned, as when taking them apart fails
+func (c *Catalog) Restore(id string, quantity int, costs map[string]int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("invalid quantity: %d", quantity)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.getUnsafe(id)
+	if err != nil {
+		return err
+	}
+	for itemID, cost := range costs {
+		b.componentCost[itemID] += cost
+	}
+	b.Assembled += quantity
+	return nil
+}
+
+// Remove deletes a bundle. Assembled bundles must be sold or taken apart
+// first.
+func (c *Catalog) Remove(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, b := range c.bundles {
+		if b.ID != id {
+			continue
+		}
+		if b.Assembled > 0 {
+			return fmt.Errorf("%s still has %d bundles assembled", b.Name, b.Assembled)
+		}
+		c.bundles = append(c.bundles[:i], c.bundles[i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownBundle, id)
+}
+
+// Get returns a copy of a bundle by ID
+func (c *Catalog) Get(id string) (Bundle, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b, err := c.getUnsafe(id)
+	if err != nil {
+		return Bundle{}, err
+	}
+	return copyBundle(b), nil
+}
+
+// All returns copies of every bundle in creation order
+func (c *Catalog) All() []Bundle {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	bundles := make([]Bundle, 0, len(c.bundles))
+	for _, b := range c.bundles {
+		bundles = append(bundles, copyBundle(b))
+	}
+	return bundles
+}
+
+// Reset removes every bundle
+func (c *Catalog) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bundles = make([]*Bundle, 0)
+	c.nextID = 1
+}
+
+// Record is a bundle as kept in a save, with what the components held in
+// its assembled bundles cost
+type Record struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Components    []Component    `json:"components"`
+	Price         int            `json:"price"`
+	Assembled     int            `json:"assembled"`
+	CreatedDay    int            `json:"createdDay"`
+	ComponentCost map[string]int `json:"componentCost,omitempty"`
+}
+
+// CatalogRecord is the catalog as kept in a save
+type CatalogRecord struct {
+	Bundles []Record `json:"bundles"`
+	NextID  int      `json:"nextId"`
+}
+
+// Record returns every bundle, with the components held in it, for a save
+func (c *Catalog) Record() CatalogRecord {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	record := CatalogRecord{Bundles: make([]Record, 0, len(c.bundles)), NextID: c.nextID}
+	for _, b := range c.bundles {
+		copied := copyBundle(b)
+		record.Bundles = append(record.Bundles, Record{
+			ID:            copied.ID,
+			Name:          copied.Name,
+			Components:    copied.Components,
+			Price:         copied.Price,
+			Assembled:     copied.Assembled,
+			CreatedDay:    copied.CreatedDay,
+			ComponentCost: copied.componentCost,
+		})
+	}
+	return record
+}
+
+// RestoreRecord replaces every bundle with those of a saved catalog
+func (c *Catalog) RestoreRecord(record CatalogRecord) {
+	bundles := make([]*Bundle, 0, len(record.Bundles))
+	for _, saved := range record.Bundles {
+		b := &Bundle{
+			ID:            saved.ID,
+			Name:          saved.Name,
+			Components:    saved.Components,
+			Price:         saved.Price,
+			Assembled:     saved.Assembled,
+			CreatedDay:    saved.CreatedDay,
+			componentCost: saved.ComponentCost,
+		}
+		copied := copyBundle(b)
+		bundles = append(bundles, &copied)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bundles = bundles
+	c.nextID = max(record.NextID, 1)
+}
+
+func (c *Catalog) getUnsafe(id string) (*Bundle, error) {
+	for _, b := range c.bundles {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBundle, id)
+}
+
+// copyBundle returns a bundle sharing nothing with the original
+func copyBundle(b *Bundle) Bundle {
+	copied := *b
+	copied.Components = append([]Component(nil), b.Components...)
+	copied.componentCost = make(map[string]int, len(b.componentCost))
+	for itemID, cost := range b.componentCost {
+		copied.componentCost[itemID] = cost
+	}
+	return copied
+}
+
+// Snapshot is a copy of the catalog, including assembled bundles
+type Snapshot struct {
+	bundles []Bundle
+	nextID  int
+}
+
+// CreateSnapshot copies every bundle
+func (c *Catalog) CreateSnapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot := &Snapshot{nextID: c.nextID}
+	for _, b := range c.bundles {
+		snapshot.bundles = append(snapshot.bundles, copyBundle(b))
+	}
+	return snapshot
+}
+
+// RestoreFromSnapshot brings back the snapshot's bundles, removing any
+// created since
+func (c *Catalog) RestoreFromSnapshot(snapshot *Snapshot) {
+	bundles := make([]*Bundle, 0, len(snapshot.bundles))
+	for i := range snapshot.bundles {
+		copied := copyBundle(&snapshot.bundles[i])
+		bundles = append(bundles, &copied)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bundles = bundles
+	c.nextID = snapshot.nextID
+}
@@ -0,0 +1,119 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog_Create(t *testing.T) {
+	c := NewCatalog()
+
+	_, err := c.Create(" ", map[string]int{"iron_sword": 1, "health_potion": 1}, 100, 1)
+	assert.Error(t, err)
+	_, err = c.Create("Kit", map[string]int{"iron_sword": 1}, 100, 1)
+	assert.ErrorContains(t, err, "at least")
+	_, err = c.Create("Kit", map[string]int{"iron_sword": 1, "health_potion": 0}, 100, 1)
+	assert.Error(t, err)
+	_, err = c.Create("Kit", map[string]int{"iron_sword": 1, "health_potion": 1}, 0, 1)
+	assert.Error(t, err)
+
+	kit, err := c.Create("Adventurer's Kit", map[string]int{"iron_sword": 1, "health_potion": 2}, 150, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "bundle-1", kit.ID)
+	assert.Equal(t, []Component{{ItemID: "health_potion", Quantity: 2}, {ItemID: "iron_sword", Quantity: 1}}, kit.Components)
+	assert.Equal(t, 3, kit.CreatedDay)
+	assert.Zero(t, kit.Assembled)
+
+	_, err = c.Create("adventurer's kit", map[string]int{"apple": 2}, 20, 3)
+	assert.ErrorContains(t, err, "already exists")
+
+	_, err = c.Get("bundle-9")
+	assert.ErrorIs(t, err, ErrUnknownBundle)
+}
+
+func TestCatalog_AssembleAndTake(t *testing.T) {
+	c := NewCatalog()
+	kit, err := c.Create("Kit", map[string]int{"iron_sword": 1, "health_potion": 2}, 150, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Assemble(kit.ID, 2, map[string]int{"iron_sword": 80, "health_potion": 10}))
+	require.NoError(t, c.Assemble(kit.ID, 2, map[string]int{"iron_sword": 60, "health_potion": 10}))
+	kit, err = c.Get(kit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, kit.Assembled)
+	assert.Equal(t, 90, kit.UnitCost())
+	assert.InDelta(t, 60.0/90.0, kit.Markup(), 1e-9)
+
+	costs, err := c.Take(kit.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"iron_sword": 70, "health_potion": 20}, costs)
+
+	// Bundles put back keep exactly what their components cost
+	require.NoError(t, c.Restore(kit.ID, 1, costs))
+	kit, err = c.Get(kit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, kit.Assembled)
+	assert.Equal(t, 90, kit.UnitCost())
+	costs, err = c.Take(kit.ID, 1)
+	require.NoError(t, err)
+	assert.Error(t, c.Restore(kit.ID, 0, costs))
+
+	_, err = c.Take(kit.ID, 4)
+	assert.ErrorIs(t, err, ErrNotAssembled)
+	assert.Error(t, c.Remove(kit.ID))
+
+	_, err = c.Take(kit.ID, 3)
+	require.NoError(t, err)
+	kit, err = c.Get(kit.ID)
+	require.NoError(t, err)
+	assert.Zero(t, kit.UnitCost())
+
+	require.NoError(t, c.Remove(kit.ID))
+	assert.Empty(t, c.All())
+	assert.ErrorIs(t, c.Remove(kit.ID), ErrUnknownBundle)
+}
+
+func TestCatalog_Snapshot(t *testing.T) {
+	c := NewCatalog()
+	kit, err := c.Create("Kit", map[string]int{"iron_sword": 1, "health_potion": 1}, 150, 1)
+	require.NoError(t, err)
+	require.NoError(t, c.Assemble(kit.ID, 1, map[string]int{"iron_sword": 80, "health_potion": 10}))
+	snapshot := c.CreateSnapshot()
+
+	_, err = c.Take(kit.ID, 1)
+	require.NoError(t, err)
+	_, err = c.Create("Later", map[string]int{"apple": 2}, 20, 2)
+	require.NoError(t, err)
+
+	c.RestoreFromSnapshot(snapshot)
+	bundles := c.All()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, 1, bundles[0].Assembled)
+	assert.Equal(t, 90, bundles[0].UnitCost())
+
+	later, err := c.Create("Later", map[string]int{"apple": 2}, 20, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "bundle-2", later.ID)
+}
+
+func TestCatalog_Record(t *testing.T) {
+	c := NewCatalog()
+	kit, err := c.Create("Kit", map[string]int{"iron_sword": 1, "health_potion": 1}, 150, 1)
+	require.NoError(t, err)
+	require.NoError(t, c.Assemble(kit.ID, 2, map[string]int{"iron_sword": 80, "health_potion": 10}))
+
+	restored := NewCatalog()
+	restored.RestoreRecord(c.Record())
+	assert.Equal(t, c.All(), restored.All())
+
+	// Taking bundles apart returns what the components cost
+	costs, err := restored.Take(kit.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"iron_sword": 80, "health_potion": 10}, costs)
+
+	later, err := restored.Create("Later", map[string]int{"apple": 2}, 20, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "bundle-2", later.ID)
+}
@@ -0,0 +1,74 @@
+package difficulty
+
+import "fmt"
+
+// RampStage is one step of the difficulty ramp. A stage is reached once the
+// player's net worth or rank gets to its threshold, whichever comes first.
+type RampStage struct {
+	Name       string
+	NetWorth   int     // Net worth in gold that reaches this stage
+	Rank       int     // Player rank that reaches this stage; zero leaves rank out
+	Volatility float64 // Multiplier on random market price swings
+	Events     float64 // Multiplier on the odds of market shocks and hazards
+}
+
+// Ramp raises difficulty as the player prospers, layered on top of the base
+// difficulty setting. Stages are ordered from easiest to hardest and the
+// first one is where every game starts.
+type Ramp []RampStage
+
+// DefaultRamp returns the standard ramp: unchanged early on, then steadily
+// wilder markets and more frequent trouble for wealthy merchants
+func DefaultRamp() Ramp {
+	return Ramp{
+		{Name: "steady", NetWorth: 0, Rank: 0, Volatility: 1.0, Events: 1.0},
+		{Name: "rising", NetWorth: 5000, Rank: 1, Volatility: 1.25, Events: 1.25},
+		{Name: "cutthroat", NetWorth: 20000, Rank: 2, Volatility: 1.5, Events: 1.5},
+		{Name: "legendary", NetWorth: 50000, Rank: 3, Volatility: 2.0, Events: 1.75},
+	}
+}
+
+// Validate checks that the ramp starts from nothing and that each stage needs
+// more net worth than the one before
+func (r Ramp) Validate() error {
+	if len(r) == 0 {
+		return fmt.Errorf("difficulty ramp needs at least one stage")
+	}
+	if r[0].NetWorth != 0 {
+		return fmt.Errorf("first ramp stage must start at zero net worth")
+	}
+
+	for i, stage := range r {
+		if stage.Volatility <= 0 || stage.Events < 0 {
+			return fmt.Errorf("ramp stage %q has invalid multipliers", stage.Name)
+		}
+		if i == 0 {
+			continue
+		}
+		previous := r[i-1]
+		if stage.NetWorth <= previous.NetWorth {
+			return fmt.Errorf("ramp stage %q must come after %q", stage.Name, previous.Name)
+		}
+	}
+	return nil
+}
+
+// StageFor returns the index of the hardest stage the net worth or rank has
+// reached
+func (r Ramp) StageFor(netWorth, rank int) int {
+	reached := 0
+	for i, stage := range r {
+		if netWorth >= stage.NetWorth || (stage.Rank > 0 && rank >= stage.Rank) {
+			reached = i
+		}
+	}
+	return reached
+}
+
+// Stage returns a stage by index, or a neutral stage when out of range
+func (r Ramp) Stage(index int) RampStage {
+	if index < 0 || index >= len(r) {
+		return RampStage{Name: "base", Volatility: 1, Events: 1}
+	}
+	return r[index]
+}
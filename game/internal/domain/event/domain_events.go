@@ -12,6 +12,17 @@ const (
 	EventNameMerchantAction      = "merchant.action"
 	EventNameSeasonChanged       = "season.changed"
 	EventNameDayEnded            = "day.ended"
+
+	EventNameCircuitBreakerTripped   = "CircuitBreakerTripped"
+	EventNameCriticalGoldWarning     = "CriticalGoldWarning"
+	EventNameQuestAbandoned          = "QuestAbandoned"
+	EventNameDailyEventRolled        = "DailyEventRolled"
+	EventNameTimeUp                  = "TimeUp"
+	EventNameDifficultyRamped        = "DifficultyRamped"
+	EventNameItemSpoiled             = "ItemSpoiled"
+	EventNameOnboardingStepCompleted = "OnboardingStepCompleted"
+
+	EventNameDisplaySettingsChanged = "DisplaySettingsChanged"
 )
 
 // BaseEvent provides common fields for all events
@@ -64,6 +75,7 @@ type PriceUpdatedEvent struct {
 	ItemID   string
 	OldPrice int
 	NewPrice int
+	Impact   float64 // Percent change from the old price
 	Reason   string
 }
 
@@ -74,10 +86,130 @@ func NewPriceUpdatedEvent(itemID string, oldPrice, newPrice int, reason string)
 		ItemID:    itemID,
 		OldPrice:  oldPrice,
 		NewPrice:  newPrice,
+		Impact:    priceImpact(oldPrice, newPrice),
 		Reason:    reason,
 	}
 }
 
+// priceImpact returns the percent change from oldPrice to newPrice
+func priceImpact(oldPrice, newPrice int) float64 {
+	if oldPrice <= 0 {
+		return 0
+	}
+	return float64(newPrice-oldPrice) / float64(oldPrice) * 100
+}
+
+// CircuitBreakerTrippedEvent is fired when a daily price move is capped
+type CircuitBreakerTrippedEvent struct {
+	*BaseEvent
+	ItemID      string
+	Day         int
+	OpenPrice   int
+	TargetPrice int
+	CappedPrice int
+}
+
+// NewCircuitBreakerTrippedEvent creates a new circuit breaker tripped event
+func NewCircuitBreakerTrippedEvent(itemID string, day, openPrice, targetPrice, cappedPrice int) *CircuitBreakerTrippedEvent {
+	return &CircuitBreakerTrippedEvent{
+		BaseEvent:   NewBaseEvent(EventNameCircuitBreakerTripped),
+		ItemID:      itemID,
+		Day:         day,
+		OpenPrice:   openPrice,
+		TargetPrice: targetPrice,
+		CappedPrice: cappedPrice,
+	}
+}
+
+// CriticalGoldWarningEvent is fired when gold drops below the warning threshold
+type CriticalGoldWarningEvent struct {
+	*BaseEvent
+	Gold      int
+	Threshold int
+	Paused    bool
+}
+
+// NewCriticalGoldWarningEvent creates a new critical gold warning event
+func NewCriticalGoldWarningEvent(gold, threshold int, paused bool) *CriticalGoldWarningEvent {
+	return &CriticalGoldWarningEvent{
+		BaseEvent: NewBaseEvent(EventNameCriticalGoldWarning),
+		Gold:      gold,
+		Threshold: threshold,
+		Paused:    paused,
+	}
+}
+
+// QuestAbandonedEvent is fired when the player gives up an active quest
+type QuestAbandonedEvent struct {
+	*BaseEvent
+	QuestID string
+}
+
+// NewQuestAbandonedEvent creates a new quest abandoned event
+func NewQuestAbandonedEvent(questID string) *QuestAbandonedEvent {
+	return &QuestAbandonedEvent{
+		BaseEvent: NewBaseEvent(EventNameQuestAbandoned),
+		QuestID:   questID,
+	}
+}
+
+// DailyEventRolledEvent is fired for each random event rolled at the start of a day
+type DailyEventRolledEvent struct {
+	*BaseEvent
+	Day       int
+	Kind      string
+	ItemID    string
+	Magnitude float64
+}
+
+// NewDailyEventRolledEvent creates a new daily event rolled event
+func NewDailyEventRolledEvent(day int, kind, itemID string, magnitude float64) *DailyEventRolledEvent {
+	return &DailyEventRolledEvent{
+		BaseEvent: NewBaseEvent(EventNameDailyEventRolled),
+		Day:       day,
+		Kind:      kind,
+		ItemID:    itemID,
+		Magnitude: magnitude,
+	}
+}
+
+// TimeUpEvent is fired when a timed challenge reaches its last day
+type TimeUpEvent struct {
+	*BaseEvent
+	Day     int
+	Summary map[string]interface{} // Final game summary
+}
+
+// NewTimeUpEvent creates a new time up event
+func NewTimeUpEvent(day int, summary map[string]interface{}) *TimeUpEvent {
+	return &TimeUpEvent{
+		BaseEvent: NewBaseEvent(EventNameTimeUp),
+		Day:       day,
+		Summary:   summary,
+	}
+}
+
+// DifficultyRampedEvent is fired when the player's success pushes the game
+// to a harder ramp stage
+type DifficultyRampedEvent struct {
+	*BaseEvent
+	Stage      int
+	StageName  string
+	Volatility float64 // Multiplier on random market price swings
+	Events     float64 // Multiplier on the odds of shocks and hazards
+}
+
+// NewDifficultyRampedEvent creates a new difficulty ramped event
+func NewDifficultyRampedEvent(stage int, name string, volatility, events float64) *DifficultyRampedEvent {
+	return &DifficultyRampedEvent{
+		BaseEvent:  NewBaseEvent(EventNameDifficultyRamped),
+		Stage:      stage,
+		StageName:  name,
+		Volatility: volatility,
+		Events:     events,
+	}
+}
+
 // MarketEventOccurredEvent is fired when a special market event occurs
 type MarketEventOccurredEvent struct {
 	*BaseEvent
@@ -197,3 +329,56 @@ func NewDayEndedEvent(dayNum, sales, purchases, profit int) *DayEndedEvent {
 		NetProfit:      profit,
 	}
 }
+
+// ItemSpoiledEvent is fired for each lot of stock that spoils and is thrown out
+type ItemSpoiledEvent struct {
+	*BaseEvent
+	ItemID   string
+	Quantity int
+	Location string // "shop" or "warehouse"
+	GoldLost int    // What the spoiled stock cost to buy
+}
+
+// NewItemSpoiledEvent creates a new item spoiled event
+func NewItemSpoiledEvent(itemID string, quantity int, location string, goldLost int) *ItemSpoiledEvent {
+	return &ItemSpoiledEvent{
+		BaseEvent: NewBaseEvent(EventNameItemSpoiled),
+		ItemID:    itemID,
+		Quantity:  quantity,
+		Location:  location,
+		GoldLost:  goldLost,
+	}
+}
+
+// OnboardingStepCompletedEvent is fired the first time a new player
+// completes an onboarding step
+type OnboardingStepCompletedEvent struct {
+	*BaseEvent
+	StepID string
+}
+
+// NewOnboardingStepCompletedEvent creates a new onboarding step completed event
+func NewOnboardingStepCompletedEvent(stepID string) *OnboardingStepCompletedEvent {
+	return &OnboardingStepCompletedEvent{
+		BaseEvent: NewBaseEvent(EventNameOnboardingStepCompleted),
+		StepID:    stepID,
+	}
+}
+
+// DisplaySettingsChangedEvent is fired when UI scale or resolution changes
+type DisplaySettingsChangedEvent struct {
+	*BaseEvent
+	UIScale float64
+	Width   int
+	Height  int
+}
+
+// NewDisplaySettingsChangedEvent creates a new display settings changed event
+func NewDisplaySettingsChangedEvent(uiScale float64, width, height int) *DisplaySettingsChangedEvent {
+	return &DisplaySettingsChangedEvent{
+		BaseEvent: NewBaseEvent(EventNameDisplaySettingsChanged),
+		UIScale:   uiScale,
+		Width:     width,
+		Height:    height,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 // Event is the base interface for all domain events
@@ -19,29 +20,40 @@ type Handler func(Event) error
 
 // EventBus manages event publishing and subscription
 type EventBus struct {
-	handlers map[string][]Handler
+	handlers map[string][]*Subscription
 	mu       sync.RWMutex
 }
 
+// Subscription is a handle to a registered handler, used to remove it again
+type Subscription struct {
+	bus       *EventBus
+	eventName string
+	handler   Handler
+	active    atomic.Bool
+}
+
 // NewEventBus creates a new event bus
 func NewEventBus() *EventBus {
 	return &EventBus{
-		handlers: make(map[string][]Handler),
+		handlers: make(map[string][]*Subscription),
 	}
 }
 
 // Subscribe registers a handler for an event type
-func (eb *EventBus) Subscribe(eventName string, handler Handler) {
+func (eb *EventBus) Subscribe(eventName string, handler Handler) *Subscription {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
 
-	eb.handlers[eventName] = append(eb.handlers[eventName], handler)
+	sub := &Subscription{bus: eb, eventName: eventName, handler: handler}
+	sub.active.Store(true)
+	eb.handlers[eventName] = append(eb.handlers[eventName], sub)
+	return sub
 }
 
 // SubscribeToType subscribes to events of a specific type using reflection
-func (eb *EventBus) SubscribeToType(eventType Event, handler Handler) {
+func (eb *EventBus) SubscribeToType(eventType Event, handler Handler) *Subscription {
 	eventName := reflect.TypeOf(eventType).String()
-	eb.Subscribe(eventName, handler)
+	return eb.Subscribe(eventName, handler)
 }
 
 // Unsubscribe removes all handlers for an event type
@@ -49,9 +61,40 @@ func (eb *EventBus) Unsubscribe(eventName string) {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
 
+	for _, sub := range eb.handlers[eventName] {
+		sub.active.Store(false)
+	}
 	delete(eb.handlers, eventName)
 }
 
+// Unsubscribe removes this handler from its event bus. It is safe to call
+// more than once and from inside a handler; once it returns the handler
+// will not be invoked again, even by a publish already in progress.
+func (s *Subscription) Unsubscribe() {
+	if !s.active.Swap(false) {
+		return
+	}
+
+	eb := s.bus
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	subs := eb.handlers[s.eventName]
+	for i, sub := range subs {
+		if sub == s {
+			// Build a new slice so in-flight publishes keep a consistent copy
+			remaining := make([]*Subscription, 0, len(subs)-1)
+			remaining = append(remaining, subs[:i]...)
+			remaining = append(remaining, subs[i+1:]...)
+			eb.handlers[s.eventName] = remaining
+			break
+		}
+	}
+	if len(eb.handlers[s.eventName]) == 0 {
+		delete(eb.handlers, s.eventName)
+	}
+}
+
 // Publish sends an event to all registered handlers
 func (eb *EventBus) Publish(event Event) error {
 	eb.mu.RLock()
@@ -63,12 +106,16 @@ func (eb *EventBus) Publish(event Event) error {
 	}
 
 	// Create a copy of handlers to avoid holding the lock during execution
-	handlersCopy := make([]Handler, len(handlers))
+	handlersCopy := make([]*Subscription, len(handlers))
 	copy(handlersCopy, handlers)
 
 	var errors []error
-	for _, handler := range handlersCopy {
-		if err := handler(event); err != nil {
+	for _, sub := range handlersCopy {
+		// Skip handlers unsubscribed after this publish started
+		if !sub.active.Load() {
+			continue
+		}
+		if err := sub.handler(event); err != nil {
 			errors = append(errors, err)
 		}
 	}
@@ -92,7 +139,12 @@ func (eb *EventBus) Clear() {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
 
-	eb.handlers = make(map[string][]Handler)
+	for _, subs := range eb.handlers {
+		for _, sub := range subs {
+			sub.active.Store(false)
+		}
+	}
+	eb.handlers = make(map[string][]*Subscription)
 }
 
 // HandlerCount returns the number of handlers for a specific event
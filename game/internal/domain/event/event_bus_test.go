@@ -90,6 +90,53 @@ func TestEventBus_Unsubscribe(t *testing.T) {
 	assert.Equal(t, 0, eb.HandlerCount("remove.event"))
 }
 
+func TestSubscription_Unsubscribe(t *testing.T) {
+	eb := NewEventBus()
+
+	var removedCalls, keptCalls int32
+	sub := eb.Subscribe("handle.event", func(e Event) error {
+		atomic.AddInt32(&removedCalls, 1)
+		return nil
+	})
+	eb.Subscribe("handle.event", func(e Event) error {
+		atomic.AddInt32(&keptCalls, 1)
+		return nil
+	})
+
+	event := &TestEvent{Name: "handle.event", Timestamp: time.Now().Unix()}
+	require.NoError(t, eb.Publish(event))
+
+	sub.Unsubscribe()
+	sub.Unsubscribe() // Second call is a no-op
+	assert.Equal(t, 1, eb.HandlerCount("handle.event"))
+
+	require.NoError(t, eb.Publish(event))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&removedCalls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&keptCalls))
+}
+
+func TestSubscription_UnsubscribeDuringDispatch(t *testing.T) {
+	eb := NewEventBus()
+
+	var secondCalls int32
+	var second *Subscription
+	eb.Subscribe("dispatch.event", func(e Event) error {
+		second.Unsubscribe()
+		return nil
+	})
+	second = eb.Subscribe("dispatch.event", func(e Event) error {
+		atomic.AddInt32(&secondCalls, 1)
+		return nil
+	})
+
+	event := &TestEvent{Name: "dispatch.event", Timestamp: time.Now().Unix()}
+	require.NoError(t, eb.Publish(event))
+	require.NoError(t, eb.Publish(event))
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&secondCalls))
+	assert.Equal(t, 1, eb.HandlerCount("dispatch.event"))
+}
+
 func TestEventBus_PublishWithError(t *testing.T) {
 	eb := NewEventBus()
 
@@ -26,8 +26,8 @@ func PublishAsync(event Event) {
 }
 
 // Subscribe subscribes to events on the global event bus
-func Subscribe(eventName string, handler Handler) {
-	GetGlobalEventBus().Subscribe(eventName, handler)
+func Subscribe(eventName string, handler Handler) *Subscription {
+	return GetGlobalEventBus().Subscribe(eventName, handler)
 }
 
 // Unsubscribe removes handlers from the global event bus
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// DailyEventKind is the kind of event a daily roll can produce
+type DailyEventKind string
+
+const (
+	DailyEventMarketShock DailyEventKind = "market_shock" // An item's price jumps or slumps for the day
+	DailyEventOpportunity DailyEventKind = "opportunity"  // A caravan offers an item below market price
+	DailyEventHazard      DailyEventKind = "hazard"       // A theft takes part of the shop's stock
+)
+
+// DailyEventOdds holds the chance of each kind of event happening on a day
+type DailyEventOdds struct {
+	MarketShock float64
+	Opportunity float64
+	Hazard      float64
+}
+
+// OddsForDifficulty returns the daily event odds for a difficulty setting.
+// Harder games see more shocks and hazards and fewer opportunities.
+func OddsForDifficulty(difficulty string) DailyEventOdds {
+	switch difficulty {
+	case "easy":
+		return DailyEventOdds{MarketShock: 0.05, Opportunity: 0.15, Hazard: 0.02}
+	case "hard", "expert":
+		return DailyEventOdds{MarketShock: 0.15, Opportunity: 0.05, Hazard: 0.08}
+	default:
+		return DailyEventOdds{MarketShock: 0.10, Opportunity: 0.10, Hazard: 0.05}
+	}
+}
+
+// DailyEvent is an event produced by a daily roll. Magnitude depends on the
+// kind: the fractional price move for a shock, the discount for an
+// opportunity, and the fraction of shop stock lost for a hazard.
+type DailyEvent struct {
+	Day       int
+	Kind      DailyEventKind
+	ItemID    string // Empty for hazards, which affect the whole shop
+	Magnitude float64
+}
+
+// DailyRoller rolls each day's random events. Rolls depend only on the seed
+// and the day, so a day always produces the same events for a given seed.
+type DailyRoller struct {
+	seed      int64
+	odds      DailyEventOdds
+	frequency float64 // Multiplier on the odds of shocks and hazards
+	mu        sync.RWMutex
+}
+
+// NewDailyRoller creates a roller with the given seed and odds
+func NewDailyRoller(seed int64, odds DailyEventOdds) *DailyRoller {
+	return &DailyRoller{seed: seed, odds: odds, frequency: 1}
+}
+
+// SetSeed changes the seed used for future rolls
+func (r *DailyRoller) SetSeed(seed int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seed = seed
+}
+
+// SetOdds changes the chance of each kind of event. Each must be within 0-1.
+func (r *DailyRoller) SetOdds(odds DailyEventOdds) error {
+	for _, chance := range []float64{odds.MarketShock, odds.Opportunity, odds.Hazard} {
+		if chance < 0 || chance > 1 {
+			return fmt.Errorf("event chance must be between 0 and 1: %v", chance)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.odds = odds
+	return nil
+}
+
+// GetOdds returns the current daily event odds, before the frequency
+// multiplier
+func (r *DailyRoller) GetOdds() DailyEventOdds {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.odds
+}
+
+// SetFrequency scales the odds of market shocks and hazards, leaving
+// opportunities as they are. Scaled odds never exceed certainty.
+func (r *DailyRoller) SetFrequency(multiplier float64) error {
+	if multiplier < 0 {
+		return fmt.Errorf("event frequency must not be negative: %v", multiplier)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frequency = multiplier
+	return nil
+}
+
+// GetFrequency returns the multiplier on the odds of shocks and hazards
+func (r *DailyRoller) GetFrequency() float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.frequency
+}
+
+// Roll returns the events for a day. Shocks and opportunities pick one of
+// itemIDs, so the same set of items must be passed for rolls to repeat.
+func (r *DailyRoller) Roll(day int, itemIDs []string) []DailyEvent {
+	r.mu.RLock()
+	seed, odds := r.seed, r.odds
+	odds.MarketShock = math.Min(1, odds.MarketShock*r.frequency)
+	odds.Hazard = math.Min(1, odds.Hazard*r.frequency)
+	r.mu.RUnlock()
+
+	candidates := make([]string, len(itemIDs))
+	copy(candidates, itemIDs)
+	sort.Strings(candidates)
+
+	rng := rand.New(rand.NewSource(seed*1_000_003 + int64(day))) //nolint:gosec // weak random is OK for game events
+	rolled := make([]DailyEvent, 0)
+
+	if rng.Float64() < odds.MarketShock && len(candidates) > 0 {
+		move := 0.10 + rng.Float64()*0.20
+		if rng.Intn(2) == 0 {
+			move = -move
+		}
+		rolled = append(rolled, DailyEvent{
+			Day:       day,
+			Kind:      DailyEventMarketShock,
+			ItemID:    candidates[rng.Intn(len(candidates))],
+			Magnitude: move,
+		})
+	}
+
+	if rng.Float64() < odds.Opportunity && len(candidates) > 0 {
+		rolled = append(rolled, DailyEvent{
+			Day:       day,
+			Kind:      DailyEventOpportunity,
+			ItemID:    candidates[rng.Intn(len(candidates))],
+			Magnitude: 0.20 + rng.Float64()*0.30,
+		})
+	}
+
+	if rng.Float64() < odds.Hazard {
+		rolled = append(rolled, DailyEvent{
+			Day:       day,
+			Kind:      DailyEventHazard,
+			Magnitude: 0.10 + rng.Float64()*0.20,
+		})
+	}
+
+	return rolled
+}
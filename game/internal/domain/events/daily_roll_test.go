@@ -0,0 +1,95 @@
+package events
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyRoller(t *testing.T) {
+	itemIDs := []string{"apple", "iron_sword", "health_potion", "ruby"}
+	odds := DailyEventOdds{MarketShock: 0.3, Opportunity: 0.3, Hazard: 0.2}
+
+	rollDays := func(roller *DailyRoller, ids []string) [][]DailyEvent {
+		days := make([][]DailyEvent, 0, 30)
+		for day := 1; day <= 30; day++ {
+			days = append(days, roller.Roll(day, ids))
+		}
+		return days
+	}
+
+	t.Run("same seed repeats the sequence", func(t *testing.T) {
+		first := rollDays(NewDailyRoller(42, odds), itemIDs)
+
+		// Item order does not matter
+		reordered := []string{"ruby", "apple", "health_potion", "iron_sword"}
+		assert.Equal(t, first, rollDays(NewDailyRoller(42, odds), reordered))
+
+		total := 0
+		for _, events := range first {
+			total += len(events)
+		}
+		assert.Greater(t, total, 0)
+		assert.NotEqual(t, first, rollDays(NewDailyRoller(7, odds), itemIDs))
+	})
+
+	t.Run("events are well formed", func(t *testing.T) {
+		for _, events := range rollDays(NewDailyRoller(42, odds), itemIDs) {
+			for _, e := range events {
+				switch e.Kind {
+				case DailyEventMarketShock:
+					assert.Contains(t, itemIDs, e.ItemID)
+					assert.InDelta(t, 0.2, math.Abs(e.Magnitude), 0.1)
+				case DailyEventOpportunity:
+					assert.Contains(t, itemIDs, e.ItemID)
+					assert.InDelta(t, 0.35, e.Magnitude, 0.15)
+				case DailyEventHazard:
+					assert.Empty(t, e.ItemID)
+					assert.InDelta(t, 0.2, e.Magnitude, 0.1)
+				}
+			}
+		}
+	})
+
+	t.Run("odds bound what can happen", func(t *testing.T) {
+		roller := NewDailyRoller(42, DailyEventOdds{})
+		for _, events := range rollDays(roller, itemIDs) {
+			assert.Empty(t, events)
+		}
+
+		require.NoError(t, roller.SetOdds(DailyEventOdds{MarketShock: 1, Opportunity: 1, Hazard: 1}))
+		events := roller.Roll(1, itemIDs)
+		require.Len(t, events, 3)
+		assert.Equal(t, DailyEventMarketShock, events[0].Kind)
+		assert.Equal(t, DailyEventOpportunity, events[1].Kind)
+		assert.Equal(t, DailyEventHazard, events[2].Kind)
+
+		assert.Error(t, roller.SetOdds(DailyEventOdds{Hazard: 1.5}))
+	})
+
+	t.Run("frequency scales shocks and hazards", func(t *testing.T) {
+		roller := NewDailyRoller(42, DailyEventOdds{MarketShock: 0.5, Opportunity: 0, Hazard: 0.5})
+		require.NoError(t, roller.SetFrequency(2))
+		for _, events := range rollDays(roller, itemIDs) {
+			require.Len(t, events, 2)
+			assert.Equal(t, DailyEventMarketShock, events[0].Kind)
+			assert.Equal(t, DailyEventHazard, events[1].Kind)
+		}
+		assert.Equal(t, 0.5, roller.GetOdds().Hazard)
+
+		require.NoError(t, roller.SetFrequency(0))
+		for _, events := range rollDays(roller, itemIDs) {
+			assert.Empty(t, events)
+		}
+		assert.Error(t, roller.SetFrequency(-1))
+	})
+
+	t.Run("difficulty", func(t *testing.T) {
+		easy, hard := OddsForDifficulty("easy"), OddsForDifficulty("hard")
+		assert.Less(t, easy.Hazard, hard.Hazard)
+		assert.Greater(t, easy.Opportunity, hard.Opportunity)
+		assert.Equal(t, OddsForDifficulty("normal"), OddsForDifficulty("unknown"))
+	})
+}
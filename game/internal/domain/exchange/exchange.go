@@ -0,0 +1,363 @@
+// Package exchange converts gold to and from foreign currencies whose rates
+// drift from day to day
+package exchange
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Gold is the home currency; every rate is quoted in gold per unit
+const Gold = "gold"
+
+// Exchange desk defaults
+const (
+	DefaultSpread    = 0.02 // Share of each conversion kept by the exchange desk
+	rateHistorySize  = 30   // Daily rates kept per currency
+	rateReversion    = 0.1  // Pull back toward the base rate each day
+	minRateOfBase    = 0.5  // Rates never fall below half their base
+	maxRateOfBase    = 2.0  // Rates never rise above double their base
+	rateDecimalScale = 1000 // Rates are rounded to three decimals
+)
+
+var (
+	// ErrUnknownCurrency is returned for a currency the exchange does not trade
+	ErrUnknownCurrency = errors.New("unknown currency")
+	// ErrSameCurrency is returned when converting a currency to itself
+	ErrSameCurrency = errors.New("cannot exchange a currency for itself")
+	// ErrInvalidAmount is returned for amounts below one unit
+	ErrInvalidAmount = errors.New("exchange amount must be positive")
+	// ErrInsufficientFunds is returned when a holding is too small to spend
+	ErrInsufficientFunds = errors.New("insufficient currency")
+)
+
+// Currency describes a foreign currency and where its rate is today
+type Currency struct {
+	Code       string
+	Name       string
+	BaseRate   float64   // Gold per unit the rate reverts toward
+	Rate       float64   // Gold per unit today
+	Volatility float64   // Largest daily swing as a share of the rate
+	History    []float64 // Recent daily rates, oldest first
+}
+
+// defaultCurrencies are the foreign currencies traded at the exchange desk
+var defaultCurrencies = []Currency{
+	{Code: "crowns", Name: "Northern Crowns", BaseRate: 12, Volatility: 0.08},
+	{Code: "gems", Name: "Guild Gems", BaseRate: 50, Volatility: 0.15},
+}
+
+// Exchange quotes rates, converts amounts at a spread and holds the player's
+// foreign currency. Gold itself is held by the game state, not here.
+type Exchange struct {
+	currencies map[string]*Currency
+	holdings   map[string]int
+	spread     float64
+	random     *rand.Rand
+	mu         sync.RWMutex
+}
+
+// NewExchange creates an exchange trading the default currencies at their
+// base rates
+func NewExchange() *Exchange {
+	ex := &Exchange{
+		spread: DefaultSpread,
+		random: rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // weak random is OK for exchange rates
+	}
+	ex.Reset()
+	return ex
+}
+
+// Currencies returns the foreign currencies traded, sorted by code
+func (ex *Exchange) Currencies() []Currency {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+
+	currencies := make([]Currency, 0, len(ex.currencies))
+	for _, currency := range ex.currencies {
+		snapshot := *currency
+		snapshot.History = append([]float64(nil), currency.History...)
+		currencies = append(currencies, snapshot)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	})
+	return currencies
+}
+
+// GetSpread returns the share of each conversion kept by the exchange
+func (ex *Exchange) GetSpread() float64 {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+	return ex.spread
+}
+
+// GetExchangeRate returns how many units of to one unit of from is worth at
+// the mid rate, before the spread
+func (ex *Exchange) GetExchangeRate(from, to string) (float64, error) {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+	return ex.midRateUnsafe(from, to)
+}
+
+// Convert returns how many units of to amount units of from buy after the
+// spread, rounded down. It does not move any holdings.
+func (ex *Exchange) Convert(from, to string, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, ErrInvalidAmount
+	}
+	if from == to {
+		return 0, ErrSameCurrency
+	}
+
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+
+	rate, err := ex.midRateUnsafe(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Floor(float64(amount) * rate * (1 - ex.spread))), nil
+}
+
+// Balance returns how much of a foreign currency the player holds
+func (ex *Exchange) Balance(code string) int {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+	return ex.holdings[code]
+}
+
+// Holdings returns every foreign currency balance, including empty ones
+func (ex *Exchange) Holdings() map[string]int {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+
+	holdings := make(map[string]int, len(ex.currencies))
+	for code := range ex.currencies {
+		holdings[code] = ex.holdings[code]
+	}
+	return holdings
+}
+
+// Credit adds to a foreign currency holding
+func (ex *Exchange) Credit(code string, amount int) error {
+	if amount < 0 {
+		return ErrInvalidAmount
+	}
+
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	if _, exists := ex.currencies[code]; !exists {
+		return ErrUnknownCurrency
+	}
+	ex.holdings[code] += amount
+	return nil
+}
+
+// Debit takes from a foreign currency holding
+func (ex *Exchange) Debit(code string, amount int) error {
+	if amount < 0 {
+		return ErrInvalidAmount
+	}
+
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	if _, exists := ex.currencies[code]; !exists {
+		return ErrUnknownCurrency
+	}
+	if ex.holdings[code] < amount {
+		return ErrInsufficientFunds
+	}
+	ex.holdings[code] -= amount
+	return nil
+}
+
+// AdvanceDay takes the daily rate step for every currency: a random swing
+// within its volatility plus a pull back toward its base rate, kept between
+// half and double the base like item prices
+func (ex *Exchange) AdvanceDay() {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	for _, code := range ex.codesUnsafe() {
+		currency := ex.currencies[code]
+		swing := (ex.random.Float64()*2 - 1) * currency.Volatility
+		rate := currency.Rate * (1 + swing)
+		rate += (currency.BaseRate - rate) * rateReversion
+		ex.setRateUnsafe(currency, rate)
+	}
+}
+
+// SetRate sets a currency's rate in gold per unit, within the usual bounds
+func (ex *Exchange) SetRate(code string, rate float64) error {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	currency, exists := ex.currencies[code]
+	if !exists {
+		return ErrUnknownCurrency
+	}
+	ex.setRateUnsafe(currency, rate)
+	return nil
+}
+
+// Reset restores base rates and empties every holding
+func (ex *Exchange) Reset() {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	ex.currencies = make(map[string]*Currency, len(defaultCurrencies))
+	for _, currency := range defaultCurrencies {
+		currency := currency
+		currency.Rate = currency.BaseRate
+		currency.History = []float64{currency.BaseRate}
+		ex.currencies[currency.Code] = &currency
+	}
+	ex.holdings = make(map[string]int)
+}
+
+// Snapshot is a copy of the exchange's rates and the player's holdings
+type Snapshot struct {
+	currencies map[string]Currency
+	holdings   map[string]int
+}
+
+// CreateSnapshot copies today's rates, their history and the holdings
+func (ex *Exchange) CreateSnapshot() *Snapshot {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+
+	snapshot := &Snapshot{
+		currencies: make(map[string]Currency, len(ex.currencies)),
+		holdings:   make(map[string]int, len(ex.holdings)),
+	}
+	for code, currency := range ex.currencies {
+		copied := *currency
+		copied.History = append([]float64(nil), currency.History...)
+		snapshot.currencies[code] = copied
+	}
+	for code, amount := range ex.holdings {
+		snapshot.holdings[code] = amount
+	}
+	return snapshot
+}
+
+// RestoreFromSnapshot puts the rates and holdings back to a snapshot
+func (ex *Exchange) RestoreFromSnapshot(snapshot *Snapshot) {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	ex.currencies = make(map[string]*Currency, len(snapshot.currencies))
+	for code, currency := range snapshot.currencies {
+		currency := currency
+		currency.History = append([]float64(nil), currency.History...)
+		ex.currencies[code] = &currency
+	}
+	ex.holdings = make(map[string]int, len(snapshot.holdings))
+	for code, amount := range snapshot.holdings {
+		ex.holdings[code] = amount
+	}
+}
+
+// Record is the rates and the player's holdings as kept in a save
+type Record struct {
+	Rates    map[string][]float64 `json:"rates"` // Recent daily rates per currency, oldest first, ending with today's
+	Holdings map[string]int       `json:"holdings"`
+}
+
+// Record returns every currency's recent rates and the holdings for a save
+func (ex *Exchange) Record() Record {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+
+	record := Record{
+		Rates:    make(map[string][]float64, len(ex.currencies)),
+		Holdings: make(map[string]int, len(ex.holdings)),
+	}
+	for code, currency := range ex.currencies {
+		record.Rates[code] = append([]float64(nil), currency.History...)
+	}
+	for code, amount := range ex.holdings {
+		record.Holdings[code] = amount
+	}
+	return record
+}
+
+// RestoreRecord resets the exchange and then takes the rates and holdings
+// of a saved record. Currencies the exchange no longer trades are dropped.
+func (ex *Exchange) RestoreRecord(record Record) {
+	ex.Reset()
+
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+
+	for code, history := range record.Rates {
+		currency, exists := ex.currencies[code]
+		if !exists || len(history) == 0 {
+			continue
+		}
+		if len(history) > rateHistorySize {
+			history = history[len(history)-rateHistorySize:]
+		}
+		currency.History = append([]float64(nil), history...)
+		currency.Rate = history[len(history)-1]
+	}
+	for code, amount := range record.Holdings {
+		if _, exists := ex.currencies[code]; exists && amount > 0 {
+			ex.holdings[code] = amount
+		}
+	}
+}
+
+// goldRateUnsafe returns a currency's value in gold. Caller must hold ex.mu.
+func (ex *Exchange) goldRateUnsafe(code string) (float64, error) {
+	if code == Gold {
+		return 1, nil
+	}
+	currency, exists := ex.currencies[code]
+	if !exists {
+		return 0, ErrUnknownCurrency
+	}
+	return currency.Rate, nil
+}
+
+// midRateUnsafe returns units of to per unit of from. Caller must hold ex.mu.
+func (ex *Exchange) midRateUnsafe(from, to string) (float64, error) {
+	fromRate, err := ex.goldRateUnsafe(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := ex.goldRateUnsafe(to)
+	if err != nil {
+		return 0, err
+	}
+	return fromRate / toRate, nil
+}
+
+// setRateUnsafe bounds, rounds and records a new rate. Caller must hold ex.mu
+// for writing.
+func (ex *Exchange) setRateUnsafe(currency *Currency, rate float64) {
+	rate = math.Max(currency.BaseRate*minRateOfBase, math.Min(currency.BaseRate*maxRateOfBase, rate))
+	currency.Rate = math.Round(rate*rateDecimalScale) / rateDecimalScale
+
+	currency.History = append(currency.History, currency.Rate)
+	if len(currency.History) > rateHistorySize {
+		currency.History = currency.History[len(currency.History)-rateHistorySize:]
+	}
+}
+
+// codesUnsafe returns currency codes in sorted order. Caller must hold ex.mu.
+func (ex *Exchange) codesUnsafe() []string {
+	codes := make([]string, 0, len(ex.currencies))
+	for code := range ex.currencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
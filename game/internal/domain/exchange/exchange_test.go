@@ -0,0 +1,106 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchange_RoundTripLosesSpread(t *testing.T) {
+	ex := NewExchange()
+
+	crowns, err := ex.Convert(Gold, "crowns", 1200)
+	require.NoError(t, err)
+	assert.Equal(t, 98, crowns) // 100 crowns at 12g, less 2%
+
+	gold, err := ex.Convert("crowns", Gold, crowns)
+	require.NoError(t, err)
+	assert.Less(t, gold, 1200)
+
+	// Only the spread on each leg is lost, about 4% of the stake
+	assert.Equal(t, 1152, gold)
+}
+
+func TestExchange_Rates(t *testing.T) {
+	ex := NewExchange()
+
+	rate, err := ex.GetExchangeRate("gems", "crowns")
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0/12.0, rate, 1e-9)
+
+	_, err = ex.GetExchangeRate(Gold, "doubloons")
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+	_, err = ex.Convert(Gold, Gold, 10)
+	assert.ErrorIs(t, err, ErrSameCurrency)
+	_, err = ex.Convert(Gold, "gems", 0)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	// Daily steps stay within the bounds and are kept as history
+	for day := 0; day < 100; day++ {
+		ex.AdvanceDay()
+	}
+	for _, currency := range ex.Currencies() {
+		assert.GreaterOrEqual(t, currency.Rate, currency.BaseRate*minRateOfBase)
+		assert.LessOrEqual(t, currency.Rate, currency.BaseRate*maxRateOfBase)
+		assert.Len(t, currency.History, rateHistorySize)
+		assert.Equal(t, currency.Rate, currency.History[len(currency.History)-1])
+	}
+
+	require.NoError(t, ex.SetRate("gems", 1000))
+	assert.Equal(t, 100.0, ex.Currencies()[1].Rate)
+}
+
+func TestExchange_Holdings(t *testing.T) {
+	ex := NewExchange()
+
+	require.NoError(t, ex.Credit("crowns", 40))
+	assert.ErrorIs(t, ex.Debit("crowns", 41), ErrInsufficientFunds)
+	require.NoError(t, ex.Debit("crowns", 15))
+	assert.Equal(t, 25, ex.Balance("crowns"))
+	assert.ErrorIs(t, ex.Credit("doubloons", 1), ErrUnknownCurrency)
+	assert.Equal(t, map[string]int{"crowns": 25, "gems": 0}, ex.Holdings())
+
+	ex.Reset()
+	assert.Equal(t, 0, ex.Balance("crowns"))
+}
+
+func TestExchange_Snapshot(t *testing.T) {
+	ex := NewExchange()
+	require.NoError(t, ex.Credit("crowns", 40))
+	snapshot := ex.CreateSnapshot()
+	rate, err := ex.GetExchangeRate("gems", Gold)
+	require.NoError(t, err)
+
+	require.NoError(t, ex.Debit("crowns", 40))
+	require.NoError(t, ex.SetRate("gems", 80))
+	ex.AdvanceDay()
+
+	ex.RestoreFromSnapshot(snapshot)
+	assert.Equal(t, 40, ex.Balance("crowns"))
+	restored, err := ex.GetExchangeRate("gems", Gold)
+	require.NoError(t, err)
+	assert.Equal(t, rate, restored)
+	for _, currency := range ex.Currencies() {
+		assert.Len(t, currency.History, 1)
+	}
+}
+
+func TestExchange_Record(t *testing.T) {
+	ex := NewExchange()
+	require.NoError(t, ex.Credit("crowns", 40))
+	require.NoError(t, ex.SetRate("gems", 80))
+	ex.AdvanceDay()
+	record := ex.Record()
+
+	restored := NewExchange()
+	require.NoError(t, restored.Credit("gems", 3))
+	restored.RestoreRecord(record)
+	assert.Equal(t, map[string]int{"crowns": 40, "gems": 0}, restored.Holdings())
+	assert.Equal(t, ex.Currencies(), restored.Currencies())
+
+	// Currencies no longer traded are dropped
+	record.Holdings["doubloons"] = 5
+	restored.RestoreRecord(record)
+	assert.Equal(t, map[string]int{"crowns": 40, "gems": 0}, restored.Holdings())
+}
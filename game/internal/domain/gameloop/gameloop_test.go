@@ -35,6 +35,14 @@ func TestNewStandardGameLoop(t *testing.T) {
 		assert.NotNil(t, gl)
 		assert.Equal(t, config, gl.config)
 	})
+
+	t.Run("with partial config", func(t *testing.T) {
+		gl := NewStandardGameLoop(&Config{TargetFPS: 30})
+		assert.Equal(t, 30, gl.config.TargetFPS)
+		assert.Equal(t, DefaultConfig().DayDuration, gl.config.DayDuration)
+		assert.Len(t, gl.config.PhaseDistribution, 4)
+		assert.NoError(t, gl.Update(time.Second))
+	})
 }
 
 func TestGameLoopStartStop(t *testing.T) {
@@ -24,10 +24,24 @@ type StandardGameLoop struct {
 	cancel               context.CancelFunc
 }
 
-// NewStandardGameLoop creates a new standard game loop
+// NewStandardGameLoop creates a new standard game loop. Settings left unset
+// in config take their values from DefaultConfig.
 func NewStandardGameLoop(config *Config) *StandardGameLoop {
+	defaults := DefaultConfig()
 	if config == nil {
-		config = DefaultConfig()
+		config = defaults
+	} else {
+		filled := *config
+		if filled.TargetFPS <= 0 {
+			filled.TargetFPS = defaults.TargetFPS
+		}
+		if filled.DayDuration <= 0 {
+			filled.DayDuration = defaults.DayDuration
+		}
+		if len(filled.PhaseDistribution) == 0 {
+			filled.PhaseDistribution = defaults.PhaseDistribution
+		}
+		config = &filled
 	}
 
 	return &StandardGameLoop{
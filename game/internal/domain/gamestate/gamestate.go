@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/yourusername/merchant-tails/game/internal/infrastructure/logging"
 )
 
 // State represents the current state of the game
@@ -37,6 +39,9 @@ type GameConfig struct {
 	ShopCapacity      int
 	WarehouseCapacity int
 	InitialRank       PlayerRank
+	RankRequirements  RankRequirements // Defaults to DefaultRankRequirements when nil
+	ReputationRules   ReputationRules  // Defaults to DefaultReputationRules when nil
+	MaxDays           int              // Last day of a timed challenge; zero plays endlessly
 }
 
 // SaveData represents the data structure for saving/loading game state
@@ -46,6 +51,7 @@ type SaveData struct {
 	PlayerRank        PlayerRank
 	CurrentDay        int
 	CurrentSeason     string
+	MaxDays           int
 	ShopCapacity      int
 	WarehouseCapacity int
 	Reputation        float64
@@ -53,6 +59,9 @@ type SaveData struct {
 	TotalProfit       int
 	TotalExpenses     int
 	TotalRevenue      int
+	ReputationHistory []ReputationSample
+	ReputationToday   ReputationSample // Today's changes so far; Reputation less Change is where the day started
+	Onboarding        map[string]int   // Day each completed onboarding step was done
 	SaveTime          time.Time
 }
 
@@ -87,11 +96,24 @@ type GameState struct {
 	reputation    float64
 	currentDay    int
 	currentSeason string
+	maxDays       int // Zero for endless play
 
 	// Capacity
 	shopCapacity      int
 	warehouseCapacity int
 
+	// Progression
+	rankRequirements RankRequirements
+	reputationRules  ReputationRules
+	winConditions    WinConditions
+
+	// Reputation by day and what changed it today
+	reputationHistory []ReputationSample
+	reputationToday   reputationDay
+
+	// Completed onboarding steps and the day each was done
+	onboarding map[string]int
+
 	// Statistics
 	totalTransactions int
 	totalProfit       int
@@ -116,6 +138,12 @@ var defaultConfig = &GameConfig{
 	InitialRank:       RankApprentice,
 }
 
+// DefaultConfig returns the configuration a game starts with when none is
+// given
+func DefaultConfig() GameConfig {
+	return *defaultConfig
+}
+
 // Constants for game mechanics
 const (
 	MaxShopCapacity      = 1000
@@ -134,6 +162,15 @@ func NewGameState(config *GameConfig) *GameState {
 		config = defaultConfig
 	}
 
+	requirements := config.RankRequirements.clone()
+	if requirements == nil {
+		requirements = DefaultRankRequirements()
+	}
+	reputationRules := config.ReputationRules.clone()
+	if reputationRules == nil {
+		reputationRules = DefaultReputationRules()
+	}
+
 	gs := &GameState{
 		currentState:         StateInitializing,
 		playerName:           "Merchant",
@@ -142,14 +179,19 @@ func NewGameState(config *GameConfig) *GameState {
 		reputation:           0.0,
 		currentDay:           1,
 		currentSeason:        "Spring",
+		maxDays:              config.MaxDays,
 		shopCapacity:         config.ShopCapacity,
 		warehouseCapacity:    config.WarehouseCapacity,
+		rankRequirements:     requirements,
+		reputationRules:      reputationRules,
+		winConditions:        DefaultWinConditions(),
 		totalTransactions:    0,
 		totalProfit:          0,
 		sessionStartTime:     time.Now(),
 		stateChangeCallbacks: make([]StateChangeCallback, 0),
 		rankChangeCallbacks:  make([]RankChangeCallback, 0),
 		goldChangeCallbacks:  make([]GoldChangeCallback, 0),
+		onboarding:           make(map[string]int),
 	}
 
 	return gs
@@ -220,10 +262,16 @@ func (gs *GameState) GetGold() int {
 
 // SetGold sets the gold amount directly
 func (gs *GameState) SetGold(amount int) {
+	gs.SetGoldWithReason(amount, goldReasonUnspecified)
+}
+
+// SetGoldWithReason sets the gold amount directly, recording why it changed
+func (gs *GameState) SetGoldWithReason(amount int, reason string) {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
 	oldGold := gs.gold
 	gs.gold = amount
+	logGoldChange(reason, oldGold, gs.gold)
 
 	// Notify callbacks
 	for _, callback := range gs.goldChangeCallbacks {
@@ -233,6 +281,11 @@ func (gs *GameState) SetGold(amount int) {
 
 // AddGold adds gold to the player's inventory
 func (gs *GameState) AddGold(amount int) error {
+	return gs.AddGoldWithReason(amount, goldReasonUnspecified)
+}
+
+// AddGoldWithReason adds gold to the player's inventory, recording why
+func (gs *GameState) AddGoldWithReason(amount int, reason string) error {
 	if amount < 0 {
 		return errors.New("cannot add negative gold amount")
 	}
@@ -240,7 +293,9 @@ func (gs *GameState) AddGold(amount int) error {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
 
+	oldGold := gs.gold
 	gs.gold += amount
+	logGoldChange(reason, oldGold, gs.gold)
 
 	// Notify callbacks
 	for _, callback := range gs.goldChangeCallbacks {
@@ -252,6 +307,11 @@ func (gs *GameState) AddGold(amount int) error {
 
 // SpendGold removes gold from the player's inventory
 func (gs *GameState) SpendGold(amount int) error {
+	return gs.SpendGoldWithReason(amount, goldReasonUnspecified)
+}
+
+// SpendGoldWithReason removes gold from the player's inventory, recording why
+func (gs *GameState) SpendGoldWithReason(amount int, reason string) error {
 	if amount < 0 {
 		return errors.New("cannot spend negative gold amount")
 	}
@@ -263,7 +323,9 @@ func (gs *GameState) SpendGold(amount int) error {
 		return fmt.Errorf("insufficient gold: have %d, need %d", gs.gold, amount)
 	}
 
+	oldGold := gs.gold
 	gs.gold -= amount
+	logGoldChange(reason, oldGold, gs.gold)
 
 	// Notify callbacks
 	for _, callback := range gs.goldChangeCallbacks {
@@ -273,6 +335,15 @@ func (gs *GameState) SpendGold(amount int) error {
 	return nil
 }
 
+// goldReasonUnspecified is logged for gold changes made without a reason
+const goldReasonUnspecified = "unspecified"
+
+// logGoldChange writes a debug trace of a gold mutation so unexpected
+// balances can be traced back to the operation that caused them
+func logGoldChange(reason string, before, after int) {
+	logging.Debugf("Gold change: reason=%s before=%d after=%d delta=%+d", reason, before, after, after-before)
+}
+
 // GetPlayerRank returns the current player rank
 func (gs *GameState) GetPlayerRank() PlayerRank {
 	gs.mu.RLock()
@@ -452,14 +523,136 @@ func (gs *GameState) IsGameOver() bool {
 	return gs.currentState == StateGameOver
 }
 
-// CheckVictoryCondition checks if the player has won
-func (gs *GameState) CheckVictoryCondition() bool {
+// WinConditions are the objectives a player must meet all at once to win
+type WinConditions struct {
+	Gold       int
+	Reputation float64
+	Rank       PlayerRank
+	Quests     int  // Quests completed; zero leaves quests out of victory
+	AtTimeUp   bool // Judged once a timed game's last day comes, not as soon as met
+}
+
+// DefaultWinConditions returns the standard victory thresholds
+func DefaultWinConditions() WinConditions {
+	return WinConditions{
+		Gold:       VictoryGoldThreshold,
+		Reputation: VictoryRepThreshold,
+		Rank:       RankMaster,
+		Quests:     1,
+	}
+}
+
+// Validate checks that every objective can be met
+func (c WinConditions) Validate() error {
+	if c.Gold < 0 {
+		return errors.New("victory gold cannot be negative")
+	}
+	if c.Reputation < MinReputation || c.Reputation > MaxReputation {
+		return fmt.Errorf("victory reputation must be between %.0f and %.0f", MinReputation, MaxReputation)
+	}
+	if c.Rank < RankApprentice || c.Rank > RankMaster {
+		return fmt.Errorf("invalid victory rank: %d", c.Rank)
+	}
+	if c.Quests < 0 {
+		return errors.New("victory quests cannot be negative")
+	}
+	return nil
+}
+
+// SetWinConditions replaces what the player needs to win
+func (gs *GameState) SetWinConditions(conditions WinConditions) error {
+	if err := conditions.Validate(); err != nil {
+		return err
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.winConditions = conditions
+	return nil
+}
+
+// GetWinConditions returns what the player needs to win
+func (gs *GameState) GetWinConditions() WinConditions {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.winConditions
+}
+
+// Victory objective IDs
+const (
+	ObjectiveGold       = "gold"
+	ObjectiveReputation = "reputation"
+	ObjectiveRank       = "rank"
+	ObjectiveQuests     = "quests"
+	ObjectiveTimeUp     = "time_up"
+)
+
+// VictoryObjective is where the player stands on one win condition
+type VictoryObjective struct {
+	ID      string
+	Current float64
+	Target  float64
+	Met     bool
+}
+
+// GetVictoryProgress returns each configured win condition with the
+// player's progress toward it. The game state does not track quests, so
+// the caller passes how many have been completed. Quests are left out when
+// none are required. Conditions judged at time-up add the days played
+// against the game's last day.
+func (gs *GameState) GetVictoryProgress(questsCompleted int) []VictoryObjective {
 	gs.mu.RLock()
 	defer gs.mu.RUnlock()
 
-	return gs.gold >= VictoryGoldThreshold &&
-		gs.reputation >= VictoryRepThreshold &&
-		gs.playerRank == RankMaster
+	conditions := gs.winConditions
+	objectives := []VictoryObjective{
+		{
+			ID:      ObjectiveGold,
+			Current: float64(gs.gold),
+			Target:  float64(conditions.Gold),
+			Met:     gs.gold >= conditions.Gold,
+		},
+		{
+			ID:      ObjectiveReputation,
+			Current: gs.reputation,
+			Target:  conditions.Reputation,
+			Met:     gs.reputation >= conditions.Reputation,
+		},
+		{
+			ID:      ObjectiveRank,
+			Current: float64(gs.playerRank),
+			Target:  float64(conditions.Rank),
+			Met:     gs.playerRank >= conditions.Rank,
+		},
+	}
+	if conditions.Quests > 0 {
+		objectives = append(objectives, VictoryObjective{
+			ID:      ObjectiveQuests,
+			Current: float64(questsCompleted),
+			Target:  float64(conditions.Quests),
+			Met:     questsCompleted >= conditions.Quests,
+		})
+	}
+	if conditions.AtTimeUp {
+		objectives = append(objectives, VictoryObjective{
+			ID:      ObjectiveTimeUp,
+			Current: float64(gs.currentDay),
+			Target:  float64(gs.maxDays),
+			Met:     gs.maxDays > 0 && gs.currentDay >= gs.maxDays,
+		})
+	}
+	return objectives
+}
+
+// CheckVictoryCondition checks if the player has met every win condition,
+// given how many quests they have completed
+func (gs *GameState) CheckVictoryCondition(questsCompleted int) bool {
+	for _, objective := range gs.GetVictoryProgress(questsCompleted) {
+		if !objective.Met {
+			return false
+		}
+	}
+	return true
 }
 
 // CheckDefeatCondition checks if the player has lost
@@ -520,6 +713,7 @@ func (gs *GameState) CreateSaveData() *SaveData {
 		PlayerRank:        gs.playerRank,
 		CurrentDay:        gs.currentDay,
 		CurrentSeason:     gs.currentSeason,
+		MaxDays:           gs.maxDays,
 		ShopCapacity:      gs.shopCapacity,
 		WarehouseCapacity: gs.warehouseCapacity,
 		Reputation:        gs.reputation,
@@ -527,6 +721,9 @@ func (gs *GameState) CreateSaveData() *SaveData {
 		TotalProfit:       gs.totalProfit,
 		TotalExpenses:     gs.totalExpenses,
 		TotalRevenue:      gs.totalRevenue,
+		ReputationHistory: copyReputationSamples(gs.reputationHistory),
+		ReputationToday:   gs.todaysReputationSampleUnsafe(),
+		Onboarding:        copyOnboardingProgress(gs.onboarding),
 		SaveTime:          time.Now(),
 	}
 }
@@ -554,6 +751,7 @@ func (gs *GameState) LoadSaveData(data *SaveData) error {
 	if gs.currentSeason == "" {
 		gs.currentSeason = "Spring" // Default for old saves
 	}
+	gs.maxDays = data.MaxDays
 	gs.shopCapacity = data.ShopCapacity
 	gs.warehouseCapacity = data.WarehouseCapacity
 	gs.reputation = data.Reputation
@@ -561,6 +759,14 @@ func (gs *GameState) LoadSaveData(data *SaveData) error {
 	gs.totalProfit = data.TotalProfit
 	gs.totalExpenses = data.TotalExpenses
 	gs.totalRevenue = data.TotalRevenue
+	gs.reputationHistory = copyReputationSamples(data.ReputationHistory)
+	gs.reputationToday = reputationDay{start: data.Reputation - data.ReputationToday.Change}
+	gs.onboarding = copyOnboardingProgress(data.Onboarding)
+	for action, delta := range data.ReputationToday.Reasons {
+		if action != ReputationOther {
+			gs.noteReputationUnsafe(action, delta)
+		}
+	}
 
 	return nil
 }
@@ -657,12 +863,49 @@ func (gs *GameState) AdvanceDay() {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
 
+	gs.closeReputationDayUnsafe()
 	gs.currentDay++
+	gs.currentSeason = SeasonForDay(gs.currentDay)
+}
+
+// GetMaxDays returns the last day of a timed challenge, or zero for endless play
+func (gs *GameState) GetMaxDays() int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.maxDays
+}
+
+// SetMaxDays sets the last day of a timed challenge, such as from a save;
+// zero plays endlessly
+func (gs *GameState) SetMaxDays(days int) error {
+	if days < 0 {
+		return errors.New("day cap cannot be negative")
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.maxDays = days
+	return nil
+}
+
+// IsTimeUp reports whether a timed challenge has reached its last day
+func (gs *GameState) IsTimeUp() bool {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.maxDays > 0 && gs.currentDay >= gs.maxDays
+}
 
-	// Update season every 30 days
-	seasonIndex := (gs.currentDay - 1) / 30 % 4
+// DaysPerSeason is how many game days each season lasts
+const DaysPerSeason = 30
+
+// SeasonForDay returns the season for a game day (seasons change every 30 days)
+func SeasonForDay(day int) string {
+	if day < 1 {
+		day = 1
+	}
+	seasonIndex := (day - 1) / DaysPerSeason % 4
 	seasons := []string{"Spring", "Summer", "Autumn", "Winter"}
-	gs.currentSeason = seasons[seasonIndex]
+	return seasons[seasonIndex]
 }
 
 // GetCurrentSeason returns the current season
@@ -692,7 +935,11 @@ func (gs *GameState) SetCurrentSeason(season string) error {
 func (gs *GameState) GetRankBonus() (shopCapBonus int, warehouseCapBonus int, priceDiscount float64) {
 	gs.mu.RLock()
 	defer gs.mu.RUnlock()
+	return gs.getRankBonusUnsafe()
+}
 
+// getRankBonusUnsafe returns rank bonuses without locking
+func (gs *GameState) getRankBonusUnsafe() (shopCapBonus int, warehouseCapBonus int, priceDiscount float64) {
 	switch gs.playerRank {
 	case RankApprentice:
 		return 0, 0, 0.0
@@ -735,35 +982,207 @@ func (gs *GameState) GetRankProgress() float64 {
 	}
 }
 
-// CheckRankUp checks if player should rank up and performs it
-func (gs *GameState) CheckRankUp() bool {
+// RankRequirement describes what a player needs to advance from a rank
+type RankRequirement struct {
+	NextRank     PlayerRank
+	Gold         int
+	Reputation   float64
+	Transactions int
+}
+
+// RankRequirementsStatus reports the targets for the next rank and the player's progress
+type RankRequirementsStatus struct {
+	CurrentRank          PlayerRank
+	NextRank             PlayerRank
+	IsMaxRank            bool
+	GoldRequired         int
+	GoldCurrent          int
+	ReputationRequired   float64
+	ReputationCurrent    float64
+	TransactionsRequired int
+	TransactionsCurrent  int
+	CanRankUp            bool
+}
+
+// RankRequirements maps each rank to the requirement for advancing from it.
+// Ranks without an entry cannot be advanced from.
+type RankRequirements map[PlayerRank]RankRequirement
+
+// DefaultRankRequirements returns the standard progression thresholds
+func DefaultRankRequirements() RankRequirements {
+	return RankRequirements{
+		RankApprentice: {NextRank: RankJourneyman, Gold: 5000, Reputation: 20, Transactions: 50},
+		RankJourneyman: {NextRank: RankExpert, Gold: 15000, Reputation: 40, Transactions: 200},
+		RankExpert:     {NextRank: RankMaster, Gold: 35000, Reputation: 60, Transactions: 500},
+	}
+}
+
+// Scale returns a copy with every threshold multiplied (e.g. for difficulty profiles)
+func (r RankRequirements) Scale(multiplier float64) RankRequirements {
+	scaled := make(RankRequirements, len(r))
+	for rank, req := range r {
+		req.Gold = int(float64(req.Gold) * multiplier)
+		req.Reputation = clampFloat64(req.Reputation*multiplier, MinReputation, MaxReputation)
+		req.Transactions = int(float64(req.Transactions) * multiplier)
+		scaled[rank] = req
+	}
+	return scaled
+}
+
+// clone returns a copy of the table, or nil for a nil table
+func (r RankRequirements) clone() RankRequirements {
+	if r == nil {
+		return nil
+	}
+	copied := make(RankRequirements, len(r))
+	for rank, req := range r {
+		copied[rank] = req
+	}
+	return copied
+}
+
+// SetRankRequirements replaces the rank-up table
+func (gs *GameState) SetRankRequirements(requirements RankRequirements) {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
+	gs.rankRequirements = requirements.clone()
+}
 
-	oldRank := gs.playerRank
-	shouldRankUp := false
+// ReputationAction is something the player does that changes their reputation
+type ReputationAction string
 
-	switch gs.playerRank {
-	case RankApprentice:
-		if gs.gold >= 5000 && gs.reputation >= 20 && gs.totalTransactions >= 50 {
-			gs.playerRank = RankJourneyman
-			shouldRankUp = true
-		}
-	case RankJourneyman:
-		if gs.gold >= 15000 && gs.reputation >= 40 && gs.totalTransactions >= 200 {
-			gs.playerRank = RankExpert
-			shouldRankUp = true
-		}
-	case RankExpert:
-		if gs.gold >= 35000 && gs.reputation >= 60 && gs.totalTransactions >= 500 {
-			gs.playerRank = RankMaster
-			shouldRankUp = true
+// Actions with a reputation consequence
+const (
+	ReputationFairSale      ReputationAction = "fair_sale"      // Fresh stock sold at a fair price
+	ReputationOvercharge    ReputationAction = "overcharge"     // Stock sold well above fair value
+	ReputationSpoiledSale   ReputationAction = "spoiled_sale"   // Fully spoiled stock sold
+	ReputationLoanDefault   ReputationAction = "loan_default"   // A loan left unpaid
+	ReputationCharity       ReputationAction = "charity"        // Goods given away
+	ReputationQuestComplete ReputationAction = "quest_complete" // Per point of a quest's reputation reward
+)
+
+// ReputationRules maps each action to the reputation it gains or loses.
+// Actions without an entry do not change reputation.
+type ReputationRules map[ReputationAction]float64
+
+// DefaultReputationRules returns the standard reputation consequences
+func DefaultReputationRules() ReputationRules {
+	return ReputationRules{
+		ReputationFairSale:      0.1,
+		ReputationOvercharge:    -0.2,
+		ReputationSpoiledSale:   -2.0,
+		ReputationLoanDefault:   -10.0,
+		ReputationCharity:       0.5,
+		ReputationQuestComplete: 1.0,
+	}
+}
+
+// Scale returns a copy with gains and losses multiplied separately (e.g. for
+// difficulty profiles)
+func (r ReputationRules) Scale(gains, losses float64) ReputationRules {
+	scaled := make(ReputationRules, len(r))
+	for action, delta := range r {
+		if delta > 0 {
+			scaled[action] = delta * gains
+		} else {
+			scaled[action] = delta * losses
 		}
 	}
+	return scaled
+}
+
+// clone returns a copy of the table, or nil for a nil table
+func (r ReputationRules) clone() ReputationRules {
+	if r == nil {
+		return nil
+	}
+	copied := make(ReputationRules, len(r))
+	for action, delta := range r {
+		copied[action] = delta
+	}
+	return copied
+}
+
+// SetReputationRules replaces the reputation table
+func (gs *GameState) SetReputationRules(rules ReputationRules) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.reputationRules = rules.clone()
+}
+
+// GetReputationRules returns a copy of the reputation table
+func (gs *GameState) GetReputationRules() ReputationRules {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.reputationRules.clone()
+}
+
+// ApplyReputationAction changes reputation by the action's configured delta
+// times scale, which weighs actions that come in degrees (e.g. how spoiled
+// the stock was). It returns the change actually made after clamping.
+func (gs *GameState) ApplyReputationAction(action ReputationAction, scale float64) float64 {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	before := gs.reputation
+	gs.reputation = clampFloat64(gs.reputation+gs.reputationRules[action]*scale, MinReputation, MaxReputation)
+	gs.noteReputationUnsafe(action, gs.reputation-before)
+	return gs.reputation - before
+}
+
+// meetsRequirementUnsafe checks a requirement without locking
+func (gs *GameState) meetsRequirementUnsafe(req RankRequirement) bool {
+	return gs.gold >= req.Gold && gs.reputation >= req.Reputation && gs.totalTransactions >= req.Transactions
+}
+
+// GetRankRequirements returns the targets for the next rank and current progress toward each
+func (gs *GameState) GetRankRequirements() RankRequirementsStatus {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	status := RankRequirementsStatus{
+		CurrentRank:         gs.playerRank,
+		NextRank:            gs.playerRank,
+		GoldCurrent:         gs.gold,
+		ReputationCurrent:   gs.reputation,
+		TransactionsCurrent: gs.totalTransactions,
+	}
+
+	req, exists := gs.rankRequirements[gs.playerRank]
+	if !exists {
+		status.IsMaxRank = true
+		return status
+	}
+
+	status.NextRank = req.NextRank
+	status.GoldRequired = req.Gold
+	status.ReputationRequired = req.Reputation
+	status.TransactionsRequired = req.Transactions
+	status.CanRankUp = gs.meetsRequirementUnsafe(req)
+	return status
+}
+
+// CheckRankUp checks if player should rank up and performs it.
+// The table is walked until a requirement is unmet, so a player who
+// qualifies for several ranks at once advances through each of them.
+func (gs *GameState) CheckRankUp() bool {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	// Bounded by the table size so a misconfigured cycle cannot loop forever
+	rankedUp := false
+	for step := 0; step < len(gs.rankRequirements); step++ {
+		req, exists := gs.rankRequirements[gs.playerRank]
+		if !exists || req.NextRank == gs.playerRank || !gs.meetsRequirementUnsafe(req) {
+			break
+		}
+
+		oldRank := gs.playerRank
+		gs.playerRank = req.NextRank
+		rankedUp = true
 
-	if shouldRankUp {
 		// Apply rank bonuses
-		shopBonus, warehouseBonus, _ := gs.GetRankBonus()
+		shopBonus, warehouseBonus, _ := gs.getRankBonusUnsafe()
 		gs.shopCapacity += shopBonus
 		gs.warehouseCapacity += warehouseBonus
 
@@ -773,5 +1192,5 @@ func (gs *GameState) CheckRankUp() bool {
 		}
 	}
 
-	return shouldRankUp
+	return rankedUp
 }
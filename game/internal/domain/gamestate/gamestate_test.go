@@ -1,10 +1,14 @@
 package gamestate
 
 import (
+	"bytes"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/merchant-tails/game/internal/infrastructure/logging"
 )
 
 func TestNewGameState(t *testing.T) {
@@ -130,6 +134,35 @@ func TestGameStateGoldOperations(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGameStateGoldChangeLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logging.SetGlobalOutput(&buf)
+	logging.SetGlobalLevel(logging.LevelDebug)
+	t.Cleanup(func() {
+		logging.SetGlobalOutput(os.Stdout)
+		logging.SetGlobalLevel(logging.LevelInfo)
+	})
+
+	gs := NewGameState(&GameConfig{InitialGold: 1000})
+
+	require.NoError(t, gs.AddGoldWithReason(250, "sale:apple"))
+	require.NoError(t, gs.SpendGoldWithReason(100, "purchase:iron_sword"))
+	gs.SetGoldWithReason(500, "load_game")
+	require.NoError(t, gs.AddGold(1))
+
+	output := buf.String()
+	assert.Contains(t, output, "reason=sale:apple before=1000 after=1250 delta=+250")
+	assert.Contains(t, output, "reason=purchase:iron_sword before=1250 after=1150 delta=-100")
+	assert.Contains(t, output, "reason=load_game before=1150 after=500 delta=-650")
+	assert.Contains(t, output, "reason=unspecified before=500 after=501 delta=+1")
+
+	// Nothing is logged above debug level
+	buf.Reset()
+	logging.SetGlobalLevel(logging.LevelInfo)
+	require.NoError(t, gs.AddGoldWithReason(1, "quiet"))
+	assert.Empty(t, buf.String())
+}
+
 func TestGameStateRankProgression(t *testing.T) {
 	gs := NewGameState(&GameConfig{InitialRank: RankApprentice})
 
@@ -159,6 +192,94 @@ func TestGameStateRankProgression(t *testing.T) {
 	}
 }
 
+func TestGameStateRankRequirements(t *testing.T) {
+	gs := NewGameState(&GameConfig{InitialGold: 1000, InitialRank: RankApprentice})
+
+	status := gs.GetRankRequirements()
+	assert.Equal(t, RankApprentice, status.CurrentRank)
+	assert.Equal(t, RankJourneyman, status.NextRank)
+	assert.False(t, status.IsMaxRank)
+	assert.Equal(t, 1000, status.GoldCurrent)
+	assert.False(t, status.CanRankUp)
+	assert.Equal(t, status.CanRankUp, gs.CheckRankUp())
+
+	// Meeting exactly the reported targets must be enough to rank up
+	gs.gold = status.GoldRequired
+	gs.reputation = status.ReputationRequired
+	gs.totalTransactions = status.TransactionsRequired
+
+	status = gs.GetRankRequirements()
+	require.True(t, status.CanRankUp)
+	assert.True(t, gs.CheckRankUp())
+	assert.Equal(t, RankJourneyman, gs.GetPlayerRank())
+
+	// Falling one short of any target blocks the rank up
+	status = gs.GetRankRequirements()
+	gs.gold = status.GoldRequired
+	gs.reputation = status.ReputationRequired
+	gs.totalTransactions = status.TransactionsRequired - 1
+	assert.False(t, gs.GetRankRequirements().CanRankUp)
+	assert.False(t, gs.CheckRankUp())
+
+	gs.playerRank = RankMaster
+	status = gs.GetRankRequirements()
+	assert.True(t, status.IsMaxRank)
+	assert.Equal(t, RankMaster, status.NextRank)
+	assert.False(t, status.CanRankUp)
+}
+
+func TestGameStateCustomRankRequirements(t *testing.T) {
+	gs := NewGameState(&GameConfig{
+		InitialGold: 0,
+		InitialRank: RankApprentice,
+		RankRequirements: RankRequirements{
+			RankApprentice: {NextRank: RankJourneyman, Gold: 100, Reputation: 5, Transactions: 2},
+			RankJourneyman: {NextRank: RankExpert, Gold: 200, Reputation: 10, Transactions: 4},
+		},
+	})
+
+	status := gs.GetRankRequirements()
+	assert.Equal(t, 100, status.GoldRequired)
+	assert.Equal(t, 5.0, status.ReputationRequired)
+	assert.Equal(t, 2, status.TransactionsRequired)
+
+	gs.gold = 99
+	gs.reputation = 5
+	gs.totalTransactions = 2
+	assert.False(t, gs.CheckRankUp())
+
+	gs.gold = 100
+	assert.True(t, gs.CheckRankUp())
+	assert.Equal(t, RankJourneyman, gs.GetPlayerRank())
+
+	// Qualifying for several ranks at once advances through each
+	gs.playerRank = RankApprentice
+	gs.gold = 200
+	gs.reputation = 10
+	gs.totalTransactions = 4
+	assert.True(t, gs.CheckRankUp())
+	assert.Equal(t, RankExpert, gs.GetPlayerRank())
+
+	// Expert has no entry in the custom table
+	gs.gold = 1000000
+	assert.False(t, gs.CheckRankUp())
+	assert.True(t, gs.GetRankRequirements().IsMaxRank)
+}
+
+func TestRankRequirementsScale(t *testing.T) {
+	defaults := DefaultRankRequirements()
+	scaled := defaults.Scale(2.0)
+
+	assert.Equal(t, defaults[RankApprentice].Gold*2, scaled[RankApprentice].Gold)
+	assert.Equal(t, defaults[RankApprentice].Transactions*2, scaled[RankApprentice].Transactions)
+	assert.Equal(t, MaxReputation, scaled[RankExpert].Reputation) // 60 * 2 clamps to 100
+	assert.Equal(t, 5000, defaults[RankApprentice].Gold)          // Original untouched
+
+	gs := NewGameState(nil)
+	gs.SetRankRequirements(scaled)
+	assert.Equal(t, 10000, gs.GetRankRequirements().GoldRequired)
+}
+
 func TestGameStateCapacityUpgrades(t *testing.T) {
 	gs := NewGameState(&GameConfig{
 		ShopCapacity:      20,
@@ -328,14 +449,17 @@ func TestGameStateVictoryConditions(t *testing.T) {
 	})
 
 	// Not victory initially
-	assert.False(t, gs.CheckVictoryCondition())
+	assert.False(t, gs.CheckVictoryCondition(1))
 
 	// Set victory conditions
 	gs.AddGold(49000) // Total: 50000
 	gs.SetReputation(80.0)
 
+	// Still short of the quest the default conditions ask for
+	assert.False(t, gs.CheckVictoryCondition(0))
+
 	// Should be victory now
-	assert.True(t, gs.CheckVictoryCondition())
+	assert.True(t, gs.CheckVictoryCondition(1))
 
 	// Test defeat conditions
 	gs.SpendGold(50000)     // Go to 0 gold
@@ -344,6 +468,72 @@ func TestGameStateVictoryConditions(t *testing.T) {
 	assert.True(t, gs.CheckDefeatCondition())
 }
 
+func TestGameStateWinConditions(t *testing.T) {
+	gs := NewGameState(&GameConfig{InitialGold: 1000})
+	assert.Equal(t, DefaultWinConditions(), gs.GetWinConditions())
+
+	// Relaxed conditions let an expert win on modest gold
+	require.NoError(t, gs.SetWinConditions(WinConditions{Gold: 2000, Reputation: 10, Rank: RankExpert}))
+	gs.SetGold(2500)
+	gs.SetReputation(20)
+	gs.SetRank(RankJourneyman)
+	assert.False(t, gs.CheckVictoryCondition(0))
+	gs.SetRank(RankExpert)
+	assert.True(t, gs.CheckVictoryCondition(0))
+	gs.SetRank(RankMaster)
+	assert.True(t, gs.CheckVictoryCondition(0))
+
+	assert.Error(t, gs.SetWinConditions(WinConditions{Gold: -1, Rank: RankMaster}))
+	assert.Error(t, gs.SetWinConditions(WinConditions{Gold: 1, Reputation: 150, Rank: RankMaster}))
+	assert.Error(t, gs.SetWinConditions(WinConditions{Gold: 1, Rank: PlayerRank(9)}))
+	assert.Error(t, gs.SetWinConditions(WinConditions{Gold: 1, Rank: RankMaster, Quests: -1}))
+	assert.Equal(t, 2000, gs.GetWinConditions().Gold)
+}
+
+func TestGameStateVictoryProgress(t *testing.T) {
+	gs := NewGameState(&GameConfig{InitialGold: 1000})
+	require.NoError(t, gs.SetWinConditions(WinConditions{Gold: 2000, Reputation: 10, Rank: RankJourneyman, Quests: 2}))
+	gs.SetGold(2500)
+	gs.SetReputation(5)
+	gs.SetRank(RankExpert)
+
+	progress := gs.GetVictoryProgress(1)
+	require.Len(t, progress, 4)
+	met := make(map[string]bool, len(progress))
+	for _, objective := range progress {
+		met[objective.ID] = objective.Met
+	}
+	assert.Equal(t, map[string]bool{
+		ObjectiveGold:       true,
+		ObjectiveReputation: false,
+		ObjectiveRank:       true,
+		ObjectiveQuests:     false,
+	}, met)
+	assert.Equal(t, 5.0, progress[1].Current)
+	assert.Equal(t, 10.0, progress[1].Target)
+
+	// Every objective has to be met at once
+	gs.SetReputation(15)
+	assert.False(t, gs.CheckVictoryCondition(1))
+	assert.True(t, gs.CheckVictoryCondition(2))
+
+	// Quests drop out when none are required
+	require.NoError(t, gs.SetWinConditions(WinConditions{Gold: 2000, Reputation: 10, Rank: RankJourneyman}))
+	assert.Len(t, gs.GetVictoryProgress(0), 3)
+	assert.True(t, gs.CheckVictoryCondition(0))
+
+	// Conditions judged at time-up wait for the last day
+	require.NoError(t, gs.SetMaxDays(10))
+	require.NoError(t, gs.SetWinConditions(WinConditions{Gold: 2000, Reputation: 10, Rank: RankJourneyman, AtTimeUp: true}))
+	assert.False(t, gs.CheckVictoryCondition(0))
+	progress = gs.GetVictoryProgress(0)
+	require.Len(t, progress, 4)
+	assert.Equal(t, ObjectiveTimeUp, progress[3].ID)
+	assert.Equal(t, 10.0, progress[3].Target)
+	gs.SetCurrentDay(10)
+	assert.True(t, gs.CheckVictoryCondition(0))
+}
+
 func TestGetStateName(t *testing.T) {
 	tests := []struct {
 		state    State
@@ -437,3 +627,104 @@ func TestGameStateIntegration(t *testing.T) {
 	err = gs.TransitionTo(StatePlaying)
 	assert.NoError(t, err)
 }
+
+func TestGameStateReputationRules(t *testing.T) {
+	rules := ReputationRules{
+		ReputationFairSale:      1,
+		ReputationOvercharge:    -2,
+		ReputationSpoiledSale:   -4,
+		ReputationLoanDefault:   -30,
+		ReputationCharity:       3,
+		ReputationQuestComplete: 0.5,
+	}
+	gs := NewGameState(&GameConfig{InitialGold: 1000, ReputationRules: rules})
+
+	// Each action applies its configured delta, weighted by scale
+	for action, delta := range rules {
+		gs.SetReputation(0)
+		assert.Equal(t, delta, gs.ApplyReputationAction(action, 1), action)
+		assert.Equal(t, delta, gs.GetReputation(), action)
+	}
+	gs.SetReputation(0)
+	assert.Equal(t, 5.0, gs.ApplyReputationAction(ReputationQuestComplete, 10))
+	assert.Equal(t, 0.0, gs.ApplyReputationAction("unknown", 1))
+
+	// Changes clamp at the reputation bounds and report what was applied
+	gs.SetReputation(MinReputation + 10)
+	assert.Equal(t, -10.0, gs.ApplyReputationAction(ReputationLoanDefault, 1))
+	assert.Equal(t, MinReputation, gs.GetReputation())
+	gs.SetReputation(MaxReputation - 1)
+	assert.Equal(t, 1.0, gs.ApplyReputationAction(ReputationCharity, 1))
+	assert.Equal(t, MaxReputation, gs.GetReputation())
+
+	// The table is copied in and out
+	rules[ReputationCharity] = 100
+	assert.Equal(t, 3.0, gs.GetReputationRules()[ReputationCharity])
+}
+
+func TestReputationRulesScale(t *testing.T) {
+	scaled := DefaultReputationRules().Scale(2, 0.5)
+	assert.Equal(t, DefaultReputationRules()[ReputationCharity]*2, scaled[ReputationCharity])
+	assert.Equal(t, DefaultReputationRules()[ReputationLoanDefault]*0.5, scaled[ReputationLoanDefault])
+
+	gs := NewGameState(nil)
+	assert.Equal(t, DefaultReputationRules(), gs.GetReputationRules())
+}
+
+func TestGameStateReputationHistory(t *testing.T) {
+	gs := NewGameState(&GameConfig{InitialGold: 1000})
+	rules := gs.GetReputationRules()
+
+	// Day 1: two fair sales and an overcharge
+	gs.ApplyReputationAction(ReputationFairSale, 1)
+	gs.ApplyReputationAction(ReputationFairSale, 1)
+	gs.ApplyReputationAction(ReputationOvercharge, 1)
+	today := gs.GetTodaysReputation()
+	assert.Equal(t, 1, today.Day)
+	assert.InDelta(t, 2*rules[ReputationFairSale], today.Reasons[ReputationFairSale], 1e-9)
+	gs.AdvanceDay()
+
+	// Day 2: charity, plus a change outside the rules
+	gs.ApplyReputationAction(ReputationCharity, 1)
+	gs.SetReputation(gs.GetReputation() + 4)
+	gs.AdvanceDay()
+
+	// Day 3: nothing happens
+	gs.AdvanceDay()
+
+	history := gs.GetReputationHistory(0)
+	require.Len(t, history, 3)
+	assert.Equal(t, 1, history[0].Day)
+	assert.InDelta(t, 2*rules[ReputationFairSale]+rules[ReputationOvercharge], history[0].Change, 1e-9)
+	assert.InDelta(t, history[0].Change, history[0].Reputation, 1e-9)
+	assert.InDelta(t, rules[ReputationOvercharge], history[0].Reasons[ReputationOvercharge], 1e-9)
+	assert.NotContains(t, history[0].Reasons, ReputationOther)
+
+	assert.InDelta(t, rules[ReputationCharity], history[1].Reasons[ReputationCharity], 1e-9)
+	assert.InDelta(t, 4.0, history[1].Reasons[ReputationOther], 1e-9)
+	assert.InDelta(t, rules[ReputationCharity]+4, history[1].Change, 1e-9)
+
+	assert.Equal(t, 0.0, history[2].Change)
+	assert.Empty(t, history[2].Reasons)
+	assert.Equal(t, history[1].Reputation, history[2].Reputation)
+
+	// Asking for fewer days returns the latest, and copies are returned
+	recent := gs.GetReputationHistory(2)
+	require.Len(t, recent, 2)
+	assert.Equal(t, 2, recent[0].Day)
+	recent[0].Reasons[ReputationCharity] = 100
+	assert.InDelta(t, rules[ReputationCharity], gs.GetReputationHistory(2)[0].Reasons[ReputationCharity], 1e-9)
+
+	// Save data carries the history and today's changes
+	gs.ApplyReputationAction(ReputationQuestComplete, 1)
+	restored := NewGameState(nil)
+	require.NoError(t, restored.LoadSaveData(gs.CreateSaveData()))
+	assert.Equal(t, history, restored.GetReputationHistory(0))
+	assert.Equal(t, gs.GetTodaysReputation(), restored.GetTodaysReputation())
+
+	// The history is capped
+	for i := 0; i < ReputationHistorySize+5; i++ {
+		gs.AdvanceDay()
+	}
+	assert.Len(t, gs.GetReputationHistory(0), ReputationHistorySize)
+}
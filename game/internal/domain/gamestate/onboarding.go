@@ -0,0 +1,101 @@
+package gamestate
+
+// Onboarding step IDs, in the order a new player is walked through them
+const (
+	OnboardingFirstPurchase  = "first_purchase"
+	OnboardingSetPrice       = "set_price"
+	OnboardingProfitableSale = "profitable_sale"
+	OnboardingReachDay2      = "reach_day_2"
+)
+
+// OnboardingStep is one thing a new player is asked to try
+type OnboardingStep struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+var onboardingSteps = []OnboardingStep{
+	{
+		ID:          OnboardingFirstPurchase,
+		Title:       "Buy your first stock",
+		Description: "Purchase an item from the market to sell in your shop.",
+	},
+	{
+		ID:          OnboardingSetPrice,
+		Title:       "Set a price",
+		Description: "Choose what your shop charges for an item.",
+	},
+	{
+		ID:          OnboardingProfitableSale,
+		Title:       "Make a profitable sale",
+		Description: "Sell an item for more than you paid for it.",
+	},
+	{
+		ID:          OnboardingReachDay2,
+		Title:       "Open on day 2",
+		Description: "End the day and open your shop the next morning.",
+	},
+}
+
+// OnboardingSteps returns the onboarding steps in order
+func OnboardingSteps() []OnboardingStep {
+	return append([]OnboardingStep(nil), onboardingSteps...)
+}
+
+// isOnboardingStep reports whether id names an onboarding step
+func isOnboardingStep(id string) bool {
+	for _, step := range onboardingSteps {
+		if step.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CompleteOnboardingStep marks an onboarding step done on the current day.
+// It returns false for an unknown step or one already done.
+func (gs *GameState) CompleteOnboardingStep(id string) bool {
+	if !isOnboardingStep(id) {
+		return false
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if _, done := gs.onboarding[id]; done {
+		return false
+	}
+	if gs.onboarding == nil {
+		gs.onboarding = make(map[string]int)
+	}
+	gs.onboarding[id] = gs.currentDay
+	return true
+}
+
+// GetOnboardingProgress returns the day each completed onboarding step was
+// done, by step ID
+func (gs *GameState) GetOnboardingProgress() map[string]int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return copyOnboardingProgress(gs.onboarding)
+}
+
+// ResetOnboarding replaces the completed onboarding steps, such as from a
+// save. Unknown step IDs are dropped.
+func (gs *GameState) ResetOnboarding(progress map[string]int) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.onboarding = copyOnboardingProgress(progress)
+}
+
+// copyOnboardingProgress copies the known steps of progress
+func copyOnboardingProgress(progress map[string]int) map[string]int {
+	copied := make(map[string]int, len(progress))
+	for id, day := range progress {
+		if isOnboardingStep(id) {
+			copied[id] = day
+		}
+	}
+	return copied
+}
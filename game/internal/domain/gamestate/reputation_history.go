@@ -0,0 +1,128 @@
+package gamestate
+
+import "math"
+
+// ReputationHistorySize caps how many days of reputation history are kept
+const ReputationHistorySize = 120
+
+// ReputationOther is the reason given for reputation changes made directly
+// rather than through a reputation rule, such as a scenario or QA cheat
+const ReputationOther ReputationAction = "other"
+
+// ReputationSample is the player's reputation at the end of a day and what
+// moved it that day, by reputation rule
+type ReputationSample struct {
+	Day        int                          `json:"day"`
+	Reputation float64                      `json:"reputation"`
+	Change     float64                      `json:"change"`
+	Reasons    map[ReputationAction]float64 `json:"reasons"`
+}
+
+// reputationDay tracks today's reputation changes until the day ends
+type reputationDay struct {
+	start   float64
+	reasons map[ReputationAction]float64
+}
+
+// noteReputationUnsafe attributes a reputation change to an action for
+// today's sample. Caller must hold gs.mu.
+func (gs *GameState) noteReputationUnsafe(action ReputationAction, delta float64) {
+	if delta == 0 {
+		return
+	}
+	if gs.reputationToday.reasons == nil {
+		gs.reputationToday.reasons = make(map[ReputationAction]float64)
+	}
+	gs.reputationToday.reasons[action] += delta
+}
+
+// todaysReputationSampleUnsafe returns today's reputation sample so far.
+// Changes not made through a rule are put down to ReputationOther.
+// Caller must hold gs.mu.
+func (gs *GameState) todaysReputationSampleUnsafe() ReputationSample {
+	sample := ReputationSample{
+		Day:        gs.currentDay,
+		Reputation: gs.reputation,
+		Change:     gs.reputation - gs.reputationToday.start,
+		Reasons:    make(map[ReputationAction]float64, len(gs.reputationToday.reasons)+1),
+	}
+	explained := 0.0
+	for action, delta := range gs.reputationToday.reasons {
+		sample.Reasons[action] = delta
+		explained += delta
+	}
+	if other := sample.Change - explained; math.Abs(other) > 1e-9 {
+		sample.Reasons[ReputationOther] += other
+	}
+	return sample
+}
+
+// closeReputationDayUnsafe files today's sample in the history and starts a
+// new day's tracking. Caller must hold gs.mu.
+func (gs *GameState) closeReputationDayUnsafe() {
+	gs.reputationHistory = append(gs.reputationHistory, gs.todaysReputationSampleUnsafe())
+	if len(gs.reputationHistory) > ReputationHistorySize {
+		gs.reputationHistory = gs.reputationHistory[len(gs.reputationHistory)-ReputationHistorySize:]
+	}
+	gs.reputationToday = reputationDay{start: gs.reputation}
+}
+
+// GetReputationHistory returns the samples for the last days finished days,
+// oldest first, or every sample kept when days is zero or less
+func (gs *GameState) GetReputationHistory(days int) []ReputationSample {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	history := gs.reputationHistory
+	if days > 0 && days < len(history) {
+		history = history[len(history)-days:]
+	}
+	return copyReputationSamples(history)
+}
+
+// GetTodaysReputation returns today's reputation sample so far
+func (gs *GameState) GetTodaysReputation() ReputationSample {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.todaysReputationSampleUnsafe()
+}
+
+// ResetReputationHistory replaces the history, such as from a save, and
+// starts today's tracking from the current reputation
+func (gs *GameState) ResetReputationHistory(history []ReputationSample) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if len(history) > ReputationHistorySize {
+		history = history[len(history)-ReputationHistorySize:]
+	}
+	gs.reputationHistory = copyReputationSamples(history)
+	gs.reputationToday = reputationDay{start: gs.reputation}
+}
+
+// ResetTodaysReputation restores today's changes so far, such as from a
+// save, taking the current reputation as where they left it
+func (gs *GameState) ResetTodaysReputation(today ReputationSample) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	gs.reputationToday = reputationDay{start: gs.reputation - today.Change}
+	for action, delta := range today.Reasons {
+		if action != ReputationOther {
+			gs.noteReputationUnsafe(action, delta)
+		}
+	}
+}
+
+// copyReputationSamples copies samples and their reasons
+func copyReputationSamples(samples []ReputationSample) []ReputationSample {
+	copied := make([]ReputationSample, len(samples))
+	for i, sample := range samples {
+		copied[i] = sample
+		copied[i].Reasons = make(map[ReputationAction]float64, len(sample.Reasons))
+		for action, delta := range sample.Reasons {
+			copied[i].Reasons[action] = delta
+		}
+	}
+	return copied
+}
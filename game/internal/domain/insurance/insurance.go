@@ -0,0 +1,209 @@
+// Package insurance covers shop stock against theft for a daily premium
+package insurance
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+// Default policy terms
+const (
+	DefaultDailyPremium = 15  // Gold charged per insured day
+	DefaultCoverage     = 0.8 // Share of a loss's cost that is reimbursed
+	MaxTermDays         = 30  // Longest policy that can be bought at once
+)
+
+var (
+	// ErrInvalidTerm is returned when a policy length is outside 1-MaxTermDays
+	ErrInvalidTerm = errors.New("insurance term must be between 1 and 30 days")
+	// ErrAlreadyInsured is returned when buying while a policy is still active
+	ErrAlreadyInsured = errors.New("an insurance policy is already active")
+)
+
+// Policy is a purchased insurance policy covering StartDay through EndDay
+type Policy struct {
+	StartDay int     `json:"startDay"`
+	EndDay   int     `json:"endDay"`
+	Premium  int     `json:"premium"`  // Total gold paid for the policy
+	Coverage float64 `json:"coverage"` // Share of each loss reimbursed
+}
+
+// Covers reports whether the policy is in force on a day
+func (p Policy) Covers(day int) bool {
+	return day >= p.StartDay && day <= p.EndDay
+}
+
+// Statistics summarizes premiums paid and claims received
+type Statistics struct {
+	PoliciesBought int `json:"policiesBought"`
+	TotalPremiums  int `json:"totalPremiums"`
+	TotalClaims    int `json:"totalClaims"`
+	TotalLosses    int `json:"totalLosses"` // Cost of all losses, insured or not
+}
+
+// Insurer sells policies and settles theft claims
+type Insurer struct {
+	dailyPremium int
+	coverage     float64
+	policy       *Policy
+	stats        Statistics
+	mu           sync.RWMutex
+}
+
+// NewInsurer creates an insurer with the default terms
+func NewInsurer() *Insurer {
+	return &Insurer{
+		dailyPremium: DefaultDailyPremium,
+		coverage:     DefaultCoverage,
+	}
+}
+
+// Quote returns the premium for a policy of the given length
+func (in *Insurer) Quote(days int) (int, error) {
+	if days < 1 || days > MaxTermDays {
+		return 0, ErrInvalidTerm
+	}
+
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.dailyPremium * days, nil
+}
+
+// Buy starts a policy covering days days from day. The caller is responsible
+// for charging the returned policy's premium.
+func (in *Insurer) Buy(day, days int) (Policy, error) {
+	premium, err := in.Quote(days)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if in.policy != nil && in.policy.Covers(day) {
+		return Policy{}, ErrAlreadyInsured
+	}
+
+	policy := Policy{
+		StartDay: day,
+		EndDay:   day + days - 1,
+		Premium:  premium,
+		Coverage: in.coverage,
+	}
+	in.policy = &policy
+	in.stats.PoliciesBought++
+	in.stats.TotalPremiums += premium
+	return policy, nil
+}
+
+// ActivePolicy returns the policy in force on a day, if any
+func (in *Insurer) ActivePolicy(day int) (Policy, bool) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	if in.policy == nil || !in.policy.Covers(day) {
+		return Policy{}, false
+	}
+	return *in.policy, true
+}
+
+// Claim records a loss of the given cost on a day and returns the gold
+// reimbursed, which is zero when no policy is in force
+func (in *Insurer) Claim(day, loss int) int {
+	if loss <= 0 {
+		return 0
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.stats.TotalLosses += loss
+	if in.policy == nil || !in.policy.Covers(day) {
+		return 0
+	}
+
+	payout := int(math.Round(float64(loss) * in.policy.Coverage))
+	in.stats.TotalClaims += payout
+	return payout
+}
+
+// GetStatistics returns premiums, claims and losses so far
+func (in *Insurer) GetStatistics() Statistics {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.stats
+}
+
+// Reset cancels any policy and clears statistics
+func (in *Insurer) Reset() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.policy = nil
+	in.stats = Statistics{}
+}
+
+// Snapshot is a copy of the policy in force and the statistics so far
+type Snapshot struct {
+	policy *Policy
+	stats  Statistics
+}
+
+// CreateSnapshot copies the policy and statistics
+func (in *Insurer) CreateSnapshot() *Snapshot {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	snapshot := &Snapshot{stats: in.stats}
+	if in.policy != nil {
+		policy := *in.policy
+		snapshot.policy = &policy
+	}
+	return snapshot
+}
+
+// RestoreFromSnapshot puts the policy and statistics back to a snapshot,
+// cancelling any policy bought since
+func (in *Insurer) RestoreFromSnapshot(snapshot *Snapshot) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.policy = nil
+	if snapshot.policy != nil {
+		policy := *snapshot.policy
+		in.policy = &policy
+	}
+	in.stats = snapshot.stats
+}
+
+// Record is the insurer's policy and statistics as kept in a save
+type Record struct {
+	Policy *Policy    `json:"policy,omitempty"`
+	Stats  Statistics `json:"stats"`
+}
+
+// Record returns the policy, lapsed or not, and the statistics for a save
+func (in *Insurer) Record() Record {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	record := Record{Stats: in.stats}
+	if in.policy != nil {
+		policy := *in.policy
+		record.Policy = &policy
+	}
+	return record
+}
+
+// RestoreRecord replaces the policy and statistics with a saved record
+func (in *Insurer) RestoreRecord(record Record) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.policy = nil
+	if record.Policy != nil {
+		policy := *record.Policy
+		in.policy = &policy
+	}
+	in.stats = record.Stats
+}
@@ -0,0 +1,73 @@
+package insurance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsurer_BuyAndClaim(t *testing.T) {
+	in := NewInsurer()
+
+	// Losses before buying a policy are not reimbursed
+	assert.Equal(t, 0, in.Claim(1, 100))
+
+	policy, err := in.Buy(2, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 8, policy.EndDay)
+	assert.Equal(t, DefaultDailyPremium*7, policy.Premium)
+
+	_, err = in.Buy(5, 7)
+	assert.ErrorIs(t, err, ErrAlreadyInsured)
+
+	assert.Equal(t, 80, in.Claim(8, 100))
+	assert.Equal(t, 0, in.Claim(9, 100))
+
+	// A new policy can be bought once the old one lapses
+	_, err = in.Buy(9, 1)
+	assert.NoError(t, err)
+
+	stats := in.GetStatistics()
+	assert.Equal(t, 2, stats.PoliciesBought)
+	assert.Equal(t, DefaultDailyPremium*8, stats.TotalPremiums)
+	assert.Equal(t, 80, stats.TotalClaims)
+	assert.Equal(t, 300, stats.TotalLosses)
+}
+
+func TestInsurer_InvalidTerm(t *testing.T) {
+	in := NewInsurer()
+
+	_, err := in.Buy(1, 0)
+	assert.ErrorIs(t, err, ErrInvalidTerm)
+	_, err = in.Quote(MaxTermDays + 1)
+	assert.ErrorIs(t, err, ErrInvalidTerm)
+
+	_, active := in.ActivePolicy(1)
+	assert.False(t, active)
+}
+
+func TestInsurer_Snapshot(t *testing.T) {
+	in := NewInsurer()
+	snapshot := in.CreateSnapshot()
+
+	_, err := in.Buy(1, 5)
+	require.NoError(t, err)
+	in.Claim(2, 100)
+
+	in.RestoreFromSnapshot(snapshot)
+	_, active := in.ActivePolicy(2)
+	assert.False(t, active)
+	assert.Equal(t, Statistics{}, in.GetStatistics())
+
+	// A snapshot with a policy brings it back
+	_, err = in.Buy(3, 5)
+	require.NoError(t, err)
+	snapshot = in.CreateSnapshot()
+	in.Reset()
+	in.RestoreFromSnapshot(snapshot)
+	policy, active := in.ActivePolicy(4)
+	require.True(t, active)
+	assert.Equal(t, 7, policy.EndDay)
+	assert.Equal(t, 1, in.GetStatistics().PoliciesBought)
+}
@@ -115,7 +115,11 @@ func NewCapacityManager(config *CapacityConfig) *CapacityManager {
 func (cm *CapacityManager) GetShopCapacity() int {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
+	return cm.shopCapacityUnsafe()
+}
 
+// shopCapacityUnsafe computes the shop capacity without locking
+func (cm *CapacityManager) shopCapacityUnsafe() int {
 	capacity := float64(cm.baseShopCapacity)
 	for _, modifier := range cm.shopCapacityModifiers {
 		capacity *= modifier
@@ -132,7 +136,11 @@ func (cm *CapacityManager) GetShopCapacity() int {
 func (cm *CapacityManager) GetWarehouseCapacity() int {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
+	return cm.warehouseCapacityUnsafe()
+}
 
+// warehouseCapacityUnsafe computes the warehouse capacity without locking
+func (cm *CapacityManager) warehouseCapacityUnsafe() int {
 	capacity := float64(cm.baseWarehouseCapacity)
 	for _, modifier := range cm.warehouseCapacityModifiers {
 		capacity *= modifier
@@ -174,8 +182,8 @@ func (cm *CapacityManager) RecordUtilization(shopItems, warehouseItems int) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	shopCapacity := cm.GetShopCapacity()
-	warehouseCapacity := cm.GetWarehouseCapacity()
+	shopCapacity := cm.shopCapacityUnsafe()
+	warehouseCapacity := cm.warehouseCapacityUnsafe()
 
 	record := UtilizationRecord{
 		Timestamp:            time.Now(),
@@ -263,8 +271,8 @@ func (cm *CapacityManager) GetCapacityStats(currentShopItems, currentWarehouseIt
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
 
-	shopCapacity := cm.GetShopCapacity()
-	warehouseCapacity := cm.GetWarehouseCapacity()
+	shopCapacity := cm.shopCapacityUnsafe()
+	warehouseCapacity := cm.warehouseCapacityUnsafe()
 
 	stats := &CapacityStats{
 		CurrentShopCapacity:      shopCapacity,
@@ -388,8 +396,8 @@ func (cm *CapacityManager) CalculateOptimalTransfer(shopItems, warehouseItems in
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
 
-	shopCapacity := cm.GetShopCapacity()
-	warehouseCapacity := cm.GetWarehouseCapacity()
+	shopCapacity := cm.shopCapacityUnsafe()
+	warehouseCapacity := cm.warehouseCapacityUnsafe()
 
 	shopUtil := float64(shopItems) / float64(shopCapacity)
 	warehouseUtil := float64(warehouseItems) / float64(warehouseCapacity)
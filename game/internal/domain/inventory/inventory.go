@@ -3,6 +3,7 @@ package inventory
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -22,7 +23,11 @@ type InventoryManager struct {
 	minimumStock       map[string]int
 	salesHistory       map[string]*SalesHistory
 	spoiledItems       []*SpoiledItem
-	capacityManager    *CapacityManager // Capacity management
+	capacityManager    *CapacityManager      // Capacity management
+	itemMaxStack       map[string]int        // Per-item holding limits
+	categoryMaxStack   map[item.Category]int // Per-category holding limits
+	autoSellRules      map[string]*AutoSellRule
+	inTransit          []*Shipment // Stock moving between shop and warehouse
 	mu                 sync.RWMutex
 }
 
@@ -35,6 +40,20 @@ type InventoryItem struct {
 	Location      InventoryLocation
 }
 
+// addLot merges more stock into an item's entry. A priced lot moves the
+// purchase price to the average over both, weighted by quantity.
+func (entry *InventoryItem) addLot(quantity, purchasePrice int) {
+	if purchasePrice > 0 {
+		if entry.PurchasePrice > 0 {
+			total := entry.PurchasePrice*entry.Quantity + purchasePrice*quantity
+			entry.PurchasePrice = int(math.Round(float64(total) / float64(entry.Quantity+quantity)))
+		} else {
+			entry.PurchasePrice = purchasePrice
+		}
+	}
+	entry.Quantity += quantity
+}
+
 // InventoryLocation represents where an item is stored
 type InventoryLocation int
 
@@ -43,12 +62,24 @@ const (
 	LocationWarehouse
 )
 
+// Shipment is stock moving between shop and warehouse that has not arrived
+type Shipment struct {
+	ItemID     string
+	Quantity   int
+	To         InventoryLocation
+	ArrivalDay int
+
+	item          *item.Item
+	purchasePrice int
+}
+
 // SpoiledItem tracks spoiled items
 type SpoiledItem struct {
-	Item     *item.Item
-	Quantity int
-	Location InventoryLocation
-	Date     time.Time
+	Item      *item.Item
+	Quantity  int
+	Location  InventoryLocation
+	Date      time.Time
+	LostValue int // What the spoiled stock cost to buy
 }
 
 // SalesHistory tracks sales data for an item
@@ -72,6 +103,35 @@ type InventorySnapshot struct {
 	WarehouseItems map[string]int
 	TotalValue     int
 	Timestamp      time.Time
+
+	// Full stock records, so a restore keeps item details, purchase prices
+	// and stock in transit. Snapshots built by hand leave these empty.
+	shopEntries      map[string]InventoryItem
+	warehouseEntries map[string]InventoryItem
+	inTransit        []Shipment
+}
+
+// AutoSellRule sells all stock of an item automatically during the daily
+// update. A zero threshold or day limit disables that trigger.
+type AutoSellRule struct {
+	PriceThreshold float64 // Sell when the market price rises above this
+	MaxDaysHeld    int     // Sell once stock has been held this many days
+	daysHeld       int
+}
+
+// Auto-sell reasons
+const (
+	AutoSellReasonPrice   = "price_threshold"
+	AutoSellReasonMaxDays = "max_days_held"
+)
+
+// AutoSellAction records stock sold by an auto-sell rule
+type AutoSellAction struct {
+	ItemID        string
+	Quantity      int
+	Price         int // Market price per unit at the time of sale
+	PurchasePrice int
+	Reason        string
 }
 
 // SellStrategy interface for different selling strategies
@@ -110,25 +170,236 @@ func NewInventoryManager(shopCapacity, warehouseCapacity int) (*InventoryManager
 		salesHistory:       make(map[string]*SalesHistory),
 		spoiledItems:       make([]*SpoiledItem, 0),
 		capacityManager:    NewCapacityManager(capacityConfig),
+		itemMaxStack:       make(map[string]int),
+		categoryMaxStack:   make(map[item.Category]int),
+		autoSellRules:      make(map[string]*AutoSellRule),
 	}
 
 	return im, nil
 }
 
+// SetMaxStack limits how many of an item can be held across shop and warehouse.
+// A limit of 0 removes it. Per-item limits override category limits.
+func (im *InventoryManager) SetMaxStack(itemID string, maxStack int) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if maxStack <= 0 {
+		delete(im.itemMaxStack, itemID)
+		return
+	}
+	im.itemMaxStack[itemID] = maxStack
+}
+
+// SetCategoryMaxStack limits how many of each item in a category can be held.
+// A limit of 0 removes it.
+func (im *InventoryManager) SetCategoryMaxStack(category item.Category, maxStack int) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if maxStack <= 0 {
+		delete(im.categoryMaxStack, category)
+		return
+	}
+	im.categoryMaxStack[category] = maxStack
+}
+
+// GetMaxStack returns the holding limit for an item, or 0 if unlimited
+func (im *InventoryManager) GetMaxStack(itemID string) int {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	return im.getMaxStackUnsafe(itemID, im.categoryOfUnsafe(itemID))
+}
+
+// getMaxStackUnsafe returns the holding limit without locking
+func (im *InventoryManager) getMaxStackUnsafe(itemID string, category item.Category) int {
+	if maxStack, exists := im.itemMaxStack[itemID]; exists {
+		return maxStack
+	}
+	return im.categoryMaxStack[category]
+}
+
+// GetWarehouseRoom returns how many more units of an item fit in the
+// warehouse by space, given the item's footprint
+func (im *InventoryManager) GetWarehouseRoom(itemID string) int {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	footprint := (&item.Item{ID: itemID, Category: im.categoryOfUnsafe(itemID)}).GetFootprint()
+	free := im.WarehouseCapacity - im.getWarehouseSpaceUsedUnsafe()
+	if free <= 0 || footprint <= 0 {
+		return 0
+	}
+	return free / footprint
+}
+
+// GetStackRoom returns how many more units of an item can be held before its
+// stack limit, and false if the item has no limit
+func (im *InventoryManager) GetStackRoom(itemID string) (int, bool) {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	maxStack := im.getMaxStackUnsafe(itemID, im.categoryOfUnsafe(itemID))
+	if maxStack == 0 {
+		return 0, false
+	}
+	held := im.ShopInventory.GetQuantity(itemID) + im.WarehouseInventory.GetQuantity(itemID)
+	return max(0, maxStack-held), true
+}
+
+// categoryOfUnsafe looks up an item's category in the registry, falling back
+// to held stock. Caller must hold im.mu.
+func (im *InventoryManager) categoryOfUnsafe(itemID string) item.Category {
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists {
+		return master.Category
+	}
+	if entry, exists := im.warehouseItems[itemID]; exists {
+		return entry.Item.Category
+	}
+	if entry, exists := im.shopItems[itemID]; exists {
+		return entry.Item.Category
+	}
+	var category item.Category
+	return category
+}
+
+// checkMaxStackUnsafe returns an error if adding quantity would exceed the item's holding limit
+func (im *InventoryManager) checkMaxStackUnsafe(itemID string, category item.Category, quantity int) error {
+	maxStack := im.getMaxStackUnsafe(itemID, category)
+	if maxStack == 0 {
+		return nil
+	}
+
+	held := im.ShopInventory.GetQuantity(itemID) + im.WarehouseInventory.GetQuantity(itemID)
+	if held+quantity > maxStack {
+		return fmt.Errorf("exceeds maximum stack for %s: holding %d + new %d > max %d",
+			itemID, held, quantity, maxStack)
+	}
+	return nil
+}
+
+// SetAutoSell sells an item automatically once its market price exceeds
+// priceThreshold or it has been held for maxDaysHeld days. Pass 0 for either
+// to disable that trigger.
+func (im *InventoryManager) SetAutoSell(itemID string, priceThreshold float64, maxDaysHeld int) error {
+	if priceThreshold < 0 {
+		return errors.New("price threshold cannot be negative")
+	}
+	if maxDaysHeld < 0 {
+		return errors.New("max days held cannot be negative")
+	}
+	if priceThreshold == 0 && maxDaysHeld == 0 {
+		return errors.New("auto-sell needs a price threshold or a day limit")
+	}
+
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	rule := &AutoSellRule{PriceThreshold: priceThreshold, MaxDaysHeld: maxDaysHeld}
+	if existing, exists := im.autoSellRules[itemID]; exists {
+		rule.daysHeld = existing.daysHeld
+	}
+	im.autoSellRules[itemID] = rule
+	return nil
+}
+
+// ClearAutoSell removes an item's auto-sell rule
+func (im *InventoryManager) ClearAutoSell(itemID string) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	delete(im.autoSellRules, itemID)
+}
+
+// GetAutoSell returns a copy of an item's auto-sell rule
+func (im *InventoryManager) GetAutoSell(itemID string) (AutoSellRule, bool) {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	rule, exists := im.autoSellRules[itemID]
+	if !exists {
+		return AutoSellRule{}, false
+	}
+	return *rule, true
+}
+
+// ProcessAutoSell runs the day's auto-sell rules, removing all shop and
+// warehouse stock of each item whose rule triggers. It should be called once
+// per game day; priceOf returns the current market price of an item. The
+// caller is responsible for crediting the returned sales.
+func (im *InventoryManager) ProcessAutoSell(priceOf func(itemID string) int) []AutoSellAction {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	itemIDs := make([]string, 0, len(im.autoSellRules))
+	for itemID := range im.autoSellRules {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+
+	actions := make([]AutoSellAction, 0)
+	for _, itemID := range itemIDs {
+		rule := im.autoSellRules[itemID]
+		shopQty := im.ShopInventory.GetQuantity(itemID)
+		warehouseQty := im.WarehouseInventory.GetQuantity(itemID)
+		if shopQty+warehouseQty == 0 {
+			rule.daysHeld = 0
+			continue
+		}
+		rule.daysHeld++
+
+		price := priceOf(itemID)
+		reason := ""
+		switch {
+		case rule.PriceThreshold > 0 && float64(price) > rule.PriceThreshold:
+			reason = AutoSellReasonPrice
+		case rule.MaxDaysHeld > 0 && rule.daysHeld >= rule.MaxDaysHeld:
+			reason = AutoSellReasonMaxDays
+		default:
+			continue
+		}
+
+		action := AutoSellAction{
+			ItemID:        itemID,
+			Quantity:      shopQty + warehouseQty,
+			Price:         price,
+			PurchasePrice: im.getPurchasePriceUnsafe(itemID),
+			Reason:        reason,
+		}
+		if shopQty > 0 {
+			_ = im.ShopInventory.RemoveItem(itemID, shopQty)
+			delete(im.shopItems, itemID)
+		}
+		if warehouseQty > 0 {
+			_ = im.WarehouseInventory.RemoveItem(itemID, warehouseQty)
+			delete(im.warehouseItems, itemID)
+		}
+		rule.daysHeld = 0
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
 // AddToShop adds items to shop inventory
 func (im *InventoryManager) AddToShop(item *item.Item, quantity int) error {
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	currentTotal := im.getTotalShopItemsUnsafe()
+	currentSpace := im.getShopSpaceUsedUnsafe()
+	newSpace := quantity * item.GetFootprint()
 	actualCapacity := im.ShopCapacity
 	if im.capacityManager != nil {
 		actualCapacity = im.capacityManager.GetShopCapacity()
 	}
 
-	if currentTotal+quantity > actualCapacity {
+	if currentSpace+newSpace > actualCapacity {
 		return fmt.Errorf("exceeds shop capacity: current %d + new %d > capacity %d",
-			currentTotal, quantity, actualCapacity)
+			currentSpace, newSpace, actualCapacity)
+	}
+
+	if err := im.checkMaxStackUnsafe(item.ID, item.Category, quantity); err != nil {
+		return err
 	}
 
 	err := im.ShopInventory.AddItem(item, quantity)
@@ -153,15 +424,20 @@ func (im *InventoryManager) AddToWarehouse(item *item.Item, quantity int) error
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	currentTotal := im.getTotalWarehouseItemsUnsafe()
+	currentSpace := im.getWarehouseSpaceUsedUnsafe()
+	newSpace := quantity * item.GetFootprint()
 	actualCapacity := im.WarehouseCapacity
 	if im.capacityManager != nil {
 		actualCapacity = im.capacityManager.GetWarehouseCapacity()
 	}
 
-	if currentTotal+quantity > actualCapacity {
+	if currentSpace+newSpace > actualCapacity {
 		return fmt.Errorf("exceeds warehouse capacity: current %d + new %d > capacity %d",
-			currentTotal, quantity, actualCapacity)
+			currentSpace, newSpace, actualCapacity)
+	}
+
+	if err := im.checkMaxStackUnsafe(item.ID, item.Category, quantity); err != nil {
+		return err
 	}
 
 	err := im.WarehouseInventory.AddItem(item, quantity)
@@ -186,104 +462,278 @@ func (im *InventoryManager) TransferToWarehouse(itemID string, quantity int) err
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	shopQty := im.ShopInventory.GetQuantity(itemID)
-	if shopQty < quantity {
-		return fmt.Errorf("insufficient quantity in shop: have %d, need %d", shopQty, quantity)
+	itemRef, purchasePrice, err := im.takeFromShopUnsafe(itemID, quantity)
+	if err != nil {
+		return err
+	}
+	return im.addWarehouseStockUnsafe(itemRef, quantity, purchasePrice)
+}
+
+// TransferToShop moves items from warehouse to shop
+func (im *InventoryManager) TransferToShop(itemID string, quantity int) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	itemRef, purchasePrice, err := im.takeFromWarehouseUnsafe(itemID, quantity)
+	if err != nil {
+		return err
+	}
+	return im.addShopStockUnsafe(itemRef, quantity, purchasePrice)
+}
+
+// TransferWarehouseTo moves warehouse stock into another inventory's
+// warehouse, such as a branch shop's, keeping its details and purchase price.
+// Stock the destination cannot hold stays where it was.
+func (im *InventoryManager) TransferWarehouseTo(dest *InventoryManager, itemID string, quantity int) error {
+	if dest == im {
+		return errors.New("cannot transfer stock to the same inventory")
+	}
+	if quantity <= 0 {
+		return errors.New("quantity must be positive")
 	}
 
-	warehouseTotal := im.getTotalWarehouseItemsUnsafe()
-	if warehouseTotal+quantity > im.WarehouseCapacity {
+	im.mu.Lock()
+	warehouseQty := im.WarehouseInventory.GetQuantity(itemID)
+	entry, exists := im.warehouseItems[itemID]
+	if !exists || warehouseQty < quantity {
+		im.mu.Unlock()
+		return fmt.Errorf("insufficient quantity in warehouse: have %d, need %d", warehouseQty, quantity)
+	}
+	itemCopy := *entry.Item
+	itemRef, purchasePrice := &itemCopy, entry.PurchasePrice
+	if err := im.WarehouseInventory.RemoveItem(itemID, quantity); err != nil {
+		im.mu.Unlock()
+		return err
+	}
+	entry.Quantity -= quantity
+	if entry.Quantity == 0 {
+		delete(im.warehouseItems, itemID)
+	}
+	im.mu.Unlock()
+
+	dest.mu.Lock()
+	err := dest.receiveWarehouseStockUnsafe(itemRef, quantity, purchasePrice)
+	dest.mu.Unlock()
+	if err != nil {
+		im.mu.Lock()
+		_ = im.addWarehouseStockUnsafe(itemRef, quantity, purchasePrice)
+		im.mu.Unlock()
+	}
+	return err
+}
+
+// receiveWarehouseStockUnsafe adds stock arriving from another inventory,
+// checking warehouse space and holding limits
+func (im *InventoryManager) receiveWarehouseStockUnsafe(itemRef *item.Item, quantity, purchasePrice int) error {
+	space := im.getWarehouseSpaceUsedUnsafe() + im.inTransitSpaceUnsafe(LocationWarehouse)
+	if space+quantity*itemRef.GetFootprint() > im.WarehouseCapacity {
 		return errors.New("exceeds warehouse capacity")
 	}
+	if err := im.checkMaxStackUnsafe(itemRef.ID, itemRef.Category, quantity); err != nil {
+		return err
+	}
+	return im.addWarehouseStockUnsafe(itemRef, quantity, purchasePrice)
+}
 
-	// Get the item reference
-	var itemRef *item.Item
-	if entry, exists := im.shopItems[itemID]; exists {
-		itemRef = entry.Item
-	} else {
-		return errors.New("item not found in shop")
+// ShipToShop moves items out of the warehouse now and into the shop once
+// ReceiveShipments is called for arrivalDay. Shop space is reserved for the
+// shipment while it is in transit.
+func (im *InventoryManager) ShipToShop(itemID string, quantity, arrivalDay int) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	itemRef, purchasePrice, err := im.takeFromWarehouseUnsafe(itemID, quantity)
+	if err != nil {
+		return err
 	}
+	im.inTransit = append(im.inTransit, &Shipment{
+		ItemID:        itemID,
+		Quantity:      quantity,
+		To:            LocationShop,
+		ArrivalDay:    arrivalDay,
+		item:          itemRef,
+		purchasePrice: purchasePrice,
+	})
+	return nil
+}
 
-	// Transfer
-	if err := im.ShopInventory.RemoveItem(itemID, quantity); err != nil {
+// ShipToWarehouse moves items out of the shop now and into the warehouse
+// once ReceiveShipments is called for arrivalDay
+func (im *InventoryManager) ShipToWarehouse(itemID string, quantity, arrivalDay int) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	itemRef, purchasePrice, err := im.takeFromShopUnsafe(itemID, quantity)
+	if err != nil {
 		return err
 	}
+	im.inTransit = append(im.inTransit, &Shipment{
+		ItemID:        itemID,
+		Quantity:      quantity,
+		To:            LocationWarehouse,
+		ArrivalDay:    arrivalDay,
+		item:          itemRef,
+		purchasePrice: purchasePrice,
+	})
+	return nil
+}
 
-	// Update internal tracking
-	if entry, exists := im.shopItems[itemID]; exists {
-		entry.Quantity -= quantity
-		if entry.Quantity == 0 {
-			delete(im.shopItems, itemID)
+// ReceiveShipments delivers every shipment due on or before day and returns
+// the shipments delivered
+func (im *InventoryManager) ReceiveShipments(day int) []Shipment {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	delivered := make([]Shipment, 0)
+	pending := make([]*Shipment, 0, len(im.inTransit))
+	for _, shipment := range im.inTransit {
+		if shipment.ArrivalDay > day {
+			pending = append(pending, shipment)
+			continue
 		}
-	}
 
-	err := im.WarehouseInventory.AddItem(itemRef, quantity)
-	if err == nil {
-		if existing, exists := im.warehouseItems[itemID]; exists {
-			existing.Quantity += quantity
+		var err error
+		if shipment.To == LocationShop {
+			err = im.addShopStockUnsafe(shipment.item, shipment.Quantity, shipment.purchasePrice)
 		} else {
-			im.warehouseItems[itemID] = &InventoryItem{
-				Item:         itemRef,
-				Quantity:     quantity,
-				PurchaseDate: time.Now(),
-				Location:     LocationWarehouse,
-			}
+			err = im.addWarehouseStockUnsafe(shipment.item, shipment.Quantity, shipment.purchasePrice)
 		}
+		if err != nil {
+			// Try again on the next delivery
+			pending = append(pending, shipment)
+			continue
+		}
+		delivered = append(delivered, *shipment)
 	}
-	return err
+	im.inTransit = pending
+	return delivered
 }
 
-// TransferToShop moves items from warehouse to shop
-func (im *InventoryManager) TransferToShop(itemID string, quantity int) error {
-	im.mu.Lock()
-	defer im.mu.Unlock()
+// GetInTransit returns copies of the shipments that have not arrived yet
+func (im *InventoryManager) GetInTransit() []Shipment {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
 
+	shipments := make([]Shipment, 0, len(im.inTransit))
+	for _, shipment := range im.inTransit {
+		shipments = append(shipments, *shipment)
+	}
+	return shipments
+}
+
+// takeFromShopUnsafe removes stock from the shop for a move to the warehouse,
+// checking the warehouse can hold it alongside anything already in transit
+func (im *InventoryManager) takeFromShopUnsafe(itemID string, quantity int) (*item.Item, int, error) {
+	shopQty := im.ShopInventory.GetQuantity(itemID)
+	if shopQty < quantity {
+		return nil, 0, fmt.Errorf("insufficient quantity in shop: have %d, need %d", shopQty, quantity)
+	}
+
+	entry, exists := im.shopItems[itemID]
+	if !exists {
+		return nil, 0, errors.New("item not found in shop")
+	}
+	itemRef, purchasePrice := entry.Item, entry.PurchasePrice
+
+	warehouseSpace := im.getWarehouseSpaceUsedUnsafe() + im.inTransitSpaceUnsafe(LocationWarehouse)
+	if warehouseSpace+quantity*itemRef.GetFootprint() > im.WarehouseCapacity {
+		return nil, 0, errors.New("exceeds warehouse capacity")
+	}
+
+	if err := im.ShopInventory.RemoveItem(itemID, quantity); err != nil {
+		return nil, 0, err
+	}
+	entry.Quantity -= quantity
+	if entry.Quantity == 0 {
+		delete(im.shopItems, itemID)
+	}
+	return itemRef, purchasePrice, nil
+}
+
+// takeFromWarehouseUnsafe removes stock from the warehouse for a move to the
+// shop, checking the shop can hold it alongside anything already in transit
+func (im *InventoryManager) takeFromWarehouseUnsafe(itemID string, quantity int) (*item.Item, int, error) {
 	warehouseQty := im.WarehouseInventory.GetQuantity(itemID)
 	if warehouseQty < quantity {
-		return fmt.Errorf("insufficient quantity in warehouse: have %d, need %d",
+		return nil, 0, fmt.Errorf("insufficient quantity in warehouse: have %d, need %d",
 			warehouseQty, quantity)
 	}
 
-	shopTotal := im.getTotalShopItemsUnsafe()
-	if shopTotal+quantity > im.ShopCapacity {
-		return errors.New("exceeds shop capacity")
+	entry, exists := im.warehouseItems[itemID]
+	if !exists {
+		return nil, 0, errors.New("item not found in warehouse")
 	}
+	itemRef, purchasePrice := entry.Item, entry.PurchasePrice
 
-	// Get the item reference
-	var itemRef *item.Item
-	if entry, exists := im.warehouseItems[itemID]; exists {
-		itemRef = entry.Item
-	} else {
-		return errors.New("item not found in warehouse")
+	shopSpace := im.getShopSpaceUsedUnsafe() + im.inTransitSpaceUnsafe(LocationShop)
+	if shopSpace+quantity*itemRef.GetFootprint() > im.ShopCapacity {
+		return nil, 0, errors.New("exceeds shop capacity")
 	}
 
-	// Transfer
 	if err := im.WarehouseInventory.RemoveItem(itemID, quantity); err != nil {
+		return nil, 0, err
+	}
+	entry.Quantity -= quantity
+	if entry.Quantity == 0 {
+		delete(im.warehouseItems, itemID)
+	}
+	return itemRef, purchasePrice, nil
+}
+
+// addShopStockUnsafe puts moved stock into the shop
+func (im *InventoryManager) addShopStockUnsafe(itemRef *item.Item, quantity, purchasePrice int) error {
+	if err := im.ShopInventory.AddItem(itemRef, quantity); err != nil {
 		return err
 	}
+	if existing, exists := im.shopItems[itemRef.ID]; exists {
+		existing.addLot(quantity, purchasePrice)
+		return nil
+	}
 
-	// Update internal tracking
-	if entry, exists := im.warehouseItems[itemID]; exists {
-		entry.Quantity -= quantity
-		if entry.Quantity == 0 {
-			delete(im.warehouseItems, itemID)
-		}
+	// Separate copy so daily spoilage isn't applied twice to
+	// stock split between shop and warehouse
+	shopItem := *itemRef
+	im.shopItems[itemRef.ID] = &InventoryItem{
+		Item:          &shopItem,
+		Quantity:      quantity,
+		PurchasePrice: purchasePrice,
+		PurchaseDate:  time.Now(),
+		Location:      LocationShop,
 	}
+	return nil
+}
 
-	err := im.ShopInventory.AddItem(itemRef, quantity)
-	if err == nil {
-		if existing, exists := im.shopItems[itemID]; exists {
-			existing.Quantity += quantity
-		} else {
-			im.shopItems[itemID] = &InventoryItem{
-				Item:         itemRef,
-				Quantity:     quantity,
-				PurchaseDate: time.Now(),
-				Location:     LocationShop,
-			}
+// addWarehouseStockUnsafe puts moved stock into the warehouse
+func (im *InventoryManager) addWarehouseStockUnsafe(itemRef *item.Item, quantity, purchasePrice int) error {
+	if err := im.WarehouseInventory.AddItem(itemRef, quantity); err != nil {
+		return err
+	}
+	if existing, exists := im.warehouseItems[itemRef.ID]; exists {
+		existing.addLot(quantity, purchasePrice)
+		return nil
+	}
+
+	// Separate copy, as for the shop
+	warehouseItem := *itemRef
+	im.warehouseItems[itemRef.ID] = &InventoryItem{
+		Item:          &warehouseItem,
+		Quantity:      quantity,
+		PurchasePrice: purchasePrice,
+		PurchaseDate:  time.Now(),
+		Location:      LocationWarehouse,
+	}
+	return nil
+}
+
+// inTransitSpaceUnsafe returns the space reserved by shipments heading to a location
+func (im *InventoryManager) inTransitSpaceUnsafe(to InventoryLocation) int {
+	total := 0
+	for _, shipment := range im.inTransit {
+		if shipment.To == to {
+			total += shipment.Quantity * shipment.item.GetFootprint()
 		}
 	}
-	return err
+	return total
 }
 
 // GetShopQuantity returns quantity of an item in shop
@@ -300,6 +750,145 @@ func (im *InventoryManager) GetWarehouseQuantity(itemID string) int {
 	return im.WarehouseInventory.GetQuantity(itemID)
 }
 
+// GetPurchasePrice returns the recorded unit purchase price for an item, or 0 if unknown
+func (im *InventoryManager) GetPurchasePrice(itemID string) int {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+	return im.getPurchasePriceUnsafe(itemID)
+}
+
+// getPurchasePriceUnsafe returns the recorded purchase price without locking
+func (im *InventoryManager) getPurchasePriceUnsafe(itemID string) int {
+	if entry, exists := im.warehouseItems[itemID]; exists && entry.PurchasePrice > 0 {
+		return entry.PurchasePrice
+	}
+	if entry, exists := im.shopItems[itemID]; exists && entry.PurchasePrice > 0 {
+		return entry.PurchasePrice
+	}
+	return 0
+}
+
+// GetAveragePurchasePrice returns what the player paid per unit of an item,
+// averaged over the shop, warehouse and stock in transit and weighted by
+// quantity, and false if no priced stock is held
+func (im *InventoryManager) GetAveragePurchasePrice(itemID string) (int, bool) {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	total, quantity := 0, 0
+	for _, entries := range []map[string]*InventoryItem{im.shopItems, im.warehouseItems} {
+		if entry, exists := entries[itemID]; exists && entry.PurchasePrice > 0 {
+			total += entry.PurchasePrice * entry.Quantity
+			quantity += entry.Quantity
+		}
+	}
+	for _, shipment := range im.inTransit {
+		if shipment.ItemID == itemID && shipment.purchasePrice > 0 {
+			total += shipment.purchasePrice * shipment.Quantity
+			quantity += shipment.Quantity
+		}
+	}
+	if quantity == 0 {
+		return 0, false
+	}
+	return int(math.Round(float64(total) / float64(quantity))), true
+}
+
+// RemoveFromShop removes sold items from the shop
+func (im *InventoryManager) RemoveFromShop(itemID string, quantity int) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if err := im.ShopInventory.RemoveItem(itemID, quantity); err != nil {
+		return err
+	}
+
+	if entry, exists := im.shopItems[itemID]; exists {
+		entry.Quantity -= quantity
+		if entry.Quantity <= 0 {
+			delete(im.shopItems, itemID)
+		}
+	}
+	return nil
+}
+
+// ApplyShopLoss removes a fraction of every item's shop stock, rounding up so
+// any stocked item loses at least one unit. It returns the units lost by item
+// and their total value at purchase price.
+func (im *InventoryManager) ApplyShopLoss(fraction float64) (map[string]int, int) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	lost := make(map[string]int)
+	value := 0
+	if fraction <= 0 {
+		return lost, value
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	for itemID, entry := range im.shopItems {
+		quantity := int(math.Ceil(float64(entry.Quantity) * fraction))
+		if quantity > entry.Quantity {
+			quantity = entry.Quantity
+		}
+		if quantity <= 0 {
+			continue
+		}
+		if err := im.ShopInventory.RemoveItem(itemID, quantity); err != nil {
+			continue
+		}
+		value += quantity * entry.PurchasePrice
+		entry.Quantity -= quantity
+		if entry.Quantity <= 0 {
+			delete(im.shopItems, itemID)
+		}
+		lost[itemID] = quantity
+	}
+	return lost, value
+}
+
+// GetShopFreshness returns the remaining fraction (0-1) of a shop item's shelf life.
+// The second value is false for items that never spoil.
+func (im *InventoryManager) GetShopFreshness(itemID string) (float64, bool) {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	entry, exists := im.shopItems[itemID]
+	if !exists || entry.Item.Durability < 0 {
+		return 1.0, false
+	}
+
+	master, exists := item.GetItemRegistry().GetItem(itemID)
+	if !exists || master.Durability <= 0 {
+		return 1.0, false
+	}
+
+	freshness := float64(entry.Item.Durability) / float64(master.Durability)
+	if freshness > 1 {
+		freshness = 1
+	}
+	return freshness, true
+}
+
+// GetSpoilingSoon returns how many units of each perishable item, across the
+// shop and warehouse, will spoil within the given number of days
+func (im *InventoryManager) GetSpoilingSoon(days int) map[string]int {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	spoiling := make(map[string]int)
+	for _, entries := range []map[string]*InventoryItem{im.shopItems, im.warehouseItems} {
+		for itemID, entry := range entries {
+			if entry.Item.Durability > 0 && entry.Item.Durability <= days {
+				spoiling[itemID] += entry.Quantity
+			}
+		}
+	}
+	return spoiling
+}
+
 // GetTotalShopItems returns total number of items in shop
 func (im *InventoryManager) GetTotalShopItems() int {
 	im.mu.RLock()
@@ -316,6 +905,98 @@ func (im *InventoryManager) getTotalShopItemsUnsafe() int {
 	return total
 }
 
+// GetShopSpaceUsed returns the shop space taken up by item footprints
+func (im *InventoryManager) GetShopSpaceUsed() int {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+	return im.getShopSpaceUsedUnsafe()
+}
+
+// GetWarehouseSpaceUsed returns the warehouse space taken up by item footprints
+func (im *InventoryManager) GetWarehouseSpaceUsed() int {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+	return im.getWarehouseSpaceUsedUnsafe()
+}
+
+// getShopSpaceUsedUnsafe sums item footprints in the shop without locking
+func (im *InventoryManager) getShopSpaceUsedUnsafe() int {
+	return sumFootprints(im.shopItems)
+}
+
+// getWarehouseSpaceUsedUnsafe sums item footprints in the warehouse without locking
+func (im *InventoryManager) getWarehouseSpaceUsedUnsafe() int {
+	return sumFootprints(im.warehouseItems)
+}
+
+// sumFootprints returns the storage space used by tracked entries
+func sumFootprints(entries map[string]*InventoryItem) int {
+	total := 0
+	for _, entry := range entries {
+		total += entry.Quantity * entry.Item.GetFootprint()
+	}
+	return total
+}
+
+// getFootprintUnsafe returns an item's footprint from tracked stock or the registry
+func (im *InventoryManager) getFootprintUnsafe(itemID string) int {
+	if entry, exists := im.warehouseItems[itemID]; exists {
+		return entry.Item.GetFootprint()
+	}
+	if entry, exists := im.shopItems[itemID]; exists {
+		return entry.Item.GetFootprint()
+	}
+	return (&item.Item{ID: itemID}).GetFootprint()
+}
+
+// CheckConsistency reports every way the item counts and the tracking
+// entries disagree, and any location holding more than its capacity. An
+// empty result means the inventory is sound.
+func (im *InventoryManager) CheckConsistency() []string {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	problems := make([]string, 0)
+	problems = append(problems, checkTracking("shop", im.ShopInventory.GetAll(), im.shopItems)...)
+	problems = append(problems, checkTracking("warehouse", im.WarehouseInventory.GetAll(), im.warehouseItems)...)
+
+	if used := im.getShopSpaceUsedUnsafe(); used > im.ShopCapacity {
+		problems = append(problems, fmt.Sprintf("shop uses %d space of %d", used, im.ShopCapacity))
+	}
+	if used := im.getWarehouseSpaceUsedUnsafe(); used > im.WarehouseCapacity {
+		problems = append(problems, fmt.Sprintf("warehouse uses %d space of %d", used, im.WarehouseCapacity))
+	}
+	return problems
+}
+
+// checkTracking compares a location's item counts with its tracking entries
+func checkTracking(location string, counts map[string]int, tracked map[string]*InventoryItem) []string {
+	ids := make(map[string]bool, len(counts)+len(tracked))
+	for id := range counts {
+		ids[id] = true
+	}
+	for id := range tracked {
+		ids[id] = true
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	problems := make([]string, 0)
+	for _, id := range sorted {
+		trackedQty := 0
+		if entry, exists := tracked[id]; exists {
+			trackedQty = entry.Quantity
+		}
+		if counts[id] != trackedQty {
+			problems = append(problems, fmt.Sprintf("%s holds %d %s but tracks %d", location, counts[id], id, trackedQty))
+		}
+	}
+	return problems
+}
+
 // GetShop returns the shop inventory
 func (im *InventoryManager) GetShop() *item.Inventory {
 	im.mu.RLock()
@@ -342,13 +1023,91 @@ func (im *InventoryManager) Clear() {
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	im.ShopInventory = item.NewInventory()
-	im.WarehouseInventory = item.NewInventory()
+	// Empty the inventories in place: callers read these fields without
+	// holding im.mu, so the pointers must never change
+	im.ShopInventory.Clear()
+	im.WarehouseInventory.Clear()
 	im.shopItems = make(map[string]*InventoryItem)
 	im.warehouseItems = make(map[string]*InventoryItem)
 	im.salesVelocity = make(map[string]float64)
 	im.salesHistory = make(map[string]*SalesHistory)
 	im.spoiledItems = []*SpoiledItem{}
+	im.inTransit = nil
+	for _, rule := range im.autoSellRules {
+		rule.daysHeld = 0
+	}
+}
+
+// SetBaseCapacity replaces the shop and warehouse capacity, dropping any
+// upgrades or modifiers applied so far. Used when starting a new game.
+func (im *InventoryManager) SetBaseCapacity(shopCapacity, warehouseCapacity int) error {
+	if shopCapacity <= 0 {
+		return errors.New("shop capacity must be positive")
+	}
+	if warehouseCapacity <= 0 {
+		return errors.New("warehouse capacity must be positive")
+	}
+
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if shopCapacity < im.getShopSpaceUsedUnsafe() || warehouseCapacity < im.getWarehouseSpaceUsedUnsafe() {
+		return errors.New("capacity is smaller than current stock")
+	}
+
+	im.capacityManager = NewCapacityManager(&CapacityConfig{
+		BaseShopCapacity:      shopCapacity,
+		BaseWarehouseCapacity: warehouseCapacity,
+		MaxShopCapacity:       shopCapacity * 10,
+		MaxWarehouseCapacity:  warehouseCapacity * 10,
+		AutoExpandEnabled:     false,
+	})
+	im.ShopCapacity = shopCapacity
+	im.WarehouseCapacity = warehouseCapacity
+	return nil
+}
+
+// newRegistryItem creates an item from registry data so spoilage is tracked
+func newRegistryItem(itemID string, price int) *item.Item {
+	newItem := &item.Item{
+		ID:         itemID,
+		Name:       itemID,
+		Price:      price,
+		Durability: -1,
+		CreatedAt:  time.Now(),
+	}
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists {
+		newItem.Name = master.Name
+		newItem.Category = master.Category
+		newItem.BasePrice = master.BasePrice
+		newItem.Durability = master.Durability
+	}
+	return newItem
+}
+
+// AddToShopByID adds items directly to the shop by ID
+func (im *InventoryManager) AddToShopByID(itemID string, quantity int, price int) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	newItem := newRegistryItem(itemID, price)
+
+	currentSpace := im.getShopSpaceUsedUnsafe()
+	newSpace := quantity * newItem.GetFootprint()
+	actualCapacity := im.ShopCapacity
+	if im.capacityManager != nil {
+		actualCapacity = im.capacityManager.GetShopCapacity()
+	}
+	if currentSpace+newSpace > actualCapacity {
+		return fmt.Errorf("exceeds shop capacity: current %d + new %d > capacity %d",
+			currentSpace, newSpace, actualCapacity)
+	}
+
+	if err := im.checkMaxStackUnsafe(itemID, newItem.Category, quantity); err != nil {
+		return err
+	}
+
+	return im.addShopStockUnsafe(newItem, quantity, price)
 }
 
 // AddToWarehouseByID adds items directly to warehouse by ID
@@ -356,18 +1115,24 @@ func (im *InventoryManager) AddToWarehouseByID(itemID string, quantity int, pric
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	// Create new item
-	newItem := &item.Item{
-		ID:    itemID,
-		Name:  itemID, // TODO: Get proper name from item registry
-		Price: price,
+	newItem := newRegistryItem(itemID, price)
+
+	currentSpace := im.getWarehouseSpaceUsedUnsafe()
+	newSpace := quantity * newItem.GetFootprint()
+	if currentSpace+newSpace > im.WarehouseCapacity {
+		return fmt.Errorf("exceeds warehouse capacity: current %d + new %d > capacity %d",
+			currentSpace, newSpace, im.WarehouseCapacity)
+	}
+
+	if err := im.checkMaxStackUnsafe(itemID, newItem.Category, quantity); err != nil {
+		return err
 	}
 
 	// Add to warehouse inventory
 	err := im.WarehouseInventory.AddItem(newItem, quantity)
 	if err == nil {
 		if existing, exists := im.warehouseItems[itemID]; exists {
-			existing.Quantity += quantity
+			existing.addLot(quantity, price)
 		} else {
 			im.warehouseItems[itemID] = &InventoryItem{
 				Item:          newItem,
@@ -381,6 +1146,154 @@ func (im *InventoryManager) AddToWarehouseByID(itemID string, quantity int, pric
 	return err
 }
 
+// StockRecord is a lot of stock as kept in a save. Durability is the days
+// of shelf life left, -1 for stock that never spoils; zero means the save
+// predates it and the item's fresh durability applies.
+type StockRecord struct {
+	ItemID        string            `json:"itemId"`
+	Quantity      int               `json:"quantity"`
+	PurchaseDate  time.Time         `json:"purchaseDate"`
+	PurchasePrice int               `json:"purchasePrice"`
+	Location      InventoryLocation `json:"location"`
+	Durability    int               `json:"durability,omitempty"`
+}
+
+// ShipmentRecord is a shipment in transit as kept in a save
+type ShipmentRecord struct {
+	ItemID        string            `json:"itemId"`
+	Quantity      int               `json:"quantity"`
+	To            InventoryLocation `json:"to"`
+	ArrivalDay    int               `json:"arrivalDay"`
+	PurchasePrice int               `json:"purchasePrice"`
+	Durability    int               `json:"durability,omitempty"`
+}
+
+// StockRecords returns every lot in the shop and then the warehouse, each in
+// item order
+func (im *InventoryManager) StockRecords() []StockRecord {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	records := make([]StockRecord, 0, len(im.shopItems)+len(im.warehouseItems))
+	for _, entries := range []map[string]*InventoryItem{im.shopItems, im.warehouseItems} {
+		itemIDs := make([]string, 0, len(entries))
+		for itemID := range entries {
+			itemIDs = append(itemIDs, itemID)
+		}
+		sort.Strings(itemIDs)
+		for _, itemID := range itemIDs {
+			entry := entries[itemID]
+			records = append(records, StockRecord{
+				ItemID:        itemID,
+				Quantity:      entry.Quantity,
+				PurchaseDate:  entry.PurchaseDate,
+				PurchasePrice: entry.PurchasePrice,
+				Location:      entry.Location,
+				Durability:    entry.Item.Durability,
+			})
+		}
+	}
+	return records
+}
+
+// ShipmentRecords returns every shipment in transit, in the order sent
+func (im *InventoryManager) ShipmentRecords() []ShipmentRecord {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	records := make([]ShipmentRecord, 0, len(im.inTransit))
+	for _, shipment := range im.inTransit {
+		records = append(records, ShipmentRecord{
+			ItemID:        shipment.ItemID,
+			Quantity:      shipment.Quantity,
+			To:            shipment.To,
+			ArrivalDay:    shipment.ArrivalDay,
+			PurchasePrice: shipment.purchasePrice,
+			Durability:    shipment.item.Durability,
+		})
+	}
+	return records
+}
+
+// RestoreShipment puts a saved shipment back in transit. Space at its
+// destination was already reserved when it was sent, so it is not checked.
+func (im *InventoryManager) RestoreShipment(record ShipmentRecord) error {
+	if record.Quantity <= 0 {
+		return fmt.Errorf("shipment of %s has no stock", record.ItemID)
+	}
+
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	itemRef := newRegistryItem(record.ItemID, record.PurchasePrice)
+	if record.Durability != 0 {
+		itemRef.Durability = record.Durability
+	}
+	im.inTransit = append(im.inTransit, &Shipment{
+		ItemID:        record.ItemID,
+		Quantity:      record.Quantity,
+		To:            record.To,
+		ArrivalDay:    record.ArrivalDay,
+		item:          itemRef,
+		purchasePrice: record.PurchasePrice,
+	})
+	return nil
+}
+
+// RestoreStock puts a saved lot back in stock with its purchase date and
+// shelf life. A record without a durability keeps the item's fresh one.
+func (im *InventoryManager) RestoreStock(record StockRecord) error {
+	add := im.AddToWarehouseByID
+	if record.Location == LocationShop {
+		add = im.AddToShopByID
+	}
+	if err := add(record.ItemID, record.Quantity, record.PurchasePrice); err != nil {
+		return fmt.Errorf("%s: %w", record.ItemID, err)
+	}
+	if err := im.SetPurchaseDate(record.ItemID, record.Location, record.PurchaseDate); err != nil {
+		return err
+	}
+	if record.Durability != 0 {
+		return im.SetDurability(record.ItemID, record.Location, record.Durability)
+	}
+	return nil
+}
+
+// SetDurability sets the shelf life left on a lot, such as when restoring a
+// save
+func (im *InventoryManager) SetDurability(itemID string, location InventoryLocation, durability int) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	entries := im.shopItems
+	if location == LocationWarehouse {
+		entries = im.warehouseItems
+	}
+	entry, exists := entries[itemID]
+	if !exists {
+		return fmt.Errorf("item %s not found", itemID)
+	}
+	entry.Item.Durability = durability
+	return nil
+}
+
+// SetPurchaseDate sets when a lot was bought, such as when restoring a save
+func (im *InventoryManager) SetPurchaseDate(itemID string, location InventoryLocation, date time.Time) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	entries := im.shopItems
+	if location == LocationWarehouse {
+		entries = im.warehouseItems
+	}
+	entry, exists := entries[itemID]
+	if !exists {
+		return fmt.Errorf("item %s not found", itemID)
+	}
+	entry.PurchaseDate = date
+	return nil
+}
+
 // getTotalWarehouseItemsUnsafe returns total items without locking
 func (im *InventoryManager) getTotalWarehouseItemsUnsafe() int {
 	total := 0
@@ -404,93 +1317,192 @@ func (im *InventoryManager) GetSalesVelocity(itemID string) float64 {
 	return im.salesVelocity[itemID]
 }
 
-// OptimizePlacement optimizes item placement based on sales velocity
-func (im *InventoryManager) OptimizePlacement() {
-	im.mu.Lock()
-	defer im.mu.Unlock()
+// PlacementScore rates an item's claim on shop shelf space
+type PlacementScore struct {
+	ItemID            string
+	Velocity          float64 // Units sold per day
+	Margin            int     // Selling price over purchase price, per unit
+	Score             float64 // Velocity × margin, or velocity alone when margin is left out
+	ShopQuantity      int
+	WarehouseQuantity int
+}
+
+// PlacementMove is stock OptimizePlacement moves from the warehouse to the
+// shop
+type PlacementMove struct {
+	ItemID   string
+	Quantity int
+}
 
-	type itemPriority struct {
-		itemID   string
-		velocity float64
-		item     *item.Item
-		quantity int
+// RankPlacement scores every stocked item by velocity × margin, best first.
+// priceOf returns the price an item sells at; when nil, margin is left out and
+// items rank by velocity alone.
+func (im *InventoryManager) RankPlacement(priceOf func(itemID string) int) []PlacementScore {
+	prices := im.placementPrices(priceOf)
+
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+	return im.rankPlacementUnsafe(prices)
+}
+
+// placementPrices looks up the price of every stocked item before ranking
+// takes im.mu, as priceOf may take locks of its own. Returns nil for a nil
+// priceOf.
+func (im *InventoryManager) placementPrices(priceOf func(itemID string) int) map[string]int {
+	if priceOf == nil {
+		return nil
 	}
 
-	// Collect all items from warehouse with their velocities
-	priorities := make([]itemPriority, 0)
-	for id, entry := range im.warehouseItems {
-		if velocity, exists := im.salesVelocity[id]; exists && velocity > 0 {
-			priorities = append(priorities, itemPriority{
-				itemID:   id,
-				velocity: velocity,
-				item:     entry.Item,
-				quantity: entry.Quantity,
-			})
+	im.mu.RLock()
+	itemIDs := make([]string, 0, len(im.shopItems)+len(im.warehouseItems))
+	for _, entries := range []map[string]*InventoryItem{im.shopItems, im.warehouseItems} {
+		for itemID := range entries {
+			itemIDs = append(itemIDs, itemID)
 		}
 	}
+	im.mu.RUnlock()
 
-	// Sort by velocity (highest first)
-	sort.Slice(priorities, func(i, j int) bool {
-		return priorities[i].velocity > priorities[j].velocity
+	prices := make(map[string]int, len(itemIDs))
+	for _, itemID := range itemIDs {
+		prices[itemID] = priceOf(itemID)
+	}
+	return prices
+}
+
+// rankPlacementUnsafe scores stocked items without locking. Items missing
+// from prices rank as if they sold for nothing; nil prices leave margin out.
+func (im *InventoryManager) rankPlacementUnsafe(prices map[string]int) []PlacementScore {
+	scores := make(map[string]*PlacementScore)
+	scoreFor := func(itemID string) *PlacementScore {
+		if score, exists := scores[itemID]; exists {
+			return score
+		}
+		score := &PlacementScore{ItemID: itemID, Velocity: im.salesVelocity[itemID]}
+		score.Score = score.Velocity
+		if prices != nil {
+			score.Margin = prices[itemID] - im.getPurchasePriceUnsafe(itemID)
+			score.Score = score.Velocity * float64(score.Margin)
+		}
+		scores[itemID] = score
+		return score
+	}
+	for itemID, entry := range im.shopItems {
+		scoreFor(itemID).ShopQuantity = entry.Quantity
+	}
+	for itemID, entry := range im.warehouseItems {
+		scoreFor(itemID).WarehouseQuantity = entry.Quantity
+	}
+
+	ranking := make([]PlacementScore, 0, len(scores))
+	for _, score := range scores {
+		ranking = append(ranking, *score)
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Score != ranking[j].Score {
+			return ranking[i].Score > ranking[j].Score
+		}
+		return ranking[i].ItemID < ranking[j].ItemID
 	})
+	return ranking
+}
+
+// PlanPlacement returns the moves that fill free shop space from the
+// warehouse, best-placed items first, without making them. Items rank by
+// sales velocity × margin as in RankPlacement; pass a nil priceOf to rank by
+// velocity alone. Items that have not sold, or that sell at a loss, stay in
+// the warehouse.
+func (im *InventoryManager) PlanPlacement(priceOf func(itemID string) int) []PlacementMove {
+	prices := im.placementPrices(priceOf)
+
+	im.mu.RLock()
+	defer im.mu.RUnlock()
 
-	// Move high-velocity items to shop
-	shopSpace := im.ShopCapacity - im.getTotalShopItemsUnsafe()
-	for _, p := range priorities {
+	moves := make([]PlacementMove, 0)
+	shopSpace := im.ShopCapacity - im.getShopSpaceUsedUnsafe() - im.inTransitSpaceUnsafe(LocationShop)
+	for _, p := range im.rankPlacementUnsafe(prices) {
 		if shopSpace <= 0 {
 			break
 		}
+		if p.Velocity <= 0 || p.Score <= 0 || p.WarehouseQuantity == 0 {
+			continue
+		}
 
-		// Calculate how many to move
-		toMove := p.quantity
-		if toMove > shopSpace {
-			toMove = shopSpace
+		// Calculate how many fit in the remaining space
+		footprint := im.getFootprintUnsafe(p.ItemID)
+		toMove := min(p.WarehouseQuantity, shopSpace/footprint)
+		if toMove == 0 {
+			continue
 		}
+		moves = append(moves, PlacementMove{ItemID: p.ItemID, Quantity: toMove})
+		shopSpace -= toMove * footprint
+	}
+	return moves
+}
 
-		// Move items
-		_ = im.WarehouseInventory.RemoveItem(p.itemID, toMove)
-		_ = im.ShopInventory.AddItem(p.item, toMove)
-		shopSpace -= toMove
+// OptimizePlacement makes the moves PlanPlacement plans, moving stock to the
+// shop straight away
+func (im *InventoryManager) OptimizePlacement(priceOf func(itemID string) int) {
+	for _, move := range im.PlanPlacement(priceOf) {
+		_ = im.TransferToShop(move.ItemID, move.Quantity)
 	}
 }
 
-// ProcessDailyUpdate processes daily inventory updates
-func (im *InventoryManager) ProcessDailyUpdate() {
+// ProcessDailyUpdate ages perishable stock by a day, removes the lots that
+// spoiled today from the shop and warehouse and returns them. Spoiled lots
+// are also kept for GetAndClearSpoilageReport.
+func (im *InventoryManager) ProcessDailyUpdate() []*SpoiledItem {
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	// Process spoilage for shop items
-	for _, entry := range im.shopItems {
-		entry.Item.UpdateDurability()
-		if entry.Item.IsSpoiled() {
-			im.spoiledItems = append(im.spoiledItems, &SpoiledItem{
-				Item:     entry.Item,
-				Quantity: entry.Quantity,
-				Location: LocationShop,
-				Date:     time.Now(),
-			})
+	spoiled := make([]*SpoiledItem, 0)
+	for _, location := range []InventoryLocation{LocationShop, LocationWarehouse} {
+		entries, stock := im.shopItems, im.ShopInventory
+		if location == LocationWarehouse {
+			entries, stock = im.warehouseItems, im.WarehouseInventory
 		}
-	}
+		for itemID, entry := range entries {
+			if !entry.Item.IsPerishable() {
+				continue
+			}
+			entry.Item.UpdateDurability()
+			if !entry.Item.IsSpoiled() {
+				continue
+			}
 
-	// Process spoilage for warehouse items
-	for _, entry := range im.warehouseItems {
-		entry.Item.UpdateDurability()
-		if entry.Item.IsSpoiled() {
-			im.spoiledItems = append(im.spoiledItems, &SpoiledItem{
-				Item:     entry.Item,
-				Quantity: entry.Quantity,
-				Location: LocationWarehouse,
-				Date:     time.Now(),
+			_ = stock.RemoveItem(itemID, entry.Quantity)
+			delete(entries, itemID)
+			spoiled = append(spoiled, &SpoiledItem{
+				Item:      entry.Item,
+				Quantity:  entry.Quantity,
+				Location:  location,
+				Date:      time.Now(),
+				LostValue: entry.Quantity * entry.PurchasePrice,
 			})
 		}
 	}
+	im.spoiledItems = append(im.spoiledItems, spoiled...)
+	return spoiled
 }
 
-// GetSpoiledItems returns list of spoiled items
+// GetSpoiledItems returns a copy of the list of spoiled items
 func (im *InventoryManager) GetSpoiledItems() []*SpoiledItem {
 	im.mu.RLock()
 	defer im.mu.RUnlock()
-	return im.spoiledItems
+
+	spoiled := make([]*SpoiledItem, len(im.spoiledItems))
+	copy(spoiled, im.spoiledItems)
+	return spoiled
+}
+
+// GetAndClearSpoilageReport returns the lots spoiled since the last report,
+// for the daily summary, and starts a new report
+func (im *InventoryManager) GetAndClearSpoilageReport() []*SpoiledItem {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	report := im.spoiledItems
+	im.spoiledItems = make([]*SpoiledItem, 0)
+	return report
 }
 
 // SetMinimumStock sets minimum stock level for an item
@@ -560,8 +1572,8 @@ func (im *InventoryManager) CalculateRestockQuantity(itemID string, currentStock
 
 	// Limit by capacity
 	totalSpace := im.ShopCapacity + im.WarehouseCapacity
-	currentTotal := im.getTotalShopItemsUnsafe() + im.getTotalWarehouseItemsUnsafe()
-	availableSpace := totalSpace - currentTotal
+	currentSpace := im.getShopSpaceUsedUnsafe() + im.getWarehouseSpaceUsedUnsafe()
+	availableSpace := (totalSpace - currentSpace) / im.getFootprintUnsafe(itemID)
 
 	if needed > availableSpace {
 		needed = availableSpace
@@ -582,28 +1594,52 @@ func (im *InventoryManager) CreateSnapshot() *InventorySnapshot {
 		Timestamp:      time.Now(),
 	}
 
+	snapshot.shopEntries = make(map[string]InventoryItem, len(im.shopItems))
+	snapshot.warehouseEntries = make(map[string]InventoryItem, len(im.warehouseItems))
+
 	// Copy shop items
 	for id, entry := range im.shopItems {
 		snapshot.ShopItems[id] = entry.Quantity
 		snapshot.TotalValue += entry.Item.BasePrice * entry.Quantity
+		snapshot.shopEntries[id] = copyInventoryItem(entry)
 	}
 
 	// Copy warehouse items
 	for id, entry := range im.warehouseItems {
 		snapshot.WarehouseItems[id] = entry.Quantity
+		snapshot.warehouseEntries[id] = copyInventoryItem(entry)
+	}
+
+	for _, shipment := range im.inTransit {
+		snapshot.inTransit = append(snapshot.inTransit, *shipment)
 	}
 
 	return snapshot
 }
 
+// copyInventoryItem copies a stock record and its item
+func copyInventoryItem(entry *InventoryItem) InventoryItem {
+	copied := *entry
+	if entry.Item != nil {
+		itemCopy := *entry.Item
+		copied.Item = &itemCopy
+	}
+	return copied
+}
+
 // RestoreFromSnapshot restores inventory from a snapshot
 func (im *InventoryManager) RestoreFromSnapshot(snapshot *InventorySnapshot) error {
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	// Clear current inventories
-	im.ShopInventory = item.NewInventory()
-	im.WarehouseInventory = item.NewInventory()
+	// Clear current inventories in place, keeping the pointers stable
+	im.ShopInventory.Clear()
+	im.WarehouseInventory.Clear()
+
+	if snapshot.shopEntries != nil || snapshot.warehouseEntries != nil {
+		im.restoreEntriesUnsafe(snapshot)
+		return nil
+	}
 
 	// Restore shop items
 	for itemID, quantity := range snapshot.ShopItems {
@@ -622,6 +1658,30 @@ func (im *InventoryManager) RestoreFromSnapshot(snapshot *InventorySnapshot) err
 	return nil
 }
 
+// restoreEntriesUnsafe rebuilds stock from a snapshot's full records.
+// Caller must hold im.mu.
+func (im *InventoryManager) restoreEntriesUnsafe(snapshot *InventorySnapshot) {
+	im.shopItems = make(map[string]*InventoryItem, len(snapshot.shopEntries))
+	for id, entry := range snapshot.shopEntries {
+		restored := copyInventoryItem(&entry)
+		im.shopItems[id] = &restored
+		_ = im.ShopInventory.AddItem(restored.Item, restored.Quantity)
+	}
+
+	im.warehouseItems = make(map[string]*InventoryItem, len(snapshot.warehouseEntries))
+	for id, entry := range snapshot.warehouseEntries {
+		restored := copyInventoryItem(&entry)
+		im.warehouseItems[id] = &restored
+		_ = im.WarehouseInventory.AddItem(restored.Item, restored.Quantity)
+	}
+
+	im.inTransit = make([]*Shipment, 0, len(snapshot.inTransit))
+	for _, shipment := range snapshot.inTransit {
+		shipment := shipment
+		im.inTransit = append(im.inTransit, &shipment)
+	}
+}
+
 // RecordSale records a sale for tracking purposes
 func (im *InventoryManager) RecordSale(itemID string, quantity, days int) {
 	im.mu.Lock()
@@ -749,8 +1809,8 @@ func (im *InventoryManager) UpdateCapacity() {
 	defer im.mu.Unlock()
 
 	if im.capacityManager != nil {
-		shopItems := im.getTotalShopItemsUnsafe()
-		warehouseItems := im.getTotalWarehouseItemsUnsafe()
+		shopItems := im.getShopSpaceUsedUnsafe()
+		warehouseItems := im.getWarehouseSpaceUsedUnsafe()
 		im.capacityManager.RecordUtilization(shopItems, warehouseItems)
 	}
 }
@@ -764,8 +1824,8 @@ func (im *InventoryManager) GetCapacityStats() *CapacityStats {
 		return nil
 	}
 
-	shopItems := im.getTotalShopItemsUnsafe()
-	warehouseItems := im.getTotalWarehouseItemsUnsafe()
+	shopItems := im.getShopSpaceUsedUnsafe()
+	warehouseItems := im.getWarehouseSpaceUsedUnsafe()
 	return im.capacityManager.GetCapacityStats(shopItems, warehouseItems)
 }
 
@@ -814,8 +1874,8 @@ func (im *InventoryManager) OptimizeCapacityUsage() error {
 		return errors.New("capacity manager not initialized")
 	}
 
-	shopItems := im.getTotalShopItemsUnsafe()
-	warehouseItems := im.getTotalWarehouseItemsUnsafe()
+	shopItems := im.getShopSpaceUsedUnsafe()
+	warehouseItems := im.getWarehouseSpaceUsedUnsafe()
 
 	toShop, toWarehouse := im.capacityManager.CalculateOptimalTransfer(shopItems, warehouseItems)
 
@@ -827,15 +1887,19 @@ func (im *InventoryManager) OptimizeCapacityUsage() error {
 				break
 			}
 
+			footprint := entry.Item.GetFootprint()
 			transferQty := entry.Quantity
-			if transferQty > toShop {
-				transferQty = toShop
+			if transferQty*footprint > toShop {
+				transferQty = toShop / footprint
+			}
+			if transferQty == 0 {
+				continue
 			}
 
 			// Transfer without locks (we're already locked)
 			_ = im.WarehouseInventory.RemoveItem(itemID, transferQty)
 			_ = im.ShopInventory.AddItem(entry.Item, transferQty)
-			toShop -= transferQty
+			toShop -= transferQty * footprint
 		}
 	}
 
@@ -852,15 +1916,19 @@ func (im *InventoryManager) OptimizeCapacityUsage() error {
 				continue
 			}
 
+			footprint := entry.Item.GetFootprint()
 			transferQty := entry.Quantity
-			if transferQty > toWarehouse {
-				transferQty = toWarehouse
+			if transferQty*footprint > toWarehouse {
+				transferQty = toWarehouse / footprint
+			}
+			if transferQty == 0 {
+				continue
 			}
 
 			// Transfer without locks
 			_ = im.ShopInventory.RemoveItem(itemID, transferQty)
 			_ = im.WarehouseInventory.AddItem(entry.Item, transferQty)
-			toWarehouse -= transferQty
+			toWarehouse -= transferQty * footprint
 		}
 	}
 
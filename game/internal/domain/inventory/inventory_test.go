@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -248,7 +249,7 @@ func TestInventoryManager_OptimizePlacement(t *testing.T) {
 	manager.SetSalesVelocity("potion_001", 3.0)
 
 	// Optimize placement
-	manager.OptimizePlacement()
+	manager.OptimizePlacement(nil)
 
 	// High velocity items should be in shop
 	assert.Greater(t, manager.GetShopQuantity("apple_001"), 0, "High velocity apple should be in shop")
@@ -259,6 +260,49 @@ func TestInventoryManager_OptimizePlacement(t *testing.T) {
 	assert.LessOrEqual(t, totalInShop, 20, "Shop capacity should not be exceeded")
 }
 
+func TestInventoryManager_RankPlacement(t *testing.T) {
+	manager, _ := NewInventoryManager(5, 100)
+
+	require.NoError(t, manager.AddToWarehouseByID("apple_001", 10, 10))
+	require.NoError(t, manager.AddToWarehouseByID("potion_001", 10, 50))
+
+	// Apples sell fastest but barely above cost; potions sell a little
+	// slower at a much better margin
+	manager.SetSalesVelocity("apple_001", 5.0)
+	manager.SetSalesVelocity("potion_001", 4.0)
+	prices := map[string]int{"apple_001": 11, "potion_001": 70}
+	priceOf := func(itemID string) int { return prices[itemID] }
+
+	byVelocity := manager.RankPlacement(nil)
+	require.Len(t, byVelocity, 2)
+	assert.Equal(t, "apple_001", byVelocity[0].ItemID)
+
+	ranking := manager.RankPlacement(priceOf)
+	require.Len(t, ranking, 2)
+	assert.Equal(t, "potion_001", ranking[0].ItemID)
+	assert.Equal(t, 20, ranking[0].Margin)
+	assert.Equal(t, 80.0, ranking[0].Score)
+	assert.Equal(t, 5.0, ranking[1].Score)
+	assert.Equal(t, 10, ranking[1].WarehouseQuantity)
+
+	// Prices are looked up before the inventory is locked, so a lookup may
+	// use the inventory itself
+	lookup := func(itemID string) int {
+		manager.SetMinimumStock(itemID, 0)
+		return prices[itemID]
+	}
+	plan := manager.PlanPlacement(lookup)
+	require.Len(t, plan, 1)
+	assert.Equal(t, PlacementMove{ItemID: "potion_001", Quantity: 5}, plan[0])
+	assert.Zero(t, manager.GetShopQuantity("potion_001"), "planning moves nothing")
+
+	// Shelf space goes to the better-placed item first
+	manager.OptimizePlacement(priceOf)
+	assert.Greater(t, manager.GetShopQuantity("potion_001"), 0)
+	assert.Equal(t, 0, manager.GetShopQuantity("apple_001"))
+	assert.Empty(t, manager.CheckConsistency())
+}
+
 func TestInventoryManager_HandleSpoilage(t *testing.T) {
 	manager, _ := NewInventoryManager(20, 100)
 
@@ -287,6 +331,39 @@ func TestInventoryManager_HandleSpoilage(t *testing.T) {
 	assert.Equal(t, 2, manager.GetShopQuantity("sword_001"), "Swords should not spoil")
 }
 
+func TestInventoryManager_SpoilageRemovesStock(t *testing.T) {
+	manager, _ := NewInventoryManager(20, 100)
+	require.NoError(t, manager.AddToShopByID("apple", 5, 8))
+	require.NoError(t, manager.AddToWarehouseByID("apple", 10, 8))
+	require.NoError(t, manager.AddToWarehouseByID("iron_sword", 2, 100))
+
+	// Apples keep for three days; two days in they are still stocked
+	assert.Empty(t, manager.ProcessDailyUpdate())
+	assert.Empty(t, manager.ProcessDailyUpdate())
+	assert.Equal(t, 5, manager.GetShopQuantity("apple"))
+	assert.Empty(t, manager.GetAndClearSpoilageReport())
+
+	spoiled := manager.ProcessDailyUpdate()
+	require.Len(t, spoiled, 2)
+	assert.Zero(t, manager.GetShopQuantity("apple"))
+	assert.Zero(t, manager.GetWarehouseQuantity("apple"))
+	assert.Equal(t, 2, manager.GetWarehouseQuantity("iron_sword"), "swords never spoil")
+	assert.Empty(t, manager.CheckConsistency())
+
+	lost := map[InventoryLocation]int{}
+	for _, lot := range spoiled {
+		assert.Equal(t, "apple", lot.Item.ID)
+		lost[lot.Location] = lot.LostValue
+	}
+	assert.Equal(t, map[InventoryLocation]int{LocationShop: 40, LocationWarehouse: 80}, lost)
+
+	// The report hands over what spoiled once, then starts afresh
+	report := manager.GetAndClearSpoilageReport()
+	assert.Len(t, report, 2)
+	assert.Empty(t, manager.GetAndClearSpoilageReport())
+	assert.Empty(t, manager.ProcessDailyUpdate())
+}
+
 func TestSellStrategy_DetermineSellPriority(t *testing.T) {
 	strategies := []SellStrategy{
 		&FIFOStrategy{},
@@ -426,6 +503,106 @@ func TestInventorySnapshot(t *testing.T) {
 	assert.Equal(t, 2, newManager.GetWarehouseQuantity("sword_001"))
 }
 
+func TestInventorySnapshot_KeepsStockRecords(t *testing.T) {
+	manager, _ := NewInventoryManager(20, 100)
+	require.NoError(t, manager.AddToWarehouseByID("iron_sword", 3, 120))
+	require.NoError(t, manager.AddToWarehouseByID("apple", 10, 8))
+	require.NoError(t, manager.TransferToShop("apple", 4))
+
+	snapshot := manager.CreateSnapshot()
+
+	require.NoError(t, manager.TransferToShop("iron_sword", 3))
+	require.NoError(t, manager.AddToWarehouseByID("orange", 5, 9))
+	require.NoError(t, manager.RestoreFromSnapshot(snapshot))
+
+	assert.Equal(t, 3, manager.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 0, manager.GetShopQuantity("iron_sword"))
+	assert.Equal(t, 4, manager.GetShopQuantity("apple"))
+	assert.Equal(t, 0, manager.GetWarehouseQuantity("orange"))
+	assert.Equal(t, 120, manager.GetPurchasePrice("iron_sword"))
+	assert.Equal(t, item.CategoryWeapon, manager.warehouseItems["iron_sword"].Item.Category)
+	assert.Empty(t, manager.CheckConsistency())
+}
+
+func TestInventoryManager_TransferWarehouseTo(t *testing.T) {
+	main, _ := NewInventoryManager(20, 100)
+	branch, _ := NewInventoryManager(10, 5)
+	require.NoError(t, main.AddToWarehouseByID("iron_sword", 4, 120))
+
+	require.NoError(t, main.TransferWarehouseTo(branch, "iron_sword", 1))
+	assert.Equal(t, 3, main.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 1, branch.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 120, branch.GetPurchasePrice("iron_sword"))
+
+	// Stock the destination cannot hold stays put
+	assert.ErrorContains(t, main.TransferWarehouseTo(branch, "iron_sword", 3), "exceeds warehouse capacity")
+	assert.Equal(t, 3, main.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 120, main.GetPurchasePrice("iron_sword"))
+
+	assert.Error(t, main.TransferWarehouseTo(branch, "apple", 1))
+	assert.Error(t, main.TransferWarehouseTo(main, "iron_sword", 1))
+	assert.Empty(t, main.CheckConsistency())
+	assert.Empty(t, branch.CheckConsistency())
+}
+
+func TestInventoryManager_ClearKeepsInventories(t *testing.T) {
+	manager, _ := NewInventoryManager(20, 100)
+	apple, _ := item.NewItem("apple_001", "Apple", item.CategoryFruit, 10)
+	_ = manager.AddToShop(apple, 5)
+
+	shop := manager.ShopInventory
+	warehouse := manager.WarehouseInventory
+
+	// Readers holding the inventories keep iterating while the manager is
+	// cleared and restored
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			for range manager.ShopInventory.GetAll() {
+			}
+			for range manager.WarehouseInventory.GetAll() {
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			manager.Clear()
+			_ = manager.AddToShop(apple, 1)
+		}
+	}()
+	wg.Wait()
+
+	snapshot := manager.CreateSnapshot()
+	require.NoError(t, manager.RestoreFromSnapshot(snapshot))
+
+	assert.True(t, shop == manager.ShopInventory, "shop inventory was replaced")
+	assert.True(t, warehouse == manager.WarehouseInventory, "warehouse inventory was replaced")
+	assert.Equal(t, 1, shop.GetQuantity("apple_001"))
+}
+
+func TestInventoryManager_CheckConsistency(t *testing.T) {
+	manager, _ := NewInventoryManager(20, 100)
+	apple, _ := item.NewItem("apple_001", "Apple", item.CategoryFruit, 10)
+	sword, _ := item.NewItem("sword_001", "Iron Sword", item.CategoryWeapon, 200)
+	require.NoError(t, manager.AddToShop(apple, 5))
+	require.NoError(t, manager.AddToWarehouse(sword, 2))
+	assert.Empty(t, manager.CheckConsistency())
+
+	// Corrupt the tracking entries behind the counts' back
+	manager.shopItems["apple_001"].Quantity = 3
+	manager.warehouseItems["ghost"] = &InventoryItem{Item: sword, Quantity: 1}
+	manager.ShopCapacity = 2
+
+	assert.Equal(t, []string{
+		"shop holds 5 apple_001 but tracks 3",
+		"warehouse holds 0 ghost but tracks 1",
+		"shop uses 3 space of 2",
+	}, manager.CheckConsistency())
+}
+
 func TestInventoryManager_GetTurnoverRate(t *testing.T) {
 	manager, _ := NewInventoryManager(20, 100)
 
@@ -445,3 +622,214 @@ func TestInventoryManager_GetTurnoverRate(t *testing.T) {
 	assert.Greater(t, appleTurnover, swordTurnover, "Apples should have higher turnover")
 	assert.Greater(t, appleTurnover, 1.0, "Apple turnover should be > 1")
 }
+
+func TestInventoryManager_MaxStack(t *testing.T) {
+	manager, err := NewInventoryManager(100, 100)
+	require.NoError(t, err)
+
+	book, err := item.NewItem("rare_spellbook", "Rare Spellbook", item.CategoryMagicBook, 500)
+	require.NoError(t, err)
+	apple, err := item.NewItem("apple", "Apple", item.CategoryFruit, 10)
+	require.NoError(t, err)
+
+	manager.SetCategoryMaxStack(item.CategoryMagicBook, 10)
+
+	// The limit covers stock held in both locations
+	require.NoError(t, manager.AddToWarehouse(book, 6))
+	require.NoError(t, manager.AddToShop(book, 4))
+	assert.Equal(t, 10, manager.GetMaxStack("rare_spellbook"))
+
+	err = manager.AddToWarehouse(book, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds maximum stack")
+	err = manager.AddToShop(book, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds maximum stack")
+
+	// Plenty of total capacity remains for other items
+	require.NoError(t, manager.AddToWarehouse(apple, 50))
+
+	// Per-item limits override the category limit
+	manager.SetMaxStack("apple", 60)
+	assert.Equal(t, 60, manager.GetMaxStack("apple"))
+	assert.Error(t, manager.AddToWarehouseByID("apple", 11, 10))
+	assert.NoError(t, manager.AddToWarehouseByID("apple", 10, 10))
+
+	manager.SetMaxStack("apple", 0)
+	assert.Equal(t, 0, manager.GetMaxStack("apple"))
+}
+
+func TestInventoryManager_WeightedSpace(t *testing.T) {
+	manager, err := NewInventoryManager(30, 30)
+	require.NoError(t, err)
+
+	sword, err := item.NewItem("iron_sword", "Iron Sword", item.CategoryWeapon, 100)
+	require.NoError(t, err)
+	apple, err := item.NewItem("apple", "Apple", item.CategoryFruit, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, sword.GetFootprint())
+	assert.Equal(t, 1, apple.GetFootprint())
+
+	// Ten swords fill the shop while ten apples only use a third of it
+	require.NoError(t, manager.AddToShop(sword, 10))
+	assert.Equal(t, 30, manager.GetShopSpaceUsed())
+	assert.Equal(t, 10, manager.GetTotalShopItems())
+	assert.Error(t, manager.AddToShop(apple, 1))
+
+	require.NoError(t, manager.AddToWarehouse(apple, 10))
+	assert.Equal(t, 10, manager.GetWarehouseSpaceUsed())
+
+	// Moving swords back needs three units of space each
+	require.NoError(t, manager.TransferToWarehouse("iron_sword", 6))
+	assert.Equal(t, 28, manager.GetWarehouseSpaceUsed())
+	assert.Error(t, manager.TransferToWarehouse("iron_sword", 1))
+}
+
+func TestInventoryManager_AutoSell(t *testing.T) {
+	manager, err := NewInventoryManager(50, 50)
+	require.NoError(t, err)
+
+	assert.Error(t, manager.SetAutoSell("apple", 0, 0))
+	assert.Error(t, manager.SetAutoSell("apple", -1, 3))
+	require.NoError(t, manager.SetAutoSell("apple", 15, 0))
+	require.NoError(t, manager.SetAutoSell("iron_sword", 0, 3))
+
+	apple, err := item.NewItem("apple", "Apple", item.CategoryFruit, 10)
+	require.NoError(t, err)
+	sword, err := item.NewItem("iron_sword", "Iron Sword", item.CategoryWeapon, 100)
+	require.NoError(t, err)
+	require.NoError(t, manager.AddToShop(apple, 4))
+	require.NoError(t, manager.AddToWarehouse(apple, 6))
+	require.NoError(t, manager.AddToWarehouse(sword, 2))
+
+	prices := map[string]int{"apple": 12, "iron_sword": 100}
+	priceOf := func(itemID string) int { return prices[itemID] }
+
+	// Below the threshold nothing is sold
+	assert.Empty(t, manager.ProcessAutoSell(priceOf))
+	assert.Equal(t, 4, manager.GetShop().GetQuantity("apple"))
+
+	// The apple price crosses the threshold and all apples are sold
+	prices["apple"] = 16
+	actions := manager.ProcessAutoSell(priceOf)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "apple", actions[0].ItemID)
+	assert.Equal(t, 10, actions[0].Quantity)
+	assert.Equal(t, 16, actions[0].Price)
+	assert.Equal(t, AutoSellReasonPrice, actions[0].Reason)
+	assert.Equal(t, 0, manager.GetShop().GetQuantity("apple"))
+	assert.Equal(t, 0, manager.GetWarehouseQuantity("apple"))
+
+	// Swords go once they have been held for three days
+	actions = manager.ProcessAutoSell(priceOf)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "iron_sword", actions[0].ItemID)
+	assert.Equal(t, AutoSellReasonMaxDays, actions[0].Reason)
+	assert.Equal(t, 0, manager.GetWarehouseQuantity("iron_sword"))
+
+	manager.ClearAutoSell("apple")
+	_, exists := manager.GetAutoSell("apple")
+	assert.False(t, exists)
+}
+
+func TestInventoryManager_Shipments(t *testing.T) {
+	manager, err := NewInventoryManager(10, 50)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.AddToWarehouseByID("apple", 12, 8))
+
+	require.NoError(t, manager.ShipToShop("apple", 8, 3))
+	assert.Equal(t, 4, manager.GetWarehouseQuantity("apple"))
+	assert.Equal(t, 0, manager.GetShopQuantity("apple"))
+	require.Len(t, manager.GetInTransit(), 1)
+
+	// Shop space is reserved for stock on its way
+	assert.Error(t, manager.TransferToShop("apple", 4))
+
+	assert.Empty(t, manager.ReceiveShipments(2))
+	delivered := manager.ReceiveShipments(3)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, 8, delivered[0].Quantity)
+	assert.Equal(t, 8, manager.GetShopQuantity("apple"))
+	assert.Equal(t, 8, manager.GetPurchasePrice("apple"))
+	assert.Empty(t, manager.GetInTransit())
+
+	require.NoError(t, manager.ShipToWarehouse("apple", 2, 4))
+	manager.Clear()
+	assert.Empty(t, manager.GetInTransit())
+}
+
+func TestInventoryManager_StockRecords(t *testing.T) {
+	manager, _ := NewInventoryManager(10, 100)
+	require.NoError(t, manager.AddToShopByID("apple", 4, 8))
+	require.NoError(t, manager.AddToWarehouseByID("iron_sword", 2, 120))
+	assert.ErrorContains(t, manager.AddToShopByID("iron_sword", 4, 120), "exceeds shop capacity")
+
+	bought := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, manager.SetPurchaseDate("iron_sword", LocationWarehouse, bought))
+	assert.Error(t, manager.SetPurchaseDate("iron_sword", LocationShop, bought))
+
+	records := manager.StockRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, "apple", records[0].ItemID)
+	assert.Equal(t, LocationShop, records[0].Location)
+	assert.Equal(t, 8, records[0].PurchasePrice)
+	assert.Equal(t, 3, records[0].Durability)
+	assert.Equal(t, StockRecord{ItemID: "iron_sword", Quantity: 2, PurchaseDate: bought, PurchasePrice: 120, Location: LocationWarehouse, Durability: -1}, records[1])
+	assert.Equal(t, item.CategoryFruit, manager.shopItems["apple"].Item.Category)
+	assert.Empty(t, manager.CheckConsistency())
+
+	// Shelf life can be set back, as when loading
+	require.NoError(t, manager.SetDurability("apple", LocationShop, 1))
+	freshness, _ := manager.GetShopFreshness("apple")
+	assert.InDelta(t, 1.0/3, freshness, 1e-9)
+	assert.Error(t, manager.SetDurability("apple", LocationWarehouse, 1))
+}
+
+func TestInventoryManager_ShipmentRecords(t *testing.T) {
+	manager, _ := NewInventoryManager(10, 100)
+	require.NoError(t, manager.AddToWarehouseByID("apple", 4, 8))
+	manager.ProcessDailyUpdate()
+	require.NoError(t, manager.ShipToShop("apple", 3, 4))
+
+	records := manager.ShipmentRecords()
+	require.Equal(t, []ShipmentRecord{{ItemID: "apple", Quantity: 3, To: LocationShop, ArrivalDay: 4, PurchasePrice: 8, Durability: 2}}, records)
+
+	restored, _ := NewInventoryManager(10, 100)
+	require.NoError(t, restored.RestoreShipment(records[0]))
+	assert.Error(t, restored.RestoreShipment(ShipmentRecord{ItemID: "apple"}))
+	assert.Equal(t, manager.GetInTransit()[0].ArrivalDay, restored.GetInTransit()[0].ArrivalDay)
+
+	restored.ReceiveShipments(4)
+	assert.Equal(t, 3, restored.GetShopQuantity("apple"))
+	assert.Equal(t, 8, restored.GetPurchasePrice("apple"))
+	freshness, _ := restored.GetShopFreshness("apple")
+	assert.InDelta(t, 2.0/3, freshness, 1e-9)
+}
+
+func TestInventoryManager_GetAveragePurchasePrice(t *testing.T) {
+	manager, _ := NewInventoryManager(30, 100)
+	_, ok := manager.GetAveragePurchasePrice("apple")
+	assert.False(t, ok)
+
+	// Two batches at different prices average by quantity
+	require.NoError(t, manager.AddToWarehouseByID("apple", 10, 8))
+	require.NoError(t, manager.AddToWarehouseByID("apple", 30, 12))
+	price, ok := manager.GetAveragePurchasePrice("apple")
+	require.True(t, ok)
+	assert.Equal(t, 11, price)
+	assert.Equal(t, 11, manager.GetPurchasePrice("apple"))
+
+	// The average spans shop and warehouse
+	require.NoError(t, manager.TransferToShop("apple", 20))
+	require.NoError(t, manager.AddToWarehouseByID("apple", 20, 5))
+	price, ok = manager.GetAveragePurchasePrice("apple")
+	require.True(t, ok)
+	assert.Equal(t, 9, price) // (20*11 + 20*11 + 20*5) / 60
+
+	// Unpriced stock does not count toward the average
+	require.NoError(t, manager.AddToShop(&item.Item{ID: "gem", Category: item.CategoryGem}, 2))
+	_, ok = manager.GetAveragePurchasePrice("gem")
+	assert.False(t, ok)
+}
@@ -68,13 +68,13 @@ func (inv *Inventory) GetQuantity(itemID string) int {
 	return inv.items[itemID]
 }
 
-// GetAll returns all items in the inventory
+// GetAll returns a snapshot of all items in the inventory. The copy is taken
+// under the lock, so callers may iterate it while the inventory keeps changing.
 func (inv *Inventory) GetAll() map[string]int {
 	inv.mu.RLock()
 	defer inv.mu.RUnlock()
 
-	// Return a copy to prevent external modification
-	result := make(map[string]int)
+	result := make(map[string]int, len(inv.items))
 	for id, qty := range inv.items {
 		result[id] = qty
 	}
@@ -2,6 +2,9 @@ package item
 
 import (
 	"errors"
+	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,6 +21,18 @@ const (
 	CategoryGem       Category = "GEM"
 )
 
+// AllCategories returns every item category in display order
+func AllCategories() []Category {
+	return []Category{
+		CategoryFruit,
+		CategoryPotion,
+		CategoryWeapon,
+		CategoryAccessory,
+		CategoryMagicBook,
+		CategoryGem,
+	}
+}
+
 // Season represents the game season
 type Season string
 
@@ -91,6 +106,12 @@ func (i *Item) IsSpoiled() bool {
 	return i.Durability == 0
 }
 
+// IsPerishable reports whether the item has a shelf life, spoiled or not;
+// a durability of -1 never spoils
+func (i *Item) IsPerishable() bool {
+	return i.Durability >= 0
+}
+
 // GetVolatility returns the price volatility for the item category
 func (i *Item) GetVolatility() float32 {
 	volatilityMap := map[Category]float32{
@@ -108,6 +129,27 @@ func (i *Item) GetVolatility() float32 {
 	return 0.1 // default volatility
 }
 
+// GetFootprint returns the storage space one unit occupies, preferring the
+// registry entry over the category default
+func (i *Item) GetFootprint() int {
+	if master, ok := GetItemRegistry().GetItem(i.ID); ok && master.Footprint > 0 {
+		return master.Footprint
+	}
+	return GetCategoryFootprint(i.Category)
+}
+
+// GetCategoryFootprint returns the default storage space per unit for a category
+func GetCategoryFootprint(category Category) int {
+	switch category {
+	case CategoryWeapon:
+		return 3 // Long and awkward to shelve
+	case CategoryMagicBook:
+		return 2
+	default:
+		return 1
+	}
+}
+
 // getDurabilityByCategory returns the default durability for each category
 func getDurabilityByCategory(category Category) int {
 	switch category {
@@ -128,11 +170,110 @@ func getDurabilityByCategory(category Category) int {
 type ItemMaster struct {
 	ID                string
 	Name              string
+	Description       string
 	Category          Category
 	BasePrice         int
 	Durability        int
+	Footprint         int // Storage space per unit; defaults by category when 0
 	Volatility        float32
 	SeasonalModifiers map[Season]float32
+	Quality           int    // Quality tier; zero means standard
+	BaseID            string // Catalog item a quality variant derives from
+}
+
+// GetQuality returns the item's quality tier, treating unset as standard
+func (im *ItemMaster) GetQuality() int {
+	if im.Quality < QualityStandard {
+		return QualityStandard
+	}
+	return im.Quality
+}
+
+// GetBaseID returns the catalog item this master derives from
+func (im *ItemMaster) GetBaseID() string {
+	if im.BaseID == "" {
+		return im.ID
+	}
+	return im.BaseID
+}
+
+// variant derives the master data for a quality tier of this item
+func (im *ItemMaster) variant(quality int) *ItemMaster {
+	return &ItemMaster{
+		ID:                VariantID(im.ID, quality),
+		Name:              QualityName(quality) + " " + im.Name,
+		Description:       im.Description,
+		Category:          im.Category,
+		BasePrice:         int(math.Round(float64(im.BasePrice) * QualityMultiplier(quality))),
+		Durability:        im.Durability,
+		Footprint:         im.Footprint,
+		Volatility:        im.Volatility,
+		SeasonalModifiers: im.SeasonalModifiers,
+		Quality:           quality,
+		BaseID:            im.ID,
+	}
+}
+
+// Quality tiers. Every catalog item can be stocked at any tier; a variant is
+// addressed by the base item ID plus a tier suffix, e.g. "apple@q3", and the
+// standard tier is the plain item ID.
+const (
+	QualityStandard = 1
+	QualityMax      = 5
+
+	qualityPriceStep = 0.25 // Price gained per tier above standard
+	variantSeparator = "@q"
+)
+
+var qualityNames = map[int]string{
+	1: "Standard",
+	2: "Fine",
+	3: "Superior",
+	4: "Masterwork",
+	5: "Legendary",
+}
+
+// IsValidQuality reports whether quality is a known tier
+func IsValidQuality(quality int) bool {
+	return quality >= QualityStandard && quality <= QualityMax
+}
+
+// QualityMultiplier returns how much a quality tier scales an item's price
+func QualityMultiplier(quality int) float64 {
+	if !IsValidQuality(quality) {
+		return 1.0
+	}
+	return 1.0 + qualityPriceStep*float64(quality-QualityStandard)
+}
+
+// QualityName returns the display name of a quality tier
+func QualityName(quality int) string {
+	if name, ok := qualityNames[quality]; ok {
+		return name
+	}
+	return qualityNames[QualityStandard]
+}
+
+// VariantID returns the item ID for a quality tier of a base item
+func VariantID(baseID string, quality int) string {
+	if !IsValidQuality(quality) || quality == QualityStandard {
+		return baseID
+	}
+	return baseID + variantSeparator + strconv.Itoa(quality)
+}
+
+// ParseVariantID splits an item ID into its base item and quality tier.
+// IDs without a valid tier suffix are standard quality.
+func ParseVariantID(id string) (string, int) {
+	index := strings.LastIndex(id, variantSeparator)
+	if index <= 0 {
+		return id, QualityStandard
+	}
+	quality, err := strconv.Atoi(id[index+len(variantSeparator):])
+	if err != nil || !IsValidQuality(quality) || quality == QualityStandard {
+		return id, QualityStandard
+	}
+	return id[:index], quality
 }
 
 // GetSeasonalModifier returns the price modifier for a given season
@@ -35,12 +35,13 @@ func GetItemRegistry() *ItemRegistry {
 func (r *ItemRegistry) initializeDefaultItems() {
 	// Fruits
 	r.RegisterItem(&ItemMaster{
-		ID:         "apple",
-		Name:       "Fresh Apple",
-		Category:   CategoryFruit,
-		BasePrice:  10,
-		Durability: 3,
-		Volatility: 0.2,
+		ID:          "apple",
+		Name:        "Fresh Apple",
+		Description: "A crisp apple picked from local orchards",
+		Category:    CategoryFruit,
+		BasePrice:   10,
+		Durability:  3,
+		Volatility:  0.2,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.1,
 			SeasonSummer: 1.0,
@@ -50,12 +51,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 	})
 
 	r.RegisterItem(&ItemMaster{
-		ID:         "orange",
-		Name:       "Juicy Orange",
-		Category:   CategoryFruit,
-		BasePrice:  12,
-		Durability: 4,
-		Volatility: 0.15,
+		ID:          "orange",
+		Name:        "Juicy Orange",
+		Description: "A sweet orange shipped from the south",
+		Category:    CategoryFruit,
+		BasePrice:   12,
+		Durability:  4,
+		Volatility:  0.15,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 0.9,
 			SeasonSummer: 1.2,
@@ -65,12 +67,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 	})
 
 	r.RegisterItem(&ItemMaster{
-		ID:         "grapes",
-		Name:       "Sweet Grapes",
-		Category:   CategoryFruit,
-		BasePrice:  15,
-		Durability: 2,
-		Volatility: 0.25,
+		ID:          "grapes",
+		Name:        "Sweet Grapes",
+		Description: "A bunch of ripe grapes that spoil quickly",
+		Category:    CategoryFruit,
+		BasePrice:   15,
+		Durability:  2,
+		Volatility:  0.25,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 0.8,
 			SeasonSummer: 1.3,
@@ -81,12 +84,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 
 	// Potions
 	r.RegisterItem(&ItemMaster{
-		ID:         "health_potion",
-		Name:       "Health Potion",
-		Category:   CategoryPotion,
-		BasePrice:  50,
-		Durability: 30,
-		Volatility: 0.3,
+		ID:          "health_potion",
+		Name:        "Health Potion",
+		Description: "Restores health to weary adventurers",
+		Category:    CategoryPotion,
+		BasePrice:   50,
+		Durability:  30,
+		Volatility:  0.3,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.0,
 			SeasonSummer: 0.9,
@@ -96,12 +100,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 	})
 
 	r.RegisterItem(&ItemMaster{
-		ID:         "mana_potion",
-		Name:       "Mana Potion",
-		Category:   CategoryPotion,
-		BasePrice:  60,
-		Durability: 30,
-		Volatility: 0.35,
+		ID:          "mana_potion",
+		Name:        "Mana Potion",
+		Description: "Restores magical energy",
+		Category:    CategoryPotion,
+		BasePrice:   60,
+		Durability:  30,
+		Volatility:  0.35,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.1,
 			SeasonSummer: 1.0,
@@ -111,12 +116,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 	})
 
 	r.RegisterItem(&ItemMaster{
-		ID:         "stamina_potion",
-		Name:       "Stamina Potion",
-		Category:   CategoryPotion,
-		BasePrice:  40,
-		Durability: 30,
-		Volatility: 0.25,
+		ID:          "stamina_potion",
+		Name:        "Stamina Potion",
+		Description: "Helps travelers keep going on long journeys",
+		Category:    CategoryPotion,
+		BasePrice:   40,
+		Durability:  30,
+		Volatility:  0.25,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.2,
 			SeasonSummer: 1.3,
@@ -127,12 +133,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 
 	// Weapons
 	r.RegisterItem(&ItemMaster{
-		ID:         "iron_sword",
-		Name:       "Iron Sword",
-		Category:   CategoryWeapon,
-		BasePrice:  150,
-		Durability: -1, // Never spoils
-		Volatility: 0.1,
+		ID:          "iron_sword",
+		Name:        "Iron Sword",
+		Description: "A sturdy iron sword for new adventurers",
+		Category:    CategoryWeapon,
+		BasePrice:   150,
+		Durability:  -1, // Never spoils
+		Volatility:  0.1,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.0,
 			SeasonSummer: 1.0,
@@ -142,12 +149,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 	})
 
 	r.RegisterItem(&ItemMaster{
-		ID:         "steel_sword",
-		Name:       "Steel Sword",
-		Category:   CategoryWeapon,
-		BasePrice:  300,
-		Durability: -1,
-		Volatility: 0.08,
+		ID:          "steel_sword",
+		Name:        "Steel Sword",
+		Description: "A well-balanced steel sword",
+		Category:    CategoryWeapon,
+		BasePrice:   300,
+		Durability:  -1,
+		Volatility:  0.08,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.0,
 			SeasonSummer: 1.0,
@@ -157,12 +165,13 @@ func (r *ItemRegistry) initializeDefaultItems() {
 	})
 
 	r.RegisterItem(&ItemMaster{
-		ID:         "magic_staff",
-		Name:       "Magic Staff",
-		Category:   CategoryWeapon,
-		BasePrice:  500,
-		Durability: -1,
-		Volatility: 0.15,
+		ID:          "magic_staff",
+		Name:        "Magic Staff",
+		Description: "A staff that channels magical power",
+		Category:    CategoryWeapon,
+		BasePrice:   500,
+		Durability:  -1,
+		Volatility:  0.15,
 		SeasonalModifiers: map[Season]float32{
 			SeasonSpring: 1.1,
 			SeasonSummer: 1.0,
@@ -174,17 +183,50 @@ func (r *ItemRegistry) initializeDefaultItems() {
 
 // RegisterItem adds an item to the registry
 func (r *ItemRegistry) RegisterItem(master *ItemMaster) {
+	if master.Footprint <= 0 {
+		master.Footprint = GetCategoryFootprint(master.Category)
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.items[master.ID] = master
 }
 
-// GetItem retrieves an item from the registry
+// GetItem retrieves an item from the registry. Quality variant IDs resolve
+// to master data derived from their base item.
 func (r *ItemRegistry) GetItem(id string) (*ItemMaster, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	item, exists := r.items[id]
-	return item, exists
+	if item, exists := r.items[id]; exists {
+		return item, true
+	}
+
+	baseID, quality := ParseVariantID(id)
+	base, exists := r.items[baseID]
+	if !exists || quality == QualityStandard {
+		return nil, false
+	}
+	return base.variant(quality), true
+}
+
+// GetQualityVariants returns an item's master data at every quality tier,
+// from standard upwards
+func (r *ItemRegistry) GetQualityVariants(id string) []*ItemMaster {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	baseID, _ := ParseVariantID(id)
+	base, exists := r.items[baseID]
+	if !exists {
+		return nil
+	}
+
+	variants := make([]*ItemMaster, 0, QualityMax)
+	variants = append(variants, base)
+	for quality := QualityStandard + 1; quality <= QualityMax; quality++ {
+		variants = append(variants, base.variant(quality))
+	}
+	return variants
 }
 
 // GetAllItems returns all items in the registry
@@ -1,6 +1,8 @@
 package item
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -303,6 +305,43 @@ func TestItemInventory_RemoveItem(t *testing.T) {
 	})
 }
 
+func TestItemInventory_GetAllSnapshot(t *testing.T) {
+	inv := NewInventory()
+	apple, _ := NewItem("apple", "Apple", CategoryFruit, 10)
+	require.NoError(t, inv.AddItem(apple, 3))
+
+	// The snapshot is detached from the inventory
+	snapshot := inv.GetAll()
+	snapshot["apple"] = 99
+	require.NoError(t, inv.AddItem(apple, 1))
+	assert.Equal(t, 99, snapshot["apple"])
+	assert.Equal(t, 4, inv.GetQuantity("apple"))
+
+	// Iterating snapshots while items are added concurrently must not race
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			added, _ := NewItem(fmt.Sprintf("item_%d", i), "Item", CategoryFruit, 10)
+			_ = inv.AddItem(added, 1)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			total := 0
+			for _, qty := range inv.GetAll() {
+				total += qty
+			}
+			assert.GreaterOrEqual(t, total, 4)
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, inv.GetAll(), 201)
+}
+
 func TestItemMaster_GetSeasonalModifier(t *testing.T) {
 	master := &ItemMaster{
 		ID:        "apple_001",
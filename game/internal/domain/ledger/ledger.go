@@ -0,0 +1,187 @@
+// Package ledger records every change to the player's gold
+package ledger
+
+import (
+	"sync"
+	"time"
+)
+
+// EntryType categorizes a ledger entry
+type EntryType string
+
+const (
+	EntryPurchase   EntryType = "purchase"   // Stock bought from suppliers
+	EntrySale       EntryType = "sale"       // Stock sold to customers
+	EntryExpense    EntryType = "expense"    // Upgrades and other running costs
+	EntryClaim      EntryType = "claim"      // Insurance payouts for stolen stock
+	EntryExchange   EntryType = "exchange"   // Gold traded for or from foreign currency
+	EntryAdjustment EntryType = "adjustment" // Gold set directly, such as by a QA cheat
+	EntryDonation   EntryType = "donation"   // Stock given away for reputation; moves no gold
+	EntrySpoilage   EntryType = "spoilage"   // Stock written off as spoiled; moves no gold
+)
+
+// Entry is a single recorded change in gold
+type Entry struct {
+	Type      EntryType
+	ItemID    string // Empty for entries not tied to an item
+	Currency  string // Foreign currency traded, for exchange entries only
+	Quantity  int
+	Amount    int // Signed change in gold
+	Profit    int // Gain over cost basis for sales; the cost written off, negative, for spoilage
+	Tax       int // Sales tax or tariff included in Amount
+	Day       int
+	Timestamp time.Time
+}
+
+// Ledger keeps the opening balance and every gold change since
+type Ledger struct {
+	openingBalance int
+	entries        []Entry
+	revision       int
+	mu             sync.RWMutex
+}
+
+// NewLedger creates a ledger starting from the given balance
+func NewLedger(openingBalance int) *Ledger {
+	return &Ledger{
+		openingBalance: openingBalance,
+		entries:        make([]Entry, 0),
+	}
+}
+
+// Record appends an entry, stamping it with the current time if unset
+func (l *Ledger) Record(entry Entry) {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	l.revision++
+}
+
+// Recent returns up to limit entries, newest first
+func (l *Ledger) Recent(limit int) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if limit > len(l.entries) {
+		limit = len(l.entries)
+	}
+	if limit < 0 {
+		limit = 0
+	}
+
+	recent := make([]Entry, 0, limit)
+	for i := len(l.entries) - 1; i >= len(l.entries)-limit; i-- {
+		recent = append(recent, l.entries[i])
+	}
+	return recent
+}
+
+// Revision returns a counter that changes whenever the ledger does, letting
+// callers cache views of it
+func (l *Ledger) Revision() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.revision
+}
+
+// Entries returns a copy of all entries in the order they were recorded
+func (l *Ledger) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]Entry, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
+
+// OpeningBalance returns the gold the ledger started from
+func (l *Ledger) OpeningBalance() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.openingBalance
+}
+
+// ExpectedBalance returns the opening balance plus every recorded change
+func (l *Ledger) ExpectedBalance() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	balance := l.openingBalance
+	for _, entry := range l.entries {
+		balance += entry.Amount
+	}
+	return balance
+}
+
+// BalanceAtStartOf returns the balance before any entry stamped with day or
+// later, i.e. the gold held when that day began
+func (l *Ledger) BalanceAtStartOf(day int) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	balance := l.openingBalance
+	for _, entry := range l.entries {
+		if entry.Day < day {
+			balance += entry.Amount
+		}
+	}
+	return balance
+}
+
+// PeakBalance returns the highest balance reached, including the opening balance
+func (l *Ledger) PeakBalance() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	balance := l.openingBalance
+	peak := balance
+	for _, entry := range l.entries {
+		balance += entry.Amount
+		if balance > peak {
+			peak = balance
+		}
+	}
+	return peak
+}
+
+// Snapshot is a copy of the ledger's opening balance and entries
+type Snapshot struct {
+	openingBalance int
+	entries        []Entry
+}
+
+// CreateSnapshot copies the ledger as it stands
+func (l *Ledger) CreateSnapshot() *Snapshot {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return &Snapshot{
+		openingBalance: l.openingBalance,
+		entries:        append([]Entry(nil), l.entries...),
+	}
+}
+
+// RestoreFromSnapshot puts the ledger back to a snapshot, dropping entries
+// recorded since. The revision still moves forward so caches rebuild.
+func (l *Ledger) RestoreFromSnapshot(snapshot *Snapshot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.openingBalance = snapshot.openingBalance
+	l.entries = append(make([]Entry, 0, len(snapshot.entries)), snapshot.entries...)
+	l.revision++
+}
+
+// Reset clears all entries and starts again from a new opening balance
+func (l *Ledger) Reset(openingBalance int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.openingBalance = openingBalance
+	l.entries = make([]Entry, 0)
+	l.revision++
+}
@@ -0,0 +1,85 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_ExpectedBalance(t *testing.T) {
+	l := NewLedger(1000)
+
+	l.Record(Entry{Type: EntryPurchase, ItemID: "apple", Quantity: 5, Amount: -50})
+	l.Record(Entry{Type: EntrySale, ItemID: "apple", Quantity: 5, Amount: 80})
+	l.Record(Entry{Type: EntryExpense, Amount: -200})
+
+	assert.Equal(t, 830, l.ExpectedBalance())
+	assert.Len(t, l.Entries(), 3)
+	assert.False(t, l.Entries()[0].Timestamp.IsZero())
+
+	l.Reset(500)
+	assert.Equal(t, 500, l.OpeningBalance())
+	assert.Equal(t, 500, l.ExpectedBalance())
+	assert.Empty(t, l.Entries())
+}
+
+func TestLedger_Recent(t *testing.T) {
+	l := NewLedger(100)
+	revision := l.Revision()
+
+	for i := 1; i <= 5; i++ {
+		l.Record(Entry{Type: EntrySale, ItemID: "apple", Quantity: i, Amount: i * 10})
+	}
+	assert.NotEqual(t, revision, l.Revision())
+
+	recent := l.Recent(3)
+	assert.Len(t, recent, 3)
+	assert.Equal(t, 5, recent[0].Quantity)
+	assert.Equal(t, 3, recent[2].Quantity)
+
+	assert.Len(t, l.Recent(10), 5)
+	assert.Empty(t, l.Recent(0))
+}
+
+func TestLedger_PeakBalance(t *testing.T) {
+	l := NewLedger(100)
+	assert.Equal(t, 100, l.PeakBalance())
+
+	l.Record(Entry{Type: EntrySale, Amount: 150})
+	l.Record(Entry{Type: EntryPurchase, Amount: -200})
+	l.Record(Entry{Type: EntrySale, Amount: 50})
+
+	assert.Equal(t, 250, l.PeakBalance())
+	assert.Equal(t, 100, l.ExpectedBalance())
+}
+
+func TestLedger_BalanceAtStartOf(t *testing.T) {
+	l := NewLedger(100)
+	l.Record(Entry{Type: EntrySale, Amount: 50, Day: 1})
+	l.Record(Entry{Type: EntryPurchase, Amount: -30, Day: 2})
+	l.Record(Entry{Type: EntrySale, Amount: 20, Day: 2})
+
+	assert.Equal(t, 100, l.BalanceAtStartOf(1))
+	assert.Equal(t, 150, l.BalanceAtStartOf(2))
+	assert.Equal(t, 140, l.BalanceAtStartOf(3))
+	assert.Equal(t, l.ExpectedBalance(), l.BalanceAtStartOf(3))
+}
+
+func TestLedger_Snapshot(t *testing.T) {
+	l := NewLedger(1000)
+	l.Record(Entry{Type: EntryPurchase, ItemID: "apple", Quantity: 5, Amount: -50})
+	snapshot := l.CreateSnapshot()
+
+	l.Record(Entry{Type: EntrySale, ItemID: "apple", Quantity: 5, Amount: 80})
+	revision := l.Revision()
+
+	l.RestoreFromSnapshot(snapshot)
+	assert.Len(t, l.Entries(), 1)
+	assert.Equal(t, 950, l.ExpectedBalance())
+	assert.NotEqual(t, revision, l.Revision())
+
+	// The snapshot is unaffected by entries recorded after a restore
+	l.Record(Entry{Type: EntryExpense, Amount: -10})
+	l.RestoreFromSnapshot(snapshot)
+	assert.Equal(t, 950, l.ExpectedBalance())
+}
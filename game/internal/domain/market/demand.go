@@ -0,0 +1,134 @@
+package market
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// salesHistoryDays is how many finished days of sales demand simulation
+// looks back over
+const salesHistoryDays = 7
+
+// DefaultElasticity is the price elasticity of categories without their own
+const DefaultElasticity = 1.0
+
+// defaultElasticities is how sharply each category's sales fall as its
+// price rises above the market's. Necessities barely respond; luxuries do.
+var defaultElasticities = map[item.Category]float64{
+	item.CategoryFruit:     0.5, // Inelastic (necessity)
+	item.CategoryPotion:    0.7, // Somewhat inelastic
+	item.CategoryWeapon:    1.0, // Unit elastic
+	item.CategoryAccessory: 1.5, // Elastic
+	item.CategoryMagicBook: 1.8, // Elastic
+	item.CategoryGem:       2.0, // Very elastic (luxury)
+}
+
+// copyElasticities returns a copy of an elasticity table
+func copyElasticities(elasticities map[item.Category]float64) map[item.Category]float64 {
+	copied := make(map[item.Category]float64, len(elasticities))
+	for category, elasticity := range elasticities {
+		copied[category] = elasticity
+	}
+	return copied
+}
+
+// SetCategoryElasticity sets how sharply a category's sales respond to
+// price. One means a 1% price rise costs about 1% of sales.
+func (m *Market) SetCategoryElasticity(category item.Category, elasticity float64) error {
+	if elasticity <= 0 {
+		return fmt.Errorf("elasticity must be positive, got %.2f", elasticity)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.elasticities[category] = elasticity
+	return nil
+}
+
+// GetCategoryElasticity returns how sharply a category's sales respond to price
+func (m *Market) GetCategoryElasticity(category item.Category) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.getElasticityUnsafe(category)
+}
+
+// getElasticityUnsafe returns a category's elasticity without locking
+func (m *Market) getElasticityUnsafe(category item.Category) float64 {
+	if elasticity, exists := m.elasticities[category]; exists {
+		return elasticity
+	}
+	return DefaultElasticity
+}
+
+// SimulateDemand estimates how many units of an item customers buy in a day
+// at the given price. Selling at the market price moves about the day's
+// liquidity, pulled toward what has actually sold on recent days, and scaled
+// by the current demand level. Pricing above or below the market price
+// changes that by the category's elasticity. Unknown items sell nothing.
+func (m *Market) SimulateDemand(itemID string, price float64) int {
+	if price <= 0 {
+		return 0
+	}
+	marketPrice := float64(m.GetPrice(itemID))
+	baseID, _ := resolveVariant(itemID)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	itemObj, exists := m.items[baseID]
+	if !exists {
+		return 0
+	}
+
+	baseline := float64(m.getLiquidityUnsafe(baseID))
+	if recent, ok := m.recentDailySalesUnsafe(baseID); ok {
+		baseline = (baseline + recent) / 2
+	}
+	expected := baseline * m.State.GetDemandModifier()
+	if marketPrice > 0 {
+		expected *= math.Pow(price/marketPrice, -m.getElasticityUnsafe(itemObj.Category))
+	}
+
+	return int(math.Round(expected))
+}
+
+// recentDailySalesUnsafe returns the average units of an item sold on the
+// finished days within the sales history that saw any sales, and whether
+// there were any
+func (m *Market) recentDailySalesUnsafe(itemID string) (float64, bool) {
+	days := append([]dailySales(nil), m.recentSales[itemID]...)
+	if today, exists := m.soldToday[itemID]; exists && today.day < m.State.CurrentDay {
+		days = append(days, *today) // Not rolled into the history until the next sale
+	}
+
+	total, count := 0, 0
+	for _, sales := range days {
+		if sales.units > 0 && sales.day >= m.State.CurrentDay-salesHistoryDays && sales.day < m.State.CurrentDay {
+			total += sales.units
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return float64(total) / float64(count), true
+}
+
+// rollDailySalesUnsafe moves an item's sales from an earlier day into its
+// sales history, dropping days too old to matter
+func (m *Market) rollDailySalesUnsafe(itemID string) {
+	previous, exists := m.soldToday[itemID]
+	if !exists || previous.day == m.State.CurrentDay {
+		return
+	}
+
+	kept := make([]dailySales, 0, salesHistoryDays)
+	for _, sales := range m.recentSales[itemID] {
+		if sales.day >= m.State.CurrentDay-salesHistoryDays {
+			kept = append(kept, sales)
+		}
+	}
+	m.recentSales[itemID] = append(kept, *previous)
+}
@@ -0,0 +1,83 @@
+package market
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultPriceSmoothing is the weight each new daily price gets in the
+// smoothed display price; the rest carries over from the previous display
+const DefaultPriceSmoothing = 0.3
+
+// DisplayPoint is one recorded price and the smoothed price shown for it
+type DisplayPoint struct {
+	Raw     int
+	Display float64
+}
+
+// SetPriceSmoothing sets how much weight each new price gets in the
+// display price, from just above zero (calmest) to one (the raw price).
+// Only charts and the HUD use the display price; trading uses the raw one.
+func (m *Market) SetPriceSmoothing(factor float64) error {
+	if factor <= 0 || factor > 1 {
+		return fmt.Errorf("price smoothing must be above 0 and at most 1, got %.2f", factor)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.priceSmoothing = factor
+	return nil
+}
+
+// GetPriceSmoothing returns the weight each new price gets in the display price
+func (m *Market) GetPriceSmoothing() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.priceSmoothing
+}
+
+// GetDisplayPrice returns an item's price smoothed over its recorded
+// history with an exponential moving average, for charts and the HUD.
+// Unknown items return zero.
+func (m *Market) GetDisplayPrice(itemID string) int {
+	if baseID, multiplier := resolveVariant(itemID); baseID != itemID {
+		return scaleVariantPrice(m.GetDisplayPrice(baseID), multiplier)
+	}
+
+	series := m.GetDisplaySeries(itemID)
+	if len(series) == 0 {
+		if _, exists := m.GetItem(itemID); !exists {
+			return 0
+		}
+		return m.GetPrice(itemID)
+	}
+	return int(math.Round(series[len(series)-1].Display))
+}
+
+// GetDisplaySeries returns an item's recorded prices, oldest first, each
+// with the smoothed display price as of that record
+func (m *Market) GetDisplaySeries(itemID string) []DisplayPoint {
+	m.mu.RLock()
+	history, exists := m.Prices[itemID]
+	factor := m.priceSmoothing
+	m.mu.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	history.mu.RLock()
+	defer history.mu.RUnlock()
+
+	series := make([]DisplayPoint, 0, len(history.Records))
+	display := 0.0
+	for i, record := range history.Records {
+		raw := float64(record.Price)
+		if i == 0 {
+			display = raw
+		} else {
+			display += (raw - display) * factor
+		}
+		series = append(series, DisplayPoint{Raw: record.Price, Display: display})
+	}
+	return series
+}
@@ -0,0 +1,78 @@
+package market
+
+import (
+	"math"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// uniformStdDev is the standard deviation of a uniform swing of width one,
+// the shape of the daily random price factor
+var uniformStdDev = 1 / math.Sqrt(12)
+
+// PriceBand is a projected price for a day ahead with a one standard
+// deviation range around it
+type PriceBand struct {
+	DaysAhead int
+	Center    float64
+	Lower     float64
+	Upper     float64
+}
+
+// ForecastPriceBand projects an item's price over the coming days, one season
+// per day. The central path moves from today's price toward each day's fair
+// value as fast as the circuit breaker allows, with the economy's mood fading
+// back toward normal. The band is one standard deviation of the item's daily
+// random swing plus the mood's uncertainty, which grows the further ahead the
+// day, at the given daily mood drift.
+func (m *Market) ForecastPriceBand(itemID string, seasons []item.Season, moodDrift float64) []PriceBand {
+	if baseID, multiplier := resolveVariant(itemID); baseID != itemID {
+		bands := m.ForecastPriceBand(baseID, seasons, moodDrift)
+		for i := range bands {
+			bands[i].Center *= multiplier
+			bands[i].Lower *= multiplier
+			bands[i].Upper *= multiplier
+		}
+		return bands
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	itemObj, exists := m.items[itemID]
+	if !exists {
+		return nil
+	}
+
+	price := float64(itemObj.BasePrice)
+	if history, ok := m.Prices[itemID]; ok && history.CurrentPrice > 0 {
+		price = float64(history.CurrentPrice)
+	}
+	swing := float64(itemObj.GetVolatility()) * 0.2 * m.PricingEngine.volatilityScale * uniformStdDev
+	moodSwing := MoodModifier(1, m.State.MoodStrength, itemObj.Category) - 1
+	minPrice, maxPrice := float64(itemObj.BasePrice)*0.5, float64(itemObj.BasePrice)*2.0
+
+	state := *m.State
+	moodVariance := 0.0
+	bands := make([]PriceBand, 0, len(seasons))
+	for i, season := range seasons {
+		state.CurrentSeason = season
+		state.MoodIndex *= moodReversion
+		moodVariance = moodVariance*moodReversion*moodReversion + moodDrift*moodDrift/3
+
+		fairValue := math.Max(minPrice, math.Min(maxPrice, m.PricingEngine.calculateFundamentalPrice(itemObj, &state)))
+		if m.maxDailyChange > 0 {
+			fairValue = math.Max(price*(1-m.maxDailyChange), math.Min(price*(1+m.maxDailyChange), fairValue))
+		}
+		price = fairValue
+
+		spread := price * math.Sqrt(swing*swing+moodVariance*moodSwing*moodSwing)
+		bands = append(bands, PriceBand{
+			DaysAhead: i + 1,
+			Center:    price,
+			Lower:     math.Max(minPrice, price-spread),
+			Upper:     math.Min(maxPrice, price+spread),
+		})
+	}
+	return bands
+}
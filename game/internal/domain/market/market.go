@@ -1,8 +1,10 @@
 package market
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -72,6 +74,25 @@ const (
 	ActionSell
 )
 
+// defaultHistorySize is how many price records each item keeps
+const defaultHistorySize = 10
+
+// quoteSmoothingSeconds is how quickly quoted prices glide toward the day's
+// price; after this many seconds about two thirds of the gap is closed
+const quoteSmoothingSeconds = 5.0
+
+// DefaultMaxDailyChange is the largest fraction an item's price may move
+// away from its opening price in a single day
+const DefaultMaxDailyChange = 0.25
+
+// liquidityPenalty is how much each unit sold past an item's daily liquidity
+// lowers its price; minLiquidityFactor is the lowest that price may fall to
+// as a fraction of the pre-sale price
+const (
+	liquidityPenalty   = 0.02
+	minLiquidityFactor = 0.5
+)
+
 // Market represents the game's market system
 type Market struct {
 	PricingEngine *PricingEngine
@@ -79,7 +100,60 @@ type Market struct {
 	Prices        map[string]*PriceHistory
 	ActiveEvents  []*MarketEvent
 	items         map[string]*item.Item
-	mu            sync.RWMutex
+
+	// Prices shown to players, easing toward each day's price between days
+	quotes map[string]float64
+
+	// Weight of each new price in the smoothed display price
+	priceSmoothing float64
+
+	// Circuit breaker limiting how far prices move per day
+	maxDailyChange   float64
+	dailyOpen        map[string]*dailyOpenPrice
+	onCircuitBreaker func(CircuitBreakerTrip)
+
+	// Daily liquidity: units the market absorbs before sales depress prices
+	liquidity   map[string]int
+	soldToday   map[string]*dailySales
+	recentSales map[string][]dailySales // Earlier days' sales, for demand simulation
+
+	// Price elasticity of each category's sales
+	elasticities map[item.Category]float64
+
+	// Channels streaming price changes to subscribers
+	priceSubscribers map[*priceSubscriber]struct{}
+
+	mu sync.RWMutex
+}
+
+// dailySales counts the units sold into the market on a given day
+type dailySales struct {
+	day   int
+	units int
+}
+
+// LiquidityFill describes how the market absorbed a sale
+type LiquidityFill struct {
+	Quantity    int
+	ExcessUnits int     // Units sold past the day's liquidity
+	PriceFactor float64 // Average fraction of the asking price realized
+	NewPrice    int     // Market price after the sale
+}
+
+// dailyOpenPrice is the reference price the circuit breaker measures moves from
+type dailyOpenPrice struct {
+	day     int
+	price   int
+	tripped bool
+}
+
+// CircuitBreakerTrip describes a price move that was capped by the circuit breaker
+type CircuitBreakerTrip struct {
+	ItemID      string
+	Day         int
+	OpenPrice   int
+	TargetPrice int
+	CappedPrice int
 }
 
 // MarketState represents the current state of the market
@@ -88,14 +162,17 @@ type MarketState struct {
 	CurrentSupply SupplyLevel
 	CurrentSeason item.Season
 	CurrentDay    int
+	MoodIndex     float64 // Economy from -1 (recession) to 1 (boom)
+	MoodStrength  float64 // Price and demand swing at full boom or recession
 }
 
 // PricingEngine calculates prices based on various factors
 type PricingEngine struct {
-	baseFormula    PriceFormula
-	modifiers      []PriceModifier
-	volatilityCalc VolatilityCalculator
-	random         *rand.Rand
+	baseFormula     PriceFormula
+	modifiers       []PriceModifier
+	volatilityCalc  VolatilityCalculator
+	volatilityScale float64 // Multiplier on each item's random price swings
+	random          *rand.Rand
 }
 
 // PriceFormula interface for price calculation
@@ -130,6 +207,12 @@ type EventEffect struct {
 	Value float64
 }
 
+// PriceFactor is a single multiplier applied to an item's base price
+type PriceFactor struct {
+	Name       string
+	Multiplier float64
+}
+
 // PriceHistory tracks historical prices for an item
 type PriceHistory struct {
 	Records      []PriceRecord
@@ -156,9 +239,17 @@ func NewMarket() *Market {
 			CurrentSeason: item.SeasonSpring,
 			CurrentDay:    1,
 		},
-		Prices:       make(map[string]*PriceHistory),
-		ActiveEvents: make([]*MarketEvent, 0),
-		items:        make(map[string]*item.Item),
+		Prices:         make(map[string]*PriceHistory),
+		ActiveEvents:   make([]*MarketEvent, 0),
+		items:          make(map[string]*item.Item),
+		quotes:         make(map[string]float64),
+		priceSmoothing: DefaultPriceSmoothing,
+		maxDailyChange: DefaultMaxDailyChange,
+		dailyOpen:      make(map[string]*dailyOpenPrice),
+		liquidity:      make(map[string]int),
+		soldToday:      make(map[string]*dailySales),
+		recentSales:    make(map[string][]dailySales),
+		elasticities:   copyElasticities(defaultElasticities),
 	}
 
 	// Initialize with items from registry
@@ -189,6 +280,10 @@ func (m *Market) initializeMarketItems() {
 			Records: []PriceRecord{
 				{Price: master.BasePrice, Timestamp: time.Now()},
 			},
+			CurrentPrice: master.BasePrice,
+			AveragePrice: master.BasePrice,
+			Trend:        TrendStable,
+			MaxSize:      defaultHistorySize,
 		}
 	}
 }
@@ -196,10 +291,11 @@ func (m *Market) initializeMarketItems() {
 // NewPricingEngine creates a new pricing engine
 func NewPricingEngine() *PricingEngine {
 	return &PricingEngine{
-		baseFormula:    &DefaultPriceFormula{},
-		modifiers:      []PriceModifier{},
-		volatilityCalc: &DefaultVolatilityCalculator{},
-		random:         rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // weak random is OK for market simulation
+		baseFormula:     &DefaultPriceFormula{},
+		modifiers:       []PriceModifier{},
+		volatilityCalc:  &DefaultVolatilityCalculator{},
+		volatilityScale: 1,
+		random:          rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // weak random is OK for market simulation
 	}
 }
 
@@ -214,41 +310,404 @@ func (m *Market) RegisterItem(item *item.Item) {
 		CurrentPrice: item.BasePrice,
 		AveragePrice: item.BasePrice,
 		Trend:        TrendStable,
-		MaxSize:      10,
+		MaxSize:      defaultHistorySize,
 	}
 }
 
-// UpdatePrices updates all item prices based on current market conditions
+// UpdatePrices recalculates all item prices for the current day from market
+// conditions without advancing the day
 func (m *Market) UpdatePrices() {
+	m.mu.Lock()
+	trips := m.updatePricesUnsafe()
+	handler := m.onCircuitBreaker
+	m.mu.Unlock()
+
+	notifyCircuitBreaker(handler, trips)
+}
+
+// AdvanceDay moves the market to day, the game's new day, and takes the daily
+// price step for every item. This is the only place prices evolve over time.
+// The day is the caller's so the market cannot drift from the game's calendar
+// across loads and undos; sales history is kept by it.
+func (m *Market) AdvanceDay(day int) {
+	m.mu.Lock()
+	m.State.CurrentDay = day
+	trips := m.updatePricesUnsafe()
+	handler := m.onCircuitBreaker
+	m.mu.Unlock()
+
+	notifyCircuitBreaker(handler, trips)
+}
+
+// Tick eases quoted prices toward the current day's prices. It never changes
+// the day's prices, so it is safe to call every frame.
+func (m *Market) Tick(deltaTime float64) {
+	if deltaTime <= 0 {
+		return
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	blend := math.Min(1, deltaTime/quoteSmoothingSeconds)
+	for itemID := range m.items {
+		history, exists := m.Prices[itemID]
+		if !exists || history.CurrentPrice <= 0 {
+			continue
+		}
+		target := float64(history.CurrentPrice)
+		quote, quoted := m.quotes[itemID]
+		if !quoted {
+			quote = target
+		}
+		m.quotes[itemID] = quote + (target-quote)*blend
+	}
+}
+
+// updatePricesUnsafe records a new price for every item, returning any
+// circuit breaker trips
+func (m *Market) updatePricesUnsafe() []CircuitBreakerTrip {
+	trips := make([]CircuitBreakerTrip, 0)
 	for id, item := range m.items {
-		newPrice := m.PricingEngine.CalculatePrice(item, m.State)
-		history := m.Prices[id]
+		newPrice, trip := m.capDailyMoveUnsafe(id, m.PricingEngine.CalculatePrice(item, m.State))
+		if trip != nil {
+			trips = append(trips, *trip)
+		}
+		history, exists := m.Prices[id]
+		if !exists {
+			history = &PriceHistory{MaxSize: defaultHistorySize}
+			m.Prices[id] = history
+		}
+		oldPrice := history.CurrentPrice
 
 		// Add to history
 		history.AddRecord(newPrice, time.Now())
 		history.updateTrend()
+		m.publishPriceUnsafe(id, oldPrice, newPrice, PriceReasonMarket)
 	}
+	return trips
 }
 
 // UpdatePrice updates the price for a single item
 func (m *Market) UpdatePrice(itemID string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	item, exists := m.items[itemID]
 	if !exists {
+		m.mu.Unlock()
 		return
 	}
 
-	newPrice := m.PricingEngine.CalculatePrice(item, m.State)
+	newPrice, trip := m.capDailyMoveUnsafe(itemID, m.PricingEngine.CalculatePrice(item, m.State))
 	history := m.Prices[itemID]
+	oldPrice := history.CurrentPrice
 
 	// Add to history
 	history.AddRecord(newPrice, time.Now())
 	history.updateTrend()
+	m.publishPriceUnsafe(itemID, oldPrice, newPrice, PriceReasonMarket)
+	handler := m.onCircuitBreaker
+	m.mu.Unlock()
+
+	if trip != nil {
+		notifyCircuitBreaker(handler, []CircuitBreakerTrip{*trip})
+	}
+}
+
+// SetMaxDailyChange sets the largest fraction a price may move from its
+// opening price in one day. Zero disables the circuit breaker.
+func (m *Market) SetMaxDailyChange(fraction float64) error {
+	if fraction < 0 || fraction >= 1 {
+		return fmt.Errorf("max daily change must be between 0 and 1, got %.2f", fraction)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxDailyChange = fraction
+	return nil
+}
+
+// GetMaxDailyChange returns the circuit breaker's daily move limit
+func (m *Market) GetMaxDailyChange() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.maxDailyChange
+}
+
+// SetVolatilityScale scales how far prices swing at random each day, on top
+// of each item's own volatility. One leaves swings as they are.
+func (m *Market) SetVolatilityScale(scale float64) error {
+	if scale <= 0 {
+		return fmt.Errorf("volatility scale must be positive, got %.2f", scale)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.PricingEngine.volatilityScale = scale
+	return nil
+}
+
+// GetVolatilityScale returns the multiplier on random price swings
+func (m *Market) GetVolatilityScale() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.PricingEngine.volatilityScale
+}
+
+// SetCircuitBreakerHandler registers a callback run whenever the circuit
+// breaker first caps an item's price on a given day
+func (m *Market) SetCircuitBreakerHandler(handler func(CircuitBreakerTrip)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onCircuitBreaker = handler
+}
+
+// SetDay moves the market to a new day, letting prices move again from
+// where they closed
+func (m *Market) SetDay(day int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.State.CurrentDay = day
+}
+
+// capDailyMoveUnsafe limits a new price to the allowed band around the item's
+// opening price for the current day. The remainder of a larger move is left
+// for later days, since prices are recalculated from market conditions.
+func (m *Market) capDailyMoveUnsafe(itemID string, target int) (int, *CircuitBreakerTrip) {
+	open, exists := m.dailyOpen[itemID]
+	if !exists || open.day != m.State.CurrentDay {
+		openPrice := target
+		if history, ok := m.Prices[itemID]; ok && history.CurrentPrice > 0 {
+			openPrice = history.CurrentPrice
+		}
+		open = &dailyOpenPrice{day: m.State.CurrentDay, price: openPrice}
+		m.dailyOpen[itemID] = open
+	}
+
+	capped := m.limitToBandUnsafe(open.price, target)
+	if capped == target {
+		return target, nil
+	}
+
+	if open.tripped {
+		return capped, nil
+	}
+	open.tripped = true
+	return capped, &CircuitBreakerTrip{
+		ItemID:      itemID,
+		Day:         open.day,
+		OpenPrice:   open.price,
+		TargetPrice: target,
+		CappedPrice: capped,
+	}
+}
+
+// limitToBandUnsafe clamps a price to within maxDailyChange of the open price
+func (m *Market) limitToBandUnsafe(openPrice, price int) int {
+	if m.maxDailyChange <= 0 || openPrice <= 0 {
+		return price
+	}
+
+	maxPrice := int(math.Floor(float64(openPrice) * (1 + m.maxDailyChange)))
+	minPrice := int(math.Ceil(float64(openPrice) * (1 - m.maxDailyChange)))
+	if price > maxPrice {
+		return maxPrice
+	}
+	if price < minPrice {
+		return minPrice
+	}
+	return price
+}
+
+// defaultLiquidity returns how many units of a category the market absorbs
+// per day. Cheap everyday goods trade in bulk; luxuries in small numbers.
+func defaultLiquidity(category item.Category) int {
+	switch category {
+	case item.CategoryFruit:
+		return 50
+	case item.CategoryPotion:
+		return 30
+	case item.CategoryWeapon, item.CategoryAccessory:
+		return 10
+	case item.CategoryMagicBook, item.CategoryGem:
+		return 5
+	default:
+		return 20
+	}
+}
+
+// SetLiquidity overrides how many units of an item the market absorbs per
+// day. Zero restores the category default.
+func (m *Market) SetLiquidity(itemID string, units int) error {
+	if units < 0 {
+		return fmt.Errorf("liquidity cannot be negative: %d", units)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if units == 0 {
+		delete(m.liquidity, itemID)
+		return nil
+	}
+	m.liquidity[itemID] = units
+	return nil
+}
+
+// GetLiquidity returns how many units of an item the market absorbs per day
+// before sales start to depress the price
+func (m *Market) GetLiquidity(itemID string) int {
+	itemID, _ = resolveVariant(itemID)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.getLiquidityUnsafe(itemID)
+}
+
+// GetRemainingLiquidity returns how many more units of an item can be sold
+// today at full price
+func (m *Market) GetRemainingLiquidity(itemID string) int {
+	itemID, _ = resolveVariant(itemID)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	remaining := m.getLiquidityUnsafe(itemID) - m.getSoldTodayUnsafe(itemID)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// getLiquidityUnsafe returns an item's daily liquidity without locking
+func (m *Market) getLiquidityUnsafe(itemID string) int {
+	if units, exists := m.liquidity[itemID]; exists {
+		return units
+	}
+	if itemObj, exists := m.items[itemID]; exists {
+		return defaultLiquidity(itemObj.Category)
+	}
+	return defaultLiquidity("")
+}
+
+// getSoldTodayUnsafe returns the units of an item sold today without locking
+func (m *Market) getSoldTodayUnsafe(itemID string) int {
+	if sales, exists := m.soldToday[itemID]; exists && sales.day == m.State.CurrentDay {
+		return sales.units
+	}
+	return 0
+}
+
+// AbsorbSale records units sold into the market. Units within the day's
+// liquidity sell at full price; each unit past it sells for, and leaves the
+// market price, liquidityPenalty lower than the one before. The price drop
+// stays within the circuit breaker's daily band and recovers as prices are
+// recalculated on later days. Quality variants share their base item's
+// liquidity.
+func (m *Market) AbsorbSale(itemID string, quantity int) LiquidityFill {
+	fill := LiquidityFill{Quantity: quantity, PriceFactor: 1}
+	if quantity <= 0 {
+		return fill
+	}
+	itemID, _ = resolveVariant(itemID)
+
+	m.mu.Lock()
+	fill, trip := m.absorbSaleUnsafe(itemID, fill)
+	handler := m.onCircuitBreaker
+	m.mu.Unlock()
+
+	if trip != nil {
+		notifyCircuitBreaker(handler, []CircuitBreakerTrip{*trip})
+	}
+	return fill
+}
+
+// absorbSaleUnsafe applies a sale to the day's liquidity and the market
+// price, returning any circuit breaker trip caused by the price drop
+func (m *Market) absorbSaleUnsafe(itemID string, fill LiquidityFill) (LiquidityFill, *CircuitBreakerTrip) {
+	quantity := fill.Quantity
+
+	sold := m.getSoldTodayUnsafe(itemID)
+	remaining := m.getLiquidityUnsafe(itemID) - sold
+	if remaining < 0 {
+		remaining = 0
+	}
+	m.rollDailySalesUnsafe(itemID)
+	m.soldToday[itemID] = &dailySales{day: m.State.CurrentDay, units: sold + quantity}
+
+	history, priced := m.Prices[itemID]
+	if priced {
+		fill.NewPrice = history.CurrentPrice
+	}
+	if quantity <= remaining {
+		return fill, nil
+	}
+
+	// Units already sold past today's liquidity have pushed the price down
+	// already, so this sale continues from there: splitting a sale into
+	// smaller ones fetches no more than selling it at once
+	fill.ExcessUnits = quantity - remaining
+	priorExcess := sold - m.getLiquidityUnsafe(itemID)
+	if priorExcess < 0 {
+		priorExcess = 0
+	}
+	start := math.Max(minLiquidityFactor, math.Pow(1-liquidityPenalty, float64(priorExcess)))
+	realized := float64(remaining)
+	factor := start
+	for i := 0; i < fill.ExcessUnits; i++ {
+		factor = math.Max(minLiquidityFactor, factor*(1-liquidityPenalty))
+		realized += factor
+	}
+	fill.PriceFactor = realized / float64(quantity)
+
+	var trip *CircuitBreakerTrip
+	if priced && history.CurrentPrice > 0 {
+		fill.NewPrice, trip = m.movePriceUnsafe(itemID, history, factor/start, PriceReasonSale)
+	}
+
+	return fill, trip
+}
+
+// ApplyPriceShock moves an item's price for the rest of the day by the given
+// fraction, e.g. -0.2 for a 20% slump, within the circuit breaker's band.
+// Prices are recalculated from market conditions on the next day. Returns the
+// new price.
+func (m *Market) ApplyPriceShock(itemID string, fraction float64) int {
+	m.mu.Lock()
+	history, priced := m.Prices[itemID]
+	if !priced || history.CurrentPrice <= 0 {
+		m.mu.Unlock()
+		return 0
+	}
+	newPrice, trip := m.movePriceUnsafe(itemID, history, 1+fraction, PriceReasonShock)
+	handler := m.onCircuitBreaker
+	m.mu.Unlock()
+
+	if trip != nil {
+		notifyCircuitBreaker(handler, []CircuitBreakerTrip{*trip})
+	}
+	return newPrice
+}
+
+// movePriceUnsafe scales an item's current price within the day without
+// recording history, snapping its quote to the new price
+func (m *Market) movePriceUnsafe(itemID string, history *PriceHistory, factor float64, reason string) (int, *CircuitBreakerTrip) {
+	oldPrice := history.CurrentPrice
+	target := int(math.Round(float64(oldPrice) * factor))
+	newPrice, trip := m.capDailyMoveUnsafe(itemID, target)
+	history.CurrentPrice = newPrice
+	m.publishPriceUnsafe(itemID, oldPrice, newPrice, reason)
+	if _, quoted := m.quotes[itemID]; quoted {
+		m.quotes[itemID] = float64(newPrice)
+	}
+	return newPrice, trip
+}
+
+// notifyCircuitBreaker reports trips to the handler outside the market lock
+func notifyCircuitBreaker(handler func(CircuitBreakerTrip), trips []CircuitBreakerTrip) {
+	if handler == nil {
+		return
+	}
+	for _, trip := range trips {
+		handler(trip)
+	}
 }
 
 // GetPriceHistory returns the price history for an item
@@ -277,6 +736,9 @@ func (m *Market) ApplyEvent(event *MarketEvent) {
 		}
 	}
 
+	if event.StartDay == 0 {
+		event.StartDay = m.State.CurrentDay
+	}
 	event.IsActive = true
 	m.ActiveEvents = append(m.ActiveEvents, event)
 }
@@ -319,8 +781,36 @@ func (m *Market) GetAllItems() []*item.Item {
 	return items
 }
 
-// GetPrice returns the current price for an item
+// GetTrackedItems returns the IDs of every item the market tracks a price
+// for, sorted
+func (m *Market) GetTrackedItems() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.Prices))
+	for id := range m.Prices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// GetItem returns a market item by ID
+func (m *Market) GetItem(itemID string) (*item.Item, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	itemObj, exists := m.items[itemID]
+	return itemObj, exists
+}
+
+// GetPrice returns the current price for an item. Quality variants trade at
+// their base item's price scaled by the tier's multiplier.
 func (m *Market) GetPrice(itemID string) int {
+	if baseID, multiplier := resolveVariant(itemID); baseID != itemID {
+		return scaleVariantPrice(m.GetPrice(baseID), multiplier)
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -331,7 +821,15 @@ func (m *Market) GetPrice(itemID string) int {
 		return 10
 	}
 
-	// Calculate current price
+	// Prefer the quoted price, then the day's price
+	if quote, ok := m.quotes[itemID]; ok {
+		return int(math.Round(quote))
+	}
+	if history, ok := m.Prices[itemID]; ok && history.CurrentPrice > 0 {
+		return history.CurrentPrice
+	}
+
+	// Calculate a price for items that have never been priced
 	if m.PricingEngine != nil && m.State != nil {
 		return m.PricingEngine.CalculatePrice(itemObj, m.State)
 	}
@@ -339,6 +837,93 @@ func (m *Market) GetPrice(itemID string) int {
 	return itemObj.BasePrice
 }
 
+// GetFairValue returns an item's fundamental value for the current season and
+// market conditions, ignoring random volatility
+func (m *Market) GetFairValue(itemID string) int {
+	if baseID, multiplier := resolveVariant(itemID); baseID != itemID {
+		return scaleVariantPrice(m.GetFairValue(baseID), multiplier)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	itemObj, exists := m.items[itemID]
+	if !exists {
+		return 0
+	}
+
+	return m.PricingEngine.CalculateFairValue(itemObj, m.State)
+}
+
+// resolveVariant maps a quality variant to the base item it trades alongside
+// and the tier's price multiplier; plain item IDs come back unchanged
+func resolveVariant(itemID string) (string, float64) {
+	baseID, quality := item.ParseVariantID(itemID)
+	return baseID, item.QualityMultiplier(quality)
+}
+
+// scaleVariantPrice applies a quality multiplier to a base item's price
+func scaleVariantPrice(price int, multiplier float64) int {
+	return int(math.Round(float64(price) * multiplier))
+}
+
+// GetPriceFactors returns the multipliers applied to an item's base price
+// under current market conditions, in the order the pricing engine applies them
+func (m *Market) GetPriceFactors(itemID string) ([]PriceFactor, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	itemObj, exists := m.items[itemID]
+	if !exists {
+		return nil, false
+	}
+
+	return m.PricingEngine.priceFactors(itemObj, m.State), true
+}
+
+// GetActiveEventNames returns the names of market events currently in effect
+func (m *Market) GetActiveEventNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.ActiveEvents))
+	for _, event := range m.ActiveEvents {
+		if event.IsActive {
+			names = append(names, event.Name)
+		}
+	}
+	return names
+}
+
+// GetActiveEvents returns copies of the market events currently in effect
+func (m *Market) GetActiveEvents() []MarketEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	events := make([]MarketEvent, 0, len(m.ActiveEvents))
+	for _, event := range m.ActiveEvents {
+		if event.IsActive {
+			events = append(events, *event)
+		}
+	}
+	return events
+}
+
+// GetDemandOutlook returns the combined demand and seasonal multiplier for an
+// item in the given season, using current market conditions
+func (m *Market) GetDemandOutlook(itemID string, season item.Season) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	itemObj, exists := m.items[itemID]
+	if !exists {
+		return 1.0
+	}
+
+	return m.State.GetDemandModifier() * m.State.GetMoodModifier(itemObj.Category) *
+		SeasonalModifier(season, itemObj.Category)
+}
+
 // Reset resets the market to initial state
 func (m *Market) Reset() {
 	m.mu.Lock()
@@ -351,29 +936,30 @@ func (m *Market) Reset() {
 		CurrentDay:    1,
 	}
 	m.ActiveEvents = []*MarketEvent{}
-	m.Prices = make(map[string]*PriceHistory)
-}
-
-// Update updates the market state
-func (m *Market) Update() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Update prices for all items
-	for itemID, itemObj := range m.items {
-		if m.PricingEngine != nil && m.State != nil {
-			newPrice := m.PricingEngine.CalculatePrice(itemObj, m.State)
-
-			// Update price history
-			if history, exists := m.Prices[itemID]; exists {
-				history.AddRecord(newPrice, time.Now())
-			} else {
-				m.Prices[itemID] = &PriceHistory{
-					Records: []PriceRecord{{Price: newPrice, Timestamp: time.Now()}},
-				}
-			}
+	m.dailyOpen = make(map[string]*dailyOpenPrice)
+	m.quotes = make(map[string]float64)
+	m.soldToday = make(map[string]*dailySales)
+	m.recentSales = make(map[string][]dailySales)
+
+	// Restart every item's price history from its base price so no prices
+	// carry over from a previous game
+	now := time.Now()
+	prices := make(map[string]*PriceHistory, len(m.items))
+	for id, itemObj := range m.items {
+		itemObj.Price = itemObj.BasePrice
+		maxSize := defaultHistorySize
+		if old, exists := m.Prices[id]; exists {
+			maxSize = old.MaxSize
+		}
+		prices[id] = &PriceHistory{
+			Records:      []PriceRecord{{Price: itemObj.BasePrice, Timestamp: now}},
+			CurrentPrice: itemObj.BasePrice,
+			AveragePrice: itemObj.BasePrice,
+			Trend:        TrendStable,
+			MaxSize:      maxSize,
 		}
 	}
+	m.Prices = prices
 }
 
 // GetDemandModifier returns the price modifier for the current demand level
@@ -402,26 +988,48 @@ func (s *MarketState) GetSupplyModifier() float64 {
 
 // CalculatePrice calculates the price for an item
 func (pe *PricingEngine) CalculatePrice(item *item.Item, state *MarketState) int {
-	basePrice := float64(item.BasePrice)
-
-	// Apply demand and supply modifiers
-	demandMod := state.GetDemandModifier()
-	supplyMod := state.GetSupplyModifier()
-
-	// Apply seasonal modifier
-	seasonMod := pe.getSeasonalModifier(item, state.CurrentSeason)
-
-	// Calculate base price with modifiers
-	price := basePrice * demandMod * supplyMod * seasonMod
+	price := pe.calculateFundamentalPrice(item, state)
 
 	// Apply volatility (reduced for more predictable pricing)
 	volatility := item.GetVolatility()
-	randomFactor := 1.0 + (pe.random.Float64()-0.5)*float64(volatility)*0.2
+	randomFactor := 1.0 + (pe.random.Float64()-0.5)*float64(volatility)*0.2*pe.volatilityScale
 	price *= randomFactor
 
-	// Ensure price doesn't go below 50% or above 200% of base
-	minPrice := basePrice * 0.5
-	maxPrice := basePrice * 2.0
+	return clampPrice(price, item.BasePrice)
+}
+
+// CalculateFairValue calculates an item's value without random price swings
+func (pe *PricingEngine) CalculateFairValue(item *item.Item, state *MarketState) int {
+	return clampPrice(pe.calculateFundamentalPrice(item, state), item.BasePrice)
+}
+
+// calculateFundamentalPrice applies demand, supply, and seasonal modifiers to the base price
+func (pe *PricingEngine) calculateFundamentalPrice(item *item.Item, state *MarketState) float64 {
+	price := float64(item.BasePrice)
+	for _, factor := range pe.priceFactors(item, state) {
+		price *= factor.Multiplier
+	}
+	return price
+}
+
+// priceFactors returns the demand, supply, and seasonal modifiers for an
+// item, followed by the economy's mood when it is anything but neutral
+func (pe *PricingEngine) priceFactors(item *item.Item, state *MarketState) []PriceFactor {
+	factors := []PriceFactor{
+		{Name: "demand", Multiplier: state.GetDemandModifier()},
+		{Name: "supply", Multiplier: state.GetSupplyModifier()},
+		{Name: "season", Multiplier: SeasonalModifier(state.CurrentSeason, item.Category)},
+	}
+	if mood := state.GetMoodModifier(item.Category); mood != 1 {
+		factors = append(factors, PriceFactor{Name: "mood", Multiplier: mood})
+	}
+	return factors
+}
+
+// clampPrice keeps a price between 50% and 200% of base and rounds it
+func clampPrice(price float64, basePrice int) int {
+	minPrice := float64(basePrice) * 0.5
+	maxPrice := float64(basePrice) * 2.0
 
 	if price < minPrice {
 		price = minPrice
@@ -432,30 +1040,6 @@ func (pe *PricingEngine) CalculatePrice(item *item.Item, state *MarketState) int
 	return int(math.Round(price))
 }
 
-// getSeasonalModifier returns the seasonal price modifier for an item
-func (pe *PricingEngine) getSeasonalModifier(i *item.Item, season item.Season) float64 {
-	// Simple seasonal effects for different item categories
-	switch i.Category {
-	case item.CategoryFruit:
-		switch season {
-		case item.SeasonSpring:
-			return 1.1
-		case item.SeasonSummer:
-			return 1.0
-		case item.SeasonAutumn:
-			return 1.3
-		case item.SeasonWinter:
-			return 0.8
-		}
-	case item.CategoryPotion:
-		// Potions are more needed in winter (cold season)
-		if season == item.SeasonWinter {
-			return 1.2
-		}
-	}
-	return 1.0
-}
-
 // AddRecord adds a new price record to the history
 func (ph *PriceHistory) AddRecord(price int, timestamp time.Time) {
 	ph.mu.Lock()
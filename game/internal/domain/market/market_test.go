@@ -1,6 +1,8 @@
 package market
 
 import (
+	"math"
+	"sort"
 	"testing"
 	"time"
 
@@ -53,8 +55,8 @@ func TestPricingEngine_CalculatePrice(t *testing.T) {
 			demand:      DemandNormal,
 			supply:      SupplyNormal,
 			season:      item.SeasonSpring,
-			expectedMin: 95,
-			expectedMax: 115,
+			expectedMin: 85,
+			expectedMax: 105,
 		},
 		{
 			name:        "high demand low supply",
@@ -280,31 +282,85 @@ func TestMarket_PriceVolatility(t *testing.T) {
 }
 
 func TestMarket_SeasonalPricing(t *testing.T) {
+	tests := []struct {
+		season string
+		want   item.Season
+		rises  bool // Whether fruit costs more than its base price
+	}{
+		{season: "Spring", want: item.SeasonSpring, rises: false},
+		{season: "Summer", want: item.SeasonSummer, rises: false},
+		{season: "Autumn", want: item.SeasonAutumn, rises: false},
+		{season: "Winter", want: item.SeasonWinter, rises: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.season, func(t *testing.T) {
+			market := NewMarket()
+			apple, _ := item.NewItem("apple_001", "Apple", item.CategoryFruit, 100)
+			market.RegisterItem(apple)
+
+			require.NoError(t, market.SetSeason(tt.season))
+			assert.Equal(t, tt.want, market.GetSeason())
+			market.UpdatePrices()
+
+			price := market.Prices["apple_001"].CurrentPrice
+			if tt.rises {
+				assert.Greater(t, price, 100)
+			} else {
+				assert.Less(t, price, 100)
+			}
+		})
+	}
+
+	// Winter fruit is dearest, harvest-time fruit cheapest
+	assert.Greater(t, SeasonalModifier(item.SeasonWinter, item.CategoryFruit), SeasonalModifier(item.SeasonSpring, item.CategoryFruit))
+	assert.Less(t, SeasonalModifier(item.SeasonAutumn, item.CategoryFruit), SeasonalModifier(item.SeasonSummer, item.CategoryFruit))
+}
+
+func TestMarket_SetSeason(t *testing.T) {
 	market := NewMarket()
+	assert.Error(t, market.SetSeason("Monsoon"))
+	assert.Equal(t, item.SeasonSpring, market.GetSeason())
 
-	apple, _ := item.NewItem("apple_001", "Apple", item.CategoryFruit, 10)
-	market.RegisterItem(apple)
+	require.NoError(t, market.SetSeason("winter"))
+	assert.Equal(t, item.SeasonWinter, market.GetSeason())
 
-	seasons := []item.Season{
-		item.SeasonSpring,
-		item.SeasonSummer,
-		item.SeasonAutumn,
-		item.SeasonWinter,
+	// Categories the table leaves out are unaffected
+	for _, season := range []item.Season{item.SeasonSpring, item.SeasonSummer, item.SeasonAutumn, item.SeasonWinter} {
+		assert.Equal(t, 1.0, SeasonalModifier(season, item.CategoryGem))
+		assert.Equal(t, 1.0, SeasonalModifier(season, item.Category("UNKNOWN")))
 	}
+	gem, _ := item.NewItem("ruby_001", "Ruby", item.CategoryGem, 500)
+	market.RegisterItem(gem)
+	assert.Equal(t, 500, market.GetFairValue("ruby_001"))
+}
 
-	prices := make(map[item.Season]int)
+func TestMarket_GetFairValue(t *testing.T) {
+	market := NewMarket()
 
-	for _, season := range seasons {
-		market.State.CurrentSeason = season
+	apple, _ := item.NewItem("apple_001", "Apple", item.CategoryFruit, 100)
+	market.RegisterItem(apple)
+
+	// Fair value follows seasonal modifiers
+	market.State.CurrentSeason = item.SeasonAutumn
+	assert.Equal(t, 80, market.GetFairValue("apple_001"))
+	market.State.CurrentSeason = item.SeasonWinter
+	assert.Equal(t, 130, market.GetFairValue("apple_001"))
+
+	// Random price swings don't move fair value
+	for i := 0; i < 20; i++ {
 		market.UpdatePrices()
-		prices[season] = market.Prices["apple_001"].CurrentPrice
+		assert.Equal(t, 130, market.GetFairValue("apple_001"))
 	}
 
-	// Autumn should have highest price for fruit (harvest season)
-	// Winter should have lowest
-	assert.Greater(t, prices[item.SeasonAutumn], prices[item.SeasonSpring])
-	assert.Greater(t, prices[item.SeasonAutumn], prices[item.SeasonSummer])
-	assert.Less(t, prices[item.SeasonWinter], prices[item.SeasonAutumn])
+	// Market conditions from events are included
+	market.ApplyEvent(&MarketEvent{
+		Type:    EventDragonAttack,
+		Effects: []EventEffect{{Type: EffectSupplyDecrease, Value: 1}},
+	})
+	assert.Equal(t, 150, market.GetFairValue("apple_001"))
+
+	assert.Equal(t, 0, market.GetFairValue("unknown"))
 }
 
 func TestMarket_PriceHistory(t *testing.T) {
@@ -405,3 +461,456 @@ func calculateVariance(prices []int) float64 {
 
 	return variance / float64(len(prices))
 }
+
+func TestMarket_CircuitBreaker(t *testing.T) {
+	market := NewMarket()
+
+	sword, err := item.NewItem("sword_cb", "Test Sword", item.CategoryWeapon, 100)
+	require.NoError(t, err)
+	market.RegisterItem(sword)
+
+	trips := make([]CircuitBreakerTrip, 0)
+	market.SetCircuitBreakerHandler(func(trip CircuitBreakerTrip) {
+		trips = append(trips, trip)
+	})
+
+	// Stack every upward modifier so the target price nearly doubles
+	market.State.CurrentDemand = DemandVeryHigh
+	market.State.CurrentSupply = SupplyVeryLow
+	market.State.CurrentSeason = item.SeasonWinter
+
+	market.UpdatePrice("sword_cb")
+	assert.Equal(t, 125, market.GetPriceHistory("sword_cb").CurrentPrice)
+	require.Len(t, trips, 1)
+	assert.Equal(t, "sword_cb", trips[0].ItemID)
+	assert.Equal(t, 100, trips[0].OpenPrice)
+	assert.Equal(t, 125, trips[0].CappedPrice)
+	assert.Greater(t, trips[0].TargetPrice, 125)
+
+	// Further updates the same day stay capped and fire only once
+	market.UpdatePrice("sword_cb")
+	assert.Equal(t, 125, market.GetPriceHistory("sword_cb").CurrentPrice)
+	assert.LessOrEqual(t, market.GetPrice("sword_cb"), 125)
+	assert.Len(t, trips, 1)
+
+	// The remainder of the move is deferred to the next day
+	market.SetDay(2)
+	market.UpdatePrice("sword_cb")
+	assert.Equal(t, 156, market.GetPriceHistory("sword_cb").CurrentPrice)
+	assert.Len(t, trips, 2)
+
+	// A wider cap lets the price reach its target
+	require.NoError(t, market.SetMaxDailyChange(0.9))
+	market.SetDay(3)
+	market.UpdatePrice("sword_cb")
+	assert.Greater(t, market.GetPriceHistory("sword_cb").CurrentPrice, 156)
+	assert.Len(t, trips, 2)
+
+	assert.Error(t, market.SetMaxDailyChange(-0.1))
+	assert.Error(t, market.SetMaxDailyChange(1))
+}
+
+func TestMarket_TickAndAdvanceDay(t *testing.T) {
+	market := NewMarket()
+
+	sword, err := item.NewItem("sword_tick", "Test Sword", item.CategoryWeapon, 100)
+	require.NoError(t, err)
+	market.RegisterItem(sword)
+	market.UpdatePrice("sword_tick")
+
+	history := market.GetPriceHistory("sword_tick")
+	records := len(history.Records)
+	price := history.CurrentPrice
+
+	// Ticking at a high frame rate never takes a price step
+	market.State.CurrentDemand = DemandVeryHigh
+	for i := 0; i < 600; i++ {
+		market.Tick(1.0 / 60)
+	}
+	assert.Len(t, history.Records, records)
+	assert.Equal(t, price, history.CurrentPrice)
+	assert.Equal(t, price, market.GetPrice("sword_tick"))
+
+	// A new day takes exactly one step
+	market.AdvanceDay(2)
+	assert.Equal(t, 2, market.State.CurrentDay)
+	assert.Len(t, history.Records, records+1)
+	assert.Greater(t, history.CurrentPrice, price)
+
+	// Quotes ease toward the new price rather than jumping
+	market.Tick(1.0 / 60)
+	quoted := market.GetPrice("sword_tick")
+	assert.GreaterOrEqual(t, quoted, price)
+	assert.Less(t, quoted, history.CurrentPrice)
+
+	for i := 0; i < 60; i++ {
+		market.Tick(1)
+	}
+	assert.Equal(t, history.CurrentPrice, market.GetPrice("sword_tick"))
+	assert.Len(t, history.Records, records+1)
+}
+
+func TestMarket_Liquidity(t *testing.T) {
+	market := NewMarket()
+
+	gem, err := item.NewItem("gem_liquidity", "Test Gem", item.CategoryGem, 200)
+	require.NoError(t, err)
+	market.RegisterItem(gem)
+	market.UpdatePrice("gem_liquidity")
+	price := market.GetPriceHistory("gem_liquidity").CurrentPrice
+
+	assert.Equal(t, 5, market.GetLiquidity("gem_liquidity"))
+	require.NoError(t, market.SetLiquidity("gem_liquidity", 4))
+	assert.Error(t, market.SetLiquidity("gem_liquidity", -1))
+
+	// Sales within the day's liquidity go through at full price
+	fill := market.AbsorbSale("gem_liquidity", 3)
+	assert.Equal(t, 0, fill.ExcessUnits)
+	assert.Equal(t, 1.0, fill.PriceFactor)
+	assert.Equal(t, price, market.GetPrice("gem_liquidity"))
+	assert.Equal(t, 1, market.GetRemainingLiquidity("gem_liquidity"))
+
+	// Dumping more than the market can absorb realizes less and depresses the price
+	fill = market.AbsorbSale("gem_liquidity", 6)
+	assert.Equal(t, 5, fill.ExcessUnits)
+	assert.Less(t, fill.PriceFactor, 1.0)
+	assert.Less(t, fill.NewPrice, price)
+	assert.Equal(t, fill.NewPrice, market.GetPrice("gem_liquidity"))
+	assert.Equal(t, 0, market.GetRemainingLiquidity("gem_liquidity"))
+
+	// Further sales the same day continue from the lower price
+	next := market.AbsorbSale("gem_liquidity", 1)
+	assert.Less(t, next.NewPrice, fill.NewPrice)
+
+	// The next day the market can absorb a full day's sales again
+	market.AdvanceDay(2)
+	assert.Equal(t, 4, market.GetRemainingLiquidity("gem_liquidity"))
+	assert.Equal(t, 1.0, market.AbsorbSale("gem_liquidity", 4).PriceFactor)
+}
+
+func TestMarket_LiquidityIgnoresSplitting(t *testing.T) {
+	bulk := NewMarket()
+	split := NewMarket()
+	require.NoError(t, bulk.SetLiquidity("apple", 5))
+	require.NoError(t, split.SetLiquidity("apple", 5))
+
+	// Selling one unit at a time realizes the same as selling them all at once
+	whole := bulk.AbsorbSale("apple", 30).PriceFactor * 30
+	pieces := 0.0
+	for i := 0; i < 30; i++ {
+		pieces += split.AbsorbSale("apple", 1).PriceFactor
+	}
+	assert.InDelta(t, whole, pieces, 1e-9)
+	assert.Less(t, pieces, 30.0)
+}
+
+func TestMarket_SubscribePriceUpdates(t *testing.T) {
+	market := NewMarket()
+	updates, unsubscribe := market.SubscribePriceUpdates()
+
+	oldPrice := market.GetPrice("apple")
+	newPrice := market.ApplyPriceShock("apple", 0.2)
+	require.NotEqual(t, oldPrice, newPrice)
+
+	select {
+	case update := <-updates:
+		assert.Equal(t, "apple", update.ItemID)
+		assert.Equal(t, oldPrice, update.OldPrice)
+		assert.Equal(t, newPrice, update.NewPrice)
+		assert.Equal(t, PriceReasonShock, update.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("expected a price update")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	_, open := <-updates
+	assert.False(t, open)
+}
+
+func TestMarket_GetTrackedItems(t *testing.T) {
+	market := NewMarket()
+	tracked := market.GetTrackedItems()
+	require.NotEmpty(t, tracked)
+	assert.Contains(t, tracked, "apple")
+	assert.True(t, sort.StringsAreSorted(tracked))
+
+	gem, err := item.NewItem("ruby_tracked", "Ruby", item.CategoryGem, 500)
+	require.NoError(t, err)
+	market.RegisterItem(gem)
+	assert.Contains(t, market.GetTrackedItems(), "ruby_tracked")
+}
+
+func TestMarket_SlowPriceSubscriberDoesNotBlock(t *testing.T) {
+	market := NewMarket()
+	require.NoError(t, market.SetMaxDailyChange(0))
+	_, unsubscribe := market.SubscribePriceUpdates()
+	defer unsubscribe()
+
+	// Nobody reads the channel, so updates beyond its buffer are dropped
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < priceUpdateBuffer+10; i++ {
+			market.ApplyPriceShock("apple", 0.5)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("market blocked on a slow subscriber")
+	}
+	assert.Greater(t, market.GetDroppedPriceUpdates(), 0)
+}
+
+func TestMarket_Mood(t *testing.T) {
+	t.Run("recession hits luxuries hardest", func(t *testing.T) {
+		market := NewMarket()
+		gem, err := item.NewItem("ruby_001", "Ruby", item.CategoryGem, 500)
+		require.NoError(t, err)
+		market.RegisterItem(gem)
+
+		season := market.State.CurrentSeason
+		gemBefore := market.GetDemandOutlook("ruby_001", season)
+		fruitBefore := market.GetDemandOutlook("apple", season)
+		gemValue := market.GetFairValue("ruby_001")
+
+		market.SetMood(-1, DefaultMoodStrength)
+		mood, index, _ := market.GetMood()
+		assert.Equal(t, MoodRecession, mood)
+		assert.Equal(t, -1.0, index)
+
+		gemDrop := 1 - market.GetDemandOutlook("ruby_001", season)/gemBefore
+		fruitDrop := 1 - market.GetDemandOutlook("apple", season)/fruitBefore
+		assert.Greater(t, fruitDrop, 0.0)
+		assert.Greater(t, gemDrop, fruitDrop)
+		assert.Less(t, market.GetFairValue("ruby_001"), gemValue)
+
+		factors, ok := market.GetPriceFactors("ruby_001")
+		require.True(t, ok)
+		assert.Equal(t, "mood", factors[len(factors)-1].Name)
+
+		market.SetMood(1, DefaultMoodStrength)
+		assert.Greater(t, market.GetFairValue("ruby_001"), gemValue)
+	})
+
+	t.Run("cycle is seeded and gradual", func(t *testing.T) {
+		cycle := NewMoodCycle(42)
+		assert.Equal(t, 0.0, cycle.IndexAt(1))
+
+		history := make([]float64, 0, 60)
+		for day := 1; day <= 60; day++ {
+			index := cycle.IndexAt(day)
+			assert.GreaterOrEqual(t, index, -1.0)
+			assert.LessOrEqual(t, index, 1.0)
+			if day > 1 {
+				assert.LessOrEqual(t, math.Abs(index-history[day-2]), DefaultMoodDrift+0.1+1e-9)
+			}
+			history = append(history, index)
+		}
+
+		assert.Equal(t, history[59], NewMoodCycle(42).IndexAt(60))
+		assert.NotEqual(t, history[59], NewMoodCycle(7).IndexAt(60))
+	})
+
+	t.Run("strength is bounded", func(t *testing.T) {
+		cycle := NewMoodCycle(1)
+		assert.Error(t, cycle.SetStrength(-0.1))
+		assert.Error(t, cycle.SetStrength(MaxMoodStrength+0.1))
+		require.NoError(t, cycle.SetStrength(0))
+		assert.Equal(t, 1.0, MoodModifier(-1, cycle.GetStrength(), item.CategoryGem))
+		assert.Equal(t, MoodNormal, MoodForIndex(0.1))
+		assert.Equal(t, MoodBoom, MoodForIndex(0.5))
+	})
+}
+
+func TestMarket_Snapshot(t *testing.T) {
+	market := NewMarket()
+
+	gem, err := item.NewItem("gem_snapshot", "Test Gem", item.CategoryGem, 200)
+	require.NoError(t, err)
+	market.RegisterItem(gem)
+	market.UpdatePrice("gem_snapshot")
+	market.AbsorbSale("gem_snapshot", 2)
+
+	snapshot := market.CreateSnapshot()
+	assert.Equal(t, 1, snapshot.Day)
+	price := market.GetPrice("gem_snapshot")
+	records := len(market.GetPriceHistory("gem_snapshot").Records)
+	remaining := market.GetRemainingLiquidity("gem_snapshot")
+
+	// A crash and a few days of trading later
+	market.AbsorbSale("gem_snapshot", 20)
+	for day := 2; day <= 4; day++ {
+		market.AdvanceDay(day)
+	}
+	require.NotEqual(t, 1, market.State.CurrentDay)
+
+	market.RestoreFromSnapshot(snapshot)
+	assert.Equal(t, 1, market.State.CurrentDay)
+	assert.Equal(t, price, market.GetPrice("gem_snapshot"))
+	assert.Len(t, market.GetPriceHistory("gem_snapshot").Records, records)
+	assert.Equal(t, remaining, market.GetRemainingLiquidity("gem_snapshot"))
+
+	// Restoring twice gives the same market; the snapshot is not shared
+	market.AdvanceDay(2)
+	market.RestoreFromSnapshot(snapshot)
+	assert.Equal(t, price, market.GetPrice("gem_snapshot"))
+	assert.Len(t, market.GetPriceHistory("gem_snapshot").Records, records)
+}
+
+func TestMarket_ForecastPriceBand(t *testing.T) {
+	market := NewMarket()
+	market.SetMood(0.5, DefaultMoodStrength)
+	seasons := []item.Season{item.SeasonSpring, item.SeasonSpring, item.SeasonSpring, item.SeasonSpring, item.SeasonSpring}
+
+	bands := market.ForecastPriceBand("health_potion", seasons, DefaultMoodDrift)
+	require.Len(t, bands, len(seasons))
+	for i, band := range bands {
+		assert.Equal(t, i+1, band.DaysAhead)
+		assert.Less(t, band.Lower, band.Center)
+		assert.Greater(t, band.Upper, band.Center)
+		if i > 0 {
+			// Mood uncertainty builds up, while the boom fades toward normal
+			assert.Greater(t, band.Upper-band.Lower, bands[i-1].Upper-bands[i-1].Lower)
+			assert.Less(t, band.Center, bands[i-1].Center)
+		}
+	}
+
+	// Without mood drift the band is just the item's daily swing
+	steady := market.ForecastPriceBand("health_potion", seasons, 0)
+	assert.InDelta(t, (steady[0].Upper-steady[0].Lower)/steady[0].Center, (steady[4].Upper-steady[4].Lower)/steady[4].Center, 1e-9)
+
+	// Quality variants scale the whole band
+	fine := market.ForecastPriceBand(item.VariantID("health_potion", 2), seasons, DefaultMoodDrift)
+	require.Len(t, fine, len(seasons))
+	assert.InDelta(t, bands[0].Upper*item.QualityMultiplier(2), fine[0].Upper, 1e-9)
+
+	assert.Nil(t, market.ForecastPriceBand("unknown", seasons, DefaultMoodDrift))
+}
+
+func TestMarket_DisplayPrice(t *testing.T) {
+	market := NewMarket()
+	sword, err := item.NewItem("sword_display", "Display Sword", item.CategoryWeapon, 100)
+	require.NoError(t, err)
+	market.RegisterItem(sword)
+	require.NoError(t, market.SetPriceSmoothing(0.3))
+
+	// A calm run, a jump, then prices bouncing around the new level
+	raw := []int{100, 100, 100, 160, 160, 140, 180, 150, 170}
+	history := market.GetPriceHistory("sword_display")
+	history.Records = nil
+	for _, price := range raw {
+		history.AddRecord(price, time.Now())
+	}
+
+	series := market.GetDisplaySeries("sword_display")
+	require.Len(t, series, len(raw))
+	for i, point := range series {
+		assert.Equal(t, raw[i], point.Raw)
+	}
+
+	// The display price lags the jump, closing the gap over the next days
+	assert.InDelta(t, 100, series[2].Display, 1e-9)
+	assert.InDelta(t, 118, series[3].Display, 1e-9)
+	assert.Less(t, series[3].Display, series[4].Display)
+	assert.Less(t, series[4].Display, 160.0)
+
+	// Day-to-day swings after the jump are damped
+	rawSwing, displaySwing := 0.0, 0.0
+	for i := 5; i < len(series); i++ {
+		rawSwing += math.Abs(float64(series[i].Raw - series[i-1].Raw))
+		displaySwing += math.Abs(series[i].Display - series[i-1].Display)
+	}
+	assert.Less(t, displaySwing, rawSwing/2)
+
+	// Trading still uses the raw price
+	assert.Equal(t, 170, market.GetPrice("sword_display"))
+	assert.Equal(t, int(math.Round(series[len(series)-1].Display)), market.GetDisplayPrice("sword_display"))
+
+	// Full weight on each new price shows the raw price
+	require.NoError(t, market.SetPriceSmoothing(1))
+	assert.Equal(t, 170, market.GetDisplayPrice("sword_display"))
+
+	assert.Error(t, market.SetPriceSmoothing(0))
+	assert.Error(t, market.SetPriceSmoothing(1.5))
+	assert.Equal(t, 1.0, market.GetPriceSmoothing())
+	assert.Equal(t, 0, market.GetDisplayPrice("unknown"))
+}
+
+func TestMarket_SimulateDemand(t *testing.T) {
+	newDemandMarket := func(t *testing.T) *Market {
+		market := NewMarket()
+		bread, err := item.NewItem("bread_demand", "Bread", item.CategoryFruit, 100)
+		require.NoError(t, err)
+		ruby, err := item.NewItem("ruby_demand", "Ruby", item.CategoryGem, 100)
+		require.NoError(t, err)
+		market.RegisterItem(bread)
+		market.RegisterItem(ruby)
+		require.NoError(t, market.SetLiquidity("bread_demand", 100))
+		require.NoError(t, market.SetLiquidity("ruby_demand", 100))
+		return market
+	}
+
+	t.Run("luxuries lose sales to high prices faster than necessities", func(t *testing.T) {
+		market := newDemandMarket(t)
+		for _, itemID := range []string{"bread_demand", "ruby_demand"} {
+			assert.Equal(t, 100, market.SimulateDemand(itemID, float64(market.GetPrice(itemID))))
+		}
+
+		breadPrice := float64(market.GetPrice("bread_demand"))
+		rubyPrice := float64(market.GetPrice("ruby_demand"))
+		for _, markup := range []float64{1.2, 1.5, 2.0} {
+			bread := market.SimulateDemand("bread_demand", breadPrice*markup)
+			ruby := market.SimulateDemand("ruby_demand", rubyPrice*markup)
+			assert.Less(t, bread, 100)
+			assert.Less(t, ruby, bread, "markup %.1f", markup)
+		}
+
+		// Discounts win more sales
+		assert.Greater(t, market.SimulateDemand("ruby_demand", rubyPrice*0.8), 100)
+		assert.Equal(t, 0, market.SimulateDemand("ruby_demand", 0))
+		assert.Equal(t, 0, market.SimulateDemand("unknown", 100))
+	})
+
+	t.Run("demand level scales sales", func(t *testing.T) {
+		market := newDemandMarket(t)
+		price := float64(market.GetPrice("bread_demand"))
+		market.State.CurrentDemand = DemandHigh
+		assert.Equal(t, 120, market.SimulateDemand("bread_demand", price))
+		market.State.CurrentDemand = DemandLow
+		assert.Equal(t, 85, market.SimulateDemand("bread_demand", price))
+	})
+
+	t.Run("recent sales pull the baseline", func(t *testing.T) {
+		market := newDemandMarket(t)
+		market.AbsorbSale("bread_demand", 20)
+		price := float64(market.GetPrice("bread_demand"))
+
+		// Today's sales are not counted until the day is over
+		assert.Equal(t, 100, market.SimulateDemand("bread_demand", price))
+
+		market.SetDay(2)
+		assert.Equal(t, 60, market.SimulateDemand("bread_demand", price))
+		market.AbsorbSale("bread_demand", 40)
+		market.SetDay(3)
+		assert.Equal(t, 65, market.SimulateDemand("bread_demand", price))
+
+		// Sales older than the history are forgotten
+		market.SetDay(3 + salesHistoryDays)
+		assert.Equal(t, 100, market.SimulateDemand("bread_demand", price))
+	})
+
+	t.Run("elasticity is configurable per category", func(t *testing.T) {
+		market := newDemandMarket(t)
+		assert.Equal(t, 2.0, market.GetCategoryElasticity(item.CategoryGem))
+		assert.Equal(t, DefaultElasticity, market.GetCategoryElasticity(item.Category("UNKNOWN")))
+
+		price := float64(market.GetPrice("bread_demand"))
+		before := market.SimulateDemand("bread_demand", price*1.5)
+		require.NoError(t, market.SetCategoryElasticity(item.CategoryFruit, 3))
+		assert.Less(t, market.SimulateDemand("bread_demand", price*1.5), before)
+		assert.Error(t, market.SetCategoryElasticity(item.CategoryFruit, 0))
+	})
+}
@@ -0,0 +1,160 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// Mood is the economy-wide state of the market
+type Mood string
+
+const (
+	MoodRecession Mood = "recession"
+	MoodNormal    Mood = "normal"
+	MoodBoom      Mood = "boom"
+)
+
+// Mood cycle defaults
+const (
+	DefaultMoodStrength = 0.2  // Price and demand swing of an average item at full boom or recession
+	DefaultMoodDrift    = 0.15 // Largest daily step of the mood index
+	MaxMoodStrength     = 0.5  // Keeps the hardest-hit category's multiplier positive
+
+	moodReversion = 0.9  // Share of yesterday's index kept, pulling the economy back toward normal
+	moodThreshold = 0.33 // Index beyond which the market is in a boom or recession
+)
+
+// moodSensitivity is how strongly each category feels the economy. Luxuries
+// swing hardest; necessities barely notice.
+var moodSensitivity = map[item.Category]float64{
+	item.CategoryFruit:     0.25,
+	item.CategoryPotion:    0.5,
+	item.CategoryWeapon:    0.75,
+	item.CategoryMagicBook: 1.0,
+	item.CategoryAccessory: 1.25,
+	item.CategoryGem:       1.5,
+}
+
+// MoodForIndex names the mood for a mood index between -1 and 1
+func MoodForIndex(index float64) Mood {
+	switch {
+	case index >= moodThreshold:
+		return MoodBoom
+	case index <= -moodThreshold:
+		return MoodRecession
+	default:
+		return MoodNormal
+	}
+}
+
+// MoodModifier returns the price and demand multiplier a mood index at the
+// given strength puts on a category
+func MoodModifier(index, strength float64, category item.Category) float64 {
+	sensitivity, exists := moodSensitivity[category]
+	if !exists {
+		sensitivity = 1.0
+	}
+	return 1 + index*strength*sensitivity
+}
+
+// MoodCycle drifts the economy between recession and boom. The mood index
+// runs from -1 (deep recession) to 1 (roaring boom) and takes a small seeded
+// random step each day, so the same seed always gives the same economic
+// history.
+type MoodCycle struct {
+	seed     int64
+	strength float64
+	drift    float64
+	mu       sync.RWMutex
+}
+
+// NewMoodCycle creates a mood cycle with the given seed and default strength
+func NewMoodCycle(seed int64) *MoodCycle {
+	return &MoodCycle{seed: seed, strength: DefaultMoodStrength, drift: DefaultMoodDrift}
+}
+
+// SetSeed changes the seed the economic history is drawn from
+func (c *MoodCycle) SetSeed(seed int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seed = seed
+}
+
+// SetStrength sets how far prices and demand swing at full boom or
+// recession. Zero turns the mood off.
+func (c *MoodCycle) SetStrength(strength float64) error {
+	if strength < 0 || strength > MaxMoodStrength {
+		return fmt.Errorf("mood strength must be between 0 and %v: %v", MaxMoodStrength, strength)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.strength = strength
+	return nil
+}
+
+// GetStrength returns how far prices and demand swing at full boom or recession
+func (c *MoodCycle) GetStrength() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.strength
+}
+
+// SetDrift sets the largest step the mood index may take in a day
+func (c *MoodCycle) SetDrift(drift float64) error {
+	if drift < 0 || drift > 1 {
+		return fmt.Errorf("mood drift must be between 0 and 1: %v", drift)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.drift = drift
+	return nil
+}
+
+// GetDrift returns the largest step the mood index may take in a day
+func (c *MoodCycle) GetDrift() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.drift
+}
+
+// IndexAt returns the mood index on a day. Every game starts on day 1 with a
+// normal economy.
+func (c *MoodCycle) IndexAt(day int) float64 {
+	c.mu.RLock()
+	seed, drift := c.seed, c.drift
+	c.mu.RUnlock()
+
+	index := 0.0
+	for d := 2; d <= day; d++ {
+		rng := rand.New(rand.NewSource(seed*7_919 + int64(d))) //nolint:gosec // weak random is OK for market simulation
+		index = math.Max(-1, math.Min(1, index*moodReversion+(rng.Float64()*2-1)*drift))
+	}
+	return index
+}
+
+// SetMood sets the economy's mood index and strength, which scale every
+// item's price and demand on top of its own modifiers
+func (m *Market) SetMood(index, strength float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.State.MoodIndex = math.Max(-1, math.Min(1, index))
+	m.State.MoodStrength = strength
+}
+
+// GetMood returns the economy's mood, its index, and its strength
+func (m *Market) GetMood() (Mood, float64, float64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return MoodForIndex(m.State.MoodIndex), m.State.MoodIndex, m.State.MoodStrength
+}
+
+// GetMoodModifier returns the multiplier the economy's mood puts on a category
+func (s *MarketState) GetMoodModifier(category item.Category) float64 {
+	return MoodModifier(s.MoodIndex, s.MoodStrength, category)
+}
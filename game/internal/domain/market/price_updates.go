@@ -0,0 +1,97 @@
+package market
+
+import (
+	"sync"
+	"time"
+)
+
+// priceUpdateBuffer is how many updates a subscriber can fall behind before
+// further updates to it are dropped
+const priceUpdateBuffer = 64
+
+// Reasons a price changed
+const (
+	PriceReasonMarket = "market" // Recalculation from market conditions
+	PriceReasonSale   = "sale"   // Sales beyond the day's liquidity
+	PriceReasonShock  = "shock"  // A random market shock
+)
+
+// PriceUpdate describes a change in an item's current price
+type PriceUpdate struct {
+	ItemID    string
+	OldPrice  int
+	NewPrice  int
+	Day       int
+	Reason    string
+	Timestamp time.Time
+}
+
+// priceSubscriber is a channel receiving price updates
+type priceSubscriber struct {
+	updates chan PriceUpdate
+	dropped int
+}
+
+// SubscribePriceUpdates returns a channel that receives every price change as
+// it happens, and a function that unsubscribes and closes the channel. The
+// channel is buffered; updates a slow consumer has no room for are dropped
+// rather than blocking the market.
+func (m *Market) SubscribePriceUpdates() (<-chan PriceUpdate, func()) {
+	subscriber := &priceSubscriber{updates: make(chan PriceUpdate, priceUpdateBuffer)}
+
+	m.mu.Lock()
+	if m.priceSubscribers == nil {
+		m.priceSubscribers = make(map[*priceSubscriber]struct{})
+	}
+	m.priceSubscribers[subscriber] = struct{}{}
+	m.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			delete(m.priceSubscribers, subscriber)
+			close(subscriber.updates)
+		})
+	}
+	return subscriber.updates, unsubscribe
+}
+
+// GetDroppedPriceUpdates returns how many updates were dropped across all
+// current subscribers because their buffers were full
+func (m *Market) GetDroppedPriceUpdates() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	dropped := 0
+	for subscriber := range m.priceSubscribers {
+		dropped += subscriber.dropped
+	}
+	return dropped
+}
+
+// publishPriceUnsafe sends a price change to every subscriber without
+// blocking. Unchanged prices are not published. Caller must hold m.mu for
+// writing.
+func (m *Market) publishPriceUnsafe(itemID string, oldPrice, newPrice int, reason string) {
+	if oldPrice == newPrice || len(m.priceSubscribers) == 0 {
+		return
+	}
+
+	update := PriceUpdate{
+		ItemID:    itemID,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		Day:       m.State.CurrentDay,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	}
+	for subscriber := range m.priceSubscribers {
+		select {
+		case subscriber.updates <- update:
+		default:
+			subscriber.dropped++
+		}
+	}
+}
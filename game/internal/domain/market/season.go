@@ -0,0 +1,102 @@
+package market
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// SeasonalEffect is how a season moves prices, by item category. The
+// multipliers compound with demand, supply and mood.
+type SeasonalEffect struct {
+	Description string
+	Modifiers   map[item.Category]float64
+}
+
+// seasonalEffects is the price table for each season, shared by pricing and
+// what the UI shows players. Categories missing from a season are unaffected.
+var seasonalEffects = map[item.Season]SeasonalEffect{
+	item.SeasonSpring: {
+		Description: "Spring: Fresh produce is abundant",
+		Modifiers: map[item.Category]float64{
+			item.CategoryFruit:  0.9, // 10% cheaper
+			item.CategoryWeapon: 1.0,
+			item.CategoryPotion: 1.05, // 5% more expensive
+		},
+	},
+	item.SeasonSummer: {
+		Description: "Summer: Travel season increases demand for supplies",
+		Modifiers: map[item.Category]float64{
+			item.CategoryFruit:  0.95,
+			item.CategoryWeapon: 1.1,  // 10% more expensive
+			item.CategoryPotion: 1.15, // 15% more expensive
+		},
+	},
+	item.SeasonAutumn: {
+		Description: "Autumn: Harvest season brings plenty",
+		Modifiers: map[item.Category]float64{
+			item.CategoryFruit:  0.8, // 20% cheaper
+			item.CategoryWeapon: 1.05,
+			item.CategoryPotion: 1.0,
+		},
+	},
+	item.SeasonWinter: {
+		Description: "Winter: Scarcity drives prices up",
+		Modifiers: map[item.Category]float64{
+			item.CategoryFruit:  1.3, // 30% more expensive
+			item.CategoryWeapon: 0.95,
+			item.CategoryPotion: 1.2, // 20% more expensive
+		},
+	},
+}
+
+// ParseSeason reads a season name in any case, such as "Winter" as the game
+// state names it
+func ParseSeason(name string) (item.Season, error) {
+	season := item.Season(strings.ToUpper(strings.TrimSpace(name)))
+	if _, ok := seasonalEffects[season]; !ok {
+		return "", fmt.Errorf("unknown season: %q", name)
+	}
+	return season, nil
+}
+
+// GetSeasonalEffect returns a copy of a season's price table, or an empty
+// one for an unknown season
+func GetSeasonalEffect(season item.Season) SeasonalEffect {
+	effect := seasonalEffects[season]
+	modifiers := make(map[item.Category]float64, len(effect.Modifiers))
+	for category, modifier := range effect.Modifiers {
+		modifiers[category] = modifier
+	}
+	return SeasonalEffect{Description: effect.Description, Modifiers: modifiers}
+}
+
+// SeasonalModifier returns the multiplier a season puts on a category's
+// prices, 1.0 when the season or category is not in the table
+func SeasonalModifier(season item.Season, category item.Category) float64 {
+	if modifier, ok := seasonalEffects[season].Modifiers[category]; ok {
+		return modifier
+	}
+	return 1.0
+}
+
+// SetSeason sets the season prices are computed for from the next update on
+func (m *Market) SetSeason(season string) error {
+	parsed, err := ParseSeason(season)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.State.CurrentSeason = parsed
+	return nil
+}
+
+// GetSeason returns the season prices are computed for
+func (m *Market) GetSeason() item.Season {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.State.CurrentSeason
+}
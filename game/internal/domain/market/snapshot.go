@@ -0,0 +1,134 @@
+package market
+
+import "time"
+
+// MarketSnapshot is a copy of the market's daily state: prices and their
+// history, quotes, active events, circuit breaker opens and recent days' sales.
+// Configuration such as liquidity and the circuit breaker limit is not part
+// of it.
+type MarketSnapshot struct {
+	Day       int
+	Timestamp time.Time
+
+	state       MarketState
+	histories   map[string]priceHistoryCopy
+	itemPrices  map[string]int
+	quotes      map[string]float64
+	events      []MarketEvent
+	dailyOpen   map[string]dailyOpenPrice
+	soldToday   map[string]dailySales
+	recentSales map[string][]dailySales
+}
+
+// priceHistoryCopy holds a price history's data without its lock
+type priceHistoryCopy struct {
+	records []PriceRecord
+	current int
+	average int
+	trend   PriceTrend
+	maxSize int
+}
+
+// CreateSnapshot copies the market's daily state
+func (m *Market) CreateSnapshot() *MarketSnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := &MarketSnapshot{
+		Day:         m.State.CurrentDay,
+		Timestamp:   time.Now(),
+		state:       *m.State,
+		histories:   make(map[string]priceHistoryCopy, len(m.Prices)),
+		itemPrices:  make(map[string]int, len(m.items)),
+		quotes:      make(map[string]float64, len(m.quotes)),
+		events:      make([]MarketEvent, 0, len(m.ActiveEvents)),
+		dailyOpen:   make(map[string]dailyOpenPrice, len(m.dailyOpen)),
+		soldToday:   make(map[string]dailySales, len(m.soldToday)),
+		recentSales: make(map[string][]dailySales, len(m.recentSales)),
+	}
+
+	for id, history := range m.Prices {
+		history.mu.RLock()
+		snapshot.histories[id] = priceHistoryCopy{
+			records: append([]PriceRecord(nil), history.Records...),
+			current: history.CurrentPrice,
+			average: history.AveragePrice,
+			trend:   history.Trend,
+			maxSize: history.MaxSize,
+		}
+		history.mu.RUnlock()
+	}
+	for id, itemObj := range m.items {
+		snapshot.itemPrices[id] = itemObj.Price
+	}
+	for id, quote := range m.quotes {
+		snapshot.quotes[id] = quote
+	}
+	for _, marketEvent := range m.ActiveEvents {
+		copied := *marketEvent
+		copied.Effects = append([]EventEffect(nil), marketEvent.Effects...)
+		snapshot.events = append(snapshot.events, copied)
+	}
+	for id, open := range m.dailyOpen {
+		snapshot.dailyOpen[id] = *open
+	}
+	for id, sales := range m.soldToday {
+		snapshot.soldToday[id] = *sales
+	}
+	for id, days := range m.recentSales {
+		snapshot.recentSales[id] = append([]dailySales(nil), days...)
+	}
+
+	return snapshot
+}
+
+// RestoreFromSnapshot puts the market back to a snapshot's daily state.
+// Price subscribers are not notified of the restored prices.
+func (m *Market) RestoreFromSnapshot(snapshot *MarketSnapshot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := snapshot.state
+	m.State = &state
+
+	m.Prices = make(map[string]*PriceHistory, len(snapshot.histories))
+	for id, history := range snapshot.histories {
+		m.Prices[id] = &PriceHistory{
+			Records:      append([]PriceRecord(nil), history.records...),
+			CurrentPrice: history.current,
+			AveragePrice: history.average,
+			Trend:        history.trend,
+			MaxSize:      history.maxSize,
+		}
+	}
+	for id, price := range snapshot.itemPrices {
+		if itemObj, exists := m.items[id]; exists {
+			itemObj.Price = price
+		}
+	}
+
+	m.quotes = make(map[string]float64, len(snapshot.quotes))
+	for id, quote := range snapshot.quotes {
+		m.quotes[id] = quote
+	}
+	m.ActiveEvents = make([]*MarketEvent, 0, len(snapshot.events))
+	for _, marketEvent := range snapshot.events {
+		restored := marketEvent
+		restored.Effects = append([]EventEffect(nil), marketEvent.Effects...)
+		m.ActiveEvents = append(m.ActiveEvents, &restored)
+	}
+	m.dailyOpen = make(map[string]*dailyOpenPrice, len(snapshot.dailyOpen))
+	for id, open := range snapshot.dailyOpen {
+		open := open
+		m.dailyOpen[id] = &open
+	}
+	m.soldToday = make(map[string]*dailySales, len(snapshot.soldToday))
+	for id, sales := range snapshot.soldToday {
+		sales := sales
+		m.soldToday[id] = &sales
+	}
+	m.recentSales = make(map[string][]dailySales, len(snapshot.recentSales))
+	for id, days := range snapshot.recentSales {
+		m.recentSales[id] = append([]dailySales(nil), days...)
+	}
+}
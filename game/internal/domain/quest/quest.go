@@ -1,7 +1,9 @@
 package quest
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
@@ -63,13 +65,128 @@ const (
 	QuestStatusFailed
 )
 
+// String returns the display name of a quest status
+func (s QuestStatus) String() string {
+	switch s {
+	case QuestStatusLocked:
+		return "locked"
+	case QuestStatusAvailable:
+		return "available"
+	case QuestStatusActive:
+		return "active"
+	case QuestStatusCompleted:
+		return "completed"
+	case QuestStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ObjectiveKind determines how progress reports are applied to an objective
+type ObjectiveKind int
+
+const (
+	// ObjectiveCounter tracks a running total, such as items bought
+	ObjectiveCounter ObjectiveKind = iota
+	// ObjectiveThreshold completes once a reported value reaches the target
+	ObjectiveThreshold
+	// ObjectiveDuration completes once a value stays at or above Threshold for Target days
+	ObjectiveDuration
+	// ObjectiveRatio completes once a part/whole percentage reaches the target
+	ObjectiveRatio
+)
+
 // QuestObjective represents a single objective within a quest
 type QuestObjective struct {
 	ID          string
 	Description string
+	Kind        ObjectiveKind
 	Current     int
 	Target      int
+	Threshold   int // Value to maintain for duration objectives
 	Completed   bool
+	held        bool // Whether a duration objective's condition currently holds
+	heldSince   int  // Day the condition started holding
+}
+
+// ObjectiveReport is a progress report for an objective. Total is only used
+// by ratio objectives and Day by duration objectives.
+type ObjectiveReport struct {
+	Value int
+	Total int
+	Day   int
+}
+
+// ObjectiveEvaluator applies progress reports for one kind of objective
+type ObjectiveEvaluator interface {
+	Evaluate(objective *QuestObjective, report ObjectiveReport)
+}
+
+// objectiveEvaluators maps each objective kind to its evaluator
+var objectiveEvaluators = map[ObjectiveKind]ObjectiveEvaluator{
+	ObjectiveCounter:   counterEvaluator{},
+	ObjectiveThreshold: thresholdEvaluator{},
+	ObjectiveDuration:  durationEvaluator{},
+	ObjectiveRatio:     ratioEvaluator{},
+}
+
+// counterEvaluator sets progress to the reported total
+type counterEvaluator struct{}
+
+func (counterEvaluator) Evaluate(objective *QuestObjective, report ObjectiveReport) {
+	objective.Current = min(report.Value, objective.Target)
+	if objective.Current >= objective.Target {
+		objective.Completed = true
+	}
+}
+
+// thresholdEvaluator keeps the best value reported so far
+type thresholdEvaluator struct{}
+
+func (thresholdEvaluator) Evaluate(objective *QuestObjective, report ObjectiveReport) {
+	if report.Value > objective.Current {
+		objective.Current = min(report.Value, objective.Target)
+	}
+	if objective.Current >= objective.Target {
+		objective.Completed = true
+	}
+}
+
+// durationEvaluator counts the days a value has stayed at or above the
+// objective's threshold. Dropping below it restarts the count.
+type durationEvaluator struct{}
+
+func (durationEvaluator) Evaluate(objective *QuestObjective, report ObjectiveReport) {
+	if report.Value < objective.Threshold {
+		objective.held = false
+		objective.Current = 0
+		return
+	}
+
+	if !objective.held {
+		objective.held = true
+		objective.heldSince = report.Day
+	}
+	objective.Current = min(report.Day-objective.heldSince, objective.Target)
+	if objective.Current >= objective.Target {
+		objective.Completed = true
+	}
+}
+
+// ratioEvaluator converts a part/whole report to a percentage. A report with
+// no total is taken to be a percentage already.
+type ratioEvaluator struct{}
+
+func (ratioEvaluator) Evaluate(objective *QuestObjective, report ObjectiveReport) {
+	percent := report.Value
+	if report.Total > 0 {
+		percent = report.Value * 100 / report.Total
+	}
+	objective.Current = min(percent, objective.Target)
+	if objective.Current >= objective.Target {
+		objective.Completed = true
+	}
 }
 
 // QuestReward represents rewards for completing a quest
@@ -97,6 +214,7 @@ type Quest struct {
 	FailedAt    *time.Time
 	Chain       []QuestID // Quest chain sequence
 	ChainIndex  int       // Current position in chain
+	Permanent   bool      // Cannot be abandoned once started; set for main quests
 }
 
 // QuestManager manages all quests
@@ -107,6 +225,7 @@ type QuestManager struct {
 	questChains     map[string][]QuestID // Chain name -> Quest IDs
 	statistics      *QuestStatistics
 	callbacks       []QuestCallback
+	day             int // Current game day, used by duration objectives
 	mu              sync.RWMutex
 }
 
@@ -117,6 +236,7 @@ type QuestStatistics struct {
 	SideCompleted    int
 	DailyCompleted   int
 	TotalFailed      int
+	TotalAbandoned   int
 	TotalRewards     int
 	BestROIAchieved  float64
 	InvestmentProfit int
@@ -192,7 +312,7 @@ func (qm *QuestManager) initializeQuests() {
 		Status:      QuestStatusLocked,
 		Level:       2,
 		Objectives: []*QuestObjective{
-			{ID: "achieve_roi", Description: "Achieve 25% ROI", Target: 25},
+			{ID: "achieve_roi", Description: "Achieve 25% ROI", Kind: ObjectiveThreshold, Target: 25},
 		},
 		Rewards: &QuestReward{
 			Gold:       500,
@@ -210,7 +330,7 @@ func (qm *QuestManager) initializeQuests() {
 		Status:      QuestStatusLocked,
 		Level:       3,
 		Objectives: []*QuestObjective{
-			{ID: "achieve_roi", Description: "Achieve 50% ROI", Target: 50},
+			{ID: "achieve_roi", Description: "Achieve 50% ROI", Kind: ObjectiveThreshold, Target: 50},
 		},
 		Rewards: &QuestReward{
 			Gold:       1000,
@@ -228,7 +348,7 @@ func (qm *QuestManager) initializeQuests() {
 		Status:      QuestStatusLocked,
 		Level:       5,
 		Objectives: []*QuestObjective{
-			{ID: "achieve_roi", Description: "Achieve 100% ROI", Target: 100},
+			{ID: "achieve_roi", Description: "Achieve 100% ROI", Kind: ObjectiveThreshold, Target: 100},
 		},
 		Rewards: &QuestReward{
 			Gold:       2500,
@@ -312,7 +432,7 @@ func (qm *QuestManager) initializeQuests() {
 		Objectives: []*QuestObjective{
 			{ID: "buy_low", Description: "Buy during price dip", Target: 10},
 			{ID: "sell_high", Description: "Sell during price surge", Target: 10},
-			{ID: "profit_margin", Description: "Achieve 40% profit margin", Target: 40},
+			{ID: "profit_margin", Description: "Achieve 40% profit margin", Kind: ObjectiveRatio, Target: 40},
 		},
 		Rewards: &QuestReward{
 			Gold:       1800,
@@ -332,8 +452,8 @@ func (qm *QuestManager) initializeQuests() {
 		Level:       6,
 		TimeLimit:   &timeLimit,
 		Objectives: []*QuestObjective{
-			{ID: "hold_items", Description: "Hold items for 7 days", Target: 7},
-			{ID: "final_profit", Description: "Achieve 75% total ROI", Target: 75},
+			{ID: "hold_items", Description: "Hold items for 7 days", Kind: ObjectiveDuration, Threshold: 1, Target: 7},
+			{ID: "final_profit", Description: "Achieve 75% total ROI", Kind: ObjectiveRatio, Target: 75},
 		},
 		Rewards: &QuestReward{
 			Gold:       5000,
@@ -372,8 +492,14 @@ func (qm *QuestManager) initializeQuestChains() {
 	}
 }
 
+// ErrQuestNotAbandonable is returned when abandoning a permanent quest
+var ErrQuestNotAbandonable = errors.New("quest cannot be abandoned")
+
 // registerQuest adds a quest to the manager
 func (qm *QuestManager) registerQuest(quest *Quest) {
+	if quest.Type == QuestTypeMain {
+		quest.Permanent = true
+	}
 	qm.quests[quest.ID] = quest
 }
 
@@ -412,11 +538,35 @@ func (qm *QuestManager) StartQuest(questID QuestID, playerLevel int) error {
 	return nil
 }
 
-// UpdateObjective updates progress on a quest objective
+// SetDay sets the current game day used to time duration objectives
+func (qm *QuestManager) SetDay(day int) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	qm.day = day
+}
+
+// UpdateObjective updates progress on a quest objective. For ratio
+// objectives progress is a percentage; use UpdateRatio to report a part
+// and whole instead.
 func (qm *QuestManager) UpdateObjective(questID QuestID, objectiveID string, progress int) {
 	qm.mu.Lock()
 	defer qm.mu.Unlock()
 
+	qm.reportProgress(questID, objectiveID, ObjectiveReport{Value: progress, Day: qm.day})
+}
+
+// UpdateRatio reports progress on a ratio objective as part of whole
+func (qm *QuestManager) UpdateRatio(questID QuestID, objectiveID string, part, whole int) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	qm.reportProgress(questID, objectiveID, ObjectiveReport{Value: part, Total: whole, Day: qm.day})
+}
+
+// reportProgress applies a report to an active quest's objective using the
+// evaluator for its kind. Caller must hold qm.mu.
+func (qm *QuestManager) reportProgress(questID QuestID, objectiveID string, report ObjectiveReport) {
 	quest, exists := qm.activeQuests[questID]
 	if !exists || quest.Status != QuestStatusActive {
 		return
@@ -424,10 +574,14 @@ func (qm *QuestManager) UpdateObjective(questID QuestID, objectiveID string, pro
 
 	for _, objective := range quest.Objectives {
 		if objective.ID == objectiveID {
-			objective.Current = min(progress, objective.Target)
-			if objective.Current >= objective.Target {
-				objective.Completed = true
+			if objective.Completed {
+				break
+			}
+			evaluator, ok := objectiveEvaluators[objective.Kind]
+			if !ok {
+				evaluator = counterEvaluator{}
 			}
+			evaluator.Evaluate(objective, report)
 			qm.checkQuestCompletion(quest)
 			break
 		}
@@ -526,6 +680,63 @@ func (qm *QuestManager) FailQuest(questID QuestID) {
 	qm.notifyCallbacks(quest, QuestStatusActive)
 }
 
+// AbandonQuest gives up an active quest. Its progress is discarded and it
+// becomes available to start again.
+func (qm *QuestManager) AbandonQuest(questID QuestID) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	quest, exists := qm.activeQuests[questID]
+	if !exists || quest.Status != QuestStatusActive {
+		return fmt.Errorf("quest not active: %s", questID)
+	}
+	if quest.Permanent {
+		return fmt.Errorf("%w: %s", ErrQuestNotAbandonable, questID)
+	}
+
+	quest.Status = QuestStatusAvailable
+	quest.StartedAt = nil
+	for _, objective := range quest.Objectives {
+		objective.Current = 0
+		objective.Completed = false
+		objective.held = false
+	}
+
+	delete(qm.activeQuests, questID)
+	qm.statistics.TotalAbandoned++
+
+	// Notify callbacks
+	qm.notifyCallbacks(quest, QuestStatusActive)
+
+	return nil
+}
+
+// CheckConsistency reports active or completed quest references that point
+// at no known quest, and active quests not marked active. Chains may list
+// quests not written yet, so they are not checked. An empty result means the
+// quest log is sound.
+func (qm *QuestManager) CheckConsistency() []string {
+	qm.mu.RLock()
+	defer qm.mu.RUnlock()
+
+	problems := make([]string, 0)
+	for questID, quest := range qm.activeQuests {
+		if _, exists := qm.quests[questID]; !exists {
+			problems = append(problems, fmt.Sprintf("active quest %s does not exist", questID))
+		} else if quest.Status != QuestStatusActive {
+			problems = append(problems, fmt.Sprintf("active quest %s is not marked active", questID))
+		}
+	}
+	for questID := range qm.completedQuests {
+		if _, exists := qm.quests[questID]; !exists {
+			problems = append(problems, fmt.Sprintf("completed quest %s does not exist", questID))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
 // GetQuest returns a specific quest
 func (qm *QuestManager) GetQuest(questID QuestID) (*Quest, bool) {
 	qm.mu.RLock()
@@ -679,6 +890,7 @@ func (qm *QuestManager) Reset() {
 		for _, objective := range quest.Objectives {
 			objective.Current = 0
 			objective.Completed = false
+			objective.held = false
 		}
 	}
 
@@ -691,6 +903,127 @@ func (qm *QuestManager) Reset() {
 	qm.activeQuests = make(map[QuestID]*Quest)
 	qm.completedQuests = make(map[QuestID]bool)
 	qm.statistics = &QuestStatistics{}
+	qm.day = 0
+}
+
+// ObjectiveRecord is an objective's progress as kept in a save
+type ObjectiveRecord struct {
+	ID        string `json:"id"`
+	Current   int    `json:"current"`
+	Completed bool   `json:"completed,omitempty"`
+	Held      bool   `json:"held,omitempty"`
+	HeldSince int    `json:"heldSince,omitempty"`
+}
+
+// QuestRecord is a quest's status and progress as kept in a save
+type QuestRecord struct {
+	ID         QuestID           `json:"id"`
+	Status     QuestStatus       `json:"status"`
+	ChainIndex int               `json:"chainIndex,omitempty"`
+	Objectives []ObjectiveRecord `json:"objectives"`
+}
+
+// Record is the quest log and its statistics as kept in a save
+type Record struct {
+	Quests []QuestRecord   `json:"quests"`
+	Stats  QuestStatistics `json:"stats"`
+}
+
+// Record returns every quest's status and progress, and the statistics, for
+// a save
+func (qm *QuestManager) Record() Record {
+	qm.mu.RLock()
+	defer qm.mu.RUnlock()
+
+	questIDs := make([]string, 0, len(qm.quests))
+	for questID := range qm.quests {
+		questIDs = append(questIDs, string(questID))
+	}
+	sort.Strings(questIDs)
+
+	record := Record{Quests: make([]QuestRecord, 0, len(questIDs)), Stats: *qm.statistics}
+	for _, questID := range questIDs {
+		quest := qm.quests[QuestID(questID)]
+		saved := QuestRecord{
+			ID:         quest.ID,
+			Status:     quest.Status,
+			ChainIndex: quest.ChainIndex,
+			Objectives: make([]ObjectiveRecord, 0, len(quest.Objectives)),
+		}
+		for _, objective := range quest.Objectives {
+			saved.Objectives = append(saved.Objectives, ObjectiveRecord{
+				ID:        objective.ID,
+				Current:   objective.Current,
+				Completed: objective.Completed,
+				Held:      objective.held,
+				HeldSince: objective.heldSince,
+			})
+		}
+		record.Quests = append(record.Quests, saved)
+	}
+	return record
+}
+
+// RestoreRecord replaces the quest log with a saved record. Quests the save
+// does not mention keep their current state; quests and objectives that no
+// longer exist are reported but the rest is kept. Active quests restart
+// their time limits.
+func (qm *QuestManager) RestoreRecord(record Record) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	var errs []error
+	now := time.Now()
+	for _, saved := range record.Quests {
+		quest, exists := qm.quests[saved.ID]
+		if !exists {
+			errs = append(errs, fmt.Errorf("quest not found: %s", saved.ID))
+			continue
+		}
+
+		quest.Status = saved.Status
+		quest.ChainIndex = saved.ChainIndex
+		quest.StartedAt, quest.CompletedAt, quest.FailedAt = nil, nil, nil
+		for _, objective := range quest.Objectives {
+			objective.Current, objective.Completed, objective.held, objective.heldSince = 0, false, false, 0
+		}
+		for _, progress := range saved.Objectives {
+			objective := findObjective(quest, progress.ID)
+			if objective == nil {
+				errs = append(errs, fmt.Errorf("quest %s has no objective %s", saved.ID, progress.ID))
+				continue
+			}
+			objective.Current = min(progress.Current, objective.Target)
+			objective.Completed = progress.Completed
+			objective.held = progress.Held
+			objective.heldSince = progress.HeldSince
+		}
+
+		delete(qm.activeQuests, quest.ID)
+		delete(qm.completedQuests, quest.ID)
+		switch quest.Status {
+		case QuestStatusActive:
+			quest.StartedAt = &now
+			qm.activeQuests[quest.ID] = quest
+		case QuestStatusCompleted:
+			quest.CompletedAt = &now
+			qm.completedQuests[quest.ID] = true
+		}
+	}
+
+	stats := record.Stats
+	qm.statistics = &stats
+	return errors.Join(errs...)
+}
+
+// findObjective returns a quest's objective by ID, or nil
+func findObjective(quest *Quest, objectiveID string) *QuestObjective {
+	for _, objective := range quest.Objectives {
+		if objective.ID == objectiveID {
+			return objective
+		}
+	}
+	return nil
 }
 
 // min returns the minimum of two integers
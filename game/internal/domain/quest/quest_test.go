@@ -287,6 +287,44 @@ func TestFailQuest(t *testing.T) {
 	assert.Equal(t, 1, stats.TotalFailed)
 }
 
+func TestAbandonQuest(t *testing.T) {
+	qm := NewQuestManager()
+
+	var notified []QuestStatus
+	qm.RegisterCallback(func(quest *Quest, oldStatus QuestStatus) {
+		notified = append(notified, quest.Status)
+	})
+
+	// Start a side quest and make some progress
+	qm.quests[QuestMarketTiming].Status = QuestStatusAvailable
+	require.NoError(t, qm.StartQuest(QuestMarketTiming, 4))
+	qm.UpdateObjective(QuestMarketTiming, "buy_low", 10)
+
+	require.NoError(t, qm.AbandonQuest(QuestMarketTiming))
+
+	quest, _ := qm.GetQuest(QuestMarketTiming)
+	assert.Equal(t, QuestStatusAvailable, quest.Status)
+	assert.Nil(t, quest.StartedAt)
+	assert.Equal(t, 0, quest.Objectives[0].Current)
+	assert.False(t, quest.Objectives[0].Completed)
+	assert.Empty(t, qm.GetActiveQuests())
+	assert.Equal(t, 1, qm.GetStatistics().TotalAbandoned)
+	assert.Equal(t, []QuestStatus{QuestStatusActive, QuestStatusAvailable}, notified)
+
+	// The quest can be picked up again
+	require.NoError(t, qm.StartQuest(QuestMarketTiming, 4))
+	assert.Equal(t, QuestStatusActive, quest.Status)
+
+	// Inactive quests cannot be abandoned
+	assert.Error(t, qm.AbandonQuest(QuestFirstProfit))
+
+	// Main quests are permanent
+	qm.quests[QuestROI25].Status = QuestStatusAvailable
+	require.NoError(t, qm.StartQuest(QuestROI25, 2))
+	assert.ErrorIs(t, qm.AbandonQuest(QuestROI25), ErrQuestNotAbandonable)
+	assert.Equal(t, QuestStatusActive, qm.quests[QuestROI25].Status)
+}
+
 func TestGetAvailableQuests(t *testing.T) {
 	qm := NewQuestManager()
 
@@ -391,6 +429,40 @@ func TestQuestReset(t *testing.T) {
 	assert.Equal(t, 0, stats.TotalRewards)
 }
 
+func TestQuestRecord(t *testing.T) {
+	qm := NewQuestManager()
+
+	// Finish the first trade and get halfway into the quest it unlocks
+	require.NoError(t, qm.StartQuest(QuestFirstTrade, 1))
+	qm.UpdateObjective(QuestFirstTrade, "buy_item", 1)
+	qm.UpdateObjective(QuestFirstTrade, "sell_item", 1)
+	require.NoError(t, qm.StartQuest(QuestFirstProfit, 1))
+	qm.UpdateObjective(QuestFirstProfit, "earn_profit", 40)
+	record := qm.Record()
+
+	restored := NewQuestManager()
+	require.NoError(t, restored.RestoreRecord(record))
+
+	firstTrade, _ := restored.GetQuest(QuestFirstTrade)
+	assert.Equal(t, QuestStatusCompleted, firstTrade.Status)
+	assert.Len(t, restored.GetCompletedQuests(), 1)
+
+	firstProfit, _ := restored.GetQuest(QuestFirstProfit)
+	assert.Equal(t, QuestStatusActive, firstProfit.Status)
+	assert.Equal(t, 40, firstProfit.Objectives[0].Current)
+	require.Len(t, restored.GetActiveQuests(), 1)
+	assert.Equal(t, 1, restored.GetStatistics().TotalCompleted)
+	assert.Empty(t, restored.CheckConsistency())
+
+	// Progress carries on from where the save left it
+	restored.UpdateObjective(QuestFirstProfit, "earn_profit", 100)
+	assert.Equal(t, QuestStatusCompleted, firstProfit.Status)
+
+	// Quests no longer in the game are reported and skipped
+	record.Quests = append(record.Quests, QuestRecord{ID: "retired_quest", Status: QuestStatusActive})
+	assert.Error(t, NewQuestManager().RestoreRecord(record))
+}
+
 func TestShopInvestmentQuests(t *testing.T) {
 	qm := NewQuestManager()
 
@@ -449,9 +521,12 @@ func TestLongTermInvestmentQuest(t *testing.T) {
 	err := qm.StartQuest(QuestLongTermInvestment, 6)
 	require.NoError(t, err)
 
-	// Update objectives
-	qm.UpdateObjective(QuestLongTermInvestment, "hold_items", 7)
-	qm.UpdateObjective(QuestLongTermInvestment, "final_profit", 75)
+	// Hold stock for a week, then sell at a profit
+	qm.SetDay(1)
+	qm.UpdateObjective(QuestLongTermInvestment, "hold_items", 5)
+	qm.SetDay(8)
+	qm.UpdateObjective(QuestLongTermInvestment, "hold_items", 5)
+	qm.UpdateRatio(QuestLongTermInvestment, "final_profit", 750, 1000)
 
 	assert.Equal(t, QuestStatusCompleted, quest.Status)
 
@@ -463,6 +538,85 @@ func TestLongTermInvestmentQuest(t *testing.T) {
 	assert.Equal(t, 1, reward.Items["investor_badge"])
 }
 
+func TestObjectiveEvaluators(t *testing.T) {
+	t.Run("counter", func(t *testing.T) {
+		objective := &QuestObjective{Kind: ObjectiveCounter, Target: 5}
+		evaluator := objectiveEvaluators[ObjectiveCounter]
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 3})
+		assert.Equal(t, 3, objective.Current)
+		assert.False(t, objective.Completed)
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 6})
+		assert.Equal(t, 5, objective.Current)
+		assert.True(t, objective.Completed)
+	})
+
+	t.Run("threshold keeps the best value", func(t *testing.T) {
+		objective := &QuestObjective{Kind: ObjectiveThreshold, Target: 25}
+		evaluator := objectiveEvaluators[ObjectiveThreshold]
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 20})
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 10})
+		assert.Equal(t, 20, objective.Current)
+		assert.False(t, objective.Completed)
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 30})
+		assert.True(t, objective.Completed)
+	})
+
+	t.Run("duration restarts when the condition breaks", func(t *testing.T) {
+		objective := &QuestObjective{Kind: ObjectiveDuration, Threshold: 10, Target: 7}
+		evaluator := objectiveEvaluators[ObjectiveDuration]
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 12, Day: 1})
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 15, Day: 5})
+		assert.Equal(t, 4, objective.Current)
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 8, Day: 6})
+		assert.Equal(t, 0, objective.Current)
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 10, Day: 7})
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 10, Day: 13})
+		assert.False(t, objective.Completed)
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 10, Day: 14})
+		assert.Equal(t, 7, objective.Current)
+		assert.True(t, objective.Completed)
+	})
+
+	t.Run("ratio", func(t *testing.T) {
+		objective := &QuestObjective{Kind: ObjectiveRatio, Target: 40}
+		evaluator := objectiveEvaluators[ObjectiveRatio]
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 30, Total: 100})
+		assert.Equal(t, 30, objective.Current)
+		assert.False(t, objective.Completed)
+
+		evaluator.Evaluate(objective, ObjectiveReport{Value: 90, Total: 200})
+		assert.True(t, objective.Completed)
+
+		// Without a total the value is already a percentage
+		percent := &QuestObjective{Kind: ObjectiveRatio, Target: 40}
+		evaluator.Evaluate(percent, ObjectiveReport{Value: 40})
+		assert.True(t, percent.Completed)
+	})
+}
+
+func TestMarketTimingQuestMargin(t *testing.T) {
+	qm := NewQuestManager()
+	qm.quests[QuestMarketTiming].Status = QuestStatusAvailable
+	require.NoError(t, qm.StartQuest(QuestMarketTiming, 4))
+
+	// A 35% margin falls short of the 40% goal
+	qm.UpdateRatio(QuestMarketTiming, "profit_margin", 35, 100)
+	quest, _ := qm.GetQuest(QuestMarketTiming)
+	assert.False(t, quest.Objectives[2].Completed)
+
+	qm.UpdateRatio(QuestMarketTiming, "profit_margin", 210, 500)
+	assert.True(t, quest.Objectives[2].Completed)
+}
+
 func TestConcurrentQuestOperations(t *testing.T) {
 	qm := NewQuestManager()
 
@@ -0,0 +1,157 @@
+package scenario
+
+import (
+	"fmt"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// Scenario is a named starting situation. Where difficulty tunes the rules,
+// a scenario sets the stage: how much gold and stock the player starts with,
+// what running the shop costs, and what they are trying to achieve.
+type Scenario struct {
+	ID          string
+	Name        string
+	Description string
+	InitialGold int
+	Reputation  float64        // Starting reputation, from -100 to 100
+	Overhead    float64        // Multiplier on taxes and tariffs; zero or one leaves them unchanged
+	MaxDays     int            // Last day of the scenario; zero plays endlessly
+	Inventory   map[string]int // Item ID to quantity, stocked in the warehouse at base price
+	StockAge    int            // Days of shelf life the starting stock has already lost
+	Objectives  []string
+	Victory     *gamestate.WinConditions // What winning takes; nil keeps the game's usual conditions
+}
+
+// Scenario IDs
+const (
+	Standard           = "standard"
+	StrugglingShop     = "struggling_shop"
+	InheritedFortune   = "inherited_fortune"
+	SeasonalSpecialist = "seasonal_specialist"
+)
+
+var scenarios = []Scenario{
+	{
+		ID:          Standard,
+		Name:        "A Fresh Start",
+		Description: "An empty shop, a modest purse and the whole market ahead of you",
+		InitialGold: 1000,
+		Objectives:  []string{"Grow your shop into a trading house"},
+	},
+	{
+		ID:          StrugglingShop,
+		Name:        "The Struggling Shop",
+		Description: "You've taken over a failing shop with little gold, aging stock and a poor name in town",
+		InitialGold: 250,
+		Reputation:  -30,
+		Inventory:   map[string]int{"apple": 15, "orange": 10},
+		StockAge:    1,
+		Objectives: []string{
+			"Clear the old stock before it spoils",
+			"Restore your reputation above zero",
+			"Build your gold back up to 2000",
+		},
+		Victory: &gamestate.WinConditions{Gold: 2000, Reputation: 1, Rank: gamestate.RankApprentice},
+	},
+	{
+		ID:          InheritedFortune,
+		Name:        "Inherited Fortune",
+		Description: "A rich relative left you a grand shop, and the tax collector knows it",
+		InitialGold: 8000,
+		Reputation:  20,
+		Overhead:    2.0,
+		Inventory:   map[string]int{"iron_sword": 3, "magic_staff": 1},
+		Objectives: []string{
+			"Keep your fortune growing despite the high overhead",
+			"Reach a net worth of 20000",
+		},
+	},
+	{
+		ID:          SeasonalSpecialist,
+		Name:        "Seasonal Specialist",
+		Description: "Your warehouse is full of the spring harvest and you have one season to sell it",
+		InitialGold: 600,
+		MaxDays:     30,
+		Inventory:   map[string]int{"apple": 30, "orange": 25, "grapes": 20},
+		Objectives: []string{
+			"Sell the harvest before the season ends",
+			"Finish the season with more than 3000 gold",
+		},
+		Victory: &gamestate.WinConditions{Gold: 3001, Reputation: gamestate.MinReputation, Rank: gamestate.RankApprentice, AtTimeUp: true},
+	},
+}
+
+// All returns every scenario in the order they are offered to the player
+func All() []Scenario {
+	all := make([]Scenario, len(scenarios))
+	for i, s := range scenarios {
+		all[i] = s.clone()
+	}
+	return all
+}
+
+// Get returns a scenario by ID
+func Get(id string) (Scenario, error) {
+	for _, s := range scenarios {
+		if s.ID == id {
+			return s.clone(), nil
+		}
+	}
+	return Scenario{}, fmt.Errorf("unknown scenario: %s", id)
+}
+
+// Validate checks that a scenario can start a game
+func (s Scenario) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("scenario needs an ID")
+	}
+	if s.InitialGold < 0 {
+		return fmt.Errorf("scenario %q cannot start with negative gold", s.ID)
+	}
+	if s.Overhead < 0 || s.MaxDays < 0 || s.StockAge < 0 {
+		return fmt.Errorf("scenario %q has negative overhead, day cap or stock age", s.ID)
+	}
+	if s.Victory != nil {
+		if err := s.Victory.Validate(); err != nil {
+			return fmt.Errorf("scenario %q: %w", s.ID, err)
+		}
+		if s.Victory.AtTimeUp && s.MaxDays == 0 {
+			return fmt.Errorf("scenario %q judges victory on a last day it does not have", s.ID)
+		}
+	}
+	for itemID, quantity := range s.Inventory {
+		if _, exists := item.GetItemRegistry().GetItem(itemID); !exists {
+			return fmt.Errorf("scenario %q stocks unknown item %q", s.ID, itemID)
+		}
+		if quantity <= 0 {
+			return fmt.Errorf("scenario %q stocks a non-positive quantity of %q", s.ID, itemID)
+		}
+	}
+	return nil
+}
+
+// GetOverhead returns the tax multiplier, treating an unset overhead as none
+func (s Scenario) GetOverhead() float64 {
+	if s.Overhead == 0 {
+		return 1.0
+	}
+	return s.Overhead
+}
+
+func (s Scenario) clone() Scenario {
+	if s.Inventory != nil {
+		inventory := make(map[string]int, len(s.Inventory))
+		for itemID, quantity := range s.Inventory {
+			inventory[itemID] = quantity
+		}
+		s.Inventory = inventory
+	}
+	s.Objectives = append([]string(nil), s.Objectives...)
+	if s.Victory != nil {
+		victory := *s.Victory
+		s.Victory = &victory
+	}
+	return s
+}
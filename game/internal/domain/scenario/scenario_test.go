@@ -0,0 +1,59 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
+)
+
+func TestScenarios(t *testing.T) {
+	all := All()
+	require.NotEmpty(t, all)
+	assert.Equal(t, Standard, all[0].ID)
+
+	seen := make(map[string]bool)
+	for _, s := range all {
+		assert.NoError(t, s.Validate(), s.ID)
+		assert.False(t, seen[s.ID], "duplicate scenario %s", s.ID)
+		seen[s.ID] = true
+	}
+
+	struggling, err := Get(StrugglingShop)
+	require.NoError(t, err)
+	assert.Less(t, struggling.Reputation, 0.0)
+	assert.Equal(t, 1.0, struggling.GetOverhead())
+	assert.Greater(t, struggling.StockAge, 0)
+
+	// Callers get their own copy of the starting stock
+	struggling.Inventory["apple"] = 999
+	again, err := Get(StrugglingShop)
+	require.NoError(t, err)
+	assert.Equal(t, 15, again.Inventory["apple"])
+
+	// Scenarios with their own goals win on them, and callers get a copy
+	require.NotNil(t, struggling.Victory)
+	assert.Equal(t, 2000, struggling.Victory.Gold)
+	struggling.Victory.Gold = 1
+	assert.Equal(t, 2000, again.Victory.Gold)
+	standard, err := Get(Standard)
+	require.NoError(t, err)
+	assert.Nil(t, standard.Victory)
+
+	_, err = Get("dragon_hoard")
+	assert.ErrorContains(t, err, "unknown scenario")
+
+	invalid := Scenario{ID: "broken", Inventory: map[string]int{"unobtainium": 1}}
+	assert.ErrorContains(t, invalid.Validate(), "unknown item")
+
+	stale := Scenario{ID: "stale", StockAge: -1}
+	assert.ErrorContains(t, stale.Validate(), "stock age")
+
+	unwinnable := Scenario{ID: "unwinnable", Victory: &gamestate.WinConditions{Gold: -1}}
+	assert.ErrorContains(t, unwinnable.Validate(), "victory gold")
+
+	endless := Scenario{ID: "endless", Victory: &gamestate.WinConditions{Rank: gamestate.RankApprentice, AtTimeUp: true}}
+	assert.ErrorContains(t, endless.Validate(), "last day")
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -54,6 +56,7 @@ const (
 	SettingAutoSave          = "auto_save"
 	SettingAutoSaveInt       = "auto_save_interval"
 	SettingLanguage          = "language"
+	SettingCurrency          = "currency"
 	SettingFullscreen        = "fullscreen"
 	SettingVSync             = "vsync"
 	SettingTargetFPS         = "target_fps"
@@ -66,6 +69,14 @@ const (
 	SettingShowFPS           = "show_fps"
 	SettingShowNotifications = "show_notifications"
 	SettingShowTutorialHints = "show_tutorial_hints"
+	SettingUIScale           = "ui_scale"
+	SettingResolution        = "resolution"
+)
+
+// UI scale bounds
+const (
+	MinUIScale = 0.5
+	MaxUIScale = 2.0
 )
 
 // Errors
@@ -146,6 +157,10 @@ type GameSettings struct {
 	NetworkTimeout  int    `json:"network_timeout_seconds"`
 	CacheSize       int    `json:"cache_size_mb"`
 
+	// Sampling for high-frequency log messages such as price changes
+	LogSampleRate       int `json:"log_sample_rate"`        // Log 1 in N per message key
+	LogSampleIntervalMs int `json:"log_sample_interval_ms"` // Minimum gap per message key
+
 	// Accessibility settings
 	ColorblindMode      string `json:"colorblind_mode"`
 	HighContrast        bool   `json:"high_contrast"`
@@ -210,6 +225,11 @@ func NewSettingsManager(settingsPath string) *SettingsManager {
 	return sm
 }
 
+// DefaultGameSettings returns a fresh copy of the default settings
+func DefaultGameSettings() *GameSettings {
+	return (&SettingsManager{}).createDefaultSettings()
+}
+
 // createDefaultSettings creates default game settings
 func (sm *SettingsManager) createDefaultSettings() *GameSettings {
 	return &GameSettings{
@@ -278,6 +298,9 @@ func (sm *SettingsManager) createDefaultSettings() *GameSettings {
 		NetworkTimeout:  30,
 		CacheSize:       100,
 
+		LogSampleRate:       1,
+		LogSampleIntervalMs: 1000,
+
 		// Accessibility
 		ColorblindMode:      "none",
 		HighContrast:        false,
@@ -374,6 +397,21 @@ func (sm *SettingsManager) registerValidators() {
 		return ErrInvalidSetting
 	}
 
+	// Language validator
+	sm.validators["language"] = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return ErrInvalidType
+		}
+		validLanguages := []string{"en", "ja", "es", "fr", "de", "zh", "ko"}
+		for _, l := range validLanguages {
+			if v == l {
+				return nil
+			}
+		}
+		return ErrInvalidSetting
+	}
+
 	// Quality validators
 	qualityValidator := func(value interface{}) error {
 		v, ok := value.(string)
@@ -392,6 +430,59 @@ func (sm *SettingsManager) registerValidators() {
 	sm.validators["shadow_quality"] = qualityValidator
 	sm.validators["texture_quality"] = qualityValidator
 	sm.validators["effects_quality"] = qualityValidator
+
+	// UI scale validator (0.5 to 2.0)
+	sm.validators["ui_scale"] = func(value interface{}) error {
+		v, ok := value.(float64)
+		if !ok {
+			return ErrInvalidType
+		}
+		if v < MinUIScale || v > MaxUIScale {
+			return ErrInvalidRange
+		}
+		return nil
+	}
+
+	// Resolution validator
+	sm.validators["resolution"] = func(value interface{}) error {
+		_, err := ParseResolution(value)
+		return err
+	}
+}
+
+// ParseResolution converts a "WIDTHxHEIGHT" string, a width/height map, or a Resolution
+func ParseResolution(value interface{}) (Resolution, error) {
+	var res Resolution
+
+	switch v := value.(type) {
+	case Resolution:
+		res = v
+	case string:
+		parts := strings.Split(v, "x")
+		if len(parts) != 2 {
+			return res, ErrInvalidSetting
+		}
+		width, errW := strconv.Atoi(parts[0])
+		height, errH := strconv.Atoi(parts[1])
+		if errW != nil || errH != nil {
+			return res, ErrInvalidSetting
+		}
+		res = Resolution{Width: width, Height: height}
+	case map[string]interface{}:
+		width, okW := v["width"].(float64)
+		height, okH := v["height"].(float64)
+		if !okW || !okH {
+			return res, ErrInvalidType
+		}
+		res = Resolution{Width: int(width), Height: int(height)}
+	default:
+		return res, ErrInvalidType
+	}
+
+	if res.Width < 640 || res.Height < 480 || res.Width > 7680 || res.Height > 4320 {
+		return res, ErrInvalidRange
+	}
+	return res, nil
 }
 
 // LoadSettings loads settings from file
@@ -434,6 +525,11 @@ func (sm *SettingsManager) SaveSettings() error {
 		return ErrSettingsLocked
 	}
 
+	return sm.saveSettingsUnlocked()
+}
+
+// saveSettingsUnlocked writes settings to file without locking (caller holds the lock)
+func (sm *SettingsManager) saveSettingsUnlocked() error {
 	// Update last modified time
 	sm.settings.LastModified = time.Now()
 
@@ -474,6 +570,8 @@ func (sm *SettingsManager) GetSetting(key string) (interface{}, error) {
 		return sm.settings.AutoSaveInterval, nil
 	case SettingLanguage:
 		return sm.settings.Language, nil
+	case SettingCurrency:
+		return sm.settings.Currency, nil
 
 	// Graphics settings
 	case SettingFullscreen:
@@ -488,6 +586,10 @@ func (sm *SettingsManager) GetSetting(key string) (interface{}, error) {
 		return sm.settings.TextureQuality, nil
 	case SettingEffectsQuality:
 		return sm.settings.EffectsQuality, nil
+	case SettingUIScale:
+		return sm.settings.UIScale, nil
+	case SettingResolution:
+		return sm.settings.Resolution, nil
 
 	// Audio settings
 	case SettingMasterVolume:
@@ -527,6 +629,65 @@ func (sm *SettingsManager) SetSetting(key string, value interface{}) error {
 		return ErrSettingsLocked
 	}
 
+	// Store old value for callbacks
+	oldValue, _ := sm.GetSettingUnlocked(key)
+
+	if err := sm.applySetting(sm.settings, key, value); err != nil {
+		return err
+	}
+
+	// Trigger change callbacks
+	sm.notifyChange(key, oldValue, value)
+
+	// Auto-save if enabled
+	if sm.autoSave {
+		return sm.saveSettingsUnlocked()
+	}
+
+	return nil
+}
+
+// SetSettingsBatch applies several settings at once. Every value is validated
+// before anything changes; if any key fails, no settings are modified.
+func (sm *SettingsManager) SetSettingsBatch(updates map[string]interface{}) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.locked {
+		return ErrSettingsLocked
+	}
+
+	// Apply to a copy so a failure leaves the current settings untouched
+	staged := *sm.settings
+	staged.CustomSettings = make(map[string]interface{}, len(sm.settings.CustomSettings))
+	for k, v := range sm.settings.CustomSettings {
+		staged.CustomSettings[k] = v
+	}
+
+	oldValues := make(map[string]interface{}, len(updates))
+	for key, value := range updates {
+		oldValues[key], _ = sm.GetSettingUnlocked(key)
+		if err := sm.applySetting(&staged, key, value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	sm.settings = &staged
+
+	// Trigger change callbacks once everything is applied
+	for key, value := range updates {
+		sm.notifyChange(key, oldValues[key], value)
+	}
+
+	if sm.autoSave {
+		return sm.saveSettingsUnlocked()
+	}
+
+	return nil
+}
+
+// applySetting validates a value and writes it into the given settings
+func (sm *SettingsManager) applySetting(target *GameSettings, key string, value interface{}) error {
 	// Validate if validator exists
 	if validator, ok := sm.validators[key]; ok {
 		if err := validator(value); err != nil {
@@ -534,33 +695,41 @@ func (sm *SettingsManager) SetSetting(key string, value interface{}) error {
 		}
 	}
 
-	// Store old value for callbacks
-	oldValue, _ := sm.GetSettingUnlocked(key)
-
-	// Set the value
 	switch key {
 	// Game settings
 	case SettingGameSpeed:
 		if v, ok := value.(float64); ok {
-			sm.settings.GameSpeed = v
+			target.GameSpeed = v
 		} else {
 			return ErrInvalidType
 		}
 	case SettingDifficulty:
 		if v, ok := value.(string); ok {
-			sm.settings.Difficulty = v
+			target.Difficulty = v
 		} else {
 			return ErrInvalidType
 		}
 	case SettingAutoSave:
 		if v, ok := value.(bool); ok {
-			sm.settings.AutoSave = v
+			target.AutoSave = v
 		} else {
 			return ErrInvalidType
 		}
 	case SettingAutoSaveInt:
 		if v, ok := value.(int); ok {
-			sm.settings.AutoSaveInterval = v
+			target.AutoSaveInterval = v
+		} else {
+			return ErrInvalidType
+		}
+	case SettingLanguage:
+		if v, ok := value.(string); ok {
+			target.Language = v
+		} else {
+			return ErrInvalidType
+		}
+	case SettingCurrency:
+		if v, ok := value.(string); ok && v != "" {
+			target.Currency = v
 		} else {
 			return ErrInvalidType
 		}
@@ -568,35 +737,44 @@ func (sm *SettingsManager) SetSetting(key string, value interface{}) error {
 	// Audio settings
 	case SettingMusicVolume:
 		if v, ok := value.(float64); ok {
-			sm.settings.MusicVolume = v
+			target.MusicVolume = v
 		} else {
 			return ErrInvalidType
 		}
 	case SettingSFXVolume:
 		if v, ok := value.(float64); ok {
-			sm.settings.SFXVolume = v
+			target.SFXVolume = v
 		} else {
 			return ErrInvalidType
 		}
 
+	// Display settings
+	case SettingUIScale:
+		if v, ok := value.(float64); ok {
+			target.UIScale = v
+		} else {
+			return ErrInvalidType
+		}
+	case SettingResolution:
+		res, err := ParseResolution(value)
+		if err != nil {
+			return err
+		}
+		target.Resolution = res
+
 	default:
 		// Set custom setting
-		sm.settings.CustomSettings[key] = value
+		target.CustomSettings[key] = value
 	}
 
-	// Trigger change callbacks
-	if callbacks, ok := sm.changeCallbacks[key]; ok {
-		for _, callback := range callbacks {
-			callback(oldValue, value)
-		}
-	}
+	return nil
+}
 
-	// Auto-save if enabled
-	if sm.autoSave {
-		return sm.SaveSettings()
+// notifyChange calls the change callbacks registered for a key
+func (sm *SettingsManager) notifyChange(key string, oldValue, newValue interface{}) {
+	for _, callback := range sm.changeCallbacks[key] {
+		callback(oldValue, newValue)
 	}
-
-	return nil
 }
 
 // GetSettingUnlocked gets a setting without locking (internal use)
@@ -608,6 +786,10 @@ func (sm *SettingsManager) GetSettingUnlocked(key string) (interface{}, error) {
 		return sm.settings.MusicVolume, nil
 	case SettingSFXVolume:
 		return sm.settings.SFXVolume, nil
+	case SettingUIScale:
+		return sm.settings.UIScale, nil
+	case SettingResolution:
+		return sm.settings.Resolution, nil
 	default:
 		if val, ok := sm.settings.CustomSettings[key]; ok {
 			return val, nil
@@ -628,7 +810,7 @@ func (sm *SettingsManager) ResetToDefaults() error {
 	sm.settings = sm.createDefaultSettings()
 
 	if sm.autoSave {
-		return sm.SaveSettings()
+		return sm.saveSettingsUnlocked()
 	}
 
 	return nil
@@ -659,6 +841,7 @@ func (sm *SettingsManager) ResetCategory(category SettingsCategory) error {
 		sm.settings.ShadowQuality = defaults.ShadowQuality
 		sm.settings.TextureQuality = defaults.TextureQuality
 		sm.settings.EffectsQuality = defaults.EffectsQuality
+		sm.settings.UIScale = defaults.UIScale
 
 	case CategoryAudio:
 		sm.settings.MasterVolume = defaults.MasterVolume
@@ -681,10 +864,12 @@ func (sm *SettingsManager) ResetCategory(category SettingsCategory) error {
 		sm.settings.EnableDebugMode = defaults.EnableDebugMode
 		sm.settings.ShowDebugInfo = defaults.ShowDebugInfo
 		sm.settings.MaxAutoSaves = defaults.MaxAutoSaves
+		sm.settings.LogSampleRate = defaults.LogSampleRate
+		sm.settings.LogSampleIntervalMs = defaults.LogSampleIntervalMs
 	}
 
 	if sm.autoSave {
-		return sm.SaveSettings()
+		return sm.saveSettingsUnlocked()
 	}
 
 	return nil
@@ -726,7 +911,7 @@ func (sm *SettingsManager) ApplySettings(settings *GameSettings) error {
 	sm.settings = settings
 
 	if sm.autoSave {
-		return sm.SaveSettings()
+		return sm.saveSettingsUnlocked()
 	}
 
 	return nil
@@ -783,7 +968,7 @@ func (sm *SettingsManager) ImportSettings(jsonStr string) error {
 	sm.settings = &settings
 
 	if sm.autoSave {
-		return sm.SaveSettings()
+		return sm.saveSettingsUnlocked()
 	}
 
 	return nil
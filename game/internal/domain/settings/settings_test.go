@@ -0,0 +1,93 @@
+package settings
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func newTestSettingsManager(t *testing.T) *SettingsManager {
+	t.Helper()
+	sm := NewSettingsManager(filepath.Join(t.TempDir(), "settings.json"))
+	sm.SetAutoSave(false)
+	return sm
+}
+
+func TestSetSettingsBatch(t *testing.T) {
+	sm := newTestSettingsManager(t)
+
+	callbackCount := 0
+	sm.RegisterChangeCallback(SettingMusicVolume, func(oldValue, newValue interface{}) {
+		callbackCount++
+	})
+
+	err := sm.SetSettingsBatch(map[string]interface{}{
+		SettingMusicVolume: 0.3,
+		SettingDifficulty:  "hard",
+		SettingUIScale:     1.25,
+	})
+	if err != nil {
+		t.Fatalf("SetSettingsBatch failed: %v", err)
+	}
+
+	settings := sm.GetSettings()
+	if settings.MusicVolume != 0.3 {
+		t.Errorf("Expected music volume 0.3, got %f", settings.MusicVolume)
+	}
+	if settings.Difficulty != "hard" {
+		t.Errorf("Expected difficulty hard, got %s", settings.Difficulty)
+	}
+	if settings.UIScale != 1.25 {
+		t.Errorf("Expected UI scale 1.25, got %f", settings.UIScale)
+	}
+	if callbackCount != 1 {
+		t.Errorf("Expected callback to fire once, fired %d times", callbackCount)
+	}
+}
+
+func TestSetSettingsBatch_InvalidKeyChangesNothing(t *testing.T) {
+	sm := newTestSettingsManager(t)
+	before := sm.GetSettings()
+
+	callbackFired := false
+	sm.RegisterChangeCallback(SettingMusicVolume, func(oldValue, newValue interface{}) {
+		callbackFired = true
+	})
+
+	err := sm.SetSettingsBatch(map[string]interface{}{
+		SettingMusicVolume: 0.3,
+		SettingDifficulty:  "hard",
+		"custom_key":       "value",
+		SettingSFXVolume:   5.0, // Out of range
+	})
+	if !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("Expected ErrInvalidRange, got %v", err)
+	}
+
+	after := sm.GetSettings()
+	if after.MusicVolume != before.MusicVolume {
+		t.Errorf("Music volume changed to %f", after.MusicVolume)
+	}
+	if after.Difficulty != before.Difficulty {
+		t.Errorf("Difficulty changed to %s", after.Difficulty)
+	}
+	if after.SFXVolume != before.SFXVolume {
+		t.Errorf("SFX volume changed to %f", after.SFXVolume)
+	}
+	if _, exists := after.CustomSettings["custom_key"]; exists {
+		t.Error("Custom setting should not have been stored")
+	}
+	if callbackFired {
+		t.Error("Change callbacks should not fire on a failed batch")
+	}
+}
+
+func TestSetSettingsBatch_Locked(t *testing.T) {
+	sm := newTestSettingsManager(t)
+	sm.Lock()
+
+	err := sm.SetSettingsBatch(map[string]interface{}{SettingMusicVolume: 0.3})
+	if !errors.Is(err, ErrSettingsLocked) {
+		t.Errorf("Expected ErrSettingsLocked, got %v", err)
+	}
+}
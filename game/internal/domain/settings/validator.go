@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -12,9 +13,21 @@ type Validator struct {
 	rules map[string]ValidationRule
 }
 
+// FieldType is the kind of value a setting holds, for building settings forms
+type FieldType string
+
+const (
+	FieldBool   FieldType = "bool"
+	FieldNumber FieldType = "number"
+	FieldString FieldType = "string"
+	FieldEnum   FieldType = "enum" // One of AllowedValues
+	FieldList   FieldType = "list" // A list of strings checked by CustomFunc
+)
+
 // ValidationRule defines a validation rule for a setting field
 type ValidationRule struct {
 	FieldName     string
+	Type          FieldType
 	Required      bool
 	MinValue      *float64
 	MaxValue      *float64
@@ -52,6 +65,7 @@ func (v *Validator) setupDefaultRules() {
 	// Player settings
 	v.AddRule("playerName", ValidationRule{
 		FieldName: "playerName",
+		Type:      FieldString,
 		Required:  true,
 		MinLength: intPtr(1),
 		MaxLength: intPtr(30),
@@ -61,6 +75,7 @@ func (v *Validator) setupDefaultRules() {
 	// Game difficulty
 	v.AddRule("difficulty", ValidationRule{
 		FieldName:     "difficulty",
+		Type:          FieldEnum,
 		Required:      true,
 		AllowedValues: []interface{}{"easy", "normal", "hard", "expert"},
 	})
@@ -68,6 +83,7 @@ func (v *Validator) setupDefaultRules() {
 	// Audio settings
 	v.AddRule("masterVolume", ValidationRule{
 		FieldName: "masterVolume",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(0.0),
 		MaxValue:  float64Ptr(1.0),
@@ -75,6 +91,7 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("sfxVolume", ValidationRule{
 		FieldName: "sfxVolume",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(0.0),
 		MaxValue:  float64Ptr(1.0),
@@ -82,6 +99,7 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("musicVolume", ValidationRule{
 		FieldName: "musicVolume",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(0.0),
 		MaxValue:  float64Ptr(1.0),
@@ -90,6 +108,7 @@ func (v *Validator) setupDefaultRules() {
 	// Graphics settings
 	v.AddRule("resolution", ValidationRule{
 		FieldName: "resolution",
+		Type:      FieldEnum,
 		Required:  true,
 		Pattern:   regexp.MustCompile(`^\d{3,4}x\d{3,4}$`),
 		AllowedValues: []interface{}{
@@ -100,16 +119,27 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("fullscreen", ValidationRule{
 		FieldName: "fullscreen",
+		Type:      FieldBool,
 		Required:  true,
 	})
 
 	v.AddRule("vsync", ValidationRule{
 		FieldName: "vsync",
+		Type:      FieldBool,
+		Required:  false,
+	})
+
+	v.AddRule("uiScale", ValidationRule{
+		FieldName: "uiScale",
+		Type:      FieldNumber,
 		Required:  false,
+		MinValue:  float64Ptr(MinUIScale),
+		MaxValue:  float64Ptr(MaxUIScale),
 	})
 
 	v.AddRule("graphicsQuality", ValidationRule{
 		FieldName:     "graphicsQuality",
+		Type:          FieldEnum,
 		Required:      true,
 		AllowedValues: []interface{}{"low", "medium", "high", "ultra"},
 	})
@@ -117,6 +147,7 @@ func (v *Validator) setupDefaultRules() {
 	// Gameplay settings
 	v.AddRule("autoSaveInterval", ValidationRule{
 		FieldName: "autoSaveInterval",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(0),    // 0 means disabled
 		MaxValue:  float64Ptr(3600), // Max 1 hour
@@ -124,11 +155,13 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("showTutorial", ValidationRule{
 		FieldName: "showTutorial",
+		Type:      FieldBool,
 		Required:  false,
 	})
 
 	v.AddRule("language", ValidationRule{
 		FieldName:     "language",
+		Type:          FieldEnum,
 		Required:      true,
 		AllowedValues: []interface{}{"en", "ja", "es", "fr", "de", "zh", "ko"},
 	})
@@ -136,6 +169,7 @@ func (v *Validator) setupDefaultRules() {
 	// Economy settings
 	v.AddRule("startingGold", ValidationRule{
 		FieldName: "startingGold",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(100),
 		MaxValue:  float64Ptr(10000),
@@ -143,6 +177,7 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("shopCapacity", ValidationRule{
 		FieldName: "shopCapacity",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(10),
 		MaxValue:  float64Ptr(1000),
@@ -150,6 +185,7 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("warehouseCapacity", ValidationRule{
 		FieldName: "warehouseCapacity",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(50),
 		MaxValue:  float64Ptr(5000),
@@ -158,6 +194,7 @@ func (v *Validator) setupDefaultRules() {
 	// Market settings
 	v.AddRule("priceFluctuation", ValidationRule{
 		FieldName: "priceFluctuation",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(0.1),
 		MaxValue:  float64Ptr(2.0),
@@ -165,6 +202,7 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("demandSensitivity", ValidationRule{
 		FieldName: "demandSensitivity",
+		Type:      FieldNumber,
 		Required:  true,
 		MinValue:  float64Ptr(0.1),
 		MaxValue:  float64Ptr(3.0),
@@ -173,6 +211,7 @@ func (v *Validator) setupDefaultRules() {
 	// Network settings
 	v.AddRule("serverAddress", ValidationRule{
 		FieldName: "serverAddress",
+		Type:      FieldString,
 		Required:  false,
 		Pattern:   regexp.MustCompile(`^(https?://)?([a-zA-Z0-9.-]+)(:\d+)?(/.*)?$`),
 		MaxLength: intPtr(255),
@@ -180,6 +219,7 @@ func (v *Validator) setupDefaultRules() {
 
 	v.AddRule("connectionTimeout", ValidationRule{
 		FieldName: "connectionTimeout",
+		Type:      FieldNumber,
 		Required:  false,
 		MinValue:  float64Ptr(5),
 		MaxValue:  float64Ptr(300),
@@ -188,11 +228,13 @@ func (v *Validator) setupDefaultRules() {
 	// Notification settings
 	v.AddRule("enableNotifications", ValidationRule{
 		FieldName: "enableNotifications",
+		Type:      FieldBool,
 		Required:  false,
 	})
 
 	v.AddRule("notificationTypes", ValidationRule{
 		FieldName: "notificationTypes",
+		Type:      FieldList,
 		Required:  false,
 		CustomFunc: func(value interface{}) error {
 			types, ok := value.([]string)
@@ -218,6 +260,18 @@ func (v *Validator) AddRule(field string, rule ValidationRule) {
 	v.rules[field] = rule
 }
 
+// Rules returns every validation rule, sorted by field name
+func (v *Validator) Rules() []ValidationRule {
+	rules := make([]ValidationRule, 0, len(v.rules))
+	for _, rule := range v.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].FieldName < rules[j].FieldName
+	})
+	return rules
+}
+
 // RemoveRule removes a validation rule
 func (v *Validator) RemoveRule(field string) {
 	delete(v.rules, field)
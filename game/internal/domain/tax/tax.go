@@ -0,0 +1,208 @@
+package tax
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+// TransactionType represents the side of a trade being taxed
+type TransactionType int
+
+const (
+	TransactionSale     TransactionType = iota // Sales tax on items sold
+	TransactionPurchase                        // Tariff on items bought
+)
+
+// ErrInvalidRate is returned when a tax rate is outside 0-1
+var ErrInvalidRate = errors.New("tax rate must be between 0 and 1")
+
+// TaxBreakdown describes the tax applied to a single transaction
+type TaxBreakdown struct {
+	Gross int     // Amount before tax
+	Tax   int     // Tax charged
+	Net   int     // Amount after tax (gold received for sales, gold paid for purchases)
+	Rate  float64 // Effective rate applied
+}
+
+// TaxManager manages sales tax and purchase tariffs per item category
+type TaxManager struct {
+	enabled              bool
+	salesRates           map[item.Category]float64
+	tariffRates          map[item.Category]float64
+	difficultyMultiplier float64
+	totalSalesTax        int
+	totalTariffs         int
+	mu                   sync.RWMutex
+}
+
+// NewTaxManager creates a tax manager with default rates
+func NewTaxManager() *TaxManager {
+	return &TaxManager{
+		enabled: true,
+		salesRates: map[item.Category]float64{
+			item.CategoryFruit:     0.05,
+			item.CategoryPotion:    0.05,
+			item.CategoryWeapon:    0.05,
+			item.CategoryMagicBook: 0.05,
+			item.CategoryAccessory: 0.10, // Luxury goods
+			item.CategoryGem:       0.15, // Luxury goods
+		},
+		tariffRates: map[item.Category]float64{
+			item.CategoryWeapon: 0.05,
+			item.CategoryGem:    0.05,
+		},
+		difficultyMultiplier: 1.0,
+	}
+}
+
+// SetEnabled turns taxation on or off
+func (tm *TaxManager) SetEnabled(enabled bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.enabled = enabled
+}
+
+// IsEnabled returns whether taxation is active
+func (tm *TaxManager) IsEnabled() bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.enabled
+}
+
+// SetTaxRate sets the base rate for a category and transaction type
+func (tm *TaxManager) SetTaxRate(category item.Category, txType TransactionType, rate float64) error {
+	if rate < 0 || rate > 1 {
+		return ErrInvalidRate
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if txType == TransactionPurchase {
+		tm.tariffRates[category] = rate
+	} else {
+		tm.salesRates[category] = rate
+	}
+	return nil
+}
+
+// SetDifficultyMultiplier scales all tax rates (e.g. higher on hard difficulty)
+func (tm *TaxManager) SetDifficultyMultiplier(multiplier float64) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if multiplier < 0 {
+		multiplier = 0
+	}
+	tm.difficultyMultiplier = multiplier
+}
+
+// GetTaxRate returns the effective rate for a category and transaction type
+func (tm *TaxManager) GetTaxRate(category item.Category, txType TransactionType) float64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.getTaxRateUnsafe(category, txType)
+}
+
+// getTaxRateUnsafe returns the effective rate without locking
+func (tm *TaxManager) getTaxRateUnsafe(category item.Category, txType TransactionType) float64 {
+	if !tm.enabled {
+		return 0
+	}
+
+	rates := tm.salesRates
+	if txType == TransactionPurchase {
+		rates = tm.tariffRates
+	}
+
+	rate := rates[category] * tm.difficultyMultiplier
+	if rate > 1 {
+		rate = 1
+	}
+	return rate
+}
+
+// Calculate returns the tax breakdown for an amount without recording it
+func (tm *TaxManager) Calculate(category item.Category, txType TransactionType, amount int) TaxBreakdown {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.calculateUnsafe(category, txType, amount)
+}
+
+// calculateUnsafe builds a tax breakdown without locking
+func (tm *TaxManager) calculateUnsafe(category item.Category, txType TransactionType, amount int) TaxBreakdown {
+	rate := tm.getTaxRateUnsafe(category, txType)
+	tax := int(float64(amount) * rate)
+
+	net := amount - tax
+	if txType == TransactionPurchase {
+		net = amount + tax
+	}
+
+	return TaxBreakdown{
+		Gross: amount,
+		Tax:   tax,
+		Net:   net,
+		Rate:  rate,
+	}
+}
+
+// Apply calculates the tax for a transaction and adds it to the totals
+func (tm *TaxManager) Apply(category item.Category, txType TransactionType, amount int) TaxBreakdown {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	breakdown := tm.calculateUnsafe(category, txType, amount)
+	if txType == TransactionPurchase {
+		tm.totalTariffs += breakdown.Tax
+	} else {
+		tm.totalSalesTax += breakdown.Tax
+	}
+	return breakdown
+}
+
+// GetStatistics returns accumulated tax totals
+func (tm *TaxManager) GetStatistics() map[string]int {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	return map[string]int{
+		"sales_tax": tm.totalSalesTax,
+		"tariffs":   tm.totalTariffs,
+		"total":     tm.totalSalesTax + tm.totalTariffs,
+	}
+}
+
+// Reset clears accumulated tax totals
+func (tm *TaxManager) Reset() {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.totalSalesTax = 0
+	tm.totalTariffs = 0
+}
+
+// Record is the accumulated tax totals as kept in a save
+type Record struct {
+	SalesTax int `json:"salesTax"`
+	Tariffs  int `json:"tariffs"`
+}
+
+// Record returns the accumulated tax totals for a save
+func (tm *TaxManager) Record() Record {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	return Record{SalesTax: tm.totalSalesTax, Tariffs: tm.totalTariffs}
+}
+
+// RestoreRecord replaces the accumulated tax totals with a saved record
+func (tm *TaxManager) RestoreRecord(record Record) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.totalSalesTax = record.SalesTax
+	tm.totalTariffs = record.Tariffs
+}
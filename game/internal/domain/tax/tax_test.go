@@ -0,0 +1,73 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+func TestTaxManager_GetTaxRate(t *testing.T) {
+	tm := NewTaxManager()
+
+	assert.Equal(t, 0.15, tm.GetTaxRate(item.CategoryGem, TransactionSale))
+	assert.Greater(t, tm.GetTaxRate(item.CategoryGem, TransactionSale), tm.GetTaxRate(item.CategoryFruit, TransactionSale))
+	assert.Equal(t, 0.0, tm.GetTaxRate(item.CategoryFruit, TransactionPurchase))
+
+	tm.SetDifficultyMultiplier(2.0)
+	assert.Equal(t, 0.3, tm.GetTaxRate(item.CategoryGem, TransactionSale))
+
+	tm.SetEnabled(false)
+	assert.Equal(t, 0.0, tm.GetTaxRate(item.CategoryGem, TransactionSale))
+}
+
+func TestTaxManager_SetTaxRate(t *testing.T) {
+	tm := NewTaxManager()
+
+	assert.NoError(t, tm.SetTaxRate(item.CategoryFruit, TransactionPurchase, 0.1))
+	assert.Equal(t, 0.1, tm.GetTaxRate(item.CategoryFruit, TransactionPurchase))
+
+	assert.ErrorIs(t, tm.SetTaxRate(item.CategoryFruit, TransactionSale, 1.5), ErrInvalidRate)
+	assert.ErrorIs(t, tm.SetTaxRate(item.CategoryFruit, TransactionSale, -0.1), ErrInvalidRate)
+}
+
+func TestTaxManager_TaxedSaleYieldsLessGold(t *testing.T) {
+	taxed := NewTaxManager()
+	untaxed := NewTaxManager()
+	untaxed.SetEnabled(false)
+
+	taxedSale := taxed.Apply(item.CategoryGem, TransactionSale, 1000)
+	untaxedSale := untaxed.Apply(item.CategoryGem, TransactionSale, 1000)
+
+	assert.Less(t, taxedSale.Net, untaxedSale.Net)
+	assert.Equal(t, 850, taxedSale.Net)
+	assert.Equal(t, 150, taxedSale.Tax)
+	assert.Equal(t, 1000, untaxedSale.Net)
+
+	stats := taxed.GetStatistics()
+	assert.Equal(t, 150, stats["sales_tax"])
+	assert.Equal(t, 0, stats["tariffs"])
+}
+
+func TestTaxManager_PurchaseTariff(t *testing.T) {
+	tm := NewTaxManager()
+
+	breakdown := tm.Apply(item.CategoryWeapon, TransactionPurchase, 200)
+	assert.Equal(t, 10, breakdown.Tax)
+	assert.Equal(t, 210, breakdown.Net)
+	assert.Equal(t, 10, tm.GetStatistics()["tariffs"])
+
+	tm.Reset()
+	assert.Equal(t, 0, tm.GetStatistics()["total"])
+}
+
+func TestTaxManager_Record(t *testing.T) {
+	tm := NewTaxManager()
+	tm.Apply(item.CategoryWeapon, TransactionPurchase, 200)
+	tm.Apply(item.CategoryGem, TransactionSale, 1000)
+
+	restored := NewTaxManager()
+	restored.RestoreRecord(tm.Record())
+	assert.Equal(t, tm.GetStatistics(), restored.GetStatistics())
+}
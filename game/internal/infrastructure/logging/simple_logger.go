@@ -2,6 +2,7 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"sync"
@@ -22,14 +23,28 @@ const (
 type SimpleLogger struct {
 	level  LogLevel
 	logger *log.Logger
-	mu     sync.RWMutex
+
+	// Sampling for high-frequency messages, tracked per message key
+	sampleEvery    int
+	sampleInterval time.Duration
+	samples        map[string]*sampleState
+
+	mu sync.RWMutex
+}
+
+// sampleState tracks how often a sampled message key has been seen
+type sampleState struct {
+	seen       int
+	suppressed int
+	lastLogged time.Time
 }
 
 // NewSimpleLogger creates a basic logger
 func NewSimpleLogger(level LogLevel) *SimpleLogger {
 	return &SimpleLogger{
-		level:  level,
-		logger: log.New(os.Stdout, "", log.LstdFlags),
+		level:   level,
+		logger:  log.New(os.Stdout, "", log.LstdFlags),
+		samples: make(map[string]*sampleState),
 	}
 }
 
@@ -60,6 +75,66 @@ func (sl *SimpleLogger) Errorf(format string, args ...interface{}) {
 	sl.logf(LevelError, "ERROR", format, args...)
 }
 
+// SetSampling configures sampled logging: only one in every everyN messages
+// per key is written, and no more than one per minInterval. Values of 1 or
+// less and zero respectively disable each limit.
+func (sl *SimpleLogger) SetSampling(everyN int, minInterval time.Duration) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.sampleEvery = everyN
+	sl.sampleInterval = minInterval
+	sl.samples = make(map[string]*sampleState)
+}
+
+// DebugfSampled logs a debug message subject to sampling by key
+func (sl *SimpleLogger) DebugfSampled(key string, format string, args ...interface{}) {
+	sl.logfSampled(LevelDebug, "DEBUG", key, format, args...)
+}
+
+// InfofSampled logs an info message subject to sampling by key
+func (sl *SimpleLogger) InfofSampled(key string, format string, args ...interface{}) {
+	sl.logfSampled(LevelInfo, "INFO", key, format, args...)
+}
+
+// logfSampled logs a message if the sampler lets its key through, noting how
+// many messages were dropped since the last one written
+func (sl *SimpleLogger) logfSampled(level LogLevel, prefix string, key string, format string, args ...interface{}) {
+	sl.mu.Lock()
+	if level < sl.level {
+		sl.mu.Unlock()
+		return
+	}
+
+	state, exists := sl.samples[key]
+	if !exists {
+		state = &sampleState{}
+		sl.samples[key] = state
+	}
+	state.seen++
+
+	now := time.Now()
+	skip := sl.sampleEvery > 1 && (state.seen-1)%sl.sampleEvery != 0
+	if sl.sampleInterval > 0 && !state.lastLogged.IsZero() && now.Sub(state.lastLogged) < sl.sampleInterval {
+		skip = true
+	}
+	if skip {
+		state.suppressed++
+		sl.mu.Unlock()
+		return
+	}
+
+	suppressed := state.suppressed
+	state.suppressed = 0
+	state.lastLogged = now
+	sl.mu.Unlock()
+
+	message := fmt.Sprintf(format, args...)
+	if suppressed > 0 {
+		message = fmt.Sprintf("%s (%d similar suppressed)", message, suppressed)
+	}
+	sl.logger.Printf("[%s] %s", prefix, message)
+}
+
 // log handles the actual logging
 func (sl *SimpleLogger) logf(level LogLevel, prefix string, format string, args ...interface{}) {
 	sl.mu.RLock()
@@ -73,6 +148,13 @@ func (sl *SimpleLogger) logf(level LogLevel, prefix string, format string, args
 	sl.logger.Printf("[%s] %s", prefix, message)
 }
 
+// SetOutput redirects log output to a writer
+func (sl *SimpleLogger) SetOutput(w io.Writer) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.logger = log.New(w, "", log.LstdFlags)
+}
+
 // LogToFile sets up file logging
 func (sl *SimpleLogger) LogToFile(filename string) error {
 	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec // Path is controlled
@@ -101,6 +183,26 @@ func SetGlobalLevel(level LogLevel) {
 	globalLogger.SetLevel(level)
 }
 
+// SetGlobalOutput redirects global log output to a writer
+func SetGlobalOutput(w io.Writer) {
+	globalLogger.SetOutput(w)
+}
+
+// SetGlobalSampling configures sampled logging on the global logger
+func SetGlobalSampling(everyN int, minInterval time.Duration) {
+	globalLogger.SetSampling(everyN, minInterval)
+}
+
+// DebugfSampled logs a high-frequency debug message globally, subject to sampling
+func DebugfSampled(key string, format string, args ...interface{}) {
+	globalLogger.DebugfSampled(key, format, args...)
+}
+
+// InfofSampled logs a high-frequency info message globally, subject to sampling
+func InfofSampled(key string, format string, args ...interface{}) {
+	globalLogger.InfofSampled(key, format, args...)
+}
+
 // Debug logs a debug message globally
 func Debugf(format string, args ...interface{}) {
 	globalLogger.Debugf(format, args...)
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleLogger_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSimpleLogger(LevelInfo)
+	logger.SetOutput(&buf)
+	logger.SetSampling(10, 0)
+
+	for i := 0; i < 100; i++ {
+		logger.InfofSampled("price:apple", "apple price %d", i)
+	}
+	lines := strings.Count(buf.String(), "\n")
+	assert.Equal(t, 10, lines)
+	assert.Contains(t, buf.String(), "apple price 0")
+	assert.Contains(t, buf.String(), "apple price 10 (9 similar suppressed)")
+
+	// Keys are sampled independently and critical messages always log
+	buf.Reset()
+	logger.InfofSampled("price:sword", "sword price")
+	logger.Errorf("save failed")
+	logger.Errorf("save failed")
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+}
+
+func TestSimpleLogger_SamplingInterval(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSimpleLogger(LevelInfo)
+	logger.SetOutput(&buf)
+	logger.SetSampling(1, time.Hour)
+
+	for i := 0; i < 50; i++ {
+		logger.InfofSampled("price:apple", "apple price %d", i)
+	}
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	// Disabling sampling logs every message again
+	logger.SetSampling(1, 0)
+	buf.Reset()
+	for i := 0; i < 5; i++ {
+		logger.InfofSampled("price:apple", "apple price %d", i)
+	}
+	assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+}
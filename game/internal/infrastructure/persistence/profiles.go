@@ -0,0 +1,204 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
+	"github.com/yourusername/merchant-tails/game/internal/domain/inventory"
+	"github.com/yourusername/merchant-tails/game/internal/domain/market"
+	"github.com/yourusername/merchant-tails/game/internal/domain/progression"
+)
+
+// MaxProfileNameLength is the longest key a profile name is reduced to
+const MaxProfileNameLength = 32
+
+// profileIndexKey lists every named save, since stores cannot list keys
+const profileIndexKey = "profiles.json"
+
+// Profile name errors
+var (
+	ErrInvalidProfileName = errors.New("profile name needs at least one letter or digit")
+	ErrProfileNameTaken   = errors.New("another profile already saves under that name")
+)
+
+// ProfileKey reduces a profile name to the filesystem-safe key its save is
+// stored under: lowercase letters, digits, '-' and '_', with runs of
+// anything else collapsed to a single '_'
+func ProfileKey(name string) (string, error) {
+	var key strings.Builder
+	pendingSeparator := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			if pendingSeparator && key.Len() > 0 {
+				key.WriteByte('_')
+			}
+			pendingSeparator = false
+			key.WriteRune(r)
+			continue
+		}
+		pendingSeparator = true
+	}
+
+	trimmed := key.String()
+	if len(trimmed) > MaxProfileNameLength {
+		trimmed = trimmed[:MaxProfileNameLength]
+	}
+	trimmed = strings.Trim(trimmed, "_-")
+	if trimmed == "" {
+		return "", ErrInvalidProfileName
+	}
+	return trimmed, nil
+}
+
+// SaveGameNamed saves the current game under a profile name, overwriting
+// that profile's previous save. A different name that reduces to the same
+// key is rejected with ErrProfileNameTaken rather than overwriting it.
+func (sm *SaveManager) SaveGameNamed(
+	name string,
+	state *gamestate.GameState,
+	marketData *market.Market,
+	inv *inventory.InventoryManager,
+	prog *progression.ProgressionManager,
+	sections ...SaveSection,
+) error {
+	key, err := ProfileKey(name)
+	if err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+
+	if existing, err := sm.profileMetadata(key); err == nil && existing.Profile != name {
+		return fmt.Errorf("%w: %q is saved as %q", ErrProfileNameTaken, name, existing.Profile)
+	}
+
+	metadata := newSaveMetadata(state)
+	metadata.Kind = SaveKindProfile
+	metadata.Profile = name
+	if err := sm.writeSave(profileFileBase(key), saveDataFor(state, inv, sections), metadata); err != nil {
+		return err
+	}
+
+	keys, err := sm.profileKeys()
+	if err != nil {
+		return err
+	}
+	for _, existing := range keys {
+		if existing == key {
+			return nil
+		}
+	}
+	return sm.writeProfileKeys(append(keys, key))
+}
+
+// LoadGameNamed loads the save made under a profile name
+func (sm *SaveManager) LoadGameNamed(name string) (map[string]interface{}, error) {
+	key, err := ProfileKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := sm.store.Read(profileFileBase(key) + ".dat")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("no save profile named %q", name)
+		}
+		return nil, fmt.Errorf("failed to read save file: %w", err)
+	}
+
+	var saveData map[string]interface{}
+	if err := json.Unmarshal(data, &saveData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal save data: %w", err)
+	}
+	return saveData, nil
+}
+
+// ListNamedSaves returns the metadata of every named save, most recently
+// saved first
+func (sm *SaveManager) ListNamedSaves() ([]SaveMetadata, error) {
+	keys, err := sm.profileKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	saves := make([]SaveMetadata, 0, len(keys))
+	for _, key := range keys {
+		metadata, err := sm.profileMetadata(key)
+		if err != nil {
+			continue // Deleted behind the index's back
+		}
+		saves = append(saves, metadata)
+	}
+
+	sort.SliceStable(saves, func(i, j int) bool {
+		return saves[i].Timestamp.After(saves[j].Timestamp)
+	})
+	return saves, nil
+}
+
+// DeleteNamedSave deletes the save made under a profile name
+func (sm *SaveManager) DeleteNamedSave(name string) error {
+	key, err := ProfileKey(name)
+	if err != nil {
+		return err
+	}
+	_ = sm.store.Delete(profileFileBase(key) + ".dat")
+	_ = sm.store.Delete(profileFileBase(key) + ".json")
+
+	keys, err := sm.profileKeys()
+	if err != nil {
+		return err
+	}
+	kept := keys[:0]
+	for _, existing := range keys {
+		if existing != key {
+			kept = append(kept, existing)
+		}
+	}
+	return sm.writeProfileKeys(kept)
+}
+
+// profileMetadata reads the metadata saved under a profile key
+func (sm *SaveManager) profileMetadata(key string) (SaveMetadata, error) {
+	var metadata SaveMetadata
+	data, err := sm.store.Read(profileFileBase(key) + ".json")
+	if err != nil {
+		return metadata, err
+	}
+	err = json.Unmarshal(data, &metadata)
+	return metadata, err
+}
+
+// profileKeys reads the index of profile keys, empty if nothing was saved
+func (sm *SaveManager) profileKeys() ([]string, error) {
+	data, err := sm.store.Read(profileIndexKey)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile index: %w", err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal profile index: %w", err)
+	}
+	return keys, nil
+}
+
+// writeProfileKeys replaces the index of profile keys
+func (sm *SaveManager) writeProfileKeys(keys []string) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	return sm.store.Write(profileIndexKey, data)
+}
+
+// profileFileBase names a profile's keys apart from the numbered slots
+func profileFileBase(key string) string {
+	return "profile_" + key
+}
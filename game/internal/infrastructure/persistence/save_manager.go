@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,12 +15,43 @@ import (
 	"github.com/yourusername/merchant-tails/game/internal/domain/progression"
 )
 
+// Save slot layout
+const (
+	ManualSlotCount = 3  // Numbered slots the player saves to deliberately
+	QuickSaveSlot   = -1 // Reserved slot every quick save overwrites
+)
+
+// Save kinds shown by the load menu
+const (
+	SaveKindManual  = "manual"
+	SaveKindQuick   = "quick"
+	SaveKindProfile = "profile"
+)
+
+// SavedInventory is the inventory section of a save: capacities, every lot
+// of stock and the shipments still in transit
+type SavedInventory struct {
+	ShopCapacity      int                        `json:"shopCapacity"`
+	WarehouseCapacity int                        `json:"warehouseCapacity"`
+	Stock             []inventory.StockRecord    `json:"stock"`
+	InTransit         []inventory.ShipmentRecord `json:"inTransit,omitempty"`
+}
+
+// SaveSection is a named part of a save holding state of a system the save
+// manager has no parameter for, such as the insurance policy. LoadGame
+// returns it decoded under the same key.
+type SaveSection struct {
+	Key  string
+	Data interface{}
+}
+
 // SaveManager handles game save/load operations
 type SaveManager struct {
-	saveDir string
+	store Store
 }
 
-// NewSaveManager creates a new save manager
+// NewSaveManager creates a save manager writing files under the user's home
+// directory
 func NewSaveManager() (*SaveManager, error) {
 	// Get user's home directory
 	homeDir, err := os.UserHomeDir()
@@ -27,15 +59,19 @@ func NewSaveManager() (*SaveManager, error) {
 		return nil, err
 	}
 
-	// Create save directory
-	saveDir := filepath.Join(homeDir, ".merchant-tails", "saves")
-	if err := os.MkdirAll(saveDir, 0o750); err != nil {
+	store, err := NewFileStore(filepath.Join(homeDir, ".merchant-tails", "saves"))
+	if err != nil {
 		return nil, err
 	}
 
+	return NewSaveManagerWithStore(store), nil
+}
+
+// NewSaveManagerWithStore creates a save manager on the given store
+func NewSaveManagerWithStore(store Store) *SaveManager {
 	return &SaveManager{
-		saveDir: saveDir,
-	}, nil
+		store: store,
+	}
 }
 
 // SaveGame saves the current game state to a slot
@@ -45,62 +81,82 @@ func (sm *SaveManager) SaveGame(
 	marketData *market.Market,
 	inv *inventory.InventoryManager,
 	prog *progression.ProgressionManager,
+	sections ...SaveSection,
 ) error {
-	// Create save data structure
+	metadata := newSaveMetadata(state)
+	metadata.Slot = slot
+	metadata.Kind = saveKind(slot)
+	return sm.writeSave(slotFileBase(slot), saveDataFor(state, inv, sections), metadata)
+}
+
+// saveDataFor lays out a save as GameManager.LoadGame reads it
+func saveDataFor(state *gamestate.GameState, inv *inventory.InventoryManager, sections []SaveSection) map[string]interface{} {
 	saveData := map[string]interface{}{
-		"player": map[string]interface{}{
-			"name":       "Player", // TODO: Add GetPlayerName to GameState
-			"gold":       state.GetGold(),
-			"rank":       "Apprentice", // TODO: Add GetRank to GameState
-			"reputation": state.GetReputation(),
-		},
-		"game": map[string]interface{}{
-			"currentDay":    1,        // TODO: Add GetCurrentDay to GameState
-			"currentSeason": "SPRING", // TODO: Get from time manager
-			"saveTimestamp": time.Now().Unix(),
-			"saveVersion":   "1.0.0",
-		},
+		"playerName":        state.GetPlayerName(),
+		"gold":              state.GetGold(),
+		"rank":              int(state.GetRank()) + 1, // Numbered like the protobuf enum, where zero is unspecified
+		"reputation":        state.GetReputation(),
+		"reputationHistory": state.GetReputationHistory(0),
+		"reputationToday":   state.GetTodaysReputation(),
+		"currentDay":        state.GetCurrentDay(),
+		"currentSeason":     state.GetCurrentSeason(),
+		"maxDays":           state.GetMaxDays(),
+		"onboarding":        state.GetOnboardingProgress(),
+		"saveTimestamp":     time.Now().Unix(),
+		"saveVersion":       "1.0.0",
+	}
+	if inv != nil {
+		saveData["inventory"] = SavedInventory{
+			ShopCapacity:      inv.ShopCapacity,
+			WarehouseCapacity: inv.WarehouseCapacity,
+			Stock:             inv.StockRecords(),
+			InTransit:         inv.ShipmentRecords(),
+		}
+	}
+	for _, section := range sections {
+		saveData[section.Key] = section.Data
+	}
+	return saveData
+}
+
+// newSaveMetadata describes a save of state for the load menu
+func newSaveMetadata(state *gamestate.GameState) SaveMetadata {
+	return SaveMetadata{
+		Timestamp:  time.Now(),
+		PlayerName: state.GetPlayerName(),
+		Gold:       state.GetGold(),
+		Day:        state.GetCurrentDay(),
+		Rank:       gamestate.GetRankName(state.GetRank()),
 	}
+}
 
+// writeSave writes a save and its metadata under the keys named by base
+func (sm *SaveManager) writeSave(base string, saveData map[string]interface{}, metadata SaveMetadata) error {
 	// Serialize to JSON
 	data, err := json.MarshalIndent(saveData, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal save data: %w", err)
 	}
 
-	// Write to file
-	filename := sm.getSaveFilename(slot)
-	if err := os.WriteFile(filename, data, 0o600); err != nil {
+	if err := sm.store.Write(base+".dat", data); err != nil {
 		return fmt.Errorf("failed to write save file: %w", err)
 	}
 
 	// Also write metadata JSON for quick access
-	metadata := SaveMetadata{
-		Slot:       slot,
-		Timestamp:  time.Now(),
-		PlayerName: "Player", // TODO: Add GetPlayerName to GameState
-		Gold:       state.GetGold(),
-		Day:        1,            // TODO: Add GetCurrentDay to GameState
-		Rank:       "Apprentice", // TODO: Add GetRank to GameState
-	}
-
-	metadataFile := sm.getMetadataFilename(slot)
 	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(metadataFile, metadataJSON, 0o600)
+	return sm.store.Write(base+".json", metadataJSON)
 }
 
 // LoadGame loads a saved game from a slot
 func (sm *SaveManager) LoadGame(slot int) (map[string]interface{}, error) {
-	filename := sm.getSaveFilename(slot)
-
 	// Read save file
-	data, err := os.ReadFile(filepath.Clean(filename))
+	data, err := sm.store.Read(saveKey(slot))
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, ErrNotFound) {
 			return nil, fmt.Errorf("save slot %d is empty", slot)
 		}
 		return nil, fmt.Errorf("failed to read save file: %w", err)
@@ -115,49 +171,52 @@ func (sm *SaveManager) LoadGame(slot int) (map[string]interface{}, error) {
 	return saveData, nil
 }
 
-// GetSaveSlots returns information about all save slots
+// GetSaveSlots returns information about every manual save slot, followed by
+// the quick-save slot when a quick save exists
 func (sm *SaveManager) GetSaveSlots() ([]SaveSlotInfo, error) {
-	slots := make([]SaveSlotInfo, 3) // Support 3 save slots
+	slots := make([]SaveSlotInfo, 0, ManualSlotCount+1)
+	for i := 0; i < ManualSlotCount; i++ {
+		slots = append(slots, sm.slotInfo(i))
+	}
 
-	for i := 0; i < 3; i++ {
-		info := SaveSlotInfo{
-			Slot:   i,
-			Exists: false,
-		}
+	if quick := sm.slotInfo(QuickSaveSlot); quick.Exists {
+		slots = append(slots, quick)
+	}
 
-		// Check if metadata exists
-		metadataFile := sm.getMetadataFilename(i)
-		if data, err := os.ReadFile(filepath.Clean(metadataFile)); err == nil {
-			var metadata SaveMetadata
-			if err := json.Unmarshal(data, &metadata); err == nil {
-				info.Exists = true
-				info.Metadata = &metadata
-			}
-		}
+	return slots, nil
+}
 
-		slots[i] = info
+// slotInfo reads a slot's metadata, if it has any
+func (sm *SaveManager) slotInfo(slot int) SaveSlotInfo {
+	info := SaveSlotInfo{
+		Slot:   slot,
+		Kind:   saveKind(slot),
+		Exists: false,
 	}
 
-	return slots, nil
+	if data, err := sm.store.Read(metadataKey(slot)); err == nil {
+		var metadata SaveMetadata
+		if err := json.Unmarshal(data, &metadata); err == nil {
+			info.Exists = true
+			info.Metadata = &metadata
+		}
+	}
+
+	return info
 }
 
 // DeleteSave deletes a save file
 func (sm *SaveManager) DeleteSave(slot int) error {
-	saveFile := sm.getSaveFilename(slot)
-	metadataFile := sm.getMetadataFilename(slot)
-
 	// Remove both files
-	_ = os.Remove(saveFile)
-	_ = os.Remove(metadataFile)
+	_ = sm.store.Delete(saveKey(slot))
+	_ = sm.store.Delete(metadataKey(slot))
 
 	return nil
 }
 
 // ExportSave exports a save to a writer
 func (sm *SaveManager) ExportSave(slot int, w io.Writer) error {
-	filename := sm.getSaveFilename(slot)
-
-	data, err := os.ReadFile(filepath.Clean(filename))
+	data, err := sm.store.Read(saveKey(slot))
 	if err != nil {
 		return err
 	}
@@ -180,28 +239,50 @@ func (sm *SaveManager) ImportSave(slot int, r io.Reader) error {
 	}
 
 	// Write to slot
-	filename := sm.getSaveFilename(slot)
-	return os.WriteFile(filename, data, 0o600)
+	return sm.store.Write(saveKey(slot), data)
 }
 
 // Helper methods
 
-func (sm *SaveManager) getSaveFilename(slot int) string {
-	return filepath.Join(sm.saveDir, fmt.Sprintf("save_%d.dat", slot))
+func saveKey(slot int) string {
+	return slotFileBase(slot) + ".dat"
 }
 
-func (sm *SaveManager) getMetadataFilename(slot int) string {
-	return filepath.Join(sm.saveDir, fmt.Sprintf("save_%d.json", slot))
+func metadataKey(slot int) string {
+	return slotFileBase(slot) + ".json"
 }
 
-// Helper method to get save directory path
+// slotFileBase names a slot's keys; the quick-save slot gets its own name
+// so it can never collide with a numbered slot
+func slotFileBase(slot int) string {
+	if slot == QuickSaveSlot {
+		return "quicksave"
+	}
+	return fmt.Sprintf("save_%d", slot)
+}
+
+// saveKind returns which kind of save a slot holds
+func saveKind(slot int) string {
+	if slot == QuickSaveSlot {
+		return SaveKindQuick
+	}
+	return SaveKindManual
+}
+
+// GetSaveDirectory returns the directory saves are written to, or an empty
+// string when the store is not file based
 func (sm *SaveManager) GetSaveDirectory() string {
-	return sm.saveDir
+	if files, ok := sm.store.(*FileStore); ok {
+		return files.Dir()
+	}
+	return ""
 }
 
 // SaveMetadata contains quick-access save information
 type SaveMetadata struct {
 	Slot       int           `json:"slot"`
+	Kind       string        `json:"kind"`              // SaveKindManual, SaveKindQuick or SaveKindProfile
+	Profile    string        `json:"profile,omitempty"` // Name a profile save was made under
 	Timestamp  time.Time     `json:"timestamp"`
 	PlayerName string        `json:"playerName"`
 	Gold       int           `json:"gold"`
@@ -213,6 +294,7 @@ type SaveMetadata struct {
 // SaveSlotInfo contains information about a save slot
 type SaveSlotInfo struct {
 	Slot     int           `json:"slot"`
+	Kind     string        `json:"kind"`
 	Exists   bool          `json:"exists"`
 	Metadata *SaveMetadata `json:"metadata,omitempty"`
 }
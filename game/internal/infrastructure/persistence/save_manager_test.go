@@ -0,0 +1,196 @@
+package persistence
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
+)
+
+func TestSaveManager_Stores(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store {
+			return NewMemoryStore()
+		},
+		"file": func(t *testing.T) Store {
+			store, err := NewFileStore(t.TempDir())
+			require.NoError(t, err)
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			testSaveManagerSuite(t, NewSaveManagerWithStore(newStore(t)))
+		})
+	}
+}
+
+func testSaveManagerSuite(t *testing.T, sm *SaveManager) {
+	state := gamestate.NewGameState(nil)
+	require.NoError(t, state.SetPlayerName("Tester"))
+	state.SetGold(4321)
+
+	t.Run("empty slots", func(t *testing.T) {
+		_, err := sm.LoadGame(0)
+		assert.ErrorContains(t, err, "save slot 0 is empty")
+
+		slots, err := sm.GetSaveSlots()
+		require.NoError(t, err)
+		require.Len(t, slots, ManualSlotCount)
+		for _, slot := range slots {
+			assert.False(t, slot.Exists)
+		}
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		require.NoError(t, sm.SaveGame(1, state, nil, nil, nil))
+		require.NoError(t, sm.SaveGame(QuickSaveSlot, state, nil, nil, nil))
+
+		data, err := sm.LoadGame(1)
+		require.NoError(t, err)
+		assert.Equal(t, "Tester", data["playerName"])
+		assert.Equal(t, 4321.0, data["gold"])
+
+		slots, err := sm.GetSaveSlots()
+		require.NoError(t, err)
+		require.Len(t, slots, ManualSlotCount+1)
+		assert.False(t, slots[0].Exists)
+		require.True(t, slots[1].Exists)
+		assert.Equal(t, 4321, slots[1].Metadata.Gold)
+		assert.Equal(t, SaveKindQuick, slots[ManualSlotCount].Kind)
+	})
+
+	t.Run("extra sections", func(t *testing.T) {
+		section := SaveSection{Key: "insurance", Data: map[string]int{"endDay": 7}}
+		require.NoError(t, sm.SaveGame(0, state, nil, nil, nil, section))
+
+		data, err := sm.LoadGame(0)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"endDay": 7.0}, data["insurance"])
+		assert.Equal(t, 4321.0, data["gold"])
+		require.NoError(t, sm.DeleteSave(0))
+	})
+
+	t.Run("export and import", func(t *testing.T) {
+		var exported bytes.Buffer
+		require.NoError(t, sm.ExportSave(1, &exported))
+		require.NoError(t, sm.ImportSave(2, bytes.NewReader(exported.Bytes())))
+
+		data, err := sm.LoadGame(2)
+		require.NoError(t, err)
+		assert.Equal(t, "Tester", data["playerName"])
+
+		assert.Error(t, sm.ImportSave(2, bytes.NewReader([]byte("not a save"))))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, sm.DeleteSave(1))
+		require.NoError(t, sm.DeleteSave(1))
+
+		_, err := sm.LoadGame(1)
+		assert.Error(t, err)
+		slots, err := sm.GetSaveSlots()
+		require.NoError(t, err)
+		assert.False(t, slots[1].Exists)
+	})
+
+	t.Run("named saves", func(t *testing.T) {
+		saves, err := sm.ListNamedSaves()
+		require.NoError(t, err)
+		assert.Empty(t, saves)
+
+		require.NoError(t, sm.SaveGameNamed("Iron Run", state, nil, nil, nil))
+		other := gamestate.NewGameState(nil)
+		require.NoError(t, other.SetPlayerName("Other"))
+		other.SetGold(50)
+		require.NoError(t, sm.SaveGameNamed("Fruit Stand", other, nil, nil, nil))
+
+		// Profiles stay apart from each other and from the numbered slots
+		data, err := sm.LoadGameNamed("Iron Run")
+		require.NoError(t, err)
+		assert.Equal(t, 4321.0, data["gold"])
+		data, err = sm.LoadGameNamed("Fruit Stand")
+		require.NoError(t, err)
+		assert.Equal(t, "Other", data["playerName"])
+		_, err = sm.LoadGame(0)
+		assert.Error(t, err)
+
+		saves, err = sm.ListNamedSaves()
+		require.NoError(t, err)
+		require.Len(t, saves, 2)
+		byProfile := map[string]SaveMetadata{}
+		for _, save := range saves {
+			byProfile[save.Profile] = save
+		}
+		assert.Equal(t, SaveKindProfile, byProfile["Iron Run"].Kind)
+		assert.Equal(t, "Tester", byProfile["Iron Run"].PlayerName)
+		assert.Equal(t, 4321, byProfile["Iron Run"].Gold)
+		assert.Equal(t, 1, byProfile["Iron Run"].Day)
+		assert.NotEmpty(t, byProfile["Iron Run"].Rank)
+		assert.False(t, byProfile["Iron Run"].Timestamp.IsZero())
+
+		// Saving again under the same name updates that profile
+		state.SetGold(5000)
+		require.NoError(t, sm.SaveGameNamed("Iron Run", state, nil, nil, nil))
+		saves, err = sm.ListNamedSaves()
+		require.NoError(t, err)
+		require.Len(t, saves, 2)
+		assert.Equal(t, "Iron Run", saves[0].Profile)
+		assert.Equal(t, 5000, saves[0].Gold)
+
+		// A different name reducing to the same key does not overwrite it
+		err = sm.SaveGameNamed("iron  run!", other, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrProfileNameTaken)
+		data, err = sm.LoadGameNamed("Iron Run")
+		require.NoError(t, err)
+		assert.Equal(t, 5000.0, data["gold"])
+
+		assert.ErrorIs(t, sm.SaveGameNamed("../..", state, nil, nil, nil), ErrInvalidProfileName)
+		_, err = sm.LoadGameNamed("Missing")
+		assert.ErrorContains(t, err, "no save profile")
+
+		require.NoError(t, sm.DeleteNamedSave("Fruit Stand"))
+		saves, err = sm.ListNamedSaves()
+		require.NoError(t, err)
+		require.Len(t, saves, 1)
+		_, err = sm.LoadGameNamed("Fruit Stand")
+		assert.Error(t, err)
+	})
+}
+
+func TestProfileKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Iron Run", "iron_run"},
+		{"  My   Shop!! ", "my_shop"},
+		{"../../etc/passwd", "etc_passwd"},
+		{"C:\\saves\\run-2", "c_saves_run-2"},
+		{"Ünïcode Täle", "n_code_t_le"},
+		{strings.Repeat("a", 40), strings.Repeat("a", MaxProfileNameLength)},
+	}
+	for _, tt := range tests {
+		key, err := ProfileKey(tt.name)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, key, tt.name)
+	}
+
+	for _, name := range []string{"", "   ", "../", "!!!", "__"} {
+		_, err := ProfileKey(name)
+		assert.ErrorIs(t, err, ErrInvalidProfileName, name)
+	}
+}
+
+func TestSaveManager_SaveDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files, err := NewFileStore(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, NewSaveManagerWithStore(files).GetSaveDirectory())
+	assert.Empty(t, NewSaveManagerWithStore(NewMemoryStore()).GetSaveDirectory())
+}
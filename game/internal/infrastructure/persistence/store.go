@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound is returned when a store holds nothing under a key
+var ErrNotFound = errors.New("no saved data under key")
+
+// Store is where save data lives. SaveManager keeps each slot's save and
+// metadata under their own keys and never touches the backend directly.
+type Store interface {
+	Read(key string) ([]byte, error) // Returns ErrNotFound for missing keys
+	Write(key string, data []byte) error
+	Delete(key string) error // Deleting a missing key is not an error
+}
+
+// FileStore keeps each key as a file in a directory, as the desktop build does
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a file store, creating its directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Read returns the contents of a key's file
+func (s *FileStore) Read(key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Write replaces a key's file
+func (s *FileStore) Write(key string, data []byte) error {
+	return os.WriteFile(s.path(key), data, 0o600)
+}
+
+// Delete removes a key's file
+func (s *FileStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Dir returns the directory the store writes to
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.Clean(filepath.Base(key)))
+}
+
+// MemoryStore keeps save data in memory, for tests and throwaway sessions
+type MemoryStore struct {
+	data map[string][]byte
+	mu   sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		data: make(map[string][]byte),
+	}
+}
+
+// Read returns a copy of the data under a key
+func (s *MemoryStore) Read(key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, exists := s.data[key]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Write stores a copy of data under a key
+func (s *MemoryStore) Write(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = append([]byte(nil), data...)
+	return nil
+}
+
+// Delete removes a key
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+// SQLiteStore keeps save data in a table of a SQLite database, as a hosted
+// server would. The caller opens the database with whichever SQLite driver
+// the build registers, so the game itself does not depend on one.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a store on an open SQLite database, creating its
+// table if needed
+func NewSQLiteStore(db *sql.DB) (*SQLiteStore, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS saves (key TEXT PRIMARY KEY, data BLOB NOT NULL)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saves table: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Read returns the data under a key
+func (s *SQLiteStore) Read(key string) ([]byte, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM saves WHERE key = ?`, key).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Write stores data under a key, replacing what was there
+func (s *SQLiteStore) Write(key string, data []byte) error {
+	_, err := s.db.Exec(
+		`INSERT INTO saves (key, data) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET data = excluded.data`,
+		key, data,
+	)
+	return err
+}
+
+// Delete removes a key
+func (s *SQLiteStore) Delete(key string) error {
+	_, err := s.db.Exec(`DELETE FROM saves WHERE key = ?`, key)
+	return err
+}
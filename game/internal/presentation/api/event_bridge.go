@@ -72,6 +72,7 @@ func (eb *EventBridge) setupEventSubscriptions() {
 	eb.subscribeToEvent("RankUp")
 	eb.subscribeToEvent("AchievementUnlocked")
 	eb.subscribeToEvent("FeatureUnlocked")
+	eb.subscribeToEvent(event.EventNameOnboardingStepCompleted)
 
 	// Time events
 	eb.subscribeToEvent("time.advanced")
@@ -80,7 +81,10 @@ func (eb *EventBridge) setupEventSubscriptions() {
 
 	// Inventory events
 	eb.subscribeToEvent(event.EventNameInventoryChanged)
-	eb.subscribeToEvent("ItemSpoiled")
+	eb.subscribeToEvent(event.EventNameItemSpoiled)
+
+	// Settings events
+	eb.subscribeToEvent(event.EventNameDisplaySettingsChanged)
 }
 
 // subscribeToEvent subscribes to a specific event type
@@ -102,6 +106,29 @@ func (eb *EventBridge) handleEvent(e event.Event) {
 		"timestamp": e.OccurredAt(),
 	}
 
+	// Include payloads Godot needs to act on
+	if display, ok := e.(*event.DisplaySettingsChangedEvent); ok {
+		data["uiScale"] = display.UIScale
+		data["width"] = display.Width
+		data["height"] = display.Height
+	}
+	if spoiled, ok := e.(*event.ItemSpoiledEvent); ok {
+		data["itemId"] = spoiled.ItemID
+		data["quantity"] = spoiled.Quantity
+		data["location"] = spoiled.Location
+		data["goldLost"] = spoiled.GoldLost
+	}
+	if onboarding, ok := e.(*event.OnboardingStepCompletedEvent); ok {
+		data["stepId"] = onboarding.StepID
+	}
+	if price, ok := e.(*event.PriceUpdatedEvent); ok {
+		data["itemId"] = price.ItemID
+		data["oldPrice"] = price.OldPrice
+		data["newPrice"] = price.NewPrice
+		data["impact"] = price.Impact
+		data["reason"] = price.Reason
+	}
+
 	// Convert event data to JSON
 	jsonData, err := json.Marshal(data)
 	if err != nil {
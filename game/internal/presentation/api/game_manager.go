@@ -2,23 +2,61 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/yourusername/merchant-tails/game/internal/domain/branch"
+	"github.com/yourusername/merchant-tails/game/internal/domain/bundle"
+	"github.com/yourusername/merchant-tails/game/internal/domain/difficulty"
 	"github.com/yourusername/merchant-tails/game/internal/domain/event"
+	"github.com/yourusername/merchant-tails/game/internal/domain/events"
+	"github.com/yourusername/merchant-tails/game/internal/domain/exchange"
 	"github.com/yourusername/merchant-tails/game/internal/domain/gameloop"
 	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
+	"github.com/yourusername/merchant-tails/game/internal/domain/insurance"
 	"github.com/yourusername/merchant-tails/game/internal/domain/inventory"
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+	"github.com/yourusername/merchant-tails/game/internal/domain/ledger"
 	"github.com/yourusername/merchant-tails/game/internal/domain/market"
 	"github.com/yourusername/merchant-tails/game/internal/domain/progression"
+	"github.com/yourusername/merchant-tails/game/internal/domain/quest"
+	"github.com/yourusername/merchant-tails/game/internal/domain/scenario"
 	"github.com/yourusername/merchant-tails/game/internal/domain/settings"
+	"github.com/yourusername/merchant-tails/game/internal/domain/tax"
 	timemanager "github.com/yourusername/merchant-tails/game/internal/domain/time"
 	"github.com/yourusername/merchant-tails/game/internal/infrastructure/logging"
 	"github.com/yourusername/merchant-tails/game/internal/infrastructure/persistence"
 )
 
+// defaultTradeSpread is the default gap between supplier and customer prices.
+// Half is added to purchases and half taken from sales so buying and instantly
+// reselling at the same quoted price always loses gold.
+const defaultTradeSpread = 0.1
+
+// Trade advisor limits
+const (
+	maxSuggestedQuantity = 10   // Largest lot the advisor proposes at once
+	advisorBudgetShare   = 0.25 // Share of gold the advisor will commit to one purchase
+	fastSellerVelocity   = 1.0  // Daily sales above which stock belongs in the shop
+)
+
+// maxTickerEntries caps how many transactions the HUD ticker can request
+const maxTickerEntries = 20
+
+// How sales are judged for reputation
+const (
+	nearSpoilageFreshness = 0.5  // Remaining shelf life fraction below which stock counts as near-spoiled
+	fairPriceTolerance    = 1.10 // Prices up to 10% over fair value count as fair
+)
+
 // GameManager manages the overall game state and coordinates between systems
 type GameManager struct {
 	// Core systems
@@ -32,6 +70,63 @@ type GameManager struct {
 	market      *market.Market
 	inventory   *inventory.InventoryManager
 	progression *progression.ProgressionManager
+	quests      *quest.QuestManager
+	taxes       *tax.TaxManager
+	insurance   *insurance.Insurer
+	exchange    *exchange.Exchange
+	ledger      *ledger.Ledger
+	pricing     *PriceSettingUIManager
+	branches    *branch.Network
+	bundles     *bundle.Catalog
+	tradeSpread float64
+
+	// Capacity every new game starts with unless its config sets one
+	shopCapacity      int
+	warehouseCapacity int
+
+	// Friction on moving stock between shop and warehouse
+	transferCostPerUnit int
+	transferDelayDays   int
+
+	// HUD ticker cache, rebuilt when the ledger changes
+	tickerCache    []map[string]interface{}
+	tickerRevision int
+	tickerLimit    int
+	tickerMu       sync.Mutex
+
+	// Low gold warning; a zero threshold disables it
+	criticalGoldThreshold int
+	pauseOnCriticalGold   bool
+	criticalGoldWarned    bool
+
+	// Random events rolled at the start of each day
+	eventRoller  *events.DailyRoller
+	todaysEvents []dailyEventOutcome
+
+	// Economy-wide boom and recession cycle
+	moodCycle *market.MoodCycle
+
+	// Difficulty ramp layered on the base difficulty as the player prospers
+	difficultyRamp difficulty.Ramp
+	rampStage      int
+
+	// Starting scenario of the current game; nil for a standard start
+	scenario *scenario.Scenario
+
+	// What it takes to win; survives new games so a sandbox can relax it
+	winConditions gamestate.WinConditions
+
+	// Price moves and stock losses by day, for daily reports
+	dayRecords map[int]*dayRecord
+
+	// End-of-day snapshots for UndoLastDay, oldest first
+	undoSnapshots []*daySnapshot
+
+	// Stops streaming market price changes to the event bus
+	unsubscribePrices func()
+
+	// Event bus handlers, removed on Cleanup
+	subscriptions []*event.Subscription
 
 	// Infrastructure
 	saveManager *persistence.SaveManager
@@ -40,6 +135,8 @@ type GameManager struct {
 	// State management
 	isRunning bool
 	isPaused  bool
+	timeUp    bool // A timed challenge has reached its last day
+	gameEnded bool // Victory or defeat has been announced
 	ctx       context.Context
 	cancel    context.CancelFunc
 	mu        sync.RWMutex
@@ -50,11 +147,12 @@ func NewGameManager() *GameManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	gm := &GameManager{
-		gameState:   gamestate.NewGameState(nil), // Use default config
-		eventBus:    event.GetGlobalEventBus(),
-		eventBridge: NewEventBridge(),
-		ctx:         ctx,
-		cancel:      cancel,
+		gameState:     gamestate.NewGameState(nil), // Use default config
+		eventBus:      event.GetGlobalEventBus(),
+		eventBridge:   NewEventBridge(),
+		winConditions: gamestate.DefaultWinConditions(),
+		ctx:           ctx,
+		cancel:        cancel,
 	}
 
 	// Initialize systems
@@ -77,6 +175,8 @@ func (gm *GameManager) initializeSystems() {
 
 	// Get capacity from settings
 	gameSettings := gm.settings.GetSettings()
+	logging.SetGlobalSampling(gameSettings.LogSampleRate,
+		time.Duration(gameSettings.LogSampleIntervalMs)*time.Millisecond)
 	shopCap := gameSettings.CustomSettings["shopCapacity"]
 	warehouseCap := gameSettings.CustomSettings["warehouseCapacity"]
 
@@ -90,16 +190,97 @@ func (gm *GameManager) initializeSystems() {
 		warehouseCapacity = wc
 	}
 
+	if change, ok := gameSettings.CustomSettings["maxDailyPriceChange"].(float64); ok {
+		if err := gm.market.SetMaxDailyChange(change); err != nil {
+			logging.Warnf("Ignoring invalid max daily price change setting: %v", err)
+		}
+	}
+	if smoothing, ok := gameSettings.CustomSettings["priceSmoothing"].(float64); ok {
+		if err := gm.market.SetPriceSmoothing(smoothing); err != nil {
+			logging.Warnf("Ignoring invalid price smoothing setting: %v", err)
+		}
+	}
+	gm.market.SetCircuitBreakerHandler(func(trip market.CircuitBreakerTrip) {
+		logging.InfofSampled("circuit_breaker:"+trip.ItemID, "Circuit breaker tripped - Item: %s, Open: %d, Target: %d, Capped: %d",
+			trip.ItemID, trip.OpenPrice, trip.TargetPrice, trip.CappedPrice)
+		gm.eventBus.PublishAsync(event.NewCircuitBreakerTrippedEvent(
+			trip.ItemID, trip.Day, trip.OpenPrice, trip.TargetPrice, trip.CappedPrice))
+	})
+
+	// Stream market price changes to the event bus for the UI
+	priceUpdates, unsubscribe := gm.market.SubscribePriceUpdates()
+	gm.unsubscribePrices = unsubscribe
+	go gm.forwardPriceUpdates(priceUpdates)
+
+	gm.criticalGoldThreshold = 0
+	gm.pauseOnCriticalGold = true
+	if threshold, ok := gameSettings.CustomSettings["criticalGoldThreshold"].(float64); ok && threshold >= 0 {
+		gm.criticalGoldThreshold = int(threshold)
+	}
+	if pause, ok := gameSettings.CustomSettings["pauseOnCriticalGold"].(bool); ok {
+		gm.pauseOnCriticalGold = pause
+	}
+
+	gm.tradeSpread = defaultTradeSpread
+	if spread, ok := gameSettings.CustomSettings["tradeSpread"].(float64); ok && spread >= 0 && spread < 1 {
+		gm.tradeSpread = spread
+	}
+
 	// Create inventory manager with settings-based capacity
 	invManager, err := inventory.NewInventoryManager(shopCapacity, warehouseCapacity)
 	if err != nil {
 		panic(err) // Should not happen with valid capacities
 	}
 	gm.inventory = invManager
+	gm.shopCapacity = shopCapacity
+	gm.warehouseCapacity = warehouseCapacity
 
 	// Create progression manager
 	gm.progression = progression.NewProgressionManager()
 
+	// Create quest manager
+	gm.quests = quest.NewQuestManager()
+	gm.quests.SetDay(gm.gameState.GetCurrentDay())
+	gm.quests.RegisterCallback(gm.handleQuestStatusChanged)
+
+	// Create pricing manager for player-set shop prices
+	gm.pricing = NewPriceSettingUIManager(gm)
+
+	// Branch shops opened beyond the main one
+	gm.branches = branch.NewNetwork()
+
+	// Bundles the player assembles from shop stock
+	gm.bundles = bundle.NewCatalog()
+
+	// Create the daily random event roller with odds set by difficulty
+	gm.eventRoller = events.NewDailyRoller(time.Now().UnixNano(), events.OddsForDifficulty(gameSettings.Difficulty))
+	gm.moodCycle = market.NewMoodCycle(time.Now().UnixNano())
+	gm.difficultyRamp = difficulty.DefaultRamp()
+	if markup, ok := gameSettings.CustomSettings["minMarkup"].(float64); ok {
+		if err := gm.pricing.SetMinMarkup(markup); err != nil {
+			logging.Warnf("Ignoring invalid minimum markup setting: %v", err)
+		}
+	}
+
+	gm.transferCostPerUnit, gm.transferDelayDays = transferFrictionForDifficulty(gameSettings.Difficulty)
+
+	// Create tax manager scaled by difficulty
+	gm.taxes = tax.NewTaxManager()
+	gm.taxes.SetDifficultyMultiplier(taxMultiplierForDifficulty(gameSettings.Difficulty))
+
+	// Scale reputation gains and losses by difficulty
+	gm.applyReputationRulesUnsafe(nil)
+
+	// Create insurer covering shop stock against theft
+	gm.insurance = insurance.NewInsurer()
+
+	// Create exchange desk for foreign currencies
+	gm.exchange = exchange.NewExchange()
+
+	// Create ledger to account for every gold change
+	gm.ledger = ledger.NewLedger(gm.gameState.GetGold())
+	gm.resetDayRecordsUnsafe()
+
 	// AI system removed - single player only
 
 	// Create save manager
@@ -130,49 +311,163 @@ func (gm *GameManager) initializeSystems() {
 	gm.setupEventListeners()
 }
 
-// setupEventListeners sets up event handlers
-func (gm *GameManager) setupEventListeners() {
-	// Listen for time events
-	gm.eventBus.Subscribe("time.advanced", func(e event.Event) error {
-		gm.handleTimeAdvanced()
-		return nil
-	})
+// taxMultiplierForDifficulty returns the tax scaling for a difficulty setting
+func taxMultiplierForDifficulty(difficulty string) float64 {
+	switch difficulty {
+	case "easy":
+		return 0.5
+	case "hard", "expert":
+		return 1.5
+	default:
+		return 1.0
+	}
+}
 
-	// Listen for trade events
-	gm.eventBus.Subscribe(event.EventNameTransactionComplete, func(e event.Event) error {
-		gm.handleTradeCompleted()
-		return nil
-	})
+// reputationScalesForDifficulty returns the multipliers for reputation gains
+// and losses under a difficulty setting
+func reputationScalesForDifficulty(difficulty string) (float64, float64) {
+	switch difficulty {
+	case "easy":
+		return 1.5, 0.5
+	case "hard", "expert":
+		return 0.75, 1.5
+	default:
+		return 1.0, 1.0
+	}
+}
 
-	// Listen for market events
-	gm.eventBus.Subscribe(event.EventNamePriceUpdated, func(e event.Event) error {
-		gm.handleMarketPriceChanged()
-		return nil
-	})
+// applyReputationRulesUnsafe gives the game state the config's reputation
+// table, or the default table scaled for the difficulty setting when the
+// config has none. Caller must hold gm.mu.
+func (gm *GameManager) applyReputationRulesUnsafe(config *gamestate.GameConfig) {
+	if config != nil && config.ReputationRules != nil {
+		gm.gameState.SetReputationRules(config.ReputationRules)
+		return
+	}
+	gains, losses := reputationScalesForDifficulty(gm.settings.GetSettings().Difficulty)
+	gm.gameState.SetReputationRules(gamestate.DefaultReputationRules().Scale(gains, losses))
+}
+
+// transferFrictionForDifficulty returns the per-unit gold cost and the delay
+// in days for moving stock between shop and warehouse
+func transferFrictionForDifficulty(difficulty string) (int, int) {
+	switch difficulty {
+	case "easy":
+		return 0, 0
+	case "hard", "expert":
+		return 2, 1
+	default:
+		return 1, 0
+	}
+}
+
+// setupEventListeners sets up event handlers, keeping their subscriptions
+// for Cleanup
+func (gm *GameManager) setupEventListeners() {
+	gm.subscriptions = []*event.Subscription{
+		// Listen for time events
+		gm.eventBus.Subscribe("time.advanced", func(e event.Event) error {
+			gm.handleTimeAdvanced()
+			return nil
+		}),
+
+		// Listen for trade events
+		gm.eventBus.Subscribe(event.EventNameTransactionComplete, func(e event.Event) error {
+			gm.handleTradeCompleted()
+			return nil
+		}),
+
+		// Listen for market events
+		gm.eventBus.Subscribe(event.EventNamePriceUpdated, func(e event.Event) error {
+			if update, ok := e.(*event.PriceUpdatedEvent); ok {
+				gm.handleMarketPriceChanged(update)
+			}
+			return nil
+		}),
+	}
 }
 
 // StartNewGame starts a new game
 func (gm *GameManager) StartNewGame(playerName string) error {
+	return gm.StartNewGameWithConfig(playerName, nil)
+}
+
+// StartNewGameWithConfig starts a new game using the given starting gold,
+// rank and inventory capacity. A nil config uses the standard defaults;
+// zero capacities keep the capacity from the game settings.
+func (gm *GameManager) StartNewGameWithConfig(playerName string, config *gamestate.GameConfig) error {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	return gm.startNewGameUnsafe(playerName, config, nil)
+}
+
+// StartNewGameWithScenario starts a new game set up by a named scenario:
+// its starting gold, reputation, stock, overhead, day cap and win conditions
+func (gm *GameManager) StartNewGameWithScenario(playerName, scenarioID string) error {
+	start, err := scenario.Get(scenarioID)
+	if err != nil {
+		return err
+	}
+	if err := start.Validate(); err != nil {
+		return err
+	}
+
 	gm.mu.Lock()
 	defer gm.mu.Unlock()
+	return gm.startNewGameUnsafe(playerName, &gamestate.GameConfig{
+		InitialGold: start.InitialGold,
+		MaxDays:     start.MaxDays,
+	}, &start)
+}
 
+// startNewGameUnsafe resets every system and starts the game loop, applying
+// the scenario when one is given.
+// Caller must hold gm.mu.
+func (gm *GameManager) startNewGameUnsafe(playerName string, config *gamestate.GameConfig, start *scenario.Scenario) error {
 	if gm.isRunning {
 		return fmt.Errorf("game is already running")
 	}
+	if config != nil && config.InitialGold < 0 {
+		return fmt.Errorf("initial gold cannot be negative")
+	}
+	if config != nil && config.MaxDays < 0 {
+		return fmt.Errorf("day cap cannot be negative")
+	}
+	// A config that leaves the starting gold unset starts with the default;
+	// scenarios set their own
+	if config != nil && config.InitialGold == 0 && start == nil {
+		withGold := *config
+		withGold.InitialGold = gamestate.DefaultConfig().InitialGold
+		config = &withGold
+	}
+
+	// Clear everything left over from a previous game
+	gm.resetAllSystems(config)
+
+	// Every game starts from the default capacity, so a custom capacity or
+	// upgrades from an earlier game do not carry over
+	shopCapacity, warehouseCapacity := gm.shopCapacity, gm.warehouseCapacity
+	if config != nil && config.ShopCapacity > 0 {
+		shopCapacity = config.ShopCapacity
+	}
+	if config != nil && config.WarehouseCapacity > 0 {
+		warehouseCapacity = config.WarehouseCapacity
+	}
+	if err := gm.inventory.SetBaseCapacity(shopCapacity, warehouseCapacity); err != nil {
+		return fmt.Errorf("failed to set inventory capacity: %w", err)
+	}
+
+	if start != nil {
+		if err := gm.applyScenarioUnsafe(start); err != nil {
+			return fmt.Errorf("failed to set up scenario %s: %w", start.ID, err)
+		}
+	}
 
-	// Reset game state
-	gm.gameState = gamestate.NewGameState(nil)
 	// Set player name
 	if err := gm.gameState.SetPlayerName(playerName); err != nil {
 		return fmt.Errorf("failed to set player name: %w", err)
 	}
-	gm.gameState.SetGold(1000) // Starting gold
-	gm.gameState.SetRank(gamestate.RankApprentice)
-
-	// Reset systems
-	gm.progression.ResetProgression()
-	gm.market.Reset()
-	gm.inventory.Clear()
+	gm.ledger.Reset(gm.gameState.GetGold())
 
 	// Initialize AI merchants
 	gm.initializeAIMerchants()
@@ -193,6 +488,132 @@ func (gm *GameManager) StartNewGame(playerName string) error {
 	return nil
 }
 
+// resetAllSystems returns every stateful subsystem to a fresh state.
+// The manager is reused across games, so anything holding per-game data
+// must be cleared here. Settings, the trade spread and the difficulty ramp
+// are configuration and survive a new game. A nil config uses the default starting state.
+// Caller must hold gm.mu.
+func (gm *GameManager) resetAllSystems(config *gamestate.GameConfig) {
+	gm.gameState = gamestate.NewGameState(config)
+	gm.applyReputationRulesUnsafe(config)
+	_ = gm.gameState.SetWinConditions(gm.winConditions)
+	gm.progression.ResetProgression()
+	gm.quests.Reset()
+	gm.quests.SetDay(gm.gameState.GetCurrentDay())
+	gm.startFirstQuestUnsafe()
+	gm.market.Reset()
+	gm.inventory.Clear()
+	gm.taxes.Reset()
+	gm.insurance.Reset()
+	gm.exchange.Reset()
+	gm.pricing.Reset()
+	gm.branches.Reset()
+	gm.bundles.Reset()
+	gm.ledger.Reset(gm.gameState.GetGold())
+	gm.todaysEvents = nil
+	gm.resetDayRecordsUnsafe()
+	gm.undoSnapshots = nil
+	gm.timeUp = false
+	gm.gameEnded = false
+	gm.rampStage = 0
+	gm.applyRampStageUnsafe()
+	gm.scenario = nil
+	gm.taxes.SetDifficultyMultiplier(taxMultiplierForDifficulty(gm.settings.GetSettings().Difficulty))
+}
+
+// applyScenarioUnsafe sets up a freshly reset game for a scenario. Starting
+// stock goes in the warehouse at base price, already aged by the scenario's
+// stock age, and the scenario's rules are applied.
+// Caller must hold gm.mu.
+func (gm *GameManager) applyScenarioUnsafe(start *scenario.Scenario) error {
+	gm.gameState.SetReputation(start.Reputation)
+	gm.gameState.ResetReputationHistory(nil)
+
+	itemIDs := make([]string, 0, len(start.Inventory))
+	for itemID := range start.Inventory {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+	for _, itemID := range itemIDs {
+		master, _ := item.GetItemRegistry().GetItem(itemID)
+		if err := gm.inventory.AddToWarehouseByID(itemID, start.Inventory[itemID], master.BasePrice); err != nil {
+			return fmt.Errorf("failed to stock %s: %w", itemID, err)
+		}
+		if start.StockAge > 0 && master.Durability > 0 {
+			// Old stock is close to spoiling but never starts spoiled
+			durability := max(master.Durability-start.StockAge, 1)
+			if err := gm.inventory.SetDurability(itemID, inventory.LocationWarehouse, durability); err != nil {
+				return fmt.Errorf("failed to age %s: %w", itemID, err)
+			}
+		}
+	}
+
+	return gm.applyScenarioRulesUnsafe(start)
+}
+
+// applyScenarioRulesUnsafe makes a scenario the current game's: its win
+// conditions replace the usual ones and its overhead scales taxes on top of
+// the difficulty. Used by new games and by loading a scenario's save.
+// Caller must hold gm.mu.
+func (gm *GameManager) applyScenarioRulesUnsafe(start *scenario.Scenario) error {
+	if start.Victory != nil {
+		if err := gm.gameState.SetWinConditions(*start.Victory); err != nil {
+			return err
+		}
+	}
+
+	gm.taxes.SetDifficultyMultiplier(taxMultiplierForDifficulty(gm.settings.GetSettings().Difficulty) * start.GetOverhead())
+	gm.scenario = start
+	return nil
+}
+
+// GetAvailableScenarios lists the scenarios a new game can start from
+func (gm *GameManager) GetAvailableScenarios() []map[string]interface{} {
+	all := scenario.All()
+	result := make([]map[string]interface{}, 0, len(all))
+	for _, s := range all {
+		result = append(result, scenarioInfo(s))
+	}
+	return result
+}
+
+// GetCurrentScenario returns the scenario the current game started from, or
+// nil for a standard start
+func (gm *GameManager) GetCurrentScenario() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if gm.scenario == nil {
+		return nil
+	}
+	return scenarioInfo(*gm.scenario)
+}
+
+// scenarioInfo converts a scenario for the UI
+func scenarioInfo(s scenario.Scenario) map[string]interface{} {
+	inventory := make(map[string]interface{}, len(s.Inventory))
+	for itemID, quantity := range s.Inventory {
+		inventory[itemID] = quantity
+	}
+	var victory map[string]interface{}
+	if s.Victory != nil {
+		victory = winConditionsInfo(*s.Victory)
+	}
+	return map[string]interface{}{
+		"id":          s.ID,
+		"name":        s.Name,
+		"description": s.Description,
+		"initialGold": s.InitialGold,
+		"reputation":  s.Reputation,
+		"overhead":    s.GetOverhead(),
+		"maxDays":     s.MaxDays,
+		"inventory":   inventory,
+		"stockAge":    s.StockAge,
+		"objectives":  s.Objectives,
+		"victory":     victory,
+	}
+}
+
 // runGameLoop runs the main game loop
 func (gm *GameManager) runGameLoop() {
 	err := gm.gameLoop.Start(gm.ctx)
@@ -210,9 +631,9 @@ func (gm *GameManager) update(deltaTime float64) {
 	duration := time.Duration(deltaTime * float64(time.Second))
 	gm.timeManager.Update(duration)
 
-	// Update market
+	// Ease quoted market prices; daily price steps happen on day advance
 	if gm.market != nil {
-		gm.market.Update()
+		gm.market.Tick(deltaTime)
 	}
 
 	// AI system removed - single player only
@@ -245,8 +666,13 @@ func (gm *GameManager) GetGameState() (string, error) {
 		"reputation":    gm.gameState.GetReputation(),
 		"currentDay":    gm.gameState.GetCurrentDay(),
 		"currentSeason": gm.gameState.GetCurrentSeason(),
+		"maxDays":       gm.gameState.GetMaxDays(),
+		"timeUp":        gm.timeUp,
 		"time":          gm.timeManager.GetCurrentTime(),
 	}
+	if gm.scenario != nil {
+		state["scenario"] = gm.scenario.ID
+	}
 
 	jsonData, err := json.Marshal(state)
 	if err != nil {
@@ -290,6 +716,7 @@ func (gm *GameManager) SaveGame(slot int) error {
 		gm.market,
 		gm.inventory,
 		gm.progression,
+		gm.saveSectionsUnsafe()...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save game: %w", err)
@@ -315,9 +742,89 @@ func (gm *GameManager) LoadGame(slot int) error {
 	if err != nil {
 		return fmt.Errorf("failed to load game: %w", err)
 	}
+	return gm.restoreSaveUnsafe(saveData)
+}
+
+// SaveGameNamed saves the current game under a profile name, so separate
+// runs can be kept without overwriting each other
+func (gm *GameManager) SaveGameNamed(name string) error {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if gm.saveManager == nil {
+		return fmt.Errorf("save system not available")
+	}
+
+	err := gm.saveManager.SaveGameNamed(
+		name,
+		gm.gameState,
+		gm.market,
+		gm.inventory,
+		gm.progression,
+		gm.saveSectionsUnsafe()...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save game: %w", err)
+	}
+
+	gm.eventBus.PublishAsync(event.NewBaseEvent("GameSaved"))
+
+	return nil
+}
+
+// LoadGameNamed loads the game saved under a profile name
+func (gm *GameManager) LoadGameNamed(name string) error {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if gm.saveManager == nil {
+		return fmt.Errorf("save system not available")
+	}
+
+	saveData, err := gm.saveManager.LoadGameNamed(name)
+	if err != nil {
+		return fmt.Errorf("failed to load game: %w", err)
+	}
+	return gm.restoreSaveUnsafe(saveData)
+}
+
+// saveSectionsUnsafe returns the parts of a save kept by the manager's own
+// systems, which the save manager writes alongside the game state.
+// Caller must hold gm.mu.
+func (gm *GameManager) saveSectionsUnsafe() []persistence.SaveSection {
+	return []persistence.SaveSection{
+		{Key: "insurance", Data: gm.insurance.Record()},
+		{Key: "exchange", Data: gm.exchange.Record()},
+		{Key: "scenario", Data: gm.scenarioIDUnsafe()},
+		{Key: "bundles", Data: gm.bundles.Record()},
+		{Key: "branches", Data: gm.branches.Record()},
+		{Key: "quests", Data: gm.quests.Record()},
+		{Key: "taxes", Data: gm.taxes.Record()},
+	}
+}
+
+// scenarioIDUnsafe returns the current game's scenario ID, or an empty
+// string for a standard start. Caller must hold gm.mu.
+func (gm *GameManager) scenarioIDUnsafe() string {
+	if gm.scenario == nil {
+		return ""
+	}
+	return gm.scenario.ID
+}
+
+// restoreSaveUnsafe replaces the running game with a decoded save. Caller
+// must hold gm.mu.
+func (gm *GameManager) restoreSaveUnsafe(saveData map[string]interface{}) error {
+	gold, ok := saveData["gold"].(float64)
+	if !ok {
+		return fmt.Errorf("failed to load game: save data has no gold")
+	}
 
 	// Restore game state
 	gm.gameState = gamestate.NewGameState(nil)
+	gm.applyReputationRulesUnsafe(nil)
+	_ = gm.gameState.SetWinConditions(gm.winConditions)
+	gm.quests.Reset()
 	// Restore player name
 	if playerName, ok := saveData["playerName"].(string); ok && playerName != "" {
 		_ = gm.gameState.SetPlayerName(playerName)
@@ -325,12 +832,82 @@ func (gm *GameManager) LoadGame(slot int) error {
 	// Restore day and season
 	if currentDay, ok := saveData["currentDay"].(float64); ok {
 		gm.gameState.SetCurrentDay(int(currentDay))
+		gm.market.SetDay(int(currentDay))
+		gm.quests.SetDay(int(currentDay))
 	}
 	if currentSeason, ok := saveData["currentSeason"].(string); ok {
 		_ = gm.gameState.SetCurrentSeason(currentSeason)
+		_ = gm.market.SetSeason(currentSeason)
+	}
+	// Saves from before day caps were kept play endlessly
+	if maxDays, ok := saveData["maxDays"].(float64); ok {
+		_ = gm.gameState.SetMaxDays(int(maxDays))
+	}
+	gm.timeUp = gm.gameState.IsTimeUp()
+	gm.gameState.SetGoldWithReason(int(gold), "load_game")
+	gm.ledger.Reset(gm.gameState.GetGold())
+	gm.resetDayRecordsUnsafe()
+	gm.undoSnapshots = nil
+	gm.todaysEvents = nil
+	gm.gameEnded = false
+	gm.branches.Reset()
+	var branches branch.NetworkRecord
+	if decodeSaveSection(saveData["branches"], &branches) {
+		if err := gm.branches.RestoreRecord(branches); err != nil {
+			logging.Warnf("Branches not fully restored: %v", err)
+		}
+	}
+	var quests quest.Record
+	if decodeSaveSection(saveData["quests"], &quests) {
+		if err := gm.quests.RestoreRecord(quests); err != nil {
+			logging.Warnf("Quests not fully restored: %v", err)
+		}
+	} else {
+		// Saves from before quests were kept start the first one afresh
+		gm.startFirstQuestUnsafe()
+	}
+	gm.bundles.Reset()
+	var bundles bundle.CatalogRecord
+	if decodeSaveSection(saveData["bundles"], &bundles) {
+		gm.bundles.RestoreRecord(bundles)
+	}
+	gm.insurance.Reset()
+	var insured insurance.Record
+	if decodeSaveSection(saveData["insurance"], &insured) {
+		gm.insurance.RestoreRecord(insured)
+	}
+	gm.taxes.Reset()
+	var taxTotals tax.Record
+	if decodeSaveSection(saveData["taxes"], &taxTotals) {
+		gm.taxes.RestoreRecord(taxTotals)
+	}
+	gm.exchange.Reset()
+	var holdings exchange.Record
+	if decodeSaveSection(saveData["exchange"], &holdings) {
+		gm.exchange.RestoreRecord(holdings)
+	}
+	// Scenario rules come from the save, never from the game it replaces
+	gm.scenario = nil
+	gm.taxes.SetDifficultyMultiplier(taxMultiplierForDifficulty(gm.settings.GetSettings().Difficulty))
+	if scenarioID, ok := saveData["scenario"].(string); ok && scenarioID != "" {
+		if start, err := scenario.Get(scenarioID); err != nil {
+			logging.Warnf("Loading without scenario rules: %v", err)
+		} else if err := gm.applyScenarioRulesUnsafe(&start); err != nil {
+			logging.Warnf("Loading without scenario rules: %v", err)
+		}
+	}
+	if reputation, ok := saveData["reputation"].(float64); ok {
+		gm.gameState.SetReputation(reputation)
+	}
+	gm.gameState.ResetReputationHistory(decodeReputationHistory(saveData["reputationHistory"]))
+	var reputationToday gamestate.ReputationSample
+	if decodeSaveSection(saveData["reputationToday"], &reputationToday) {
+		gm.gameState.ResetTodaysReputation(reputationToday)
+	}
+	var onboarding map[string]int
+	if decodeSaveSection(saveData["onboarding"], &onboarding) {
+		gm.gameState.ResetOnboarding(onboarding)
 	}
-	gm.gameState.SetGold(int(saveData["gold"].(float64)))
-	gm.gameState.SetReputation(saveData["reputation"].(float64))
 
 	// Convert and set rank
 	if rank, ok := saveData["rank"].(float64); ok {
@@ -348,17 +925,89 @@ func (gm *GameManager) LoadGame(slot int) error {
 
 	// Restore inventory
 	gm.inventory.Clear()
-	// TODO: Implement inventory restoration from save data
+	if err := gm.restoreInventoryUnsafe(saveData["inventory"]); err != nil {
+		logging.Warnf("Inventory not fully restored: %v", err)
+	}
 
 	// Restore progression
 	// TODO: Restore achievements and stats
 
+	// The ramp stage belongs to the loaded game, not the one it replaced
+	gm.rampStage = gm.difficultyRamp.StageFor(gm.netWorthUnsafe(), int(gm.gameState.GetRank()))
+	gm.applyRampStageUnsafe()
+
+	if gm.settings.GetSettings().EnableDebugMode {
+		gm.validateGameStateUnsafe()
+	}
+
 	// Publish load event
 	gm.eventBus.PublishAsync(event.NewBaseEvent("GameLoaded"))
 
 	return nil
 }
 
+// decodeSaveSection reads a section of a decoded save into out, returning
+// false if the save lacks it, as older saves may
+func decodeSaveSection(raw interface{}, out interface{}) bool {
+	if raw == nil {
+		return false
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
+
+// decodeReputationHistory reads the reputation history from a decoded save
+func decodeReputationHistory(raw interface{}) []gamestate.ReputationSample {
+	var history []gamestate.ReputationSample
+	if !decodeSaveSection(raw, &history) {
+		return nil
+	}
+	return history
+}
+
+// restoreInventoryUnsafe refills the cleared inventory from a save's
+// inventory section, capacities first, then stock with its remaining shelf
+// life and the shipments in transit. Saves without one leave it empty.
+// Caller must hold gm.mu.
+func (gm *GameManager) restoreInventoryUnsafe(raw interface{}) error {
+	var saved persistence.SavedInventory
+	if !decodeSaveSection(raw, &saved) {
+		return nil
+	}
+	if saved.ShopCapacity > 0 && saved.WarehouseCapacity > 0 {
+		if err := gm.inventory.SetBaseCapacity(saved.ShopCapacity, saved.WarehouseCapacity); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, record := range saved.Stock {
+		if err := gm.inventory.RestoreStock(record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, shipment := range saved.InTransit {
+		if err := gm.inventory.RestoreShipment(shipment); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// QuickSave saves to the reserved quick-save slot, bound to the quicksave
+// key, leaving the manual slots untouched
+func (gm *GameManager) QuickSave() error {
+	return gm.SaveGame(persistence.QuickSaveSlot)
+}
+
+// QuickLoad loads the most recent quick save, bound to the quickload key
+func (gm *GameManager) QuickLoad() error {
+	return gm.LoadGame(persistence.QuickSaveSlot)
+}
+
 // GetSaveSlots returns information about save slots
 func (gm *GameManager) GetSaveSlots() (string, error) {
 	if gm.saveManager == nil {
@@ -378,23 +1027,31 @@ func (gm *GameManager) GetSaveSlots() (string, error) {
 	return string(jsonData), nil
 }
 
-// handleTimeAdvanced handles time advancement events
-func (gm *GameManager) handleTimeAdvanced() {
-	// Advance game day
-	gm.gameState.AdvanceDay()
+// ListNamedSaves returns the metadata of every named save as JSON, most
+// recently saved first
+func (gm *GameManager) ListNamedSaves() (string, error) {
+	if gm.saveManager == nil {
+		return "[]", nil
+	}
 
-	// Check for rank up
-	if gm.gameState.CheckRankUp() {
-		logging.Infof("Player ranked up to %s!", gamestate.GetRankName(gm.gameState.GetRank()))
-		gm.eventBus.PublishAsync(event.NewBaseEvent("RankUp"))
+	saves, err := gm.saveManager.ListNamedSaves()
+	if err != nil {
+		return "[]", err
 	}
 
-	// Update market prices based on time and season
-	if gm.market != nil {
-		gm.market.UpdatePrices()
+	jsonData, err := json.Marshal(saves)
+	if err != nil {
+		return "[]", err
 	}
 
-	// Event calendar removed - too complex
+	return string(jsonData), nil
+}
+
+// handleTimeAdvanced advances the game a day, as AdvanceTime does
+func (gm *GameManager) handleTimeAdvanced() {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.advanceDaysUnsafe(1)
 }
 
 // handleTradeCompleted handles trade completion events
@@ -425,23 +1082,25 @@ func (gm *GameManager) handleTradeCompleted() {
 }
 
 // handleMarketPriceChanged handles market price change events
-func (gm *GameManager) handleMarketPriceChanged() {
-	// Update market prices for all items
-	if gm.market != nil {
-		for _, itemID := range []string{"apple", "potion", "sword"} {
-			oldPrice := float64(gm.market.GetPrice(itemID))
-			gm.market.UpdatePrice(itemID)
-			newPrice := float64(gm.market.GetPrice(itemID))
+func (gm *GameManager) handleMarketPriceChanged(update *event.PriceUpdatedEvent) {
+	if update.OldPrice <= 0 {
+		return
+	}
+
+	// Log market event
+	logging.InfofSampled("price_change:"+update.ItemID, "Market price change - Item: %s, Old: %d, New: %d, Impact: %.2f%%, Reason: %s",
+		update.ItemID, update.OldPrice, update.NewPrice, update.Impact, update.Reason)
+}
 
-			// Log market event
-			impact := (newPrice - oldPrice) / oldPrice * 100
-			logging.Infof("Market price change - Item: %s, Old: %.2f, New: %.2f, Impact: %.2f%%",
-				itemID, oldPrice, newPrice, impact)
+// forwardPriceUpdates publishes market price changes on the event bus, where
+// the event bridge passes them to Godot, until the subscription is closed
+func (gm *GameManager) forwardPriceUpdates(updates <-chan market.PriceUpdate) {
+	for update := range updates {
+		if update.OldPrice == update.NewPrice {
+			continue
 		}
+		gm.eventBus.PublishAsync(event.NewPriceUpdatedEvent(update.ItemID, update.OldPrice, update.NewPrice, update.Reason))
 	}
-
-	// Notify UI of price changes
-	// This would be sent to Godot
 }
 
 // getMarketItems removed - AI system no longer needed
@@ -472,24 +1131,589 @@ func (gm *GameManager) GetMarketData() map[string]interface{} {
 	}
 }
 
-// GetInventoryData returns current inventory data
-func (gm *GameManager) GetInventoryData() map[string]interface{} {
+// GetItemDetail returns everything the item detail panel needs for one item
+func (gm *GameManager) GetItemDetail(itemID string) map[string]interface{} {
 	gm.mu.RLock()
 	defer gm.mu.RUnlock()
 
-	shopItems := []map[string]interface{}{}
-	warehouseItems := []map[string]interface{}{}
-	shopCapacity := 100
-	warehouseCapacity := 200
-	shopUsed := 0
-	warehouseUsed := 0
+	master, exists := item.GetItemRegistry().GetItem(itemID)
+	if !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Unknown item: %s", itemID),
+		}
+	}
 
-	if gm.inventory != nil {
-		// Update capacity utilization tracking
-		gm.inventory.UpdateCapacity()
+	marketPrice := gm.market.GetPrice(itemID)
+	trend := "stable"
+	if history := gm.market.GetPriceHistory(master.GetBaseID()); history != nil {
+		switch history.GetTrend() {
+		case market.TrendUp:
+			trend = "up"
+		case market.TrendDown:
+			trend = "down"
+		}
+	}
 
-		// Get capacity stats
-		capacityStats := gm.inventory.GetCapacityStats()
+	setPrice := gm.pricing.GetCurrentPrice(itemID)
+
+	return map[string]interface{}{
+		"success":            true,
+		"id":                 master.ID,
+		"name":               master.Name,
+		"category":           string(master.Category),
+		"description":        master.Description,
+		"basePrice":          master.BasePrice,
+		"marketPrice":        marketPrice,
+		"fairValue":          gm.market.GetFairValue(itemID),
+		"priceTrend":         trend,
+		"shopQuantity":       gm.inventory.GetShopQuantity(itemID),
+		"warehouseQuantity":  gm.inventory.GetWarehouseQuantity(itemID),
+		"costBasis":          gm.inventory.GetPurchasePrice(itemID),
+		"setPrice":           setPrice,
+		"expectedSales":      gm.pricing.GetExpectedSales(itemID, setPrice),
+		"supplyLevel":        supplyLevelName(gm.market.State.CurrentSupply),
+		"perishable":         master.Durability > 0,
+		"shelfLifeDays":      master.Durability,
+		"footprint":          master.Footprint,
+		"liquidity":          gm.market.GetLiquidity(itemID),
+		"liquidityRemaining": gm.market.GetRemainingLiquidity(itemID),
+		"baseItemId":         master.GetBaseID(),
+		"quality":            master.GetQuality(),
+		"qualityName":        item.QualityName(master.GetQuality()),
+		"qualityVariants":    gm.qualityVariantsUnsafe(itemID),
+	}
+}
+
+// qualityVariantsUnsafe lists an item's quality tiers with their market
+// prices so the player can pick which variant to stock. Caller must hold gm.mu.
+func (gm *GameManager) qualityVariantsUnsafe(itemID string) []map[string]interface{} {
+	masters := item.GetItemRegistry().GetQualityVariants(itemID)
+	variants := make([]map[string]interface{}, 0, len(masters))
+	for _, variant := range masters {
+		variants = append(variants, map[string]interface{}{
+			"itemId":      variant.ID,
+			"name":        variant.Name,
+			"quality":     variant.GetQuality(),
+			"qualityName": item.QualityName(variant.GetQuality()),
+			"marketPrice": gm.market.GetPrice(variant.ID),
+		})
+	}
+	return variants
+}
+
+// GetTradeSuggestions returns buy, sell, and restocking suggestions ranked by
+// expected gain, drawing on price trends, demand elasticity, and sales velocity
+func (gm *GameManager) GetTradeSuggestions() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	suggestions := make([]map[string]interface{}, 0)
+	addSuggestion := func(action, itemID string, quantity int, gain float64, rationale string) {
+		suggestions = append(suggestions, map[string]interface{}{
+			"action":       action,
+			"itemId":       itemID,
+			"itemName":     gm.pricing.getItemName(itemID),
+			"quantity":     quantity,
+			"expectedGain": int(math.Round(gain)),
+			"rationale":    rationale,
+		})
+	}
+
+	budget := float64(gm.gameState.GetGold()) * advisorBudgetShare
+	warehouseFree := gm.inventory.WarehouseCapacity - gm.inventory.GetWarehouseSpaceUsed()
+	shopFree := gm.inventory.ShopCapacity - gm.inventory.GetShopSpaceUsed()
+
+	for _, marketItem := range gm.market.GetAllItems() {
+		itemID := marketItem.ID
+		history := gm.market.GetPriceHistory(itemID)
+		if history == nil {
+			continue
+		}
+		price := float64(gm.market.GetPrice(itemID))
+		fairValue := float64(gm.market.GetFairValue(itemID))
+		buyPrice := price * (1 + gm.tradeSpread/2)
+		footprint := marketItem.GetFootprint()
+
+		// Buy items whose price is rising, expecting the move to continue
+		if history.GetTrend() == market.TrendUp && buyPrice > 0 {
+			momentum := float64(history.CurrentPrice - history.AveragePrice)
+			projected := math.Max(fairValue, price+momentum)
+			perUnit := projected*(1-gm.tradeSpread/2) - buyPrice
+			quantity := min(min(maxSuggestedQuantity, int(budget/buyPrice)), warehouseFree/footprint)
+			if perUnit > 0 && quantity > 0 {
+				addSuggestion("buy", itemID, quantity, perUnit*float64(quantity),
+					fmt.Sprintf("Buy %s (trending up, cheap now at %.0fg)", marketItem.Name, price))
+			}
+		}
+
+		held := gm.inventory.GetShopQuantity(itemID) + gm.inventory.GetWarehouseQuantity(itemID)
+		if held == 0 {
+			continue
+		}
+		costBasis := float64(gm.inventory.GetPurchasePrice(itemID))
+
+		// Sell stock while the market pays more than it is worth, limited to
+		// what customers will buy at that price
+		if price > fairValue*fairPriceTolerance {
+			perUnit := price*(1-gm.tradeSpread/2) - costBasis
+			quantity := min(held, gm.pricing.GetExpectedSales(itemID, price))
+			if perUnit > 0 && quantity > 0 {
+				addSuggestion("sell", itemID, quantity, perUnit*float64(quantity),
+					fmt.Sprintf("Sell %s (market pays %.0fg, above its %.0fg value)", marketItem.Name, price, fairValue))
+			}
+		}
+
+		// Put fast sellers on the shelves
+		velocity := gm.inventory.GetSalesVelocity(itemID)
+		inWarehouse := gm.inventory.GetWarehouseQuantity(itemID)
+		if velocity > fastSellerVelocity && inWarehouse > 0 {
+			perUnit := gm.pricing.GetCurrentPrice(itemID)*(1-gm.tradeSpread/2) - costBasis
+			quantity := min(inWarehouse, shopFree/footprint)
+			if perUnit > 0 && quantity > 0 {
+				addSuggestion("move_to_shop", itemID, quantity, perUnit*float64(quantity),
+					fmt.Sprintf("Move %s to shop (high velocity, %.1f sold per day)", marketItem.Name, velocity))
+			}
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		gi, gj := suggestions[i]["expectedGain"].(int), suggestions[j]["expectedGain"].(int)
+		if gi != gj {
+			return gi > gj
+		}
+		return suggestions[i]["itemId"].(string) < suggestions[j]["itemId"].(string)
+	})
+
+	return map[string]interface{}{
+		"success":     true,
+		"suggestions": suggestions,
+	}
+}
+
+// GetActiveModifiers explains the price the player is quoted as the product
+// of every multiplier currently applied to its base price. Whatever the
+// market's conditions don't explain (the day's swing, sales pressure,
+// smoothing) is shown as its own step so the product always matches the quote.
+func (gm *GameManager) GetActiveModifiers(itemID string) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	marketItem, exists := gm.market.GetItem(itemID)
+	if !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Item not found",
+		}
+	}
+
+	factors, _ := gm.market.GetPriceFactors(itemID)
+	fairValue := gm.market.GetFairValue(itemID)
+	price := gm.market.GetPrice(itemID)
+	basePrice := float64(marketItem.BasePrice)
+
+	modifiers := make([]map[string]interface{}, 0, len(factors)+1)
+	net := 1.0
+	for _, factor := range factors {
+		net *= factor.Multiplier
+		modifiers = append(modifiers, map[string]interface{}{
+			"name":       factor.Name,
+			"multiplier": factor.Multiplier,
+		})
+	}
+
+	// Prices are capped between 50% and 200% of base; show the cap as its own step
+	if basePrice > 0 {
+		if limited := float64(fairValue) / basePrice; math.Abs(limited-net) > 0.5/basePrice {
+			modifiers = append(modifiers, map[string]interface{}{
+				"name":       "price_limit",
+				"multiplier": limited / net,
+			})
+			net = limited
+		}
+		if quoted := float64(price) / basePrice; math.Abs(quoted-net) > 0.5/basePrice {
+			modifiers = append(modifiers, map[string]interface{}{
+				"name":       "market_swing",
+				"multiplier": quoted / net,
+			})
+			net = quoted
+		}
+	}
+
+	return map[string]interface{}{
+		"success":       true,
+		"itemId":        itemID,
+		"basePrice":     marketItem.BasePrice,
+		"price":         price,
+		"fairValue":     fairValue,
+		"modifiers":     modifiers,
+		"netMultiplier": net,
+		"activeEvents":  gm.market.GetActiveEventNames(),
+	}
+}
+
+// GetDemandForecast projects demand for an item over the next days using
+// known season changes and current market conditions
+func (gm *GameManager) GetDemandForecast(itemID string, days int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if _, exists := gm.market.GetItem(itemID); !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Unknown item: %s", itemID),
+		}
+	}
+	if days < 1 || days > maxForecastDays {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Forecast must cover between 1 and %d days", maxForecastDays),
+		}
+	}
+
+	currentDay := gm.gameState.GetCurrentDay()
+	forecast := make([]map[string]interface{}, 0, days)
+	for i := 1; i <= days; i++ {
+		day := currentDay + i
+		season := gamestate.SeasonForDay(day)
+		outlook := gm.market.GetDemandOutlook(itemID, item.Season(strings.ToUpper(season)))
+
+		forecast = append(forecast, map[string]interface{}{
+			"day":         day,
+			"season":      season,
+			"multiplier":  outlook,
+			"demandLevel": demandLevelForMultiplier(outlook),
+		})
+	}
+
+	return map[string]interface{}{
+		"success":    true,
+		"itemId":     itemID,
+		"forecast":   forecast,
+		"confidence": "Based on season changes and current market conditions; random events are not included",
+	}
+}
+
+// GetPriceForecastBand projects an item's market price over the next days as
+// a central path with a one standard deviation band either side. The path
+// reverts toward fair value through known season changes; the band comes
+// from the item's volatility and the uncertain economic mood, so it widens
+// further ahead. It shows a likely range, not a prediction.
+func (gm *GameManager) GetPriceForecastBand(itemID string, days int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if _, exists := item.GetItemRegistry().GetItem(itemID); !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Unknown item: %s", itemID),
+		}
+	}
+	if days < 1 || days > maxForecastDays {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Forecast must cover between 1 and %d days", maxForecastDays),
+		}
+	}
+
+	currentDay := gm.gameState.GetCurrentDay()
+	seasons := make([]item.Season, days)
+	for i := range seasons {
+		seasons[i] = item.Season(strings.ToUpper(gamestate.SeasonForDay(currentDay + i + 1)))
+	}
+
+	bands := gm.market.ForecastPriceBand(itemID, seasons, gm.moodCycle.GetDrift())
+	forecast := make([]map[string]interface{}, 0, len(bands))
+	for _, band := range bands {
+		forecast = append(forecast, map[string]interface{}{
+			"day":    currentDay + band.DaysAhead,
+			"season": gamestate.SeasonForDay(currentDay + band.DaysAhead),
+			"center": math.Round(band.Center*100) / 100,
+			"lower":  math.Round(band.Lower*100) / 100,
+			"upper":  math.Round(band.Upper*100) / 100,
+		})
+	}
+
+	return map[string]interface{}{
+		"success":      true,
+		"itemId":       itemID,
+		"currentPrice": gm.market.GetPrice(itemID),
+		"fairValue":    gm.market.GetFairValue(itemID),
+		"forecast":     forecast,
+		"confidence":   "Bands cover one standard deviation of expected price swings; random events are not included",
+	}
+}
+
+// GetExpectedDailyProfit projects tomorrow's profit from the shop's current
+// prices: what customers are expected to buy at each set price, less sales
+// tax, the cost of the goods sold, and stock that will spoil before it sells
+func (gm *GameManager) GetExpectedDailyProfit() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	shopStock := gm.inventory.GetShop().GetAll()
+	itemIDs := make([]string, 0, len(shopStock))
+	for itemID := range shopStock {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+
+	expectedUnits := make(map[string]int, len(itemIDs))
+	items := make([]map[string]interface{}, 0, len(itemIDs))
+	revenue, salesTax, costOfGoods := 0, 0, 0
+	for _, itemID := range itemIDs {
+		price := gm.pricing.GetCurrentPrice(itemID)
+		units := min(shopStock[itemID], gm.pricing.GetExpectedSales(itemID, price))
+		expectedUnits[itemID] = units
+		if units == 0 {
+			continue
+		}
+
+		gross := int(price * (1 - gm.tradeSpread/2) * float64(units))
+		breakdown := gm.taxes.Calculate(getRegistryCategory(itemID), tax.TransactionSale, gross)
+		cost := gm.inventory.GetPurchasePrice(itemID) * units
+		revenue += gross
+		salesTax += breakdown.Tax
+		costOfGoods += cost
+
+		items = append(items, map[string]interface{}{
+			"itemId":        itemID,
+			"itemName":      getRegistryItemName(itemID),
+			"price":         price,
+			"expectedUnits": units,
+			"revenue":       gross,
+			"salesTax":      breakdown.Tax,
+			"costOfGoods":   cost,
+			"profit":        breakdown.Net - cost,
+		})
+	}
+
+	// Stock due to spoil is lost unless it is among the units expected to sell
+	spoilage := 0
+	for itemID, quantity := range gm.inventory.GetSpoilingSoon(1) {
+		if unsold := quantity - expectedUnits[itemID]; unsold > 0 {
+			spoilage += unsold * gm.inventory.GetPurchasePrice(itemID)
+		}
+	}
+
+	return map[string]interface{}{
+		"success":        true,
+		"day":            gm.gameState.GetCurrentDay() + 1,
+		"expectedProfit": revenue - salesTax - costOfGoods - spoilage,
+		"breakdown": map[string]interface{}{
+			"revenue":     revenue,
+			"salesTax":    salesTax,
+			"costOfGoods": costOfGoods,
+			"spoilage":    spoilage,
+		},
+		"items": items,
+	}
+}
+
+// competitivePriceBand is how far a shop price may sit from the market and
+// competitor prices and still count as in line with them
+const competitivePriceBand = 0.05
+
+// Positions of a shop price relative to the market and competitors
+const (
+	pricePositionOverpriced  = "overpriced"
+	pricePositionUnderpriced = "underpriced"
+	pricePositionCompetitive = "competitive"
+)
+
+// GetReputationHistory returns the player's reputation at the end of each of
+// the last days finished days, oldest first, with what changed it each day by
+// reputation rule, plus today's changes so far. Zero or fewer days returns
+// every day kept.
+func (gm *GameManager) GetReputationHistory(days int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	history := make([]map[string]interface{}, 0)
+	for _, sample := range gm.gameState.GetReputationHistory(days) {
+		history = append(history, reputationSampleInfo(sample))
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"history": history,
+		"today":   reputationSampleInfo(gm.gameState.GetTodaysReputation()),
+	}
+}
+
+// GetOnboardingChecklist returns the steps a new player is walked through,
+// in order, with which are done and the first one still to do. Steps are
+// completed by playing: buying stock, setting a price, selling at a profit
+// and reaching day 2.
+func (gm *GameManager) GetOnboardingChecklist() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	progress := gm.gameState.GetOnboardingProgress()
+	steps := make([]map[string]interface{}, 0)
+	nextStep := ""
+	for _, step := range gamestate.OnboardingSteps() {
+		day, completed := progress[step.ID]
+		info := map[string]interface{}{
+			"id":          step.ID,
+			"title":       step.Title,
+			"description": step.Description,
+			"completed":   completed,
+		}
+		if completed {
+			info["completedDay"] = day
+		} else if nextStep == "" {
+			nextStep = step.ID
+		}
+		steps = append(steps, info)
+	}
+
+	return map[string]interface{}{
+		"success":   true,
+		"steps":     steps,
+		"completed": len(progress),
+		"total":     len(steps),
+		"finished":  nextStep == "",
+		"nextStep":  nextStep,
+	}
+}
+
+// completeOnboardingStep marks an onboarding step done, telling the UI the
+// first time it is. Safe to call without gm.mu, as the pricing screen does.
+func (gm *GameManager) completeOnboardingStep(id string) {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	gm.completeOnboardingStepUnsafe(id)
+}
+
+// completeOnboardingStepUnsafe marks an onboarding step done.
+// Caller must hold gm.mu.
+func (gm *GameManager) completeOnboardingStepUnsafe(id string) {
+	if gm.gameState.CompleteOnboardingStep(id) {
+		gm.eventBus.PublishAsync(event.NewOnboardingStepCompletedEvent(id))
+	}
+}
+
+// reputationSampleInfo describes a day's reputation for the UI
+func reputationSampleInfo(sample gamestate.ReputationSample) map[string]interface{} {
+	reasons := make(map[string]float64, len(sample.Reasons))
+	for action, delta := range sample.Reasons {
+		reasons[string(action)] = delta
+	}
+	return map[string]interface{}{
+		"day":        sample.Day,
+		"reputation": sample.Reputation,
+		"change":     sample.Change,
+		"reasons":    reasons,
+	}
+}
+
+// GetComparativePricing sets each shop item's price beside the market price
+// and competitor prices, with the recommended price and whether the shop is
+// competitively positioned. An item priced above both references is
+// overpriced; below both, it is leaving money on the table.
+func (gm *GameManager) GetComparativePricing() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	itemIDs := make([]string, 0)
+	for itemID := range gm.inventory.GetShop().GetAll() {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+
+	items := make([]map[string]interface{}, 0, len(itemIDs))
+	overpriced, underpriced := 0, 0
+	for _, itemID := range itemIDs {
+		comparison := gm.pricing.ComparePrices(itemID)
+		price := comparison.CurrentPrice
+		lowest := math.Min(comparison.MarketPrice, comparison.CompetitorPrice)
+		highest := math.Max(comparison.MarketPrice, comparison.CompetitorPrice)
+
+		position := pricePositionCompetitive
+		recommendation := "Priced in line with the market"
+		switch {
+		case price > highest*(1+competitivePriceBand):
+			position = pricePositionOverpriced
+			recommendation = fmt.Sprintf("Priced above the market and competitors; consider %.0fg", comparison.RecommendedPrice)
+			overpriced++
+		case price < lowest*(1-competitivePriceBand):
+			position = pricePositionUnderpriced
+			recommendation = fmt.Sprintf("Priced below the market and competitors; consider %.0fg", comparison.RecommendedPrice)
+			underpriced++
+		}
+
+		items = append(items, map[string]interface{}{
+			"itemId":           itemID,
+			"itemName":         getRegistryItemName(itemID),
+			"yourPrice":        price,
+			"marketPrice":      comparison.MarketPrice,
+			"competitorPrice":  comparison.CompetitorPrice,
+			"recommendedPrice": comparison.RecommendedPrice,
+			"position":         position,
+			"competitive":      position == pricePositionCompetitive,
+			"recommendation":   recommendation,
+		})
+	}
+
+	return map[string]interface{}{
+		"success":     true,
+		"items":       items,
+		"overpriced":  overpriced,
+		"underpriced": underpriced,
+	}
+}
+
+// demandLevelForMultiplier converts a demand multiplier to a demand level name
+func demandLevelForMultiplier(multiplier float64) string {
+	switch {
+	case multiplier >= 1.25:
+		return demandVeryHigh
+	case multiplier >= 1.1:
+		return demandHigh
+	case multiplier > 0.9:
+		return demandNormal
+	case multiplier > 0.75:
+		return demandLow
+	default:
+		return demandVeryLow
+	}
+}
+
+// supplyLevelName converts a market supply level for the UI
+func supplyLevelName(level market.SupplyLevel) string {
+	switch level {
+	case market.SupplyVeryLow:
+		return "scarce"
+	case market.SupplyLow:
+		return "low"
+	case market.SupplyHigh:
+		return "high"
+	case market.SupplyVeryHigh:
+		return "abundant"
+	default:
+		return "normal"
+	}
+}
+
+// GetInventoryData returns current inventory data
+func (gm *GameManager) GetInventoryData() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	shopItems := []map[string]interface{}{}
+	warehouseItems := []map[string]interface{}{}
+	shopCapacity := 100
+	warehouseCapacity := 200
+	shopUsed := 0
+	warehouseUsed := 0
+
+	if gm.inventory != nil {
+		// Update capacity utilization tracking
+		gm.inventory.UpdateCapacity()
+
+		// Get capacity stats
+		capacityStats := gm.inventory.GetCapacityStats()
 		if capacityStats != nil {
 			shopCapacity = capacityStats.CurrentShopCapacity
 			warehouseCapacity = capacityStats.CurrentWarehouseCapacity
@@ -526,9 +1750,26 @@ func (gm *GameManager) GetInventoryData() map[string]interface{} {
 		}
 	}
 
+	inTransit := []map[string]interface{}{}
+	if gm.inventory != nil {
+		for _, shipment := range gm.inventory.GetInTransit() {
+			to := locationShop
+			if shipment.To == inventory.LocationWarehouse {
+				to = locationWarehouse
+			}
+			inTransit = append(inTransit, map[string]interface{}{
+				"id":         shipment.ItemID,
+				"quantity":   shipment.Quantity,
+				"to":         to,
+				"arrivalDay": shipment.ArrivalDay,
+			})
+		}
+	}
+
 	result := map[string]interface{}{
 		locationShop:           shopItems,
 		locationWarehouse:      warehouseItems,
+		"inTransit":            inTransit,
 		"shopCapacity":         shopCapacity,
 		"warehouseCapacity":    warehouseCapacity,
 		"shopUsed":             shopUsed,
@@ -558,278 +1799,3857 @@ func (gm *GameManager) GetInventoryData() map[string]interface{} {
 	return result
 }
 
-// BuyItem handles item purchase
-func (gm *GameManager) BuyItem(itemID string, quantity int, price float64) map[string]interface{} {
+// TransferItem moves stock between the shop and warehouse, paying the
+// per-unit transfer cost. With a transfer delay the stock is in transit
+// until that many days have passed.
+func (gm *GameManager) TransferItem(itemID string, quantity int, from, to string) map[string]interface{} {
 	gm.mu.Lock()
 	defer gm.mu.Unlock()
 
-	totalCost := int(price * float64(quantity))
-	currentGold := gm.gameState.GetGold()
-
-	if currentGold < totalCost {
+	if quantity <= 0 {
 		return map[string]interface{}{
 			"success": false,
-			"message": "Insufficient gold",
+			"message": "Invalid quantity",
 		}
 	}
 
-	// Deduct gold
-	gm.gameState.SetGold(currentGold - totalCost)
-
-	// Add to inventory
-	if gm.inventory != nil {
-		_ = gm.inventory.AddToWarehouseByID(itemID, quantity, int(price))
-	}
-
-	// Track with progression
-	if gm.progression != nil {
-		gm.progression.HandleTradeCompletion(int(price), 0)
+	cost, arrivalDay, err := gm.transferUnsafe(itemID, quantity, from, to)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
 	}
 
 	return map[string]interface{}{
-		"success":        true,
-		"message":        "Item purchased",
-		"gold_remaining": gm.gameState.GetGold(),
+		"success":    true,
+		"message":    "Transfer successful",
+		"cost":       cost,
+		"inTransit":  gm.transferDelayDays > 0,
+		"arrivalDay": arrivalDay,
 	}
 }
 
-// SellItem handles item sale
-func (gm *GameManager) SellItem(itemID string, quantity int, price float64) map[string]interface{} {
-	gm.mu.Lock()
-	defer gm.mu.Unlock()
-
-	// Check if item exists in shop
-	if gm.inventory != nil {
-		shop := gm.inventory.GetShop()
-		if !shop.HasItem(itemID, quantity) {
-			return map[string]interface{}{
-				"success": false,
-				"message": "Insufficient quantity in shop",
-			}
-		}
-
-		// Remove from shop
-		_ = shop.RemoveItem(itemID, quantity)
+// transferUnsafe moves stock between the shop and warehouse with the
+// transfer cost and delay, returning what it cost and the day it arrives.
+// Caller must hold gm.mu.
+func (gm *GameManager) transferUnsafe(itemID string, quantity int, from, to string) (int, int, error) {
+	cost := gm.transferCostPerUnit * quantity
+	gold := gm.gameState.GetGold()
+	if gold < cost {
+		return 0, 0, fmt.Errorf("Insufficient gold: transfer costs %dg", cost)
 	}
 
-	// Add gold
-	totalGain := int(price * float64(quantity))
-	gm.gameState.SetGold(gm.gameState.GetGold() + totalGain)
-
-	// Track with progression
-	if gm.progression != nil {
-		gm.progression.HandleTradeCompletion(0, int(price))
+	arrivalDay := gm.gameState.GetCurrentDay() + gm.transferDelayDays
+	delayed := gm.transferDelayDays > 0
+	var err error
+	switch {
+	case from == locationShop && to == locationWarehouse && delayed:
+		err = gm.inventory.ShipToWarehouse(itemID, quantity, arrivalDay)
+	case from == locationShop && to == locationWarehouse:
+		err = gm.inventory.TransferToWarehouse(itemID, quantity)
+	case from == locationWarehouse && to == locationShop && delayed:
+		err = gm.inventory.ShipToShop(itemID, quantity, arrivalDay)
+	case from == locationWarehouse && to == locationShop:
+		err = gm.inventory.TransferToShop(itemID, quantity)
+	default:
+		err = fmt.Errorf("invalid transfer from %s to %s", from, to)
+	}
+	if err != nil {
+		return 0, 0, err
 	}
 
-	return map[string]interface{}{
-		"success":     true,
-		"message":     "Item sold",
-		"gold_gained": totalGain,
+	if cost > 0 {
+		gm.gameState.SetGoldWithReason(gold-cost, "transfer:"+itemID)
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryExpense, Amount: -cost})
 	}
+	return cost, arrivalDay, nil
 }
 
-// checkGameEvents checks for special game events
-func (gm *GameManager) checkGameEvents() {
-	// Check victory conditions
-	if gm.gameState.GetGold() >= 100000 {
-		gm.triggerVictory()
+// SetTransferFriction sets the gold cost per unit and the delay in days for
+// moving stock between shop and warehouse. Zero for both makes transfers
+// free and instant.
+func (gm *GameManager) SetTransferFriction(costPerUnit, delayDays int) error {
+	if costPerUnit < 0 || delayDays < 0 {
+		return fmt.Errorf("transfer cost and delay cannot be negative: %d, %d", costPerUnit, delayDays)
 	}
 
-	// Check defeat conditions
-	if gm.gameState.GetGold() <= 0 && gm.inventory.IsEmpty() {
-		gm.triggerDefeat()
-	}
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.transferCostPerUnit = costPerUnit
+	gm.transferDelayDays = delayDays
+	return nil
 }
 
-// triggerVictory triggers a victory condition
-func (gm *GameManager) triggerVictory() {
-	gm.eventBus.PublishAsync(event.NewBaseEvent("GameVictory"))
-}
+// mainBranchID identifies the player's original shop among their branches
+const mainBranchID = "main"
 
-// triggerDefeat triggers a defeat condition
-func (gm *GameManager) triggerDefeat() {
-	gm.eventBus.PublishAsync(event.NewBaseEvent("GameDefeat"))
+// OpenBranch opens a new shop at a location, paying the location's opening
+// cost. A branch has its own shop and warehouse and sells at the location's
+// local prices; a specialty category sells for a premium there.
+func (gm *GameManager) OpenBranch(name, location, specialty string) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	site, err := branch.GetLocation(location)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	gold := gm.gameState.GetGold()
+	if gold < site.OpeningCost {
+		return map[string]interface{}{
+			"success":  false,
+			"message":  fmt.Sprintf("Insufficient gold: a branch in %s costs %dg", site.Name, site.OpeningCost),
+			"required": site.OpeningCost,
+		}
+	}
+
+	opened, err := gm.branches.Open(name, location, item.Category(strings.ToUpper(specialty)), gm.gameState.GetCurrentDay())
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	gm.gameState.SetGoldWithReason(gold-site.OpeningCost, "open_branch:"+opened.ID)
+	gm.recordTransaction(ledger.Entry{Type: ledger.EntryExpense, Amount: -site.OpeningCost})
+	logging.Infof("Opened branch %s in %s", opened.Name, site.Name)
+
+	return map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Opened %s in %s", opened.Name, site.Name),
+		"branch":  branchInfo(opened),
+		"cost":    site.OpeningCost,
+	}
 }
 
-// GetQueuedEvents returns all queued events for Godot
-func (gm *GameManager) GetQueuedEvents() (string, error) {
-	events := gm.eventBridge.FlushEvents()
-	if len(events) == 0 {
-		return "[]", nil
+// GetBranches lists the main shop followed by every branch in opening order,
+// with each one's capacity, stock and local price level
+func (gm *GameManager) GetBranches() []map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	branches := []map[string]interface{}{{
+		"id":                mainBranchID,
+		"name":              gm.gameState.GetPlayerName() + "'s Shop",
+		"location":          mainBranchID,
+		"priceModifier":     1.0,
+		"shopCapacity":      gm.inventory.ShopCapacity,
+		"warehouseCapacity": gm.inventory.WarehouseCapacity,
+		"shopItems":         gm.inventory.GetTotalShopItems(),
+		"warehouseItems":    gm.inventory.WarehouseInventory.GetTotalItems(),
+	}}
+	for _, b := range gm.branches.All() {
+		branches = append(branches, branchInfo(b))
 	}
+	return branches
+}
 
-	jsonData, err := json.Marshal(events)
+// GetBranchLocations lists where branches can open and what each costs
+func (gm *GameManager) GetBranchLocations() []map[string]interface{} {
+	all := branch.Locations()
+	locations := make([]map[string]interface{}, 0, len(all))
+	for _, location := range all {
+		locations = append(locations, map[string]interface{}{
+			"id":            location.ID,
+			"name":          location.Name,
+			"priceModifier": location.PriceModifier,
+			"openingCost":   location.OpeningCost,
+		})
+	}
+	return locations
+}
+
+// branchInfo converts a branch for the UI
+func branchInfo(b *branch.Branch) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                b.ID,
+		"name":              b.Name,
+		"location":          b.Location.ID,
+		"locationName":      b.Location.Name,
+		"specialty":         string(b.Specialty),
+		"priceModifier":     b.Location.PriceModifier,
+		"openedDay":         b.OpenedDay,
+		"shopCapacity":      b.Inventory.ShopCapacity,
+		"warehouseCapacity": b.Inventory.WarehouseCapacity,
+		"shopItems":         b.Inventory.GetTotalShopItems(),
+		"warehouseItems":    b.Inventory.WarehouseInventory.GetTotalItems(),
+	}
+}
+
+// branchStockUnsafe returns a branch's inventory, the main shop's for
+// mainBranchID. Caller must hold gm.mu.
+func (gm *GameManager) branchStockUnsafe(branchID string) (*inventory.InventoryManager, error) {
+	if branchID == mainBranchID {
+		return gm.inventory, nil
+	}
+	b, err := gm.branches.Get(branchID)
 	if err != nil {
-		return "[]", err
+		return nil, err
 	}
+	return b.Inventory, nil
+}
 
-	return string(jsonData), nil
+// branchPriceUnsafe returns an item's local market price at a branch.
+// Caller must hold gm.mu.
+func (gm *GameManager) branchPriceUnsafe(branchID, itemID string) (float64, error) {
+	price := float64(gm.market.GetPrice(itemID))
+	if branchID == mainBranchID {
+		return price, nil
+	}
+	b, err := gm.branches.Get(branchID)
+	if err != nil {
+		return 0, err
+	}
+	return price * b.PriceModifier(getRegistryCategory(itemID)), nil
 }
 
-// GetUpcomingEvents returns upcoming events (simplified)
-func (gm *GameManager) GetUpcomingEvents(days int) (string, error) {
-	// Event calendar removed - too complex
-	return "[]", nil
+// GetBranchInventory returns a branch's shop and warehouse stock with each
+// item's local price there
+func (gm *GameManager) GetBranchInventory(branchID string) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	stock, err := gm.branchStockUnsafe(branchID)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	listStock := func(items map[string]int) []map[string]interface{} {
+		itemIDs := make([]string, 0, len(items))
+		for itemID := range items {
+			itemIDs = append(itemIDs, itemID)
+		}
+		sort.Strings(itemIDs)
+
+		entries := make([]map[string]interface{}, 0, len(itemIDs))
+		for _, itemID := range itemIDs {
+			localPrice, _ := gm.branchPriceUnsafe(branchID, itemID)
+			entries = append(entries, map[string]interface{}{
+				"itemId":        itemID,
+				"itemName":      getRegistryItemName(itemID),
+				"quantity":      items[itemID],
+				"purchasePrice": stock.GetPurchasePrice(itemID),
+				"localPrice":    localPrice,
+			})
+		}
+		return entries
+	}
+
+	return map[string]interface{}{
+		"success":   true,
+		"branchId":  branchID,
+		"shop":      listStock(stock.ShopInventory.GetAll()),
+		"warehouse": listStock(stock.WarehouseInventory.GetAll()),
+	}
 }
 
-// GetActiveEvents returns currently active events (simplified)
-func (gm *GameManager) GetActiveEvents() (string, error) {
-	// Event calendar removed - too complex
-	return "[]", nil
+// TransferBetweenBranches ships warehouse stock from one branch to another,
+// mainBranchID included, paying the per-unit transfer cost. Stock arrives in
+// the destination's warehouse straight away.
+func (gm *GameManager) TransferBetweenBranches(itemID string, quantity int, fromBranch, toBranch string) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if quantity <= 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Invalid quantity",
+		}
+	}
+	from, err := gm.branchStockUnsafe(fromBranch)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	to, err := gm.branchStockUnsafe(toBranch)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	cost := gm.transferCostPerUnit * quantity
+	gold := gm.gameState.GetGold()
+	if gold < cost {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Insufficient gold: transfer costs %dg", cost),
+		}
+	}
+	if err := from.TransferWarehouseTo(to, itemID, quantity); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	if cost > 0 {
+		gm.gameState.SetGoldWithReason(gold-cost, "branch_transfer:"+itemID)
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryExpense, Amount: -cost})
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Shipped %dx %s from %s to %s", quantity, getRegistryItemName(itemID), fromBranch, toBranch),
+		"cost":    cost,
+	}
 }
 
-// GetEventEffects returns the combined effects of all active events (simplified)
-func (gm *GameManager) GetEventEffects() (string, error) {
-	// Event calendar removed - too complex
-	return "{}", nil
+// StockBranchShop moves stock from a branch's warehouse onto its shop floor
+func (gm *GameManager) StockBranchShop(branchID, itemID string, quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	stock, err := gm.branchStockUnsafe(branchID)
+	if err == nil {
+		err = stock.TransferToShop(itemID, quantity)
+	}
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Transfer successful",
+	}
 }
 
-// SetEventCallback sets the callback for sending events to Godot
-func (gm *GameManager) SetEventCallback(callback func(string, string)) {
-	gm.eventBridge.SetGodotCallback(callback)
+// SellFromBranch sells a branch's shop stock at its local market price, less
+// the trade spread and sales tax. Branch towns draw on the same market as the
+// main shop, so branch sales use up its daily liquidity too.
+func (gm *GameManager) SellFromBranch(branchID, itemID string, quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if branchID == mainBranchID {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Sell from the main shop with SellItem",
+		}
+	}
+	b, err := gm.branches.Get(branchID)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	if quantity <= 0 || !b.Inventory.GetShop().HasItem(itemID, quantity) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Insufficient quantity in branch shop",
+		}
+	}
+
+	localPrice, _ := gm.branchPriceUnsafe(branchID, itemID)
+	costBasis := b.Inventory.GetPurchasePrice(itemID)
+	if err := b.Inventory.RemoveFromShop(itemID, quantity); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	fill := gm.market.AbsorbSale(itemID, quantity)
+	salePrice := localPrice * (1 - gm.tradeSpread/2) * fill.PriceFactor
+	breakdown := gm.taxes.Apply(getRegistryCategory(itemID), tax.TransactionSale, int(salePrice*float64(quantity)))
+	gm.gameState.SetGoldWithReason(gm.gameState.GetGold()+breakdown.Net, "branch_sale:"+itemID)
+	gm.recordTransaction(ledger.Entry{
+		Type:     ledger.EntrySale,
+		ItemID:   itemID,
+		Quantity: quantity,
+		Amount:   breakdown.Net,
+		Profit:   breakdown.Net - costBasis*quantity,
+		Tax:      breakdown.Tax,
+	})
+
+	return map[string]interface{}{
+		"success":         true,
+		"message":         fmt.Sprintf("Sold %dx %s at %s", quantity, getRegistryItemName(itemID), b.Name),
+		"goldGained":      breakdown.Net,
+		"localPrice":      localPrice,
+		"breakdown":       taxBreakdownMap(breakdown),
+		"excessUnits":     fill.ExcessUnits,
+		"liquidityFactor": fill.PriceFactor,
+	}
 }
 
-// GetPlayerInfo returns detailed player information
-func (gm *GameManager) GetPlayerInfo() map[string]interface{} {
+// BuyItem handles item purchase
+func (gm *GameManager) BuyItem(itemID string, quantity int, price float64) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	return gm.buyItemUnsafe(itemID, quantity, price)
+}
+
+// buyItemUnsafe buys quantity units at price into the warehouse, paying the
+// spread and any tariff. Caller must hold gm.mu.
+func (gm *GameManager) buyItemUnsafe(itemID string, quantity int, price float64) map[string]interface{} {
+	category := getRegistryCategory(itemID)
+	subtotal, breakdown := gm.purchaseCostUnsafe(itemID, quantity, price)
+	totalCost := breakdown.Net
+	currentGold := gm.gameState.GetGold()
+
+	if currentGold < totalCost {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Insufficient gold",
+		}
+	}
+
+	// Add to inventory before charging so a rejected delivery costs nothing
+	if gm.inventory != nil {
+		if err := gm.inventory.AddToWarehouseByID(itemID, quantity, int(price)); err != nil {
+			return map[string]interface{}{
+				"success": false,
+				"message": err.Error(),
+			}
+		}
+	}
+
+	// Deduct gold including any tariff
+	gm.taxes.Apply(category, tax.TransactionPurchase, subtotal)
+	gm.gameState.SetGoldWithReason(currentGold-totalCost, "purchase:"+itemID)
+	gm.recordTransaction(ledger.Entry{
+		Type:     ledger.EntryPurchase,
+		ItemID:   itemID,
+		Quantity: quantity,
+		Amount:   -totalCost,
+		Tax:      breakdown.Tax,
+	})
+
+	// Track with progression
+	if gm.progression != nil {
+		gm.progression.HandleTradeCompletion(int(price), 0)
+	}
+
+	return map[string]interface{}{
+		"success":        true,
+		"message":        "Item purchased",
+		"gold_remaining": gm.gameState.GetGold(),
+		"breakdown":      taxBreakdownMap(breakdown),
+		"quality":        getRegistryQuality(itemID),
+	}
+}
+
+// purchaseCostUnsafe returns the pre-tax subtotal and tax breakdown for
+// buying quantity units at price, including the supplier's share of the trade
+// spread. Caller must hold gm.mu.
+func (gm *GameManager) purchaseCostUnsafe(itemID string, quantity int, price float64) (int, tax.TaxBreakdown) {
+	subtotal := int(price * (1 + gm.tradeSpread/2) * float64(quantity))
+	return subtotal, gm.taxes.Calculate(getRegistryCategory(itemID), tax.TransactionPurchase, subtotal)
+}
+
+// Limits on how much of an item the player can buy
+const (
+	affordLimitGold  = "gold"
+	affordLimitSpace = "space"
+	affordLimitStack = "stack"
+)
+
+// GetAffordability returns the most units of an item the player can buy at
+// its market price, and which limit binds: gold after spread and tariff,
+// warehouse space, or the item's stack limit
+func (gm *GameManager) GetAffordability(itemID string) map[string]interface{} {
 	gm.mu.RLock()
 	defer gm.mu.RUnlock()
 
-	// Get rank bonuses
-	shopBonus, warehouseBonus, priceDiscount := gm.gameState.GetRankBonus()
+	if _, exists := gm.market.GetItem(itemID); !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Unknown item: %s", itemID),
+		}
+	}
+
+	price := float64(gm.market.GetPrice(itemID))
+	gold := gm.gameState.GetGold()
+
+	// Costs only grow with quantity, so search for the most gold covers
+	byGold := 0
+	if unitPrice := price * (1 + gm.tradeSpread/2); unitPrice > 0 {
+		low, high := 0, int(float64(gold+1)/unitPrice)+1
+		for low < high {
+			mid := (low + high + 1) / 2
+			if _, breakdown := gm.purchaseCostUnsafe(itemID, mid, price); breakdown.Net <= gold {
+				low = mid
+			} else {
+				high = mid - 1
+			}
+		}
+		byGold = low
+	}
+
+	limits := map[string]interface{}{
+		affordLimitGold:  byGold,
+		affordLimitSpace: gm.inventory.GetWarehouseRoom(itemID),
+	}
+	maxQuantity, limitedBy := byGold, affordLimitGold
+	if bySpace := limits[affordLimitSpace].(int); bySpace < maxQuantity {
+		maxQuantity, limitedBy = bySpace, affordLimitSpace
+	}
+	if byStack, capped := gm.inventory.GetStackRoom(itemID); capped {
+		limits[affordLimitStack] = byStack
+		if byStack < maxQuantity {
+			maxQuantity, limitedBy = byStack, affordLimitStack
+		}
+	}
 
+	_, breakdown := gm.purchaseCostUnsafe(itemID, maxQuantity, price)
 	return map[string]interface{}{
-		"name":              gm.gameState.GetPlayerName(),
-		"rank":              gm.gameState.GetRank(),
-		"rankName":          gamestate.GetRankName(gm.gameState.GetRank()),
-		"rankProgress":      gm.gameState.GetRankProgress(),
-		"gold":              gm.gameState.GetGold(),
-		"reputation":        gm.gameState.GetReputation(),
-		"currentDay":        gm.gameState.GetCurrentDay(),
-		"currentSeason":     gm.gameState.GetCurrentSeason(),
-		"totalTransactions": gm.gameState.GetTotalTransactions(),
-		"totalProfit":       gm.gameState.GetTotalProfit(),
-		"profitMargin":      gm.gameState.GetProfitMargin(),
-		"shopCapacity":      gm.gameState.GetShopCapacity(),
-		"warehouseCapacity": gm.gameState.GetWarehouseCapacity(),
-		"rankBonuses": map[string]interface{}{
-			"shopCapacityBonus":      shopBonus,
-			"warehouseCapacityBonus": warehouseBonus,
-			"priceDiscount":          priceDiscount,
-		},
+		"success":     true,
+		"itemId":      itemID,
+		"price":       price,
+		"maxQuantity": maxQuantity,
+		"limitedBy":   limitedBy,
+		"limits":      limits,
+		"totalCost":   breakdown.Net,
+		"canAfford":   maxQuantity > 0,
 	}
 }
 
-// UpdatePlayerName updates the player's name
-func (gm *GameManager) UpdatePlayerName(name string) error {
+// SellItem handles item sale
+func (gm *GameManager) SellItem(itemID string, quantity int, price float64) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	// Check if item exists in shop
+	var reputationAction gamestate.ReputationAction
+	reputationScale := 0.0
+	costBasis := 0
+	if gm.inventory != nil {
+		shop := gm.inventory.GetShop()
+		if !shop.HasItem(itemID, quantity) {
+			return map[string]interface{}{
+				"success": false,
+				"message": "Insufficient quantity in shop",
+			}
+		}
+
+		// Judge freshness before the stock leaves the shop
+		freshness, perishable := gm.inventory.GetShopFreshness(itemID)
+		reputationAction, reputationScale = saleReputationAction(freshness, perishable, price, float64(gm.market.GetFairValue(itemID)))
+		costBasis = gm.inventory.GetPurchasePrice(itemID)
+
+		// Remove from shop
+		_ = gm.inventory.RemoveFromShop(itemID, quantity)
+	}
+	reputationDelta := gm.gameState.ApplyReputationAction(reputationAction, reputationScale)
+
+	// Selling past the market's daily liquidity realizes progressively less
+	fill := gm.market.AbsorbSale(itemID, quantity)
+
+	// Add gold after sales tax
+	salePrice := price * (1 - gm.tradeSpread/2) * fill.PriceFactor
+	breakdown := gm.taxes.Apply(getRegistryCategory(itemID), tax.TransactionSale, int(salePrice*float64(quantity)))
+	totalGain := breakdown.Net
+	gm.gameState.SetGoldWithReason(gm.gameState.GetGold()+totalGain, "sale:"+itemID)
+	gm.recordTransaction(ledger.Entry{
+		Type:     ledger.EntrySale,
+		ItemID:   itemID,
+		Quantity: quantity,
+		Amount:   totalGain,
+		Profit:   totalGain - costBasis*quantity,
+		Tax:      breakdown.Tax,
+	})
+
+	// Track with progression
+	if gm.progression != nil {
+		gm.progression.HandleTradeCompletion(0, int(price))
+	}
+
+	return map[string]interface{}{
+		"success":          true,
+		"message":          "Item sold",
+		"gold_gained":      totalGain,
+		"breakdown":        taxBreakdownMap(breakdown),
+		"reputation_delta": reputationDelta,
+		"excess_units":     fill.ExcessUnits,
+		"liquidity_factor": fill.PriceFactor,
+		"quality":          getRegistryQuality(itemID),
+	}
+}
+
+// saleReputationAction returns the reputation action for a sale and how
+// heavily it weighs. Selling near-spoiled perishables counts as a spoiled sale
+// weighted by how close they are to spoiling; otherwise a sale is fair or an
+// overcharge depending on its price against fair value.
+func saleReputationAction(freshness float64, perishable bool, price, fairValue float64) (gamestate.ReputationAction, float64) {
+	if perishable && freshness < nearSpoilageFreshness {
+		return gamestate.ReputationSpoiledSale, 1 - freshness/nearSpoilageFreshness
+	}
+	if fairValue <= 0 {
+		return "", 0
+	}
+	if price <= fairValue*fairPriceTolerance {
+		return gamestate.ReputationFairSale, 1
+	}
+	return gamestate.ReputationOvercharge, 1
+}
+
+// donationValuePerCharity is the fair value of donated goods that earns the
+// charity reputation rule once
+const donationValuePerCharity = 100
+
+// DonateItem gives shop stock away instead of letting it spoil or dumping it
+// at a loss. No gold changes hands: the player earns the charity reputation
+// rule once per donationValuePerCharity gold of fair value given, and the
+// donation is recorded in the ledger. Spoiled stock cannot be donated.
+func (gm *GameManager) DonateItem(itemID string, quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if quantity <= 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Invalid quantity",
+		}
+	}
+	if !gm.inventory.GetShop().HasItem(itemID, quantity) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Insufficient quantity in shop",
+		}
+	}
+	if freshness, perishable := gm.inventory.GetShopFreshness(itemID); perishable && freshness <= 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Spoiled stock cannot be donated",
+		}
+	}
+
+	value := gm.market.GetFairValue(itemID) * quantity
+	costBasis := gm.inventory.GetPurchasePrice(itemID) * quantity
+	if err := gm.inventory.RemoveFromShop(itemID, quantity); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	reputationDelta := gm.gameState.ApplyReputationAction(gamestate.ReputationCharity, float64(value)/donationValuePerCharity)
+	gm.recordTransaction(ledger.Entry{
+		Type:     ledger.EntryDonation,
+		ItemID:   itemID,
+		Quantity: quantity,
+	})
+
+	return map[string]interface{}{
+		"success":         true,
+		"message":         fmt.Sprintf("Donated %dx %s", quantity, getRegistryItemName(itemID)),
+		"value":           value,
+		"costWrittenOff":  costBasis,
+		"reputationDelta": reputationDelta,
+	}
+}
+
+// CreateBundle defines a product made of several items, sold as one at
+// price, and assembles the first one from shop stock. The components are
+// held in the bundle until it is sold or taken apart.
+func (gm *GameManager) CreateBundle(components map[string]int, name string, price int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	for itemID := range components {
+		if _, known := item.GetItemRegistry().GetItem(itemID); !known {
+			return map[string]interface{}{
+				"success": false,
+				"message": fmt.Sprintf("Unknown item: %s", itemID),
+			}
+		}
+	}
+
+	created, err := gm.bundles.Create(name, components, price, gm.gameState.GetCurrentDay())
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	if err := gm.assembleBundleUnsafe(created, 1); err != nil {
+		_ = gm.bundles.Remove(created.ID)
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	assembled, _ := gm.bundles.Get(created.ID)
+	return map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Created %s", assembled.Name),
+		"bundle":  bundleInfo(assembled),
+	}
+}
+
+// AssembleBundle makes quantity more of a bundle from shop stock
+func (gm *GameManager) AssembleBundle(bundleID string, quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	b, err := gm.bundles.Get(bundleID)
+	if err == nil {
+		err = gm.assembleBundleUnsafe(b, quantity)
+	}
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	assembled, _ := gm.bundles.Get(bundleID)
+	return map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Assembled %dx %s", quantity, assembled.Name),
+		"bundle":  bundleInfo(assembled),
+	}
+}
+
+// assembleBundleUnsafe takes a bundle's components out of the shop for
+// quantity bundles, at their average purchase price. Nothing is taken
+// unless the shop holds every component. Caller must hold gm.mu.
+func (gm *GameManager) assembleBundleUnsafe(b bundle.Bundle, quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("invalid quantity: %d", quantity)
+	}
+	shop := gm.inventory.GetShop()
+	for _, part := range b.Components {
+		if needed := part.Quantity * quantity; !shop.HasItem(part.ItemID, needed) {
+			return fmt.Errorf("insufficient %s in shop: %d needed", getRegistryItemName(part.ItemID), needed)
+		}
+	}
+
+	unitCosts := make(map[string]int, len(b.Components))
+	for _, part := range b.Components {
+		cost, ok := gm.inventory.GetAveragePurchasePrice(part.ItemID)
+		if !ok {
+			cost = gm.inventory.GetPurchasePrice(part.ItemID)
+		}
+		unitCosts[part.ItemID] = cost
+		if err := gm.inventory.RemoveFromShop(part.ItemID, part.Quantity*quantity); err != nil {
+			return err
+		}
+	}
+	return gm.bundles.Assemble(b.ID, quantity, unitCosts)
+}
+
+// maxBundlePremium caps a bundle's price as a multiple of what its
+// components are worth on the market. Customers pay a little for the
+// convenience of a kit, not any price asked.
+const maxBundlePremium = 1.25
+
+// SellBundle sells assembled bundles at their price, capped by the market
+// value of their components. Each component is sold into the market for its
+// share of the price: the trade spread, its category's sales tax and the
+// day's liquidity apply as if it were sold alone, and an asking price well
+// over market value costs reputation. The components held in the bundles
+// are consumed.
+func (gm *GameManager) SellBundle(bundleID string, quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	b, err := gm.bundles.Get(bundleID)
+	var costs map[string]int
+	if err == nil {
+		costs, err = gm.bundles.Take(bundleID, quantity)
+	}
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	costBasis := 0
+	for _, cost := range costs {
+		costBasis += cost
+	}
+
+	marketValue, values := gm.bundleMarketValueUnsafe(b)
+	price := b.Price
+	priceCap := int(float64(marketValue) * maxBundlePremium)
+	if marketValue > 0 && price > priceCap {
+		price = priceCap
+	}
+
+	reputationAction, reputationScale := saleReputationAction(1, false, float64(b.Price), float64(marketValue))
+	reputationDelta := gm.gameState.ApplyReputationAction(reputationAction, reputationScale)
+
+	breakdown, excessUnits := gm.sellBundleComponentsUnsafe(b, quantity, price*quantity, values, marketValue)
+	gm.recordBundleSaleUnsafe(bundleID, quantity, price, breakdown, costBasis)
+
+	return map[string]interface{}{
+		"success":          true,
+		"message":          fmt.Sprintf("Sold %dx %s", quantity, b.Name),
+		"revenue":          price * quantity,
+		"marketValue":      marketValue,
+		"priceCap":         priceCap,
+		"gold_gained":      breakdown.Net,
+		"costBasis":        costBasis,
+		"profit":           breakdown.Net - costBasis,
+		"breakdown":        taxBreakdownMap(breakdown),
+		"reputation_delta": reputationDelta,
+		"excess_units":     excessUnits,
+	}
+}
+
+// recordBundleSaleUnsafe pays the player for a bundle sale and writes it to
+// the ledger and progression. Caller must hold gm.mu.
+func (gm *GameManager) recordBundleSaleUnsafe(bundleID string, quantity, price int, breakdown tax.TaxBreakdown, costBasis int) {
+	gm.gameState.SetGoldWithReason(gm.gameState.GetGold()+breakdown.Net, "sale:"+bundleID)
+	gm.recordTransaction(ledger.Entry{
+		Type:     ledger.EntrySale,
+		ItemID:   bundleID,
+		Quantity: quantity,
+		Amount:   breakdown.Net,
+		Profit:   breakdown.Net - costBasis,
+		Tax:      breakdown.Tax,
+	})
+	if gm.progression != nil {
+		gm.progression.HandleTradeCompletion(0, price)
+	}
+}
+
+// bundleMarketValueUnsafe returns what one bundle's components are worth on
+// the market, in total and per item. Caller must hold gm.mu.
+func (gm *GameManager) bundleMarketValueUnsafe(b bundle.Bundle) (int, map[string]int) {
+	total := 0
+	values := make(map[string]int, len(b.Components))
+	for _, part := range b.Components {
+		values[part.ItemID] = gm.market.GetFairValue(part.ItemID) * part.Quantity
+		total += values[part.ItemID]
+	}
+	return total, values
+}
+
+// sellBundleComponentsUnsafe sells the components of quantity bundles into
+// the market for their share of revenue, by market value, and returns the
+// combined tax breakdown and the units sold past the day's liquidity.
+// Caller must hold gm.mu.
+func (gm *GameManager) sellBundleComponentsUnsafe(b bundle.Bundle, quantity, revenue int, values map[string]int, marketValue int) (tax.TaxBreakdown, int) {
+	var breakdown tax.TaxBreakdown
+	excessUnits := 0
+	for _, part := range b.Components {
+		share := 1 / float64(len(b.Components))
+		if marketValue > 0 {
+			share = float64(values[part.ItemID]) / float64(marketValue)
+		}
+		fill := gm.market.AbsorbSale(part.ItemID, part.Quantity*quantity)
+		excessUnits += fill.ExcessUnits
+		gross := float64(revenue) * share * (1 - gm.tradeSpread/2) * fill.PriceFactor
+		partBreakdown := gm.taxes.Apply(getRegistryCategory(part.ItemID), tax.TransactionSale, int(gross))
+		breakdown.Gross += partBreakdown.Gross
+		breakdown.Tax += partBreakdown.Tax
+		breakdown.Net += partBreakdown.Net
+	}
+	if breakdown.Gross > 0 {
+		breakdown.Rate = float64(breakdown.Tax) / float64(breakdown.Gross)
+	}
+	return breakdown, excessUnits
+}
+
+// DisassembleBundle takes assembled bundles apart, returning their
+// components to the shop, or the warehouse once the shop is full, at what
+// they cost
+func (gm *GameManager) DisassembleBundle(bundleID string, quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	b, err := gm.bundles.Get(bundleID)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	costs, err := gm.bundles.Take(bundleID, quantity)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	before := gm.inventory.CreateSnapshot()
+	for _, part := range b.Components {
+		if err := gm.returnComponentUnsafe(part.ItemID, part.Quantity*quantity, costs[part.ItemID]); err != nil {
+			// Put the bundles back together rather than lose or duplicate stock
+			_ = gm.inventory.RestoreFromSnapshot(before)
+			_ = gm.bundles.Restore(bundleID, quantity, costs)
+			return map[string]interface{}{
+				"success": false,
+				"message": fmt.Sprintf("No room for %s: %v", getRegistryItemName(part.ItemID), err),
+			}
+		}
+	}
+
+	taken, _ := gm.bundles.Get(bundleID)
+	return map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Took apart %dx %s", quantity, b.Name),
+		"bundle":  bundleInfo(taken),
+	}
+}
+
+// returnComponentUnsafe puts units of a component taken out of bundles back
+// in the shop, or the warehouse once the shop is full, at exactly their
+// total cost: the units left over by an uneven split cost one gold more.
+// Caller must hold gm.mu.
+func (gm *GameManager) returnComponentUnsafe(itemID string, units, totalCost int) error {
+	unitCost, dearer := totalCost/units, totalCost%units
+	lots := []struct{ units, cost int }{{dearer, unitCost + 1}, {units - dearer, unitCost}}
+	for _, lot := range lots {
+		if lot.units == 0 {
+			continue
+		}
+		if err := gm.inventory.AddToShopByID(itemID, lot.units, lot.cost); err == nil {
+			continue
+		}
+		if err := gm.inventory.AddToWarehouseByID(itemID, lot.units, lot.cost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveBundle deletes a bundle with none assembled
+func (gm *GameManager) RemoveBundle(bundleID string) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if err := gm.bundles.Remove(bundleID); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+	return map[string]interface{}{
+		"success": true,
+		"message": "Bundle removed",
+	}
+}
+
+// GetBundles lists every bundle in creation order with its components,
+// assembled count and markup
+func (gm *GameManager) GetBundles() []map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	bundles := []map[string]interface{}{}
+	for _, b := range gm.bundles.All() {
+		bundles = append(bundles, bundleInfo(b))
+	}
+	return bundles
+}
+
+// bundleInfo describes a bundle for the UI
+func bundleInfo(b bundle.Bundle) map[string]interface{} {
+	components := make([]map[string]interface{}, 0, len(b.Components))
+	for _, part := range b.Components {
+		components = append(components, map[string]interface{}{
+			"itemId":   part.ItemID,
+			"name":     getRegistryItemName(part.ItemID),
+			"quantity": part.Quantity,
+		})
+	}
+	return map[string]interface{}{
+		"id":         b.ID,
+		"name":       b.Name,
+		"price":      b.Price,
+		"assembled":  b.Assembled,
+		"unitCost":   b.UnitCost(),
+		"markup":     b.Markup(),
+		"components": components,
+		"createdDay": b.CreatedDay,
+	}
+}
+
+// startFirstQuestUnsafe starts the tutorial quest every game opens with, so
+// the first purchase and profitable sale count toward it. Caller must hold
+// gm.mu.
+func (gm *GameManager) startFirstQuestUnsafe() {
+	if err := gm.quests.StartQuest(quest.QuestFirstTrade, 1); err != nil {
+		logging.Warnf("First quest not started: %v", err)
+	}
+}
+
+// handleQuestStatusChanged awards reputation for a completed quest: the
+// quest_complete rule per point of the quest's reputation reward
+func (gm *GameManager) handleQuestStatusChanged(q *quest.Quest, _ quest.QuestStatus) {
+	if q.Status != quest.QuestStatusCompleted || q.Rewards == nil || q.Rewards.Reputation == 0 {
+		return
+	}
+	gm.gameState.ApplyReputationAction(gamestate.ReputationQuestComplete, q.Rewards.Reputation)
+}
+
+// SetAutoSell configures automatic selling of an item once its market price
+// exceeds priceThreshold or it has been held for maxDaysHeld days
+func (gm *GameManager) SetAutoSell(itemID string, priceThreshold float64, maxDaysHeld int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if err := gm.inventory.SetAutoSell(itemID, priceThreshold, maxDaysHeld); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Auto-sell configured",
+	}
+}
+
+// processAutoSellUnsafe runs the day's auto-sell rules and credits each sale
+// at the market price after spread, the market's liquidity and sales tax.
+// Caller must hold gm.mu.
+func (gm *GameManager) processAutoSellUnsafe() []inventory.AutoSellAction {
+	actions := gm.inventory.ProcessAutoSell(gm.market.GetPrice)
+	for _, action := range actions {
+		fill := gm.market.AbsorbSale(action.ItemID, action.Quantity)
+		salePrice := float64(action.Price) * (1 - gm.tradeSpread/2) * fill.PriceFactor
+		breakdown := gm.taxes.Apply(getRegistryCategory(action.ItemID), tax.TransactionSale, int(salePrice*float64(action.Quantity)))
+		gm.gameState.SetGoldWithReason(gm.gameState.GetGold()+breakdown.Net, "auto_sell:"+action.ItemID)
+		gm.recordTransaction(ledger.Entry{
+			Type:     ledger.EntrySale,
+			ItemID:   action.ItemID,
+			Quantity: action.Quantity,
+			Amount:   breakdown.Net,
+			Profit:   breakdown.Net - action.PurchasePrice*action.Quantity,
+			Tax:      breakdown.Tax,
+		})
+		logging.Infof("Auto-sold %dx %s for %dg (%s)", action.Quantity, action.ItemID, breakdown.Net, action.Reason)
+	}
+	return actions
+}
+
+// recordTransaction adds a gold change to the ledger, stamped with the
+// current day, counts trades toward the first quest, and in debug mode
+// checks the books still balance and the game state is still sound.
+// Caller must hold gm.mu.
+func (gm *GameManager) recordTransaction(entry ledger.Entry) {
+	entry.Day = gm.gameState.GetCurrentDay()
+	gm.ledger.Record(entry)
+	gm.checkCriticalGoldUnsafe()
+
+	switch {
+	case entry.Type == ledger.EntryPurchase:
+		gm.completeOnboardingStepUnsafe(gamestate.OnboardingFirstPurchase)
+		gm.quests.UpdateObjective(quest.QuestFirstTrade, "buy_item", 1)
+	case entry.Type == ledger.EntrySale && entry.Profit > 0:
+		gm.completeOnboardingStepUnsafe(gamestate.OnboardingProfitableSale)
+		gm.quests.UpdateObjective(quest.QuestFirstTrade, "sell_item", 1)
+	}
+
+	if gm.settings.GetSettings().EnableDebugMode {
+		gm.validateGameStateUnsafe()
+	}
+}
+
+// ValidateGameState checks the invariants that should always hold across
+// systems: gold is not negative and matches the ledger, inventory counts
+// match their tracking entries and fit their capacity, foreign currency
+// holdings are not negative, and quests refer only to known quests. It
+// returns every violation found.
+func (gm *GameManager) ValidateGameState() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return gm.validateGameStateUnsafe()
+}
+
+// validateGameStateUnsafe runs the self-check without locking, logging any
+// violation
+func (gm *GameManager) validateGameStateUnsafe() map[string]interface{} {
+	violations := make([]map[string]interface{}, 0)
+	report := func(system, message string) {
+		logging.Warnf("Game state check failed (%s): %s", system, message)
+		violations = append(violations, map[string]interface{}{
+			"system":  system,
+			"message": message,
+		})
+	}
+
+	gold := gm.gameState.GetGold()
+	if gold < 0 {
+		report("gold", fmt.Sprintf("gold is negative: %d", gold))
+	}
+	if expected := gm.ledger.ExpectedBalance(); expected != gold {
+		report("ledger", fmt.Sprintf("ledger expects %d gold, have %d", expected, gold))
+	}
+	for _, problem := range gm.inventory.CheckConsistency() {
+		report("inventory", problem)
+	}
+	holdings := gm.exchange.Holdings()
+	for _, currency := range gm.exchange.Currencies() {
+		if held := holdings[currency.Code]; held < 0 {
+			report("exchange", fmt.Sprintf("%s holding is negative: %d", currency.Code, held))
+		}
+	}
+	for _, problem := range gm.quests.CheckConsistency() {
+		report("quests", problem)
+	}
+
+	return map[string]interface{}{
+		"success":    true,
+		"valid":      len(violations) == 0,
+		"day":        gm.gameState.GetCurrentDay(),
+		"violations": violations,
+	}
+}
+
+// AuditFinancials recomputes gold from the ledger's opening balance and
+// entries and compares it to the actual balance
+func (gm *GameManager) AuditFinancials() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return gm.auditFinancialsUnsafe()
+}
+
+// auditFinancialsUnsafe runs the audit without locking, logging any discrepancy
+func (gm *GameManager) auditFinancialsUnsafe() map[string]interface{} {
+	expected := gm.ledger.ExpectedBalance()
+	actual := gm.gameState.GetGold()
+	discrepancy := actual - expected
+
+	if discrepancy != 0 {
+		logging.Warnf("Financial audit failed: expected %d gold from ledger, have %d (discrepancy %+d)",
+			expected, actual, discrepancy)
+	}
+
+	return map[string]interface{}{
+		"success":        true,
+		"consistent":     discrepancy == 0,
+		"openingBalance": gm.ledger.OpeningBalance(),
+		"expectedGold":   expected,
+		"actualGold":     actual,
+		"discrepancy":    discrepancy,
+		"entries":        len(gm.ledger.Entries()),
+	}
+}
+
+// ApplyCheat changes game state directly so QA can set up scenarios fast. It
+// only works in debug mode and logs every cheat used. Cheats:
+//
+//	gold <amount>             set gold, recorded in the ledger as an adjustment
+//	maxrank                   jump to the top rank
+//	unlockall                 unlock every progression feature
+//	skipdays <days>           advance time, running each day's start as usual
+//	spawnitem <item> [qty]    put stock in the warehouse at today's market price
+func (gm *GameManager) ApplyCheat(cheat string, args ...string) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if !gm.settings.GetSettings().EnableDebugMode {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Cheats require debug mode",
+		}
+	}
+
+	message, err := gm.applyCheatUnsafe(cheat, args)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	logging.Warnf("Cheat applied: %s %s", cheat, strings.Join(args, " "))
+	return map[string]interface{}{
+		"success": true,
+		"message": message,
+		"cheat":   cheat,
+	}
+}
+
+// applyCheatUnsafe carries out a cheat, returning what it did. Caller must
+// hold gm.mu.
+func (gm *GameManager) applyCheatUnsafe(cheat string, args []string) (string, error) {
+	intArg := func(index int, name string) (int, error) {
+		if index >= len(args) {
+			return 0, fmt.Errorf("%s needs %s", cheat, name)
+		}
+		value, err := strconv.Atoi(args[index])
+		if err != nil {
+			return 0, fmt.Errorf("%s: invalid %s %q", cheat, name, args[index])
+		}
+		return value, nil
+	}
+
+	switch cheat {
+	case "gold":
+		amount, err := intArg(0, "an amount")
+		if err != nil {
+			return "", err
+		}
+		if amount < 0 {
+			return "", fmt.Errorf("gold cannot be negative: %d", amount)
+		}
+		change := amount - gm.gameState.GetGold()
+		gm.gameState.SetGoldWithReason(amount, "cheat")
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryAdjustment, Amount: change})
+		return fmt.Sprintf("Gold set to %dg", amount), nil
+
+	case "maxrank":
+		gm.gameState.SetRank(gamestate.RankMaster)
+		return fmt.Sprintf("Rank set to %s", gamestate.GetRankName(gamestate.RankMaster)), nil
+
+	case "unlockall":
+		unlocked := 0
+		for _, feature := range gm.progression.GetFeatureUnlockSystem().GetAllFeatures() {
+			if gm.progression.UnlockFeature(feature.ID) {
+				unlocked++
+			}
+		}
+		return fmt.Sprintf("Unlocked %d features", unlocked), nil
+
+	case "skipdays":
+		days, err := intArg(0, "a number of days")
+		if err != nil {
+			return "", err
+		}
+		if days < 1 {
+			return "", fmt.Errorf("days to skip must be at least 1: %d", days)
+		}
+		gm.advanceDaysUnsafe(days)
+		return fmt.Sprintf("Skipped to day %d", gm.gameState.GetCurrentDay()), nil
+
+	case "spawnitem":
+		if len(args) == 0 {
+			return "", fmt.Errorf("spawnitem needs an item")
+		}
+		itemID := args[0]
+		if _, exists := gm.market.GetItem(itemID); !exists {
+			return "", fmt.Errorf("unknown item: %s", itemID)
+		}
+		quantity := 1
+		if len(args) > 1 {
+			var err error
+			if quantity, err = intArg(1, "a quantity"); err != nil {
+				return "", err
+			}
+		}
+		if quantity < 1 {
+			return "", fmt.Errorf("quantity must be at least 1: %d", quantity)
+		}
+		if err := gm.inventory.AddToWarehouseByID(itemID, quantity, gm.market.GetPrice(itemID)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Spawned %dx %s in the warehouse", quantity, getRegistryItemName(itemID)), nil
+
+	default:
+		return "", fmt.Errorf("unknown cheat: %s", cheat)
+	}
+}
+
+// GetRecentTransactions returns the newest ledger entries formatted for the
+// HUD ticker. Results are cached until the ledger changes, so it is cheap to
+// call every frame.
+func (gm *GameManager) GetRecentTransactions(limit int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if limit <= 0 || limit > maxTickerEntries {
+		limit = maxTickerEntries
+	}
+
+	revision := gm.ledger.Revision()
+	gm.tickerMu.Lock()
+	defer gm.tickerMu.Unlock()
+
+	if gm.tickerCache == nil || gm.tickerRevision != revision || gm.tickerLimit != limit {
+		entries := gm.ledger.Recent(limit)
+		transactions := make([]map[string]interface{}, 0, len(entries))
+		for _, entry := range entries {
+			transactions = append(transactions, map[string]interface{}{
+				"type":   string(entry.Type),
+				"itemId": entry.ItemID,
+				"amount": entry.Amount,
+				"day":    entry.Day,
+				"text":   formatLedgerEntry(entry),
+			})
+		}
+		gm.tickerCache = transactions
+		gm.tickerRevision = revision
+		gm.tickerLimit = limit
+	}
+
+	return map[string]interface{}{
+		"success":      true,
+		"transactions": gm.tickerCache,
+	}
+}
+
+// formatLedgerEntry describes a ledger entry in one line, e.g.
+// "Sold 3x Iron Sword for 285g (+45g)"
+func formatLedgerEntry(entry ledger.Entry) string {
+	name := entry.ItemID
+	if master, exists := item.GetItemRegistry().GetItem(entry.ItemID); exists {
+		name = master.Name
+	}
+
+	switch entry.Type {
+	case ledger.EntryPurchase:
+		return fmt.Sprintf("Bought %dx %s for %dg", entry.Quantity, name, -entry.Amount)
+	case ledger.EntrySale:
+		return fmt.Sprintf("Sold %dx %s for %dg (%+dg)", entry.Quantity, name, entry.Amount, entry.Profit)
+	case ledger.EntryExpense:
+		return fmt.Sprintf("Paid %dg in expenses", -entry.Amount)
+	case ledger.EntryClaim:
+		return fmt.Sprintf("Insurance paid out %dg", entry.Amount)
+	case ledger.EntryExchange:
+		if entry.Amount < 0 {
+			return fmt.Sprintf("Exchanged %dg for %d %s", -entry.Amount, entry.Quantity, entry.Currency)
+		}
+		return fmt.Sprintf("Exchanged %d %s for %dg", entry.Quantity, entry.Currency, entry.Amount)
+	case ledger.EntryAdjustment:
+		return fmt.Sprintf("Gold adjusted by %+dg", entry.Amount)
+	case ledger.EntryDonation:
+		return fmt.Sprintf("Donated %dx %s", entry.Quantity, name)
+	case ledger.EntrySpoilage:
+		return fmt.Sprintf("Lost %dx %s to spoilage (%+dg)", entry.Quantity, name, entry.Profit)
+	default:
+		return fmt.Sprintf("%s %+dg", entry.Type, entry.Amount)
+	}
+}
+
+// GetProfitLeaderboard ranks traded items by the profit their sales have
+// realized, best first, with units traded and average margin on sales. A
+// positive window covers only the last window days; zero covers the game.
+func (gm *GameManager) GetProfitLeaderboard(window int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if window < 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Window cannot be negative",
+		}
+	}
+
+	type itemProfit struct {
+		itemID      string
+		profit      int
+		revenue     int
+		unitsSold   int
+		unitsBought int
+	}
+
+	firstDay := 0
+	if window > 0 {
+		firstDay = gm.gameState.GetCurrentDay() - window + 1
+	}
+
+	byItem := make(map[string]*itemProfit)
+	for _, entry := range gm.ledger.Entries() {
+		if entry.ItemID == "" || entry.Day < firstDay {
+			continue
+		}
+		stats, exists := byItem[entry.ItemID]
+		if !exists {
+			stats = &itemProfit{itemID: entry.ItemID}
+			byItem[entry.ItemID] = stats
+		}
+		switch entry.Type {
+		case ledger.EntrySale:
+			stats.profit += entry.Profit
+			stats.revenue += entry.Amount
+			stats.unitsSold += entry.Quantity
+		case ledger.EntryPurchase:
+			stats.unitsBought += entry.Quantity
+		}
+	}
+
+	ranked := make([]*itemProfit, 0, len(byItem))
+	for _, stats := range byItem {
+		ranked = append(ranked, stats)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].profit != ranked[j].profit {
+			return ranked[i].profit > ranked[j].profit
+		}
+		return ranked[i].itemID < ranked[j].itemID
+	})
+
+	leaderboard := make([]map[string]interface{}, 0, len(ranked))
+	for i, stats := range ranked {
+		margin := 0.0
+		if stats.revenue > 0 {
+			margin = float64(stats.profit) / float64(stats.revenue)
+		}
+		leaderboard = append(leaderboard, map[string]interface{}{
+			"rank":          i + 1,
+			"itemId":        stats.itemID,
+			"itemName":      getRegistryItemName(stats.itemID),
+			"profit":        stats.profit,
+			"revenue":       stats.revenue,
+			"unitsSold":     stats.unitsSold,
+			"unitsBought":   stats.unitsBought,
+			"unitsTraded":   stats.unitsSold + stats.unitsBought,
+			"averageMargin": margin,
+		})
+	}
+
+	return map[string]interface{}{
+		"success":     true,
+		"window":      window,
+		"leaderboard": leaderboard,
+	}
+}
+
+// Trading journal thresholds
+const (
+	journalSpoilageShare = 0.10 // Share of a category's stock lost to spoilage worth calling out
+	journalDipDiscount   = 0.15 // Price below base at which a day counts as a dip to buy
+)
+
+// GetTradingJournal reviews the player's trading from the ledger and daily
+// prices and returns insights with their supporting numbers: categories where
+// too much stock spoiled before it sold, the average margin on each
+// category's sales, and days an item the player trades dipped well below its
+// base price without the player buying any.
+func (gm *GameManager) GetTradingJournal() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	byCategory, boughtOn := gm.journalStatsUnsafe()
+	categories := make([]item.Category, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i] < categories[j] })
+
+	insights := make([]map[string]interface{}, 0)
+	for _, category := range categories {
+		insights = append(insights, categoryInsights(category, byCategory[category])...)
+	}
+	if missed, missedItems := gm.missedDipsUnsafe(boughtOn); missed > 0 {
+		insights = append(insights, map[string]interface{}{
+			"kind":    "missed_dip",
+			"message": fmt.Sprintf("You missed %d buy-the-dip opportunities", missed),
+			"count":   missed,
+			"itemIds": missedItems,
+		})
+	}
+
+	return map[string]interface{}{
+		"success":  true,
+		"insights": insights,
+	}
+}
+
+// journalCategoryStats is one category's trading as the journal reviews it
+type journalCategoryStats struct {
+	unitsSold    int
+	unitsSpoiled int
+	costSpoiled  int
+	revenue      int
+	profit       int
+	sales        int
+}
+
+// journalStatsUnsafe totals the ledger's sales and spoilage by category and
+// returns them with the days each item was bought. Caller must hold gm.mu.
+func (gm *GameManager) journalStatsUnsafe() (map[item.Category]*journalCategoryStats, map[string]map[int]bool) {
+	byCategory := make(map[item.Category]*journalCategoryStats)
+	boughtOn := make(map[string]map[int]bool)
+	for _, entry := range gm.ledger.Entries() {
+		if entry.ItemID == "" {
+			continue
+		}
+		baseID, _ := item.ParseVariantID(entry.ItemID)
+		category := getRegistryCategory(baseID)
+		stats, exists := byCategory[category]
+		if !exists {
+			stats = &journalCategoryStats{}
+			byCategory[category] = stats
+		}
+		switch entry.Type {
+		case ledger.EntrySale:
+			stats.unitsSold += entry.Quantity
+			stats.revenue += entry.Amount
+			stats.profit += entry.Profit
+			stats.sales++
+		case ledger.EntrySpoilage:
+			stats.unitsSpoiled += entry.Quantity
+			stats.costSpoiled -= entry.Profit
+		case ledger.EntryPurchase:
+			if boughtOn[entry.ItemID] == nil {
+				boughtOn[entry.ItemID] = make(map[int]bool)
+			}
+			boughtOn[entry.ItemID][entry.Day] = true
+		}
+	}
+	return byCategory, boughtOn
+}
+
+// categoryInsights returns a category's spoilage insight, when too much of
+// its stock spoiled, and its margin insight, when it has sales
+func categoryInsights(category item.Category, stats *journalCategoryStats) []map[string]interface{} {
+	insights := make([]map[string]interface{}, 0, 2)
+	name := strings.ToLower(strings.ReplaceAll(string(category), "_", " "))
+	if stocked := stats.unitsSold + stats.unitsSpoiled; stats.unitsSpoiled > 0 {
+		share := float64(stats.unitsSpoiled) / float64(stocked)
+		if share >= journalSpoilageShare {
+			insights = append(insights, map[string]interface{}{
+				"kind":           "spoilage",
+				"category":       string(category),
+				"message":        fmt.Sprintf("You tend to sell %s too late (%.0f%% spoiled)", name, share*100),
+				"unitsSpoiled":   stats.unitsSpoiled,
+				"unitsSold":      stats.unitsSold,
+				"spoiledPercent": share * 100,
+				"costLost":       stats.costSpoiled,
+			})
+		}
+	}
+	if stats.sales > 0 && stats.revenue > 0 {
+		margin := float64(stats.profit) / float64(stats.revenue)
+		insights = append(insights, map[string]interface{}{
+			"kind":          "margin",
+			"category":      string(category),
+			"message":       fmt.Sprintf("Your %s trades average %.0f%% margin", name, margin*100),
+			"trades":        stats.sales,
+			"profit":        stats.profit,
+			"marginPercent": margin * 100,
+		})
+	}
+	return insights
+}
+
+// missedDipsUnsafe counts the days an item the player has bought before
+// dipped well below its base price without the player buying any, and
+// returns the count with the items that dipped. Caller must hold gm.mu.
+func (gm *GameManager) missedDipsUnsafe(boughtOn map[string]map[int]bool) (int, []string) {
+	missed := 0
+	missedItems := make([]string, 0)
+	itemIDs := make([]string, 0, len(boughtOn))
+	for itemID := range boughtOn {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+	for _, itemID := range itemIDs {
+		itemObj, exists := gm.market.GetItem(itemID)
+		if !exists {
+			continue
+		}
+		dipPrice := float64(itemObj.BasePrice) * (1 - journalDipDiscount)
+		missedItem := false
+		for day, record := range gm.dayRecords {
+			if price, ok := record.prices[itemID]; ok && float64(price) <= dipPrice && !boughtOn[itemID][day] {
+				missed++
+				missedItem = true
+			}
+		}
+		if missedItem {
+			missedItems = append(missedItems, itemID)
+		}
+	}
+	return missed, missedItems
+}
+
+// notablePriceMove is the day-over-day price change a daily report calls out
+const notablePriceMove = 0.10
+
+// dayRecord holds what happened on a day that the ledger does not capture
+type dayRecord struct {
+	prices      map[string]int // Each item's market price for the day
+	priceMoves  []priceMove
+	stockLosses int // Purchase cost of stock stolen
+	netWorth    int // Net worth at the day's close, once the day is over
+
+	// What the start of the day brought
+	shipments []inventory.Shipment
+	spoiled   []*inventory.SpoiledItem
+	autoSold  []inventory.AutoSellAction
+}
+
+// priceMove is an item's price change from one day's close to the next day
+type priceMove struct {
+	itemID string
+	from   int
+	to     int
+}
+
+// dayRecordUnsafe returns the record for a day, creating it if needed.
+// Caller must hold gm.mu.
+func (gm *GameManager) dayRecordUnsafe(day int) *dayRecord {
+	record, exists := gm.dayRecords[day]
+	if !exists {
+		record = &dayRecord{}
+		gm.dayRecords[day] = record
+	}
+	return record
+}
+
+// resetDayRecordsUnsafe forgets every day's record and starts today's with
+// the current market prices. Caller must hold gm.mu.
+func (gm *GameManager) resetDayRecordsUnsafe() {
+	gm.dayRecords = make(map[int]*dayRecord)
+	gm.dayRecordUnsafe(gm.gameState.GetCurrentDay()).prices = gm.snapshotPricesUnsafe()
+}
+
+// snapshotPricesUnsafe returns the current market price of every item.
+// Caller must hold gm.mu.
+func (gm *GameManager) snapshotPricesUnsafe() map[string]int {
+	prices := make(map[string]int)
+	for _, marketItem := range gm.market.GetAllItems() {
+		prices[marketItem.ID] = gm.market.GetPrice(marketItem.ID)
+	}
+	return prices
+}
+
+// recordPriceMovesUnsafe records today's prices and their notable moves
+// against the previous day's closing prices. Caller must hold gm.mu.
+func (gm *GameManager) recordPriceMovesUnsafe(closingPrices map[string]int) {
+	record := gm.dayRecordUnsafe(gm.gameState.GetCurrentDay())
+	record.prices = gm.snapshotPricesUnsafe()
+	for itemID, from := range closingPrices {
+		to := record.prices[itemID]
+		if from > 0 && math.Abs(float64(to-from))/float64(from) >= notablePriceMove {
+			record.priceMoves = append(record.priceMoves, priceMove{itemID: itemID, from: from, to: to})
+		}
+	}
+	sort.Slice(record.priceMoves, func(i, j int) bool {
+		return record.priceMoves[i].itemID < record.priceMoves[j].itemID
+	})
+}
+
+// GetDailyReport summarizes a day for the end-of-day screen: opening and
+// closing gold, trading, expenses, taxes, insurance, stolen stock and notable
+// price moves. The current day reports activity so far.
+func (gm *GameManager) GetDailyReport(day int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	currentDay := gm.gameState.GetCurrentDay()
+	if day < 1 || day > currentDay {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("No report for day %d", day),
+		}
+	}
+
+	var unitsSold, revenue, salesProfit, unitsBought, purchaseCost, expenses, taxesPaid, claims int
+	for _, entry := range gm.ledger.Entries() {
+		if entry.Day != day {
+			continue
+		}
+		taxesPaid += entry.Tax
+		switch entry.Type {
+		case ledger.EntrySale:
+			unitsSold += entry.Quantity
+			revenue += entry.Amount
+			salesProfit += entry.Profit
+		case ledger.EntryPurchase:
+			unitsBought += entry.Quantity
+			purchaseCost -= entry.Amount
+		case ledger.EntryExpense:
+			expenses -= entry.Amount
+		case ledger.EntryClaim:
+			claims += entry.Amount
+		}
+	}
+
+	format := gm.numberFormatterUnsafe()
+	stockLosses := 0
+	priceChanges := make([]map[string]interface{}, 0)
+	if record, exists := gm.dayRecords[day]; exists {
+		stockLosses = record.stockLosses
+		for _, move := range record.priceMoves {
+			change := float64(move.to-move.from) / float64(move.from)
+			priceChanges = append(priceChanges, map[string]interface{}{
+				"itemId":          move.itemID,
+				"itemName":        getRegistryItemName(move.itemID),
+				"from":            move.from,
+				"to":              move.to,
+				"change":          change,
+				"formattedChange": format.Percent(change),
+			})
+		}
+	}
+
+	openingGold := gm.ledger.BalanceAtStartOf(day)
+	closingGold := gm.ledger.BalanceAtStartOf(day + 1)
+
+	return map[string]interface{}{
+		"success":         true,
+		"day":             day,
+		"inProgress":      day == currentDay,
+		"openingGold":     openingGold,
+		"closingGold":     closingGold,
+		"netProfit":       closingGold - openingGold,
+		"unitsSold":       unitsSold,
+		"salesRevenue":    revenue,
+		"salesProfit":     salesProfit,
+		"unitsBought":     unitsBought,
+		"purchaseCost":    purchaseCost,
+		"expenses":        expenses,
+		"taxesPaid":       taxesPaid,
+		"insuranceClaims": claims,
+		"stockLosses":     stockLosses,
+		"priceChanges":    priceChanges,
+		"formatted": map[string]interface{}{
+			"openingGold":  format.Gold(openingGold),
+			"closingGold":  format.Gold(closingGold),
+			"netProfit":    format.Gold(closingGold - openingGold),
+			"salesRevenue": format.Gold(revenue),
+			"purchaseCost": format.Gold(purchaseCost),
+			"expenses":     format.Gold(expenses),
+			"taxesPaid":    format.Gold(taxesPaid),
+			"stockLosses":  format.Gold(stockLosses),
+		},
+	}
+}
+
+// ExportAnalyticsCSV exports the game for spreadsheets as three CSV tables:
+// every ledger entry, net worth at the close of each day with today's so
+// far, and each item's market price by day. Days from before a save was
+// loaded are not included in the net worth and price tables.
+func (gm *GameManager) ExportAnalyticsCSV() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	entries := gm.ledger.Entries()
+	ledgerRows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		ledgerRows = append(ledgerRows, []string{
+			strconv.Itoa(entry.Day),
+			string(entry.Type),
+			entry.ItemID,
+			entry.Currency,
+			strconv.Itoa(entry.Quantity),
+			strconv.Itoa(entry.Amount),
+			strconv.Itoa(entry.Profit),
+			strconv.Itoa(entry.Tax),
+			entry.Timestamp.UTC().Format(time.RFC3339),
+		})
+	}
+
+	days := make([]int, 0, len(gm.dayRecords))
+	for day := range gm.dayRecords {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	currentDay := gm.gameState.GetCurrentDay()
+	netWorthRows := make([][]string, 0, len(days))
+	priceRows := make([][]string, 0)
+	for _, day := range days {
+		record := gm.dayRecords[day]
+		netWorth := record.netWorth
+		if day == currentDay {
+			netWorth = gm.netWorthUnsafe()
+		}
+		if day <= currentDay {
+			netWorthRows = append(netWorthRows, []string{strconv.Itoa(day), strconv.Itoa(netWorth)})
+		}
+
+		itemIDs := make([]string, 0, len(record.prices))
+		for itemID := range record.prices {
+			itemIDs = append(itemIDs, itemID)
+		}
+		sort.Strings(itemIDs)
+		for _, itemID := range itemIDs {
+			priceRows = append(priceRows, []string{strconv.Itoa(day), itemID, strconv.Itoa(record.prices[itemID])})
+		}
+	}
+
+	tables := map[string][][]string{
+		"ledger":   ledgerRows,
+		"netWorth": netWorthRows,
+		"prices":   priceRows,
+	}
+	result := map[string]interface{}{
+		"success": true,
+		"rows": map[string]int{
+			"ledger":   len(ledgerRows),
+			"netWorth": len(netWorthRows),
+			"prices":   len(priceRows),
+		},
+	}
+	for name, rows := range tables {
+		data, err := encodeCSV(analyticsCSVHeaders[name], rows)
+		if err != nil {
+			return map[string]interface{}{
+				"success": false,
+				"message": fmt.Sprintf("Failed to export %s: %v", name, err),
+			}
+		}
+		result[name] = data
+	}
+	return result
+}
+
+// analyticsCSVHeaders are the header rows of the analytics export's tables
+var analyticsCSVHeaders = map[string][]string{
+	"ledger":   {"day", "type", "item_id", "currency", "quantity", "amount", "profit", "tax", "timestamp"},
+	"netWorth": {"day", "net_worth"},
+	"prices":   {"day", "item_id", "price"},
+}
+
+// encodeCSV writes a header and rows as CSV
+func encodeCSV(header []string, rows [][]string) (string, error) {
+	var buf strings.Builder
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// heatmapPeriods split each season into thirds for the price heatmap
+var heatmapPeriods = []string{"early", "mid", "late"}
+
+// heatmapCell accumulates the recorded prices falling in one heatmap cell
+type heatmapCell struct {
+	total   int
+	samples int
+	low     int
+	high    int
+}
+
+// GetHeatmap averages an item's recorded daily prices by season and by
+// early, mid or late in the season, so players can spot when it is usually
+// cheap or dear. Prices change once a day, so there is no finer grid. Cells
+// without recorded days are left empty.
+func (gm *GameManager) GetHeatmap(itemID string) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if _, exists := gm.market.GetItem(itemID); !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Unknown item: %s", itemID),
+		}
+	}
+
+	cells, total, samples := gm.heatmapCellsUnsafe(itemID)
+	average := 0.0
+	if samples > 0 {
+		average = float64(total) / float64(samples)
+	}
+	grid := heatmapGrid(cells, average)
+
+	result := map[string]interface{}{
+		"success":     true,
+		"itemId":      itemID,
+		"itemName":    getRegistryItemName(itemID),
+		"periods":     heatmapPeriods,
+		"daysSampled": samples,
+		"cells":       grid,
+	}
+	if samples > 0 {
+		result["averagePrice"] = average
+		result["cheapest"], result["dearest"] = heatmapExtremes(grid)
+	}
+	return result
+}
+
+// heatmapCellsUnsafe sorts an item's recorded daily prices into cells by
+// season and period, and returns them with the total and count of prices.
+// Caller must hold gm.mu.
+func (gm *GameManager) heatmapCellsUnsafe(itemID string) (map[string]map[string]*heatmapCell, int, int) {
+	cells := make(map[string]map[string]*heatmapCell)
+	total, samples := 0, 0
+	for day, record := range gm.dayRecords {
+		price, recorded := record.prices[itemID]
+		if !recorded || price <= 0 {
+			continue
+		}
+
+		season := gamestate.SeasonForDay(day)
+		period := heatmapPeriods[(day-1)%gamestate.DaysPerSeason*len(heatmapPeriods)/gamestate.DaysPerSeason]
+		if cells[season] == nil {
+			cells[season] = make(map[string]*heatmapCell)
+		}
+		cell, exists := cells[season][period]
+		if !exists {
+			cell = &heatmapCell{low: price, high: price}
+			cells[season][period] = cell
+		}
+		cell.total += price
+		cell.samples++
+		cell.low = min(cell.low, price)
+		cell.high = max(cell.high, price)
+
+		total += price
+		samples++
+	}
+	return cells, total, samples
+}
+
+// heatmapGrid lays the cells out season by season, early to late, with each
+// recorded cell's average relative to the overall average price
+func heatmapGrid(cells map[string]map[string]*heatmapCell, overallAverage float64) []map[string]interface{} {
+	grid := make([]map[string]interface{}, 0)
+	for i := 0; i < 4; i++ {
+		season := gamestate.SeasonForDay(1 + i*gamestate.DaysPerSeason)
+		for _, period := range heatmapPeriods {
+			entry := map[string]interface{}{
+				"season":  season,
+				"period":  period,
+				"samples": 0,
+			}
+			if cell, exists := cells[season][period]; exists {
+				average := float64(cell.total) / float64(cell.samples)
+				entry["samples"] = cell.samples
+				entry["averagePrice"] = average
+				entry["lowPrice"] = cell.low
+				entry["highPrice"] = cell.high
+				entry["relative"] = average / overallAverage
+			}
+			grid = append(grid, entry)
+		}
+	}
+	return grid
+}
+
+// heatmapExtremes returns the grid's recorded cells with the lowest and the
+// highest average price, the first of each when tied
+func heatmapExtremes(grid []map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	var cheapest, dearest map[string]interface{}
+	for _, entry := range grid {
+		average, recorded := entry["averagePrice"].(float64)
+		if !recorded {
+			continue
+		}
+		if cheapest == nil || average < cheapest["averagePrice"].(float64) {
+			cheapest = entry
+		}
+		if dearest == nil || average > dearest["averagePrice"].(float64) {
+			dearest = entry
+		}
+	}
+	return cheapest, dearest
+}
+
+// SetDifficultyRamp replaces the stages difficulty climbs through as the
+// player's net worth and rank grow. The current game re-evaluates its stage
+// straight away.
+func (gm *GameManager) SetDifficultyRamp(ramp difficulty.Ramp) error {
+	if err := ramp.Validate(); err != nil {
+		return err
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	gm.difficultyRamp = append(difficulty.Ramp(nil), ramp...)
+	gm.rampStage = 0
+	gm.applyRampStageUnsafe()
+	gm.updateDifficultyRampUnsafe()
+	return nil
+}
+
+// GetDifficultyLevel returns the effective difficulty: the base setting and
+// the ramp stage reached on top of it, with what the next stage needs
+func (gm *GameManager) GetDifficultyLevel() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	stage := gm.difficultyRamp.Stage(gm.rampStage)
+	result := map[string]interface{}{
+		"success":        true,
+		"baseDifficulty": gm.settings.GetSettings().Difficulty,
+		"stage":          gm.rampStage,
+		"stageName":      stage.Name,
+		"stages":         len(gm.difficultyRamp),
+		"volatility":     stage.Volatility,
+		"eventFrequency": stage.Events,
+		"netWorth":       gm.netWorthUnsafe(),
+	}
+	if gm.rampStage+1 < len(gm.difficultyRamp) {
+		next := gm.difficultyRamp[gm.rampStage+1]
+		result["nextStageName"] = next.Name
+		result["nextStageNetWorth"] = next.NetWorth
+		if next.Rank > 0 {
+			result["nextStageRank"] = gamestate.GetRankName(gamestate.PlayerRank(next.Rank))
+		}
+	}
+	return result
+}
+
+// updateDifficultyRampUnsafe climbs to the hardest ramp stage the player's
+// net worth or rank has reached. Stages never drop back within a game, so a
+// bad day does not make the market calmer. Caller must hold gm.mu.
+func (gm *GameManager) updateDifficultyRampUnsafe() {
+	stage := gm.difficultyRamp.StageFor(gm.netWorthUnsafe(), int(gm.gameState.GetRank()))
+	if stage <= gm.rampStage {
+		return
+	}
+
+	gm.rampStage = stage
+	gm.applyRampStageUnsafe()
+
+	reached := gm.difficultyRamp.Stage(stage)
+	logging.Infof("Difficulty ramped up to %s", reached.Name)
+	gm.eventBus.PublishAsync(event.NewDifficultyRampedEvent(stage, reached.Name, reached.Volatility, reached.Events))
+}
+
+// applyRampStageUnsafe sets market volatility and event frequency for the
+// current ramp stage. Caller must hold gm.mu.
+func (gm *GameManager) applyRampStageUnsafe() {
+	stage := gm.difficultyRamp.Stage(gm.rampStage)
+	if err := gm.market.SetVolatilityScale(stage.Volatility); err != nil {
+		logging.Warnf("Ignoring ramp volatility: %v", err)
+	}
+	if err := gm.eventRoller.SetFrequency(stage.Events); err != nil {
+		logging.Warnf("Ignoring ramp event frequency: %v", err)
+	}
+}
+
+// GetMarketComparison compares every item's price now with its recorded
+// price daysAgo days ago, biggest movers first. Items without a price
+// recorded on that day, such as before the game started or after a load,
+// are listed last and marked as not compared.
+func (gm *GameManager) GetMarketComparison(daysAgo int) map[string]interface{} {
+	if daysAgo < 1 {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Days ago must be at least 1: %d", daysAgo),
+		}
+	}
+
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	today := gm.gameState.GetCurrentDay()
+	fromDay := today - daysAgo
+	var thenPrices map[string]int
+	if record, exists := gm.dayRecords[fromDay]; exists {
+		thenPrices = record.prices
+	}
+
+	format := gm.numberFormatterUnsafe()
+	compared := make([]map[string]interface{}, 0)
+	missing := make([]map[string]interface{}, 0)
+	for _, marketItem := range gm.market.GetAllItems() {
+		now := gm.market.GetPrice(marketItem.ID)
+		entry := map[string]interface{}{
+			"itemId":   marketItem.ID,
+			"itemName": getRegistryItemName(marketItem.ID),
+			"now":      now,
+			"compared": false,
+		}
+
+		then, recorded := thenPrices[marketItem.ID]
+		if !recorded || then <= 0 {
+			missing = append(missing, entry)
+			continue
+		}
+
+		change := float64(now-then) / float64(then)
+		entry["compared"] = true
+		entry["then"] = then
+		entry["change"] = change
+		entry["formattedChange"] = format.Percent(change)
+		compared = append(compared, entry)
+	}
+
+	sort.Slice(compared, func(i, j int) bool {
+		a, b := math.Abs(compared[i]["change"].(float64)), math.Abs(compared[j]["change"].(float64))
+		if a != b {
+			return a > b
+		}
+		return compared[i]["itemId"].(string) < compared[j]["itemId"].(string)
+	})
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i]["itemId"].(string) < missing[j]["itemId"].(string)
+	})
+
+	return map[string]interface{}{
+		"success":       true,
+		"daysAgo":       daysAgo,
+		"fromDay":       fromDay,
+		"toDay":         today,
+		"itemsCompared": len(compared),
+		"items":         append(compared, missing...),
+	}
+}
+
+// numberFormatterUnsafe returns a formatter for the language and currency
+// settings. Caller must hold gm.mu.
+func (gm *GameManager) numberFormatterUnsafe() numberFormatter {
+	gameSettings := gm.settings.GetSettings()
+	return newNumberFormatter(gameSettings.Language, gameSettings.Currency)
+}
+
+// SetTradeSpread sets the supplier/customer price spread (0 disables it)
+func (gm *GameManager) SetTradeSpread(spread float64) error {
+	if spread < 0 || spread >= 1 {
+		return fmt.Errorf("trade spread must be between 0 and 1: %.2f", spread)
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.tradeSpread = spread
+	return nil
+}
+
+// GetTradeSpread returns the supplier/customer price spread
+func (gm *GameManager) GetTradeSpread() float64 {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return gm.tradeSpread
+}
+
+// GetTaxRate returns the effective tax rate for a category ("sale" or "purchase")
+func (gm *GameManager) GetTaxRate(category string, txType string) float64 {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	transaction := tax.TransactionSale
+	if txType == "purchase" {
+		transaction = tax.TransactionPurchase
+	}
+	return gm.taxes.GetTaxRate(item.Category(category), transaction)
+}
+
+// GetTaxStatistics returns the total tax paid this game
+func (gm *GameManager) GetTaxStatistics() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	stats := gm.taxes.GetStatistics()
+	return map[string]interface{}{
+		"salesTax": stats["sales_tax"],
+		"tariffs":  stats["tariffs"],
+		"total":    stats["total"],
+	}
+}
+
+// getRegistryCategory returns the registry category for an item ID
+func getRegistryCategory(itemID string) item.Category {
+	if master, ok := item.GetItemRegistry().GetItem(itemID); ok {
+		return master.Category
+	}
+	return ""
+}
+
+// isRegistryPerishable reports whether stock of an item has a shelf life,
+// going by the durability the registry gives its items
+func isRegistryPerishable(itemID string) bool {
+	master, ok := item.GetItemRegistry().GetItem(itemID)
+	return ok && master.Durability > 0
+}
+
+// getRegistryQuality returns the quality tier of an item ID
+func getRegistryQuality(itemID string) int {
+	if master, ok := item.GetItemRegistry().GetItem(itemID); ok {
+		return master.GetQuality()
+	}
+	_, quality := item.ParseVariantID(itemID)
+	return quality
+}
+
+// getRegistryItemName returns the registry name for an item ID, or the ID
+// itself for items outside the registry
+func getRegistryItemName(itemID string) string {
+	if master, ok := item.GetItemRegistry().GetItem(itemID); ok {
+		return master.Name
+	}
+	return itemID
+}
+
+// taxBreakdownMap converts a tax breakdown for the UI
+func taxBreakdownMap(breakdown tax.TaxBreakdown) map[string]interface{} {
+	return map[string]interface{}{
+		"gross":   breakdown.Gross,
+		"tax":     breakdown.Tax,
+		"net":     breakdown.Net,
+		"taxRate": breakdown.Rate,
+	}
+}
+
+// categoryInfo describes how an item category is presented and when the
+// player gains access to it
+type categoryInfo struct {
+	displayName  string
+	icon         string
+	requiredRank gamestate.PlayerRank
+}
+
+// categoryCatalog holds the display data and unlock rank for each category
+var categoryCatalog = map[item.Category]categoryInfo{
+	item.CategoryFruit:     {"Fruit", "res://assets/categories/fruit.png", gamestate.RankApprentice},
+	item.CategoryPotion:    {"Potions", "res://assets/categories/potion.png", gamestate.RankApprentice},
+	item.CategoryWeapon:    {"Weapons", "res://assets/categories/weapon.png", gamestate.RankJourneyman},
+	item.CategoryAccessory: {"Accessories", "res://assets/categories/accessory.png", gamestate.RankJourneyman},
+	item.CategoryMagicBook: {"Magic Books", "res://assets/categories/magic_book.png", gamestate.RankExpert},
+	item.CategoryGem:       {"Gems", "res://assets/categories/gem.png", gamestate.RankMaster},
+}
+
+// GetCategories returns every item category with its display name, icon and
+// whether the player's current rank has unlocked it
+func (gm *GameManager) GetCategories() []map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	rank := gm.gameState.GetRank()
+	categories := make([]map[string]interface{}, 0, len(categoryCatalog))
+	for _, category := range item.AllCategories() {
+		info := categoryCatalog[category]
+		categories = append(categories, map[string]interface{}{
+			"id":           string(category),
+			"displayName":  info.displayName,
+			"icon":         info.icon,
+			"requiredRank": gamestate.GetRankName(info.requiredRank),
+			"unlocked":     rank >= info.requiredRank,
+		})
+	}
+
+	return categories
+}
+
+// SetCriticalGoldWarning configures the low gold warning. A threshold of
+// zero disables it; otherwise the game warns, and optionally pauses, once
+// gold drops below the threshold.
+func (gm *GameManager) SetCriticalGoldWarning(threshold int, autoPause bool) error {
+	if threshold < 0 {
+		return fmt.Errorf("critical gold threshold cannot be negative: %d", threshold)
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	gm.criticalGoldThreshold = threshold
+	gm.pauseOnCriticalGold = autoPause
+	gm.criticalGoldWarned = false
+	return nil
+}
+
+// checkCriticalGoldUnsafe warns the first time gold drops below the critical
+// threshold, pausing the game if configured. The warning re-arms once gold
+// recovers. Caller must hold gm.mu.
+func (gm *GameManager) checkCriticalGoldUnsafe() {
+	if gm.criticalGoldThreshold <= 0 {
+		return
+	}
+
+	gold := gm.gameState.GetGold()
+	if gold >= gm.criticalGoldThreshold {
+		gm.criticalGoldWarned = false
+		return
+	}
+	if gm.criticalGoldWarned {
+		return
+	}
+	gm.criticalGoldWarned = true
+
+	logging.Warnf("Gold is critically low: %d (threshold %d)", gold, gm.criticalGoldThreshold)
+	if gm.pauseOnCriticalGold && !gm.isPaused {
+		gm.isPaused = true
+		gm.eventBus.PublishAsync(event.NewBaseEvent("GamePaused"))
+	}
+	gm.eventBus.PublishAsync(event.NewCriticalGoldWarningEvent(gold, gm.criticalGoldThreshold, gm.pauseOnCriticalGold))
+}
+
+// checkGameEvents checks for special game events
+func (gm *GameManager) checkGameEvents() {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if gm.gameEnded {
+		return
+	}
+
+	// Victory takes every objective of the win conditions at once
+	if gm.gameState.CheckVictoryCondition(gm.completedQuestCount()) {
+		gm.gameEnded = true
+		gm.triggerVictory()
+		return
+	}
+
+	// Defeat comes from ruined reputation, or from running out of gold with
+	// no stock left to sell
+	if gm.gameState.CheckDefeatCondition() &&
+		(gm.gameState.GetReputation() <= gamestate.DefeatRepThreshold || gm.inventory.IsEmpty()) {
+		gm.gameEnded = true
+		gm.triggerDefeat()
+	}
+}
+
+// SetWinConditions sets the gold, reputation, rank and completed quests
+// needed to win, such as to relax them for a sandbox. Zero quests leaves
+// quests out of victory. They apply to the current game and every game
+// after, except that a scenario with its own win conditions uses those.
+func (gm *GameManager) SetWinConditions(gold int, reputation float64, rank gamestate.PlayerRank, quests int) error {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	conditions := gamestate.WinConditions{Gold: gold, Reputation: reputation, Rank: rank, Quests: quests}
+	if err := gm.gameState.SetWinConditions(conditions); err != nil {
+		return err
+	}
+	gm.winConditions = conditions
+	return nil
+}
+
+// GetWinConditions returns what it takes to win the current game, which
+// may be a scenario's own conditions
+func (gm *GameManager) GetWinConditions() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	return winConditionsInfo(gm.gameState.GetWinConditions())
+}
+
+// winConditionsInfo converts win conditions for the UI
+func winConditionsInfo(conditions gamestate.WinConditions) map[string]interface{} {
+	return map[string]interface{}{
+		"gold":       conditions.Gold,
+		"reputation": conditions.Reputation,
+		"rank":       gamestate.GetRankName(conditions.Rank),
+		"quests":     conditions.Quests,
+		"atTimeUp":   conditions.AtTimeUp,
+	}
+}
+
+// GetVictoryProgress returns where the player stands on each objective the
+// current game needs for victory, and whether all of them are met
+func (gm *GameManager) GetVictoryProgress() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	progress := gm.gameState.GetVictoryProgress(gm.completedQuestCount())
+	objectives := make([]map[string]interface{}, 0, len(progress))
+	victory := true
+	for _, objective := range progress {
+		info := map[string]interface{}{
+			"id":      objective.ID,
+			"current": objective.Current,
+			"target":  objective.Target,
+			"met":     objective.Met,
+		}
+		if objective.ID == gamestate.ObjectiveRank {
+			info["currentRank"] = gamestate.GetRankName(gamestate.PlayerRank(objective.Current))
+			info["targetRank"] = gamestate.GetRankName(gamestate.PlayerRank(objective.Target))
+		}
+		objectives = append(objectives, info)
+		victory = victory && objective.Met
+	}
+
+	return map[string]interface{}{
+		"success":    true,
+		"objectives": objectives,
+		"victory":    victory,
+	}
+}
+
+// completedQuestCount returns how many quests the player has completed
+func (gm *GameManager) completedQuestCount() int {
+	return len(gm.quests.GetCompletedQuests())
+}
+
+// triggerVictory triggers a victory condition
+func (gm *GameManager) triggerVictory() {
+	gm.eventBus.PublishAsync(event.NewBaseEvent("GameVictory"))
+}
+
+// triggerDefeat triggers a defeat condition
+func (gm *GameManager) triggerDefeat() {
+	gm.eventBus.PublishAsync(event.NewBaseEvent("GameDefeat"))
+}
+
+// summaryGrades maps net worth, as a multiple of starting gold, to a grade
+var summaryGrades = []struct {
+	minMultiple float64
+	grade       string
+}{
+	{50, "S"},
+	{10, "A"},
+	{3, "B"},
+	{1.5, "C"},
+	{1, "D"},
+}
+
+// netWorthUnsafe returns everything the player owns valued in gold. Caller
+// must hold gm.mu.
+func (gm *GameManager) netWorthUnsafe() int {
+	return gm.assetsUnsafe().total()
+}
+
+// playerAssets is what the player owns, by asset class, valued in gold
+type playerAssets struct {
+	cash       int
+	inventory  map[item.Category]int // Stock held or in transit at what the market considers it worth
+	currencies map[string]int        // Foreign currency holdings at the mid rate
+}
+
+// total returns the combined value of every asset class
+func (a playerAssets) total() int {
+	total := a.cash
+	for _, value := range a.inventory {
+		total += value
+	}
+	for _, value := range a.currencies {
+		total += value
+	}
+	return total
+}
+
+// assetsUnsafe values everything the player owns. Caller must hold gm.mu.
+func (gm *GameManager) assetsUnsafe() playerAssets {
+	assets := playerAssets{
+		cash:       gm.gameState.GetGold(),
+		inventory:  make(map[item.Category]int),
+		currencies: make(map[string]int),
+	}
+
+	addStock := func(itemID string, quantity int) {
+		assets.inventory[getRegistryCategory(itemID)] += gm.market.GetFairValue(itemID) * quantity
+	}
+	for _, stock := range []map[string]int{gm.inventory.ShopInventory.GetAll(), gm.inventory.WarehouseInventory.GetAll()} {
+		for itemID, quantity := range stock {
+			addStock(itemID, quantity)
+		}
+	}
+	for _, shipment := range gm.inventory.GetInTransit() {
+		addStock(shipment.ItemID, shipment.Quantity)
+	}
+	for _, b := range gm.branches.All() {
+		for _, stock := range []map[string]int{b.Inventory.ShopInventory.GetAll(), b.Inventory.WarehouseInventory.GetAll()} {
+			for itemID, quantity := range stock {
+				addStock(itemID, quantity)
+			}
+		}
+	}
+	for _, b := range gm.bundles.All() {
+		for _, part := range b.Components {
+			addStock(part.ItemID, part.Quantity*b.Assembled)
+		}
+	}
+
+	for code, amount := range gm.exchange.Holdings() {
+		if amount == 0 {
+			continue
+		}
+		if rate, err := gm.exchange.GetExchangeRate(code, exchange.Gold); err == nil {
+			assets.currencies[code] = int(math.Round(float64(amount) * rate))
+		}
+	}
+	return assets
+}
+
+// concentrationShare is the share of net worth in one category of stock or
+// one foreign currency above which the portfolio counts as over-concentrated
+const concentrationShare = 0.5
+
+// GetPortfolioAllocation breaks the player's net worth down by asset class:
+// gold on hand, stock by category, and foreign currencies, each as a value
+// and a percentage of net worth. Any single category or currency holding more
+// than half of net worth is flagged as a concentration risk.
+func (gm *GameManager) GetPortfolioAllocation() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	assets := gm.assetsUnsafe()
+	netWorth := assets.total()
+	percentOf := func(value int) float64 {
+		if netWorth <= 0 {
+			return 0
+		}
+		return float64(value) * 100 / float64(netWorth)
+	}
+
+	concentrated := make([]string, 0)
+	holding := func(kind, name string, value int) map[string]interface{} {
+		if netWorth > 0 && float64(value) > float64(netWorth)*concentrationShare {
+			concentrated = append(concentrated, name)
+		}
+		return map[string]interface{}{
+			kind:      name,
+			"value":   value,
+			"percent": percentOf(value),
+		}
+	}
+
+	inventoryValue := 0
+	categories := make([]map[string]interface{}, 0, len(assets.inventory))
+	for _, category := range item.AllCategories() {
+		if value, held := assets.inventory[category]; held {
+			inventoryValue += value
+			categories = append(categories, holding("category", string(category), value))
+		}
+	}
+
+	codes := make([]string, 0, len(assets.currencies))
+	for code := range assets.currencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	currencyValue := 0
+	currencies := make([]map[string]interface{}, 0, len(codes))
+	for _, code := range codes {
+		currencyValue += assets.currencies[code]
+		currencies = append(currencies, holding("currency", code, assets.currencies[code]))
+	}
+
+	return map[string]interface{}{
+		"success":  true,
+		"netWorth": netWorth,
+		"classes": []map[string]interface{}{
+			{"class": "cash", "value": assets.cash, "percent": percentOf(assets.cash)},
+			{"class": "inventory", "value": inventoryValue, "percent": percentOf(inventoryValue), "categories": categories},
+			{"class": "currency", "value": currencyValue, "percent": percentOf(currencyValue), "currencies": currencies},
+		},
+		"concentrated": concentrated,
+	}
+}
+
+// GetGameSummary returns the post-game report shown on victory, defeat or
+// when a timed challenge runs out of days
+func (gm *GameManager) GetGameSummary() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return gm.gameSummaryUnsafe()
+}
+
+// gameSummaryUnsafe builds the post-game report. Caller must hold gm.mu.
+func (gm *GameManager) gameSummaryUnsafe() map[string]interface{} {
+	gold := gm.gameState.GetGold()
+	netWorth := gm.netWorthUnsafe()
+	inventoryValue := netWorth - gold
+
+	// Find the best and worst sales and count trades
+	var bestTrade, worstTrade *ledger.Entry
+	trades := 0
+	entries := gm.ledger.Entries()
+	for i := range entries {
+		entry := &entries[i]
+		switch entry.Type {
+		case ledger.EntryPurchase:
+			trades++
+		case ledger.EntrySale:
+			trades++
+			if bestTrade == nil || entry.Profit > bestTrade.Profit {
+				bestTrade = entry
+			}
+			if entry.Profit < 0 && (worstTrade == nil || entry.Profit < worstTrade.Profit) {
+				worstTrade = entry
+			}
+		}
+	}
+
+	outcome := "in_progress"
+	if gm.gameState.CheckVictoryCondition(gm.completedQuestCount()) {
+		outcome = "victory"
+	} else if gm.gameState.CheckDefeatCondition() {
+		outcome = "defeat"
+	} else if gm.timeUp {
+		outcome = "time_up"
+	}
+
+	grade := "F"
+	if opening := gm.ledger.OpeningBalance(); opening > 0 {
+		multiple := float64(netWorth) / float64(opening)
+		for _, threshold := range summaryGrades {
+			if multiple >= threshold.minMultiple {
+				grade = threshold.grade
+				break
+			}
+		}
+	}
+
+	format := gm.numberFormatterUnsafe()
+	return map[string]interface{}{
+		"success":           true,
+		"outcome":           outcome,
+		"finalGold":         gold,
+		"inventoryValue":    inventoryValue,
+		"netWorth":          netWorth,
+		"startingGold":      gm.ledger.OpeningBalance(),
+		"peakGold":          gm.ledger.PeakBalance(),
+		"daysPlayed":        gm.gameState.GetCurrentDay(),
+		"maxDays":           gm.gameState.GetMaxDays(),
+		"rank":              gamestate.GetRankName(gm.gameState.GetRank()),
+		"reputation":        gm.gameState.GetReputation(),
+		"totalTransactions": trades,
+		"bestTrade":         summaryTrade(bestTrade),
+		"biggestLoss":       summaryTrade(worstTrade),
+		"grade":             grade,
+		"formatted": map[string]interface{}{
+			"finalGold":      format.Gold(gold),
+			"inventoryValue": format.Gold(inventoryValue),
+			"netWorth":       format.Gold(netWorth),
+			"startingGold":   format.Gold(gm.ledger.OpeningBalance()),
+			"peakGold":       format.Gold(gm.ledger.PeakBalance()),
+		},
+	}
+}
+
+// summaryTrade describes a sale for the game summary, or nil if there is none
+func summaryTrade(entry *ledger.Entry) map[string]interface{} {
+	if entry == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"itemId":   entry.ItemID,
+		"quantity": entry.Quantity,
+		"amount":   entry.Amount,
+		"profit":   entry.Profit,
+		"day":      entry.Day,
+		"text":     formatLedgerEntry(*entry),
+	}
+}
+
+// AbandonQuest gives up an active quest so it can be started again later
+func (gm *GameManager) AbandonQuest(questID string) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if err := gm.quests.AbandonQuest(quest.QuestID(questID)); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		}
+	}
+
+	gm.eventBus.PublishAsync(event.NewQuestAbandonedEvent(questID))
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Quest abandoned",
+		"questId": questID,
+	}
+}
+
+// GetQuestRewardsPreview returns what a quest pays out on completion,
+// including reward items by name and the quests it unlocks
+func (gm *GameManager) GetQuestRewardsPreview(questID string) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	q, exists := gm.quests.GetQuest(quest.QuestID(questID))
+	if !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Quest not found",
+		}
+	}
+
+	preview := map[string]interface{}{
+		"success":    true,
+		"questId":    questID,
+		"name":       q.Name,
+		"status":     q.Status.String(),
+		"gold":       0,
+		"experience": 0,
+		"reputation": 0.0,
+		"items":      []map[string]interface{}{},
+		"unlocks":    []map[string]interface{}{},
+	}
+	if q.Rewards == nil {
+		return preview
+	}
+
+	preview["gold"] = q.Rewards.Gold
+	preview["experience"] = q.Rewards.Experience
+	preview["reputation"] = q.Rewards.Reputation
+
+	itemIDs := make([]string, 0, len(q.Rewards.Items))
+	for itemID := range q.Rewards.Items {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+
+	items := make([]map[string]interface{}, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		items = append(items, map[string]interface{}{
+			"itemId":   itemID,
+			"name":     getRegistryItemName(itemID),
+			"quantity": q.Rewards.Items[itemID],
+		})
+	}
+	preview["items"] = items
+
+	unlocks := make([]map[string]interface{}, 0, len(q.Rewards.Unlocks))
+	for _, unlockID := range q.Rewards.Unlocks {
+		name := string(unlockID)
+		if unlocked, ok := gm.quests.GetQuest(unlockID); ok {
+			name = unlocked.Name
+		}
+		unlocks = append(unlocks, map[string]interface{}{
+			"questId": string(unlockID),
+			"name":    name,
+		})
+	}
+	preview["unlocks"] = unlocks
+
+	return preview
+}
+
+// GetQueuedEvents returns all queued events for Godot
+func (gm *GameManager) GetQueuedEvents() (string, error) {
+	events := gm.eventBridge.FlushEvents()
+	if len(events) == 0 {
+		return "[]", nil
+	}
+
+	jsonData, err := json.Marshal(events)
+	if err != nil {
+		return "[]", err
+	}
+
+	return string(jsonData), nil
+}
+
+// GetUpcomingEvents returns upcoming events (simplified)
+func (gm *GameManager) GetUpcomingEvents(days int) (string, error) {
+	// Event calendar removed - too complex
+	return "[]", nil
+}
+
+// GetActiveEvents returns currently active events (simplified)
+func (gm *GameManager) GetActiveEvents() (string, error) {
+	// Event calendar removed - too complex
+	return "[]", nil
+}
+
+// GetEventEffects returns the combined effects of all active events (simplified)
+func (gm *GameManager) GetEventEffects() (string, error) {
+	// Event calendar removed - too complex
+	return "{}", nil
+}
+
+// SetEventCallback sets the callback for sending events to Godot
+func (gm *GameManager) SetEventCallback(callback func(string, string)) {
+	gm.eventBridge.SetGodotCallback(callback)
+}
+
+// GetPlayerInfo returns detailed player information
+func (gm *GameManager) GetPlayerInfo() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	// Get rank bonuses
+	shopBonus, warehouseBonus, priceDiscount := gm.gameState.GetRankBonus()
+	requirements := gm.gameState.GetRankRequirements()
+
+	return map[string]interface{}{
+		"name":              gm.gameState.GetPlayerName(),
+		"rank":              gm.gameState.GetRank(),
+		"rankName":          gamestate.GetRankName(gm.gameState.GetRank()),
+		"rankProgress":      gm.gameState.GetRankProgress(),
+		"gold":              gm.gameState.GetGold(),
+		"reputation":        gm.gameState.GetReputation(),
+		"currentDay":        gm.gameState.GetCurrentDay(),
+		"currentSeason":     gm.gameState.GetCurrentSeason(),
+		"totalTransactions": gm.gameState.GetTotalTransactions(),
+		"totalProfit":       gm.gameState.GetTotalProfit(),
+		"profitMargin":      gm.gameState.GetProfitMargin(),
+		"shopCapacity":      gm.gameState.GetShopCapacity(),
+		"warehouseCapacity": gm.gameState.GetWarehouseCapacity(),
+		"rankBonuses": map[string]interface{}{
+			"shopCapacityBonus":      shopBonus,
+			"warehouseCapacityBonus": warehouseBonus,
+			"priceDiscount":          priceDiscount,
+		},
+		"rankRequirements": map[string]interface{}{
+			"nextRank":             gamestate.GetRankName(requirements.NextRank),
+			"isMaxRank":            requirements.IsMaxRank,
+			"canRankUp":            requirements.CanRankUp,
+			"goldRequired":         requirements.GoldRequired,
+			"goldCurrent":          requirements.GoldCurrent,
+			"reputationRequired":   requirements.ReputationRequired,
+			"reputationCurrent":    requirements.ReputationCurrent,
+			"transactionsRequired": requirements.TransactionsRequired,
+			"transactionsCurrent":  requirements.TransactionsCurrent,
+		},
+	}
+}
+
+// UpdatePlayerName updates the player's name
+func (gm *GameManager) UpdatePlayerName(name string) error {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	return gm.gameState.SetPlayerName(name)
+}
+
+// AdvanceTime advances the game time by specified number of days
+func (gm *GameManager) AdvanceTime(days int) {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.advanceDaysUnsafe(days)
+}
+
+// advanceDaysUnsafe moves the game forward by days days, stopping early if a
+// timed challenge runs out. Caller must hold gm.mu.
+func (gm *GameManager) advanceDaysUnsafe(days int) {
+	for i := 0; i < days && !gm.timeUp; i++ {
+		gm.pushUndoSnapshotUnsafe()
+		gm.dayRecordUnsafe(gm.gameState.GetCurrentDay()).netWorth = gm.netWorthUnsafe()
+		gm.gameState.AdvanceDay()
+
+		// Check for rank up after each day
+		if gm.gameState.CheckRankUp() {
+			logging.Infof("Player ranked up to %s!", gamestate.GetRankName(gm.gameState.GetRank()))
+			gm.eventBus.PublishAsync(event.NewBaseEvent("RankUp"))
+		}
+
+		gm.startDayUnsafe()
+		gm.checkDayCapUnsafe()
+	}
+}
+
+// checkDayCapUnsafe ends a timed challenge once its last day begins: time
+// stops advancing and a TimeUp event carries the final summary. Endless games
+// have no cap. Caller must hold gm.mu.
+func (gm *GameManager) checkDayCapUnsafe() {
+	if gm.timeUp || !gm.gameState.IsTimeUp() {
+		return
+	}
+	gm.timeUp = true
+
+	summary := gm.gameSummaryUnsafe()
+	logging.Infof("Time up on day %d - Net worth: %d, Grade: %s",
+		gm.gameState.GetCurrentDay(), summary["netWorth"], summary["grade"])
+	gm.eventBus.PublishAsync(event.NewTimeUpEvent(gm.gameState.GetCurrentDay(), summary))
+}
+
+// maxUndoDays is how many days UndoLastDay can rewind
+const maxUndoDays = 3
+
+// daySnapshot is the game as it stood at the end of a day, just before the
+// next one began
+type daySnapshot struct {
+	day          int
+	gameState    *gamestate.SaveData
+	inventory    *inventory.InventorySnapshot
+	market       *market.MarketSnapshot
+	exchange     *exchange.Snapshot
+	insurance    *insurance.Snapshot
+	ledger       *ledger.Snapshot
+	branches     *branch.Snapshot
+	bundles      *bundle.Snapshot
+	todaysEvents []dailyEventOutcome
+	rampStage    int
+	timeUp       bool
+	gameEnded    bool
+}
+
+// pushUndoSnapshotUnsafe records the end of the current day so it can be
+// returned to, dropping the oldest snapshot beyond maxUndoDays.
+// Caller must hold gm.mu.
+func (gm *GameManager) pushUndoSnapshotUnsafe() {
+	gm.undoSnapshots = append(gm.undoSnapshots, &daySnapshot{
+		day:          gm.gameState.GetCurrentDay(),
+		gameState:    gm.gameState.CreateSaveData(),
+		inventory:    gm.inventory.CreateSnapshot(),
+		market:       gm.market.CreateSnapshot(),
+		exchange:     gm.exchange.CreateSnapshot(),
+		insurance:    gm.insurance.CreateSnapshot(),
+		ledger:       gm.ledger.CreateSnapshot(),
+		branches:     gm.branches.CreateSnapshot(),
+		bundles:      gm.bundles.CreateSnapshot(),
+		todaysEvents: append([]dailyEventOutcome(nil), gm.todaysEvents...),
+		rampStage:    gm.rampStage,
+		timeUp:       gm.timeUp,
+		gameEnded:    gm.gameEnded,
+	})
+	if len(gm.undoSnapshots) > maxUndoDays {
+		gm.undoSnapshots = gm.undoSnapshots[len(gm.undoSnapshots)-maxUndoDays:]
+	}
+}
+
+// UndoLastDay rewinds to the end of the previous day, undoing the day's
+// trades, the overnight market moves and anything else since. Only the last
+// few days are kept and each undo uses one up. Quest progress, tax totals
+// and the pricing screen's history are not rewound.
+func (gm *GameManager) UndoLastDay() map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if len(gm.undoSnapshots) == 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "There is no earlier day to return to",
+		}
+	}
+
+	snapshot := gm.undoSnapshots[len(gm.undoSnapshots)-1]
+	gm.undoSnapshots = gm.undoSnapshots[:len(gm.undoSnapshots)-1]
+	undoneDay := gm.gameState.GetCurrentDay()
+
+	if err := gm.gameState.LoadSaveData(snapshot.gameState); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Failed to restore day %d: %v", snapshot.day, err),
+		}
+	}
+	if err := gm.inventory.RestoreFromSnapshot(snapshot.inventory); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Failed to restore inventory: %v", err),
+		}
+	}
+	if err := gm.branches.RestoreFromSnapshot(snapshot.branches); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Failed to restore branches: %v", err),
+		}
+	}
+	gm.bundles.RestoreFromSnapshot(snapshot.bundles)
+	gm.market.RestoreFromSnapshot(snapshot.market)
+	gm.exchange.RestoreFromSnapshot(snapshot.exchange)
+	gm.insurance.RestoreFromSnapshot(snapshot.insurance)
+	gm.ledger.RestoreFromSnapshot(snapshot.ledger)
+	gm.quests.SetDay(snapshot.day)
+	gm.todaysEvents = snapshot.todaysEvents
+	gm.timeUp = snapshot.timeUp
+	gm.gameEnded = snapshot.gameEnded
+	gm.rampStage = snapshot.rampStage
+	gm.applyRampStageUnsafe()
+	for day := range gm.dayRecords {
+		if day > snapshot.day {
+			delete(gm.dayRecords, day)
+		}
+	}
+
+	logging.Infof("Undid day %d, back to the end of day %d", undoneDay, snapshot.day)
+	gm.eventBus.PublishAsync(event.NewBaseEvent("DayUndone"))
+
+	return map[string]interface{}{
+		"success":   true,
+		"message":   fmt.Sprintf("Returned to the end of day %d", snapshot.day),
+		"day":       snapshot.day,
+		"undoneDay": undoneDay,
+		"gold":      gm.gameState.GetGold(),
+		"remaining": len(gm.undoSnapshots),
+	}
+}
+
+// startDayUnsafe runs the daily processing for a day that has just begun:
+// the market price step, quest day tracking, shipment deliveries, auto-sell
+// and random events.
+// Caller must hold gm.mu.
+func (gm *GameManager) startDayUnsafe() {
+	closingPrices := gm.snapshotPricesUnsafe()
+	gm.updateDifficultyRampUnsafe()
+	if gm.gameState.GetCurrentDay() >= 2 {
+		gm.completeOnboardingStepUnsafe(gamestate.OnboardingReachDay2)
+	}
+
+	// Take the daily market price step in the day's economic mood and season
+	if gm.market != nil {
+		gm.market.SetMood(gm.moodCycle.IndexAt(gm.gameState.GetCurrentDay()), gm.moodCycle.GetStrength())
+		_ = gm.market.SetSeason(gm.gameState.GetCurrentSeason())
+		gm.market.AdvanceDay(gm.gameState.GetCurrentDay())
+	}
+	gm.exchange.AdvanceDay()
+	gm.quests.SetDay(gm.gameState.GetCurrentDay())
+	shipments := gm.inventory.ReceiveShipments(gm.gameState.GetCurrentDay())
+	gm.inventory.ProcessDailyUpdate()
+	spoiled := gm.recordSpoilageUnsafe(gm.inventory)
+	for _, b := range gm.branches.All() {
+		b.Inventory.ProcessDailyUpdate()
+		spoiled = append(spoiled, gm.recordSpoilageUnsafe(b.Inventory)...)
+	}
+	autoSold := gm.processAutoSellUnsafe()
+	gm.rollDailyEventsUnsafe()
+
+	gm.recordPriceMovesUnsafe(closingPrices)
+	record := gm.dayRecordUnsafe(gm.gameState.GetCurrentDay())
+	record.shipments = shipments
+	record.spoiled = spoiled
+	record.autoSold = autoSold
+}
+
+// recordSpoilageUnsafe takes the lots that spoiled in stock since the last
+// report, writes them off in the ledger and tells the UI what rotted and what
+// it cost. Draining the report keeps it from growing all game. Returns the
+// lots. Caller must hold gm.mu.
+func (gm *GameManager) recordSpoilageUnsafe(stock *inventory.InventoryManager) []*inventory.SpoiledItem {
+	spoiled := stock.GetAndClearSpoilageReport()
+	for _, lot := range spoiled {
+		gm.recordTransaction(ledger.Entry{
+			Type:     ledger.EntrySpoilage,
+			ItemID:   lot.Item.ID,
+			Quantity: lot.Quantity,
+			Profit:   -lot.LostValue,
+		})
+		gm.eventBus.PublishAsync(event.NewItemSpoiledEvent(lot.Item.ID, lot.Quantity, spoiledLocation(lot), lot.LostValue))
+	}
+	return spoiled
+}
+
+// spoiledLocation names where a spoiled lot was kept
+func spoiledLocation(lot *inventory.SpoiledItem) string {
+	if lot.Location == inventory.LocationWarehouse {
+		return locationWarehouse
+	}
+	return locationShop
+}
+
+// maxBatchDays caps how many days one batch advance covers
+const maxBatchDays = gamestate.DaysPerSeason
+
+// BatchAdvanceDays advances the game days days, running each day's
+// processing in turn, and returns a digest of what each day brought: gold
+// gained or lost, daily events, stock that arrived, spoiled or sold itself,
+// and notable price moves. A timed challenge stops at its last day.
+func (gm *GameManager) BatchAdvanceDays(days int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if days < 1 || days > maxBatchDays {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Can advance between 1 and %d days at once", maxBatchDays),
+		}
+	}
+
+	digests := make([]map[string]interface{}, 0, days)
+	for i := 0; i < days && !gm.timeUp; i++ {
+		goldBefore := gm.gameState.GetGold()
+		gm.advanceDaysUnsafe(1)
+		digests = append(digests, gm.dayDigestUnsafe(gm.gameState.GetCurrentDay(), gm.gameState.GetGold()-goldBefore))
+	}
+
+	return map[string]interface{}{
+		"success":      true,
+		"daysAdvanced": len(digests),
+		"day":          gm.gameState.GetCurrentDay(),
+		"gold":         gm.gameState.GetGold(),
+		"digests":      digests,
+	}
+}
+
+// dayDigestUnsafe recaps the start of a day for BatchAdvanceDays, with a
+// readable highlight per happening. Caller must hold gm.mu.
+func (gm *GameManager) dayDigestUnsafe(day, goldChange int) map[string]interface{} {
+	record := gm.dayRecordUnsafe(day)
+	highlights := make([]string, 0)
+
+	dailyEvents := make([]map[string]interface{}, 0, len(gm.todaysEvents))
+	for _, outcome := range gm.todaysEvents {
+		dailyEvents = append(dailyEvents, dailyEventInfo(outcome))
+		highlights = append(highlights, describeDailyEvent(outcome))
+	}
+
+	shipments := make([]map[string]interface{}, 0, len(record.shipments))
+	for _, shipment := range record.shipments {
+		to := locationShop
+		if shipment.To == inventory.LocationWarehouse {
+			to = locationWarehouse
+		}
+		shipments = append(shipments, map[string]interface{}{
+			"itemId":   shipment.ItemID,
+			"quantity": shipment.Quantity,
+			"to":       to,
+		})
+		highlights = append(highlights, fmt.Sprintf("%dx %s arrived in the %s", shipment.Quantity, getRegistryItemName(shipment.ItemID), to))
+	}
+
+	sort.Slice(record.spoiled, func(i, j int) bool { return record.spoiled[i].Item.ID < record.spoiled[j].Item.ID })
+	spoiled := make([]map[string]interface{}, 0, len(record.spoiled))
+	for _, lot := range record.spoiled {
+		location := spoiledLocation(lot)
+		spoiled = append(spoiled, map[string]interface{}{
+			"itemId":   lot.Item.ID,
+			"quantity": lot.Quantity,
+			"location": location,
+			"goldLost": lot.LostValue,
+		})
+		highlights = append(highlights, fmt.Sprintf("%dx %s spoiled in the %s", lot.Quantity, getRegistryItemName(lot.Item.ID), location))
+	}
+
+	autoSold := make([]map[string]interface{}, 0, len(record.autoSold))
+	for _, action := range record.autoSold {
+		autoSold = append(autoSold, map[string]interface{}{
+			"itemId":   action.ItemID,
+			"quantity": action.Quantity,
+			"price":    action.Price,
+			"reason":   action.Reason,
+		})
+		highlights = append(highlights, fmt.Sprintf("Auto-sold %dx %s at %dg", action.Quantity, getRegistryItemName(action.ItemID), action.Price))
+	}
+
+	priceChanges := make([]map[string]interface{}, 0, len(record.priceMoves))
+	for _, move := range record.priceMoves {
+		change := float64(move.to-move.from) / float64(move.from)
+		priceChanges = append(priceChanges, map[string]interface{}{
+			"itemId": move.itemID,
+			"from":   move.from,
+			"to":     move.to,
+			"change": change,
+		})
+		highlights = append(highlights, fmt.Sprintf("%s moved from %dg to %dg", getRegistryItemName(move.itemID), move.from, move.to))
+	}
+
+	return map[string]interface{}{
+		"day":          day,
+		"season":       gamestate.SeasonForDay(day),
+		"goldChange":   goldChange,
+		"events":       dailyEvents,
+		"shipments":    shipments,
+		"spoiled":      spoiled,
+		"autoSold":     autoSold,
+		"priceChanges": priceChanges,
+		"highlights":   highlights,
+	}
+}
+
+// UpgradeInventoryCapacity upgrades shop or warehouse capacity
+func (gm *GameManager) UpgradeInventoryCapacity(location string, amount int, cost int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	// Check if player has enough gold
+	currentGold := gm.gameState.GetGold()
+	if currentGold < cost {
+		return map[string]interface{}{
+			"success":   false,
+			"message":   "Insufficient gold for upgrade",
+			"required":  cost,
+			"available": currentGold,
+		}
+	}
+
+	// Determine location
+	var invLocation inventory.InventoryLocation
+	switch location {
+	case locationShop:
+		invLocation = inventory.LocationShop
+	case locationWarehouse:
+		invLocation = inventory.LocationWarehouse
+	default:
+		return map[string]interface{}{
+			"success": false,
+			"message": "Invalid location. Must be 'shop' or 'warehouse'",
+		}
+	}
+
+	// Perform upgrade
+	if gm.inventory != nil {
+		err := gm.inventory.UpgradeCapacity(invLocation, amount)
+		if err != nil {
+			return map[string]interface{}{
+				"success": false,
+				"message": err.Error(),
+			}
+		}
+
+		// Deduct gold
+		gm.gameState.SetGoldWithReason(currentGold-cost, "capacity_upgrade")
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryExpense, Amount: -cost})
+
+		// Get new capacity stats
+		stats := gm.inventory.GetCapacityStats()
+		newCapacity := 0
+		if invLocation == inventory.LocationShop && stats != nil {
+			newCapacity = stats.CurrentShopCapacity
+		} else if stats != nil {
+			newCapacity = stats.CurrentWarehouseCapacity
+		}
+
+		// Metrics removed
+
+		return map[string]interface{}{
+			"success":       true,
+			"message":       fmt.Sprintf("Successfully upgraded %s capacity", location),
+			"newCapacity":   newCapacity,
+			"goldRemaining": gm.gameState.GetGold(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": false,
+		"message": "Inventory system not initialized",
+	}
+}
+
+// OptimizeInventory fills free shop space from the warehouse by sales
+// velocity and margin, paying the transfer cost and delay as for any other
+// move. Moves the player cannot afford in full are cut down to what they can.
+func (gm *GameManager) OptimizeInventory() map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if gm.inventory == nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Inventory system not initialized",
+		}
+	}
+
+	moved, cost := 0, 0
+	for _, move := range gm.inventory.PlanPlacement(gm.shopPriceOf) {
+		quantity := move.Quantity
+		if gm.transferCostPerUnit > 0 {
+			quantity = min(quantity, gm.gameState.GetGold()/gm.transferCostPerUnit)
+		}
+		if quantity <= 0 {
+			continue
+		}
+		moveCost, _, err := gm.transferUnsafe(move.ItemID, quantity, locationWarehouse, locationShop)
+		if err != nil {
+			continue
+		}
+		moved += quantity
+		cost += moveCost
+	}
+
+	return map[string]interface{}{
+		"success":   true,
+		"message":   "Inventory optimized successfully",
+		"moved":     moved,
+		"cost":      cost,
+		"inTransit": moved > 0 && gm.transferDelayDays > 0,
+	}
+}
+
+// Shop layout placements and the moves that reach them
+const (
+	placementShop      = "shop"
+	placementWarehouse = "warehouse"
+
+	layoutMoveToShop = "move_to_shop"
+	layoutRelegate   = "relegate"
+	layoutKeep       = "keep"
+)
+
+// GetShopLayoutSuggestions ranks stocked items by sales velocity × margin and
+// recommends the top slots items for prime shelf space, relegating the rest
+// to the warehouse. Items that don't sell, or sell at a loss, never earn a slot.
+func (gm *GameManager) GetShopLayoutSuggestions(slots int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if slots <= 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Number of shop slots must be positive",
+		}
+	}
+
+	ranking := make([]map[string]interface{}, 0)
+	for i, score := range gm.inventory.RankPlacement(gm.shopPriceOf) {
+		placement, action := placementWarehouse, layoutKeep
+		if i < slots && score.Score > 0 {
+			placement = placementShop
+		}
+		switch {
+		case placement == placementShop && score.WarehouseQuantity > 0:
+			action = layoutMoveToShop
+		case placement == placementWarehouse && score.ShopQuantity > 0:
+			action = layoutRelegate
+		}
+
+		ranking = append(ranking, map[string]interface{}{
+			"rank":              i + 1,
+			"itemId":            score.ItemID,
+			"itemName":          getRegistryItemName(score.ItemID),
+			"velocity":          score.Velocity,
+			"margin":            score.Margin,
+			"score":             score.Score,
+			"shopQuantity":      score.ShopQuantity,
+			"warehouseQuantity": score.WarehouseQuantity,
+			"placement":         placement,
+			"action":            action,
+		})
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"slots":   slots,
+		"ranking": ranking,
+	}
+}
+
+// shopPriceOf returns the price the shop currently sells an item at
+func (gm *GameManager) shopPriceOf(itemID string) int {
+	return int(math.Round(gm.pricing.GetCurrentPrice(itemID)))
+}
+
+// GetCapacityRecommendations returns capacity upgrade recommendations
+func (gm *GameManager) GetCapacityRecommendations() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if gm.inventory == nil {
+		return map[string]interface{}{
+			"hasRecommendations": false,
+		}
+	}
+
+	stats := gm.inventory.GetCapacityStats()
+	if stats == nil {
+		return map[string]interface{}{
+			"hasRecommendations": false,
+		}
+	}
+
+	recommendations := []map[string]interface{}{}
+
+	// Check if shop needs upgrade
+	if stats.PeakShopUtilization > 0.8 {
+		upgradeAmount := stats.RecommendedShopCapacity - stats.CurrentShopCapacity
+		if upgradeAmount > 0 {
+			recommendations = append(recommendations, map[string]interface{}{
+				"location":        locationShop,
+				"currentCapacity": stats.CurrentShopCapacity,
+				"recommended":     stats.RecommendedShopCapacity,
+				"upgradeAmount":   upgradeAmount,
+				"reason":          fmt.Sprintf("Peak utilization reached %.1f%%", stats.PeakShopUtilization*100),
+				"estimatedCost":   upgradeAmount * 50, // 50 gold per capacity unit
+			})
+		}
+	}
+
+	// Check if warehouse needs upgrade
+	if stats.PeakWarehouseUtilization > 0.8 {
+		upgradeAmount := stats.RecommendedWarehouseCapacity - stats.CurrentWarehouseCapacity
+		if upgradeAmount > 0 {
+			recommendations = append(recommendations, map[string]interface{}{
+				"location":        locationWarehouse,
+				"currentCapacity": stats.CurrentWarehouseCapacity,
+				"recommended":     stats.RecommendedWarehouseCapacity,
+				"upgradeAmount":   upgradeAmount,
+				"reason":          fmt.Sprintf("Peak utilization reached %.1f%%", stats.PeakWarehouseUtilization*100),
+				"estimatedCost":   upgradeAmount * 20, // 20 gold per capacity unit
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"hasRecommendations": len(recommendations) > 0,
+		"recommendations":    recommendations,
+		"statistics": map[string]interface{}{
+			"avgShopUtilization":       stats.AvgShopUtilization,
+			"avgWarehouseUtilization":  stats.AvgWarehouseUtilization,
+			"peakShopUtilization":      stats.PeakShopUtilization,
+			"peakWarehouseUtilization": stats.PeakWarehouseUtilization,
+		},
+	}
+}
+
+// capacityTrendWindow is how many recent days of trading set the pace of a
+// capacity forecast
+const capacityTrendWindow = 7
+
+// GetCapacityForecast projects shop and warehouse space used over the next
+// days days at the recent pace of trading: each day sales empty the shop,
+// the shop is restocked from the warehouse, and purchases arrive in the
+// warehouse. Purchases and sales are the daily averages over the
+// last capacityTrendWindow days of the ledger, with an item's sales velocity
+// used in place of its ledger sales when known. Each location reports the
+// first day it is projected to be full, or zero if it stays within capacity.
+func (gm *GameManager) GetCapacityForecast(days int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if days < 1 || days > maxForecastDays {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Forecast must cover between 1 and %d days", maxForecastDays),
+		}
+	}
+
+	currentDay := gm.gameState.GetCurrentDay()
+	window, dailyPurchases, dailySales := gm.capacityTrendUnsafe(currentDay)
+	forecast, shopFullOn, warehouseFullOn := gm.projectCapacityUnsafe(currentDay, days, dailyPurchases, dailySales)
+
+	return map[string]interface{}{
+		"success":        true,
+		"trendWindow":    window,
+		"dailyPurchases": dailyPurchases,
+		"dailySales":     dailySales,
+		"shop":           capacityLocation(gm.inventory.ShopCapacity, gm.inventory.GetShopSpaceUsed(), shopFullOn),
+		"warehouse":      capacityLocation(gm.inventory.WarehouseCapacity, gm.inventory.GetWarehouseSpaceUsed(), warehouseFullOn),
+		"forecast":       forecast,
+	}
+}
+
+// capacityTrendUnsafe returns the trend window in days and the space bought
+// into the warehouse and sold out of the shop on an average day within it.
+// Caller must hold gm.mu.
+func (gm *GameManager) capacityTrendUnsafe(currentDay int) (int, float64, float64) {
+	window := min(capacityTrendWindow, max(currentDay, 1))
+	bought := make(map[string]int)
+	sold := make(map[string]int)
+	for _, entry := range gm.ledger.Entries() {
+		if entry.ItemID == "" || entry.Day <= currentDay-window || entry.Day > currentDay {
+			continue
+		}
+		switch entry.Type {
+		case ledger.EntryPurchase:
+			bought[entry.ItemID] += entry.Quantity
+		case ledger.EntrySale:
+			sold[entry.ItemID] += entry.Quantity
+		}
+	}
+
+	itemIDs := make(map[string]bool)
+	for _, stock := range []map[string]int{gm.inventory.ShopInventory.GetAll(), gm.inventory.WarehouseInventory.GetAll(), bought, sold} {
+		for itemID := range stock {
+			itemIDs[itemID] = true
+		}
+	}
+	dailyPurchases, dailySales := 0.0, 0.0
+	for itemID := range itemIDs {
+		baseID, _ := item.ParseVariantID(itemID)
+		footprint := float64((&item.Item{ID: baseID, Category: getRegistryCategory(baseID)}).GetFootprint())
+		salesRate := gm.inventory.GetSalesVelocity(itemID)
+		if salesRate <= 0 {
+			salesRate = float64(sold[itemID]) / float64(window)
+		}
+		dailyPurchases += float64(bought[itemID]) / float64(window) * footprint
+		dailySales += salesRate * footprint
+	}
+	return window, dailyPurchases, dailySales
+}
+
+// projectCapacityUnsafe plays the daily purchases and sales forward over
+// days days from the space used now, and returns each day's projection with
+// the first day the shop and the warehouse are full, or zero.
+// Caller must hold gm.mu.
+func (gm *GameManager) projectCapacityUnsafe(currentDay, days int, dailyPurchases, dailySales float64) ([]map[string]interface{}, int, int) {
+	shopCapacity := float64(gm.inventory.ShopCapacity)
+	warehouseCapacity := float64(gm.inventory.WarehouseCapacity)
+	shopUsed := float64(gm.inventory.GetShopSpaceUsed())
+	warehouseUsed := float64(gm.inventory.GetWarehouseSpaceUsed())
+	shopFullOn, warehouseFullOn := 0, 0
+	if shopUsed >= shopCapacity {
+		shopFullOn = currentDay
+	}
+	if warehouseUsed >= warehouseCapacity {
+		warehouseFullOn = currentDay
+	}
+
+	forecast := make([]map[string]interface{}, 0, days)
+	for i := 1; i <= days; i++ {
+		sales := math.Min(shopUsed, dailySales)
+		shopUsed -= sales
+		restock := math.Min(math.Min(sales, warehouseUsed), shopCapacity-shopUsed)
+		shopUsed += restock
+		warehouseUsed = math.Min(warehouseCapacity, warehouseUsed-restock+dailyPurchases)
+
+		day := currentDay + i
+		if shopFullOn == 0 && shopUsed >= shopCapacity {
+			shopFullOn = day
+		}
+		if warehouseFullOn == 0 && warehouseUsed >= warehouseCapacity {
+			warehouseFullOn = day
+		}
+		forecast = append(forecast, map[string]interface{}{
+			"day":                  day,
+			"shopUsed":             math.Round(shopUsed*100) / 100,
+			"warehouseUsed":        math.Round(warehouseUsed*100) / 100,
+			"shopUtilization":      shopUsed / shopCapacity,
+			"warehouseUtilization": warehouseUsed / warehouseCapacity,
+		})
+	}
+	return forecast, shopFullOn, warehouseFullOn
+}
+
+// capacityLocation reports a location's capacity, space used now and the
+// first day it is projected to be full
+func capacityLocation(capacity, used, fullOn int) map[string]interface{} {
+	return map[string]interface{}{
+		"capacity":  capacity,
+		"used":      used,
+		"fullOnDay": fullOn,
+	}
+}
+
+// seasonalModifierKeys names the categories in the seasonal price
+// modifiers shown to players
+var seasonalModifierKeys = map[item.Category]string{
+	item.CategoryFruit:  "fruits",
+	item.CategoryWeapon: "weapons",
+	item.CategoryPotion: "potions",
+}
+
+// seasonalEffectInfo returns the description and price modifiers the market
+// applies in a season, named as shown to players
+func seasonalEffectInfo(season string) (string, map[string]float64) {
+	parsed, err := market.ParseSeason(season)
+	if err != nil {
+		return "", map[string]float64{}
+	}
+	effect := market.GetSeasonalEffect(parsed)
+	modifiers := make(map[string]float64, len(effect.Modifiers))
+	for category, modifier := range effect.Modifiers {
+		key, ok := seasonalModifierKeys[category]
+		if !ok {
+			key = strings.ToLower(string(category))
+		}
+		modifiers[key] = modifier
+	}
+	return effect.Description, modifiers
+}
+
+// maxForecastDays limits seasonal forecasts to one full year
+const maxForecastDays = gamestate.DaysPerSeason * 4
+
+// GetSeasonalEffects returns the current seasonal effects on the market
+func (gm *GameManager) GetSeasonalEffects() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	season := gm.gameState.GetCurrentSeason()
+	effects := make(map[string]interface{})
+
+	if description, modifiers := seasonalEffectInfo(season); description != "" {
+		effects["description"] = description
+		effects["priceModifiers"] = modifiers
+	}
+
+	effects["currentSeason"] = season
+	effects["currentDay"] = gm.gameState.GetCurrentDay()
+
+	return effects
+}
+
+// GetSeasonalForecast returns each season falling within the next days,
+// starting today, with its price modifiers and any market events in effect
+func (gm *GameManager) GetSeasonalForecast(days int) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	if days <= 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Forecast must cover at least one day",
+		}
+	}
+	if days > maxForecastDays {
+		days = maxForecastDays
+	}
+
+	currentDay := gm.gameState.GetCurrentDay()
+	lastDay := currentDay + days - 1
+	activeEvents := gm.market.GetActiveEventNames()
+
+	seasons := make([]map[string]interface{}, 0)
+	for day := currentDay; day <= lastDay; {
+		season := gamestate.SeasonForDay(day)
+		endDay := ((day-1)/gamestate.DaysPerSeason + 1) * gamestate.DaysPerSeason
+		if endDay > lastDay {
+			endDay = lastDay
+		}
+
+		// Only events already underway are known; none are scheduled ahead
+		events := []string{}
+		if day == currentDay {
+			events = activeEvents
+		}
+
+		description, modifiers := seasonalEffectInfo(season)
+		seasons = append(seasons, map[string]interface{}{
+			"season":         season,
+			"startDay":       day,
+			"endDay":         endDay,
+			"daysUntil":      day - currentDay,
+			"description":    description,
+			"priceModifiers": modifiers,
+			"events":         events,
+		})
+		day = endDay + 1
+	}
+
+	return map[string]interface{}{
+		"success":    true,
+		"currentDay": currentDay,
+		"days":       days,
+		"seasons":    seasons,
+	}
+}
+
+// dailyEventOutcome is a rolled daily event and what it did
+type dailyEventOutcome struct {
+	event      events.DailyEvent
+	price      int            // New price after a shock, or the caravan's asking price
+	unitsLeft  int            // Units the caravan still has for sale
+	unitsLost  map[string]int // Shop stock taken by a hazard
+	lossValue  int            // Purchase cost of the stock taken
+	reimbursed int            // Gold paid out by insurance for the loss
+}
+
+// SetEventSeed sets the seed for daily random events and the market mood,
+// making them repeatable
+func (gm *GameManager) SetEventSeed(seed int64) {
+	gm.eventRoller.SetSeed(seed)
+	gm.moodCycle.SetSeed(seed)
+}
+
+// SetMarketMoodStrength sets how far a boom or recession moves prices and
+// demand. Zero keeps the economy permanently normal.
+func (gm *GameManager) SetMarketMoodStrength(strength float64) error {
 	gm.mu.Lock()
 	defer gm.mu.Unlock()
 
-	return gm.gameState.SetPlayerName(name)
+	if err := gm.moodCycle.SetStrength(strength); err != nil {
+		return err
+	}
+	_, index, _ := gm.market.GetMood()
+	gm.market.SetMood(index, strength)
+	return nil
 }
 
-// AdvanceTime advances the game time by specified number of days
-func (gm *GameManager) AdvanceTime(days int) {
+// SetPriceSmoothing sets how much weight each new daily price gets in the
+// display price shown on charts and the HUD, from just above zero (calmest)
+// to one (the raw price). Trading always uses the raw price.
+func (gm *GameManager) SetPriceSmoothing(factor float64) error {
 	gm.mu.Lock()
 	defer gm.mu.Unlock()
+	return gm.market.SetPriceSmoothing(factor)
+}
 
-	for i := 0; i < days; i++ {
-		gm.gameState.AdvanceDay()
+// GetDisplayPrice returns an item's raw market price alongside the smoothed
+// display price for charts and the HUD, with both series over the price
+// history of the item's base quality, oldest first
+func (gm *GameManager) GetDisplayPrice(itemID string) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
 
-		// Check for rank up after each day
-		if gm.gameState.CheckRankUp() {
-			logging.Infof("Player ranked up to %s!", gamestate.GetRankName(gm.gameState.GetRank()))
-			gm.eventBus.PublishAsync(event.NewBaseEvent("RankUp"))
+	master, exists := item.GetItemRegistry().GetItem(itemID)
+	if !exists {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Unknown item: %s", itemID),
 		}
 	}
 
-	// Update market and events
-	if gm.market != nil {
-		gm.market.UpdatePrices()
+	rawSeries := make([]int, 0)
+	displaySeries := make([]int, 0)
+	for _, point := range gm.market.GetDisplaySeries(master.GetBaseID()) {
+		rawSeries = append(rawSeries, point.Raw)
+		displaySeries = append(displaySeries, int(math.Round(point.Display)))
+	}
+
+	return map[string]interface{}{
+		"success":       true,
+		"itemId":        itemID,
+		"rawPrice":      gm.market.GetPrice(itemID),
+		"displayPrice":  gm.market.GetDisplayPrice(itemID),
+		"smoothing":     gm.market.GetPriceSmoothing(),
+		"rawSeries":     rawSeries,
+		"displaySeries": displaySeries,
 	}
 }
 
-// UpgradeInventoryCapacity upgrades shop or warehouse capacity
-func (gm *GameManager) UpgradeInventoryCapacity(location string, amount int, cost int) map[string]interface{} {
-	gm.mu.Lock()
-	defer gm.mu.Unlock()
+// GetMarketMood returns the economy's mood and how it scales each category's
+// prices and demand, with the direction it has moved since yesterday
+func (gm *GameManager) GetMarketMood() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
 
-	// Check if player has enough gold
-	currentGold := gm.gameState.GetGold()
-	if currentGold < cost {
-		return map[string]interface{}{
-			"success":   false,
-			"message":   "Insufficient gold for upgrade",
-			"required":  cost,
-			"available": currentGold,
+	mood, index, _ := gm.market.GetMood()
+	strength := gm.moodCycle.GetStrength()
+	day := gm.gameState.GetCurrentDay()
+
+	trend := "stable"
+	if day > 1 {
+		switch yesterday := gm.moodCycle.IndexAt(day - 1); {
+		case index > yesterday:
+			trend = "improving"
+		case index < yesterday:
+			trend = "worsening"
 		}
 	}
 
-	// Determine location
-	var invLocation inventory.InventoryLocation
-	switch location {
-	case locationShop:
-		invLocation = inventory.LocationShop
-	case locationWarehouse:
-		invLocation = inventory.LocationWarehouse
-	default:
-		return map[string]interface{}{
-			"success": false,
-			"message": "Invalid location. Must be 'shop' or 'warehouse'",
+	modifiers := make(map[string]interface{}, len(item.AllCategories()))
+	for _, category := range item.AllCategories() {
+		modifiers[string(category)] = market.MoodModifier(index, strength, category)
+	}
+
+	return map[string]interface{}{
+		"success":   true,
+		"mood":      string(mood),
+		"index":     index,
+		"strength":  strength,
+		"trend":     trend,
+		"modifiers": modifiers,
+	}
+}
+
+// rollDailyEventsUnsafe rolls today's random events, applies their effects
+// and publishes them. Caller must hold gm.mu.
+func (gm *GameManager) rollDailyEventsUnsafe() {
+	day := gm.gameState.GetCurrentDay()
+	itemIDs := make([]string, 0)
+	for _, marketItem := range gm.market.GetAllItems() {
+		itemIDs = append(itemIDs, marketItem.ID)
+	}
+
+	gm.todaysEvents = make([]dailyEventOutcome, 0)
+	for _, rolled := range gm.eventRoller.Roll(day, itemIDs) {
+		outcome := dailyEventOutcome{event: rolled}
+		switch rolled.Kind {
+		case events.DailyEventMarketShock:
+			outcome.price = gm.market.ApplyPriceShock(rolled.ItemID, rolled.Magnitude)
+		case events.DailyEventOpportunity:
+			outcome.price = int(math.Round(float64(gm.market.GetPrice(rolled.ItemID)) * (1 - rolled.Magnitude)))
+			outcome.unitsLeft = caravanOfferUnits
+		case events.DailyEventHazard:
+			outcome.unitsLost, outcome.lossValue = gm.inventory.ApplyShopLoss(rolled.Magnitude)
+			outcome.reimbursed = gm.settleTheftUnsafe(outcome.lossValue)
+			gm.dayRecordUnsafe(day).stockLosses += outcome.lossValue
 		}
+		gm.todaysEvents = append(gm.todaysEvents, outcome)
+
+		logging.Infof("Daily event on day %d: %s", day, describeDailyEvent(outcome))
+		gm.eventBus.PublishAsync(event.NewDailyEventRolledEvent(day, string(rolled.Kind), rolled.ItemID, rolled.Magnitude))
 	}
+}
 
-	// Perform upgrade
-	if gm.inventory != nil {
-		err := gm.inventory.UpgradeCapacity(invLocation, amount)
-		if err != nil {
+// caravanOfferUnits is how many units a caravan brings to sell
+const caravanOfferUnits = 10
+
+// BuyCaravanOffer buys from the caravan in town today at its discounted
+// asking price, paying the spread and any tariff as for any purchase, up to
+// the units it has left
+func (gm *GameManager) BuyCaravanOffer(quantity int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	for i := range gm.todaysEvents {
+		offer := &gm.todaysEvents[i]
+		if offer.event.Kind != events.DailyEventOpportunity {
+			continue
+		}
+		if quantity <= 0 || quantity > offer.unitsLeft {
 			return map[string]interface{}{
 				"success": false,
-				"message": err.Error(),
+				"message": fmt.Sprintf("The caravan has %d left to sell", offer.unitsLeft),
 			}
 		}
 
-		// Deduct gold
-		gm.gameState.SetGold(currentGold - cost)
-
-		// Get new capacity stats
-		stats := gm.inventory.GetCapacityStats()
-		newCapacity := 0
-		if invLocation == inventory.LocationShop && stats != nil {
-			newCapacity = stats.CurrentShopCapacity
-		} else if stats != nil {
-			newCapacity = stats.CurrentWarehouseCapacity
+		result := gm.buyItemUnsafe(offer.event.ItemID, quantity, float64(offer.price))
+		if result["success"].(bool) {
+			offer.unitsLeft -= quantity
+			result["itemId"] = offer.event.ItemID
+			result["price"] = offer.price
+			result["unitsLeft"] = offer.unitsLeft
 		}
+		return result
+	}
 
-		// Metrics removed
+	return map[string]interface{}{
+		"success": false,
+		"message": "No caravan is in town today",
+	}
+}
 
-		return map[string]interface{}{
-			"success":       true,
-			"message":       fmt.Sprintf("Successfully upgraded %s capacity", location),
-			"newCapacity":   newCapacity,
-			"goldRemaining": gm.gameState.GetGold(),
+// describeDailyEvent describes a daily event outcome in one line
+func describeDailyEvent(outcome dailyEventOutcome) string {
+	name := getRegistryItemName(outcome.event.ItemID)
+	switch outcome.event.Kind {
+	case events.DailyEventMarketShock:
+		if outcome.event.Magnitude < 0 {
+			return fmt.Sprintf("%s prices slump to %dg", name, outcome.price)
+		}
+		return fmt.Sprintf("%s prices surge to %dg", name, outcome.price)
+	case events.DailyEventOpportunity:
+		return fmt.Sprintf("A caravan offers %s for %dg", name, outcome.price)
+	case events.DailyEventHazard:
+		units := 0
+		for _, quantity := range outcome.unitsLost {
+			units += quantity
 		}
+		if outcome.reimbursed > 0 {
+			return fmt.Sprintf("Thieves took %d items from the shop; insurance paid %dg", units, outcome.reimbursed)
+		}
+		return fmt.Sprintf("Thieves took %d items from the shop", units)
+	default:
+		return string(outcome.event.Kind)
+	}
+}
+
+// GetDailyEvents returns the random events rolled for the current day
+func (gm *GameManager) GetDailyEvents() map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	dailyEvents := make([]map[string]interface{}, 0, len(gm.todaysEvents))
+	for _, outcome := range gm.todaysEvents {
+		dailyEvents = append(dailyEvents, dailyEventInfo(outcome))
 	}
 
 	return map[string]interface{}{
-		"success": false,
-		"message": "Inventory system not initialized",
+		"success": true,
+		"day":     gm.gameState.GetCurrentDay(),
+		"events":  dailyEvents,
 	}
 }
 
-// OptimizeInventory optimizes inventory placement
-func (gm *GameManager) OptimizeInventory() map[string]interface{} {
+// dailyEventInfo describes a daily event and what it did for the UI
+func dailyEventInfo(outcome dailyEventOutcome) map[string]interface{} {
+	entry := map[string]interface{}{
+		"kind":        string(outcome.event.Kind),
+		"day":         outcome.event.Day,
+		"itemId":      outcome.event.ItemID,
+		"magnitude":   outcome.event.Magnitude,
+		"description": describeDailyEvent(outcome),
+	}
+	if outcome.event.ItemID != "" {
+		entry["itemName"] = getRegistryItemName(outcome.event.ItemID)
+		entry["price"] = outcome.price
+	}
+	if outcome.event.Kind == events.DailyEventOpportunity {
+		entry["unitsLeft"] = outcome.unitsLeft
+	}
+	if outcome.unitsLost != nil {
+		entry["unitsLost"] = outcome.unitsLost
+		entry["lossValue"] = outcome.lossValue
+		entry["reimbursed"] = outcome.reimbursed
+	}
+	return entry
+}
+
+// settleTheftUnsafe claims a theft loss against any active insurance policy
+// and credits the payout. Caller must hold gm.mu.
+func (gm *GameManager) settleTheftUnsafe(lossValue int) int {
+	payout := gm.insurance.Claim(gm.gameState.GetCurrentDay(), lossValue)
+	if payout > 0 {
+		gm.gameState.SetGoldWithReason(gm.gameState.GetGold()+payout, "insurance_claim")
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryClaim, Amount: payout})
+	}
+	return payout
+}
+
+// BuyInsurance buys a policy covering shop stock against theft for the next
+// days days, starting today. The premium is paid up front.
+func (gm *GameManager) BuyInsurance(days int) map[string]interface{} {
 	gm.mu.Lock()
 	defer gm.mu.Unlock()
 
-	if gm.inventory == nil {
+	premium, err := gm.insurance.Quote(days)
+	if err != nil {
 		return map[string]interface{}{
 			"success": false,
-			"message": "Inventory system not initialized",
+			"message": err.Error(),
+		}
+	}
+
+	gold := gm.gameState.GetGold()
+	if gold < premium {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Insufficient gold: premium is %dg", premium),
 		}
 	}
 
-	// Perform optimization
-	err := gm.inventory.OptimizeCapacityUsage()
+	policy, err := gm.insurance.Buy(gm.gameState.GetCurrentDay(), days)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -837,125 +5657,233 @@ func (gm *GameManager) OptimizeInventory() map[string]interface{} {
 		}
 	}
 
-	// Also optimize placement based on sales velocity
-	gm.inventory.OptimizePlacement()
+	gm.gameState.SetGoldWithReason(gold-policy.Premium, "insurance_premium")
+	gm.recordTransaction(ledger.Entry{Type: ledger.EntryExpense, Amount: -policy.Premium})
 
 	return map[string]interface{}{
-		"success": true,
-		"message": "Inventory optimized successfully",
+		"success":  true,
+		"message":  fmt.Sprintf("Shop insured through day %d", policy.EndDay),
+		"premium":  policy.Premium,
+		"endDay":   policy.EndDay,
+		"coverage": policy.Coverage,
 	}
 }
 
-// GetCapacityRecommendations returns capacity upgrade recommendations
-func (gm *GameManager) GetCapacityRecommendations() map[string]interface{} {
+// GetInsuranceStatus returns the active policy, if any, and the premiums
+// paid and claims received so far
+func (gm *GameManager) GetInsuranceStatus() map[string]interface{} {
 	gm.mu.RLock()
 	defer gm.mu.RUnlock()
 
-	if gm.inventory == nil {
+	day := gm.gameState.GetCurrentDay()
+	stats := gm.insurance.GetStatistics()
+	dailyPremium, _ := gm.insurance.Quote(1)
+	status := map[string]interface{}{
+		"success":        true,
+		"insured":        false,
+		"dailyPremium":   dailyPremium,
+		"policiesBought": stats.PoliciesBought,
+		"totalPremiums":  stats.TotalPremiums,
+		"totalClaims":    stats.TotalClaims,
+		"totalLosses":    stats.TotalLosses,
+	}
+
+	if policy, active := gm.insurance.ActivePolicy(day); active {
+		status["insured"] = true
+		status["startDay"] = policy.StartDay
+		status["endDay"] = policy.EndDay
+		status["daysRemaining"] = policy.EndDay - day + 1
+		status["coverage"] = policy.Coverage
+	}
+
+	return status
+}
+
+// Exchange trades amount units of one currency for another at the exchange
+// desk. Gold is held by the game state; foreign currencies by the exchange.
+// Each conversion loses the desk's spread.
+func (gm *GameManager) Exchange(from, to string, amount int) map[string]interface{} {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	received, err := gm.exchange.Convert(from, to, amount)
+	if err != nil {
 		return map[string]interface{}{
-			"hasRecommendations": false,
+			"success": false,
+			"message": err.Error(),
 		}
 	}
-
-	stats := gm.inventory.GetCapacityStats()
-	if stats == nil {
+	if received == 0 {
 		return map[string]interface{}{
-			"hasRecommendations": false,
+			"success": false,
+			"message": fmt.Sprintf("%d %s is too little to exchange for %s", amount, from, to),
 		}
 	}
 
-	recommendations := []map[string]interface{}{}
+	if from == exchange.Gold {
+		gold := gm.gameState.GetGold()
+		if gold < amount {
+			return map[string]interface{}{
+				"success": false,
+				"message": fmt.Sprintf("Insufficient gold: have %dg, need %dg", gold, amount),
+			}
+		}
+		gm.gameState.SetGoldWithReason(gold-amount, "currency_exchange")
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryExchange, Currency: to, Quantity: received, Amount: -amount})
+	} else if err := gm.exchange.Debit(from, amount); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Cannot exchange %d %s: %v", amount, from, err),
+		}
+	}
 
-	// Check if shop needs upgrade
-	if stats.PeakShopUtilization > 0.8 {
-		upgradeAmount := stats.RecommendedShopCapacity - stats.CurrentShopCapacity
-		if upgradeAmount > 0 {
-			recommendations = append(recommendations, map[string]interface{}{
-				"location":        locationShop,
-				"currentCapacity": stats.CurrentShopCapacity,
-				"recommended":     stats.RecommendedShopCapacity,
-				"upgradeAmount":   upgradeAmount,
-				"reason":          fmt.Sprintf("Peak utilization reached %.1f%%", stats.PeakShopUtilization*100),
-				"estimatedCost":   upgradeAmount * 50, // 50 gold per capacity unit
-			})
+	if to == exchange.Gold {
+		gm.gameState.SetGoldWithReason(gm.gameState.GetGold()+received, "currency_exchange")
+		gm.recordTransaction(ledger.Entry{Type: ledger.EntryExchange, Currency: from, Quantity: amount, Amount: received})
+	} else if err := gm.exchange.Credit(to, received); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
 		}
 	}
 
-	// Check if warehouse needs upgrade
-	if stats.PeakWarehouseUtilization > 0.8 {
-		upgradeAmount := stats.RecommendedWarehouseCapacity - stats.CurrentWarehouseCapacity
-		if upgradeAmount > 0 {
-			recommendations = append(recommendations, map[string]interface{}{
-				"location":        locationWarehouse,
-				"currentCapacity": stats.CurrentWarehouseCapacity,
-				"recommended":     stats.RecommendedWarehouseCapacity,
-				"upgradeAmount":   upgradeAmount,
-				"reason":          fmt.Sprintf("Peak utilization reached %.1f%%", stats.PeakWarehouseUtilization*100),
-				"estimatedCost":   upgradeAmount * 20, // 20 gold per capacity unit
-			})
+	rate, _ := gm.exchange.GetExchangeRate(from, to)
+	return map[string]interface{}{
+		"success":  true,
+		"message":  fmt.Sprintf("Exchanged %d %s for %d %s", amount, from, received, to),
+		"received": received,
+		"rate":     rate,
+		"gold":     gm.gameState.GetGold(),
+		"holdings": gm.exchange.Holdings(),
+	}
+}
+
+// GetExchangeRate returns how many units of to one unit of from is worth,
+// before and after the desk's spread, and the recent daily rates of every
+// foreign currency in gold
+func (gm *GameManager) GetExchangeRate(from, to string) map[string]interface{} {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	rate, err := gm.exchange.GetExchangeRate(from, to)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
 		}
 	}
 
+	spread := gm.exchange.GetSpread()
+	currencies := make([]map[string]interface{}, 0)
+	for _, currency := range gm.exchange.Currencies() {
+		currencies = append(currencies, map[string]interface{}{
+			"code":     currency.Code,
+			"name":     currency.Name,
+			"rate":     currency.Rate,
+			"baseRate": currency.BaseRate,
+			"history":  currency.History,
+		})
+	}
+
 	return map[string]interface{}{
-		"hasRecommendations": len(recommendations) > 0,
-		"recommendations":    recommendations,
-		"statistics": map[string]interface{}{
-			"avgShopUtilization":       stats.AvgShopUtilization,
-			"avgWarehouseUtilization":  stats.AvgWarehouseUtilization,
-			"peakShopUtilization":      stats.PeakShopUtilization,
-			"peakWarehouseUtilization": stats.PeakWarehouseUtilization,
-		},
+		"success":       true,
+		"from":          from,
+		"to":            to,
+		"rate":          rate,
+		"effectiveRate": rate * (1 - spread),
+		"spread":        spread,
+		"currencies":    currencies,
+		"holdings":      gm.exchange.Holdings(),
 	}
 }
 
-// GetSeasonalEffects returns the current seasonal effects on the market
-func (gm *GameManager) GetSeasonalEffects() map[string]interface{} {
+// GetActiveEffectsTimeline returns market events and seasons that are in
+// effect or begin within the next days, with their day ranges and impacts
+func (gm *GameManager) GetActiveEffectsTimeline(days int) map[string]interface{} {
 	gm.mu.RLock()
 	defer gm.mu.RUnlock()
 
-	season := gm.gameState.GetCurrentSeason()
-	effects := make(map[string]interface{})
+	if days <= 0 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Timeline must cover at least one day",
+		}
+	}
+	if days > maxForecastDays {
+		days = maxForecastDays
+	}
 
-	// Define seasonal effects
-	switch season {
-	case "Spring":
-		effects["description"] = "Spring: Fresh produce is abundant"
-		effects["priceModifiers"] = map[string]float64{
-			"fruits":     0.9,  // 10% cheaper
-			"vegetables": 0.85, // 15% cheaper
-			"weapons":    1.0,
-			"potions":    1.05, // 5% more expensive
-		}
-	case "Summer":
-		effects["description"] = "Summer: Travel season increases demand for supplies"
-		effects["priceModifiers"] = map[string]float64{
-			"fruits":     0.95,
-			"vegetables": 0.9,
-			"weapons":    1.1,  // 10% more expensive
-			"potions":    1.15, // 15% more expensive
-		}
-	case "Autumn":
-		effects["description"] = "Autumn: Harvest season brings plenty"
-		effects["priceModifiers"] = map[string]float64{
-			"fruits":     0.8,  // 20% cheaper
-			"vegetables": 0.75, // 25% cheaper
-			"weapons":    1.05,
-			"potions":    1.0,
-		}
-	case "Winter":
-		effects["description"] = "Winter: Scarcity drives prices up"
-		effects["priceModifiers"] = map[string]float64{
-			"fruits":     1.3, // 30% more expensive
-			"vegetables": 1.4, // 40% more expensive
-			"weapons":    0.95,
-			"potions":    1.2, // 20% more expensive
+	currentDay := gm.gameState.GetCurrentDay()
+	lastDay := currentDay + days - 1
+	timeline := make([]map[string]interface{}, 0)
+
+	// Market events, open-ended when they have no duration
+	for _, marketEvent := range gm.market.GetActiveEvents() {
+		var endDay interface{}
+		if marketEvent.Duration > 0 {
+			end := marketEvent.StartDay + marketEvent.Duration - 1
+			if end < currentDay {
+				continue
+			}
+			endDay = end
 		}
+		timeline = append(timeline, map[string]interface{}{
+			"type":        "event",
+			"name":        marketEvent.Name,
+			"description": marketEvent.Description,
+			"startDay":    marketEvent.StartDay,
+			"endDay":      endDay,
+			"active":      marketEvent.StartDay <= currentDay,
+			"impacts":     eventImpacts(marketEvent.Effects),
+		})
 	}
 
-	effects["currentSeason"] = season
-	effects["currentDay"] = gm.gameState.GetCurrentDay()
+	// The current season and any that begin within the window
+	seasonStart := (currentDay-1)/gamestate.DaysPerSeason*gamestate.DaysPerSeason + 1
+	for start := seasonStart; start <= lastDay; start += gamestate.DaysPerSeason {
+		season := gamestate.SeasonForDay(start)
+		description, modifiers := seasonalEffectInfo(season)
+		timeline = append(timeline, map[string]interface{}{
+			"type":        "season",
+			"name":        season,
+			"description": description,
+			"startDay":    start,
+			"endDay":      start + gamestate.DaysPerSeason - 1,
+			"active":      start <= currentDay,
+			"impacts":     modifiers,
+		})
+	}
 
-	return effects
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i]["startDay"].(int) < timeline[j]["startDay"].(int)
+	})
+
+	return map[string]interface{}{
+		"success":    true,
+		"currentDay": currentDay,
+		"days":       days,
+		"timeline":   timeline,
+	}
+}
+
+// eventImpacts summarizes a market event's effects for display
+func eventImpacts(effects []market.EventEffect) map[string]float64 {
+	impacts := make(map[string]float64)
+	for _, effect := range effects {
+		switch effect.Type {
+		case market.EffectDemandIncrease:
+			impacts["demand"] += effect.Value
+		case market.EffectDemandDecrease:
+			impacts["demand"] -= effect.Value
+		case market.EffectSupplyIncrease:
+			impacts["supply"] += effect.Value
+		case market.EffectSupplyDecrease:
+			impacts["supply"] -= effect.Value
+		case market.EffectPriceModifier:
+			impacts["price"] += effect.Value
+		}
+	}
+	return impacts
 }
 
 // GetSettings returns current game settings
@@ -974,6 +5902,7 @@ func (gm *GameManager) GetSettings() map[string]interface{} {
 			"autoSave":         gameSettings.AutoSave,
 			"autoSaveInterval": gameSettings.AutoSaveInterval,
 			"language":         gameSettings.Language,
+			"currency":         gameSettings.Currency,
 			"pauseOnFocusLoss": gameSettings.PauseOnFocusLoss,
 		},
 		"graphics": map[string]interface{}{
@@ -984,6 +5913,7 @@ func (gm *GameManager) GetSettings() map[string]interface{} {
 			"shadowQuality":  gameSettings.ShadowQuality,
 			"textureQuality": gameSettings.TextureQuality,
 			"effectsQuality": gameSettings.EffectsQuality,
+			"uiScale":        gameSettings.UIScale,
 		},
 		"audio": map[string]interface{}{
 			"masterVolume": gameSettings.MasterVolume,
@@ -1012,19 +5942,16 @@ func (gm *GameManager) UpdateSettings(category string, updates map[string]interf
 		}
 	}
 
-	// Apply updates based on category
-	var errors []string
+	// Apply all updates together so a failed form changes nothing
+	batch := make(map[string]interface{}, len(updates))
 	for key, value := range updates {
-		fullKey := fmt.Sprintf("%s_%s", category, key)
-		if err := gm.settings.SetSetting(fullKey, value); err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", key, err))
-		}
+		batch[settingKey(category, key)] = value
 	}
 
-	if len(errors) > 0 {
+	if err := gm.settings.SetSettingsBatch(batch); err != nil {
 		return map[string]interface{}{
 			"success": false,
-			"errors":  errors,
+			"errors":  []string{err.Error()},
 		}
 	}
 
@@ -1045,6 +5972,27 @@ func (gm *GameManager) UpdateSettings(category string, updates map[string]interf
 	}
 }
 
+// settingKey maps a UI category/key pair to a settings manager key
+func settingKey(category, key string) string {
+	switch category {
+	case "game":
+		switch key {
+		case "language":
+			return settings.SettingLanguage
+		case "currency":
+			return settings.SettingCurrency
+		}
+	case "graphics":
+		switch key {
+		case "uiScale":
+			return settings.SettingUIScale
+		case "resolution":
+			return settings.SettingResolution
+		}
+	}
+	return fmt.Sprintf("%s_%s", category, key)
+}
+
 // applySettingsChanges applies settings changes that need immediate effect
 func (gm *GameManager) applySettingsChanges(category string, updates map[string]interface{}) {
 	switch category {
@@ -1067,6 +6015,15 @@ func (gm *GameManager) applySettingsChanges(category string, updates map[string]
 		if fps, ok := updates["targetFPS"].(int); ok && gm.gameLoop != nil {
 			logging.Infof("Target FPS changed to: %d", fps)
 		}
+
+		// Let Godot reapply UI scale and window size
+		_, scaleChanged := updates["uiScale"]
+		_, resolutionChanged := updates["resolution"]
+		if scaleChanged || resolutionChanged {
+			display := gm.settings.GetSettings()
+			_ = gm.eventBus.Publish(event.NewDisplaySettingsChangedEvent(
+				display.UIScale, display.Resolution.Width, display.Resolution.Height))
+		}
 	}
 }
 
@@ -1151,6 +6108,99 @@ func (gm *GameManager) ValidateSettings(updates map[string]interface{}) map[stri
 	}
 }
 
+// settingSchemaInfo is what the settings schema adds to a validation rule
+type settingSchemaInfo struct {
+	category        string
+	requiresRestart bool
+	defaultValue    func(defaults *settings.GameSettings) interface{}
+}
+
+// settingsSchemaInfo places each validated setting in a settings screen
+// category. Economy settings only apply to new games, so they need a restart.
+var settingsSchemaInfo = map[string]settingSchemaInfo{
+	"playerName": {category: "game"},
+	"difficulty": {category: "game", defaultValue: func(d *settings.GameSettings) interface{} { return d.Difficulty }},
+	"language": {category: "game", requiresRestart: true,
+		defaultValue: func(d *settings.GameSettings) interface{} { return d.Language }},
+	"autoSaveInterval": {category: "game", defaultValue: func(d *settings.GameSettings) interface{} { return d.AutoSaveInterval }},
+	"showTutorial":     {category: "game", defaultValue: func(d *settings.GameSettings) interface{} { return d.ShowTutorialHints }},
+	"masterVolume":     {category: "audio", defaultValue: func(d *settings.GameSettings) interface{} { return d.MasterVolume }},
+	"musicVolume":      {category: "audio", defaultValue: func(d *settings.GameSettings) interface{} { return d.MusicVolume }},
+	"sfxVolume":        {category: "audio", defaultValue: func(d *settings.GameSettings) interface{} { return d.SFXVolume }},
+	"resolution": {category: "graphics", defaultValue: func(d *settings.GameSettings) interface{} {
+		return fmt.Sprintf("%dx%d", d.Resolution.Width, d.Resolution.Height)
+	}},
+	"fullscreen":      {category: "graphics", defaultValue: func(d *settings.GameSettings) interface{} { return d.Fullscreen }},
+	"vsync":           {category: "graphics", defaultValue: func(d *settings.GameSettings) interface{} { return d.VSync }},
+	"uiScale":         {category: "graphics", defaultValue: func(d *settings.GameSettings) interface{} { return d.UIScale }},
+	"graphicsQuality": {category: "graphics", requiresRestart: true},
+	"startingGold": {category: "economy", requiresRestart: true,
+		defaultValue: func(*settings.GameSettings) interface{} { return gamestate.NewGameState(nil).GetGold() }},
+	"shopCapacity":        {category: "economy", requiresRestart: true},
+	"warehouseCapacity":   {category: "economy", requiresRestart: true},
+	"priceFluctuation":    {category: "economy", requiresRestart: true},
+	"demandSensitivity":   {category: "economy", requiresRestart: true},
+	"serverAddress":       {category: "network", requiresRestart: true},
+	"connectionTimeout":   {category: "network", defaultValue: func(d *settings.GameSettings) interface{} { return d.NetworkTimeout }},
+	"enableNotifications": {category: "ui", defaultValue: func(d *settings.GameSettings) interface{} { return d.ShowNotifications }},
+	"notificationTypes":   {category: "ui"},
+}
+
+// GetSettingsSchema describes every validated setting so the settings screen
+// can build its form: type, category, default, valid range or options, and
+// whether a change needs a restart. It is derived from the validator rules,
+// so the form always matches what ValidateSettings accepts.
+func (gm *GameManager) GetSettingsSchema() map[string]interface{} {
+	defaults := settings.DefaultGameSettings()
+
+	fields := make([]map[string]interface{}, 0)
+	for _, rule := range settings.NewValidator().Rules() {
+		info, known := settingsSchemaInfo[rule.FieldName]
+		if !known {
+			info.category = "other"
+		}
+
+		field := map[string]interface{}{
+			"key":             rule.FieldName,
+			"type":            string(rule.Type),
+			"category":        info.category,
+			"required":        rule.Required,
+			"requiresRestart": info.requiresRestart,
+		}
+		if info.defaultValue != nil {
+			field["default"] = info.defaultValue(defaults)
+		}
+		if rule.MinValue != nil {
+			field["min"] = *rule.MinValue
+		}
+		if rule.MaxValue != nil {
+			field["max"] = *rule.MaxValue
+		}
+		if rule.MinLength != nil {
+			field["minLength"] = *rule.MinLength
+		}
+		if rule.MaxLength != nil {
+			field["maxLength"] = *rule.MaxLength
+		}
+		if rule.Pattern != nil {
+			field["pattern"] = rule.Pattern.String()
+		}
+		if len(rule.AllowedValues) > 0 {
+			field["options"] = rule.AllowedValues
+		}
+		fields = append(fields, field)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i]["category"].(string) < fields[j]["category"].(string)
+	})
+
+	return map[string]interface{}{
+		"success": true,
+		"fields":  fields,
+	}
+}
+
 // getSettingKeys extracts keys from settings map
 func getSettingKeys(m map[string]interface{}) []string {
 	keys := make([]string, 0, len(m))
@@ -1172,6 +6222,11 @@ func (gm *GameManager) Cleanup() {
 
 	gm.isRunning = false
 	gm.cancel()
+	gm.unsubscribePrices()
+	for _, sub := range gm.subscriptions {
+		sub.Unsubscribe()
+	}
+	gm.subscriptions = nil
 
 	// Clean up systems
 	// AI system removed - single player only
@@ -0,0 +1,2974 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/branch"
+	"github.com/yourusername/merchant-tails/game/internal/domain/difficulty"
+	"github.com/yourusername/merchant-tails/game/internal/domain/event"
+	"github.com/yourusername/merchant-tails/game/internal/domain/events"
+	"github.com/yourusername/merchant-tails/game/internal/domain/exchange"
+	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
+	"github.com/yourusername/merchant-tails/game/internal/domain/insurance"
+	"github.com/yourusername/merchant-tails/game/internal/domain/inventory"
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+	"github.com/yourusername/merchant-tails/game/internal/domain/ledger"
+	"github.com/yourusername/merchant-tails/game/internal/domain/market"
+	"github.com/yourusername/merchant-tails/game/internal/domain/quest"
+	"github.com/yourusername/merchant-tails/game/internal/domain/scenario"
+	"github.com/yourusername/merchant-tails/game/internal/domain/settings"
+	"github.com/yourusername/merchant-tails/game/internal/domain/tax"
+	"github.com/yourusername/merchant-tails/game/internal/infrastructure/persistence"
+)
+
+// newTestGameManager creates a game manager that keeps settings and saves in temporary directories
+func newTestGameManager(t *testing.T) *GameManager {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	event.ResetGlobalEventBus()
+
+	gm := NewGameManager()
+	require.NotNil(t, gm)
+	t.Cleanup(gm.Cleanup)
+
+	// Saves stay in memory
+	gm.saveManager = persistence.NewSaveManagerWithStore(persistence.NewMemoryStore())
+
+	// Random daily events stay off unless a test turns them on
+	require.NoError(t, gm.eventRoller.SetOdds(events.DailyEventOdds{}))
+	return gm
+}
+
+func TestGameManager_CleanupUnsubscribes(t *testing.T) {
+	gm := newTestGameManager(t)
+	bus := gm.eventBus
+	before := bus.HandlerCount(event.EventNamePriceUpdated)
+	require.Positive(t, before)
+
+	// Cleanup removes the game manager's handlers so the shared bus no
+	// longer calls into it
+	gm.Cleanup()
+	assert.Equal(t, before-1, bus.HandlerCount(event.EventNamePriceUpdated))
+	assert.Empty(t, gm.subscriptions)
+	gm.Cleanup()
+}
+
+func TestGameManager_GetItemDetail(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	result := gm.BuyItem("apple", 5, 8)
+	require.True(t, result["success"].(bool))
+
+	detail := gm.GetItemDetail("apple")
+	require.True(t, detail["success"].(bool))
+
+	assert.Equal(t, "apple", detail["id"])
+	assert.Equal(t, "Fresh Apple", detail["name"])
+	assert.Equal(t, "FRUIT", detail["category"])
+	assert.NotEmpty(t, detail["description"])
+	assert.Greater(t, detail["marketPrice"].(int), 0)
+	assert.Greater(t, detail["fairValue"].(int), 0)
+	assert.Contains(t, []string{"up", "down", "stable"}, detail["priceTrend"])
+	assert.Equal(t, 0, detail["shopQuantity"])
+	assert.Equal(t, 5, detail["warehouseQuantity"])
+	assert.Equal(t, 8, detail["costBasis"])
+	assert.Greater(t, detail["setPrice"].(float64), 0.0)
+	assert.GreaterOrEqual(t, detail["expectedSales"].(int), 0)
+	assert.Equal(t, "normal", detail["supplyLevel"])
+	assert.Equal(t, true, detail["perishable"])
+	assert.Equal(t, 3, detail["shelfLifeDays"])
+	assert.Equal(t, 50, detail["liquidity"])
+	assert.Equal(t, 50, detail["liquidityRemaining"])
+}
+
+func TestGameManager_GetItemDetail_UnknownItem(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	detail := gm.GetItemDetail("dragon_egg")
+	assert.False(t, detail["success"].(bool))
+}
+
+func TestGameManager_DisplaySettingsChanged(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	var received *event.DisplaySettingsChangedEvent
+	gm.eventBus.Subscribe(event.EventNameDisplaySettingsChanged, func(e event.Event) error {
+		received = e.(*event.DisplaySettingsChangedEvent)
+		return nil
+	})
+
+	result := gm.UpdateSettings("graphics", map[string]interface{}{
+		"uiScale":    1.5,
+		"resolution": "1280x720",
+	})
+	require.True(t, result["success"].(bool))
+
+	require.NotNil(t, received)
+	assert.Equal(t, 1.5, received.UIScale)
+	assert.Equal(t, 1280, received.Width)
+	assert.Equal(t, 720, received.Height)
+
+	graphics := gm.GetSettings()["graphics"].(map[string]interface{})
+	assert.Equal(t, 1.5, graphics["uiScale"])
+}
+
+func TestGameManager_DisplaySettingsRejectsInvalidScale(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	fired := false
+	gm.eventBus.Subscribe(event.EventNameDisplaySettingsChanged, func(e event.Event) error {
+		fired = true
+		return nil
+	})
+
+	result := gm.UpdateSettings("graphics", map[string]interface{}{"uiScale": 3.0})
+	assert.False(t, result["success"].(bool))
+	assert.False(t, fired)
+
+	graphics := gm.GetSettings()["graphics"].(map[string]interface{})
+	assert.Equal(t, 1.0, graphics["uiScale"])
+}
+
+func TestGameManager_GetDemandForecast(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetCurrentDay(29)
+
+	result := gm.GetDemandForecast("apple", 3)
+	require.True(t, result["success"].(bool))
+	assert.NotEmpty(t, result["confidence"])
+
+	forecast := result["forecast"].([]map[string]interface{})
+	require.Len(t, forecast, 3)
+
+	// Day 30 is the last day of spring; summer starts on day 31
+	assert.Equal(t, 30, forecast[0]["day"])
+	assert.Equal(t, "Spring", forecast[0]["season"])
+	assert.Equal(t, "Summer", forecast[1]["season"])
+	assert.Equal(t, "Summer", forecast[2]["season"])
+
+	// Fresh spring produce keeps apples cheaper than in summer
+	assert.Less(t, forecast[0]["multiplier"].(float64), forecast[1]["multiplier"].(float64))
+	assert.Equal(t, forecast[1]["multiplier"], forecast[2]["multiplier"])
+
+	unknown := gm.GetDemandForecast("dragon_egg", 3)
+	assert.False(t, unknown["success"].(bool))
+	assert.False(t, gm.GetDemandForecast("apple", 0)["success"].(bool))
+	assert.False(t, gm.GetDemandForecast("apple", -1)["success"].(bool))
+	assert.False(t, gm.GetDemandForecast("apple", maxForecastDays+1)["success"].(bool))
+}
+
+func TestGameManager_InstantResaleIsNotProfitable(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.taxes.SetEnabled(false) // Spread alone must close the loop
+	startGold := gm.gameState.GetGold()
+
+	result := gm.BuyItem("iron_sword", 2, 150)
+	require.True(t, result["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 2))
+
+	result = gm.SellItem("iron_sword", 2, 150)
+	require.True(t, result["success"].(bool))
+
+	assert.Less(t, gm.gameState.GetGold(), startGold)
+
+	// The supplier buy screen charges the same spread, and bulk discounts
+	// never bring an order below what customers pay
+	require.NoError(t, gm.inventory.SetBaseCapacity(200, 200))
+	require.NoError(t, gm.market.SetLiquidity("apple", 1000)) // Resale is not held back by the market
+	pui := NewPurchaseUIManager(gm)
+	for _, order := range []PurchaseRequest{
+		{ItemID: "iron_sword", Quantity: 2},
+		{ItemID: "apple", Quantity: 100},
+		{ItemID: "apple", Quantity: 20, NegotiatePrice: true},
+	} {
+		gm.gameState.SetGold(5000)
+		gm.ledger.Reset(5000)
+		price := float64(gm.market.GetPrice(order.ItemID))
+
+		bought, err := pui.ExecutePurchase(&order)
+		require.NoError(t, err)
+		require.True(t, bought.Success, bought.Message)
+		require.NoError(t, gm.inventory.TransferToShop(order.ItemID, order.Quantity))
+		result = gm.SellItem(order.ItemID, order.Quantity, price)
+		require.True(t, result["success"].(bool), result["message"])
+
+		assert.LessOrEqual(t, gm.gameState.GetGold(), 5000, order)
+	}
+}
+
+func TestGameManager_SetTradeSpread(t *testing.T) {
+	gm := newTestGameManager(t)
+	assert.Equal(t, defaultTradeSpread, gm.GetTradeSpread())
+
+	require.NoError(t, gm.SetTradeSpread(0))
+	gm.taxes.SetEnabled(false)
+	startGold := gm.gameState.GetGold()
+
+	gm.BuyItem("iron_sword", 1, 150)
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	gm.SellItem("iron_sword", 1, 150)
+	assert.Equal(t, startGold, gm.gameState.GetGold())
+
+	assert.Error(t, gm.SetTradeSpread(-0.1))
+	assert.Error(t, gm.SetTradeSpread(1.0))
+}
+
+func TestGameManager_TaxTotalsSurviveSaveAndLoad(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 2, 150)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	require.True(t, gm.SellItem("iron_sword", 1, 150)["success"].(bool))
+	paid := gm.GetTaxStatistics()
+	require.Greater(t, paid["salesTax"].(int), 0)
+	require.Greater(t, paid["tariffs"].(int), 0)
+
+	require.NoError(t, gm.SaveGame(1))
+	require.NoError(t, gm.StartNewGame("Second Merchant"))
+	require.Equal(t, 0, gm.GetTaxStatistics()["total"].(int))
+
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, paid, gm.GetTaxStatistics())
+}
+
+func TestGameManager_StartNewGameClearsPreviousGame(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	// Play some of a game
+	require.True(t, gm.BuyItem("iron_sword", 2, 150)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	require.True(t, gm.SellItem("iron_sword", 1, 150)["success"].(bool))
+	_, err := gm.pricing.UpdatePrice(&PriceUpdateRequest{ItemID: "iron_sword", NewPrice: 400, Strategy: "manual"})
+	require.NoError(t, err)
+	gm.market.UpdatePrices()
+	gm.gameState.SetCurrentDay(12)
+
+	require.NotEmpty(t, gm.pricing.GetPriceHistory("iron_sword"))
+	require.Greater(t, gm.GetTaxStatistics()["total"].(int), 0)
+
+	require.NoError(t, gm.StartNewGame("Second Merchant"))
+
+	assert.Equal(t, 1000, gm.gameState.GetGold())
+	assert.Equal(t, 1, gm.gameState.GetCurrentDay())
+	assert.Equal(t, 0, gm.gameState.GetTotalTransactions())
+	assert.Equal(t, 0, gm.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 0, gm.inventory.GetTotalShopItems())
+	assert.Empty(t, gm.pricing.GetPriceHistory("iron_sword"))
+	assert.Equal(t, 0, gm.GetTaxStatistics()["total"].(int))
+
+	sword, _ := gm.market.GetItem("iron_sword")
+	history := gm.market.GetPriceHistory("iron_sword")
+	require.NotNil(t, history)
+	assert.Equal(t, sword.BasePrice, history.CurrentPrice)
+	assert.LessOrEqual(t, len(history.Records), 1)
+
+	// Prices can still be updated after the reset
+	gm.market.UpdatePrices()
+}
+
+func TestGameManager_StartNewGameWithConfig(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	assert.Error(t, gm.StartNewGameWithConfig("Broke", &gamestate.GameConfig{InitialGold: -1}))
+
+	require.NoError(t, gm.StartNewGameWithConfig("Veteran", &gamestate.GameConfig{
+		InitialGold:       5000,
+		InitialRank:       gamestate.RankJourneyman,
+		ShopCapacity:      40,
+		WarehouseCapacity: 300,
+	}))
+
+	assert.Equal(t, 5000, gm.gameState.GetGold())
+	assert.Equal(t, gamestate.RankJourneyman, gm.gameState.GetPlayerRank())
+	assert.Equal(t, 40, gm.inventory.ShopCapacity)
+	assert.Equal(t, 300, gm.inventory.WarehouseCapacity)
+	assert.Equal(t, 5000, gm.ledger.OpeningBalance())
+
+	// The next game starts from the default capacity, and with the default
+	// gold when its config leaves gold unset
+	gm.isRunning = false
+	require.NoError(t, gm.StartNewGameWithConfig("Rookie", &gamestate.GameConfig{InitialRank: gamestate.RankApprentice}))
+	assert.Equal(t, gamestate.DefaultConfig().InitialGold, gm.gameState.GetGold())
+	assert.Equal(t, gm.shopCapacity, gm.inventory.ShopCapacity)
+	assert.Equal(t, gm.warehouseCapacity, gm.inventory.WarehouseCapacity)
+	assert.NotEqual(t, 40, gm.inventory.ShopCapacity)
+}
+
+func TestGameManager_SellingNearSpoiledStockLowersReputation(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("apple", 4, 10)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 4))
+
+	// Fresh apples at a fair price earn reputation
+	result := gm.SellItem("apple", 1, 1)
+	require.True(t, result["success"].(bool))
+	assert.Greater(t, result["reputation_delta"].(float64), 0.0)
+
+	// Two of three days pass; the remaining apples are close to spoiling
+	gm.inventory.ProcessDailyUpdate()
+	gm.inventory.ProcessDailyUpdate()
+	before := gm.gameState.GetReputation()
+
+	result = gm.SellItem("apple", 1, 1)
+	require.True(t, result["success"].(bool))
+	delta := result["reputation_delta"].(float64)
+	assert.Less(t, delta, 0.0)
+	assert.Less(t, gm.gameState.GetReputation(), before)
+
+	// Spoiled stock is thrown out and can no longer be sold
+	gm.inventory.ProcessDailyUpdate()
+	assert.Zero(t, gm.inventory.GetShopQuantity("apple"))
+	assert.False(t, gm.SellItem("apple", 1, 1)["success"].(bool))
+}
+
+func TestGameManager_GetActiveModifiers(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.market.State.CurrentDemand = market.DemandHigh
+	gm.market.State.CurrentSupply = market.SupplyLow
+	gm.market.State.CurrentSeason = item.SeasonWinter
+
+	result := gm.GetActiveModifiers("health_potion")
+	require.True(t, result["success"].(bool))
+
+	modifiers := result["modifiers"].([]map[string]interface{})
+	require.GreaterOrEqual(t, len(modifiers), 3)
+	assert.Equal(t, "demand", modifiers[0]["name"])
+	assert.Equal(t, 1.2, modifiers[0]["multiplier"])
+	assert.Equal(t, "supply", modifiers[1]["name"])
+	assert.Equal(t, 1.15, modifiers[1]["multiplier"])
+	assert.Equal(t, "season", modifiers[2]["name"])
+	assert.Equal(t, 1.2, modifiers[2]["multiplier"])
+
+	assertModifiersMatchPrice(t, result)
+}
+
+func TestGameManager_GetActiveModifiers_ExplainsTheQuote(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.market.SetMaxDailyChange(0))
+	shocked := gm.market.ApplyPriceShock("health_potion", -0.3)
+
+	result := gm.GetActiveModifiers("health_potion")
+	require.True(t, result["success"].(bool))
+
+	// The breakdown ends at the price the player is quoted, not fair value
+	assert.Equal(t, shocked, result["price"])
+	assert.Equal(t, gm.market.GetPrice("health_potion"), result["price"])
+	assert.NotEqual(t, result["fairValue"], result["price"])
+
+	modifiers := result["modifiers"].([]map[string]interface{})
+	assert.Equal(t, "market_swing", modifiers[len(modifiers)-1]["name"])
+
+	assertModifiersMatchPrice(t, result)
+}
+
+func TestGameManager_GetActiveModifiers_PriceLimit(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.market.State.CurrentDemand = market.DemandVeryHigh
+	gm.market.State.CurrentSupply = market.SupplyVeryLow
+	gm.market.State.CurrentSeason = item.SeasonWinter
+
+	result := gm.GetActiveModifiers("health_potion")
+	require.True(t, result["success"].(bool))
+
+	modifiers := result["modifiers"].([]map[string]interface{})
+	require.GreaterOrEqual(t, len(modifiers), 4)
+	assert.Equal(t, "price_limit", modifiers[3]["name"])
+	assert.Equal(t, 2*result["basePrice"].(int), result["fairValue"])
+
+	assertModifiersMatchPrice(t, result)
+
+	assert.False(t, gm.GetActiveModifiers("dragon_egg")["success"].(bool))
+}
+
+// assertModifiersMatchPrice checks the listed multipliers reproduce the shown price
+func assertModifiersMatchPrice(t *testing.T, result map[string]interface{}) {
+	t.Helper()
+
+	product := 1.0
+	for _, modifier := range result["modifiers"].([]map[string]interface{}) {
+		product *= modifier["multiplier"].(float64)
+	}
+	assert.InDelta(t, result["netMultiplier"].(float64), product, 1e-9)
+
+	basePrice := float64(result["basePrice"].(int))
+	assert.InDelta(t, float64(result["price"].(int)), basePrice*product, 0.5)
+}
+
+func TestGameManager_AuditFinancials(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	require.True(t, gm.BuyItem("iron_sword", 2, 150)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 2))
+	require.True(t, gm.SellItem("iron_sword", 1, 200)["success"].(bool))
+
+	audit := gm.AuditFinancials()
+	assert.True(t, audit["consistent"].(bool))
+	assert.Equal(t, 0, audit["discrepancy"])
+	assert.Equal(t, 2, audit["entries"])
+
+	// Gold that appears without a ledger entry is flagged
+	require.NoError(t, gm.gameState.AddGold(75))
+
+	audit = gm.AuditFinancials()
+	assert.False(t, audit["consistent"].(bool))
+	assert.Equal(t, 75, audit["discrepancy"])
+	assert.Equal(t, audit["actualGold"].(int)-75, audit["expectedGold"])
+}
+
+func TestGameManager_GetRecentTransactions(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	require.True(t, gm.BuyItem("iron_sword", 3, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 3))
+	require.True(t, gm.SellItem("iron_sword", 1, 150)["success"].(bool))
+	require.True(t, gm.SellItem("iron_sword", 2, 150)["success"].(bool))
+
+	result := gm.GetRecentTransactions(2)
+	require.True(t, result["success"].(bool))
+	transactions := result["transactions"].([]map[string]interface{})
+	require.Len(t, transactions, 2)
+
+	// Newest first, and older entries beyond the limit are dropped
+	assert.Equal(t, "sale", transactions[0]["type"])
+	assert.Contains(t, transactions[0]["text"], "Sold 2x Iron Sword")
+	assert.Contains(t, transactions[1]["text"], "Sold 1x Iron Sword")
+
+	all := gm.GetRecentTransactions(10)["transactions"].([]map[string]interface{})
+	require.Len(t, all, 3)
+	assert.Contains(t, all[2]["text"], "Bought 3x Iron Sword")
+
+	// Cached results refresh once the ledger changes
+	require.True(t, gm.BuyItem("apple", 1, 10)["success"].(bool))
+	latest := gm.GetRecentTransactions(10)["transactions"].([]map[string]interface{})
+	require.Len(t, latest, 4)
+	assert.Contains(t, latest[0]["text"], "Bought 1x")
+}
+
+func TestGameManager_GetSeasonalForecast(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetCurrentDay(28)
+
+	forecast := gm.GetSeasonalForecast(5)
+	require.True(t, forecast["success"].(bool))
+
+	seasons := forecast["seasons"].([]map[string]interface{})
+	require.Len(t, seasons, 2)
+
+	assert.Equal(t, "Spring", seasons[0]["season"])
+	assert.Equal(t, 28, seasons[0]["startDay"])
+	assert.Equal(t, 30, seasons[0]["endDay"])
+
+	// Summer starts within the window and brings its own modifiers
+	assert.Equal(t, "Summer", seasons[1]["season"])
+	assert.Equal(t, 31, seasons[1]["startDay"])
+	assert.Equal(t, 32, seasons[1]["endDay"])
+	assert.Equal(t, 3, seasons[1]["daysUntil"])
+	assert.Equal(t, 1.15, seasons[1]["priceModifiers"].(map[string]float64)["potions"])
+
+	assert.False(t, gm.GetSeasonalForecast(0)["success"].(bool))
+}
+
+func TestGameManager_SeasonReachesMarket(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetCurrentDay(90)
+	valueBefore := gm.market.GetFairValue("apple")
+
+	// Day 91 is the first day of winter, when fruit gets dear
+	gm.AdvanceTime(1)
+	assert.Equal(t, "Winter", gm.gameState.GetCurrentSeason())
+	assert.Equal(t, item.SeasonWinter, gm.market.GetSeason())
+	assert.Greater(t, gm.market.GetFairValue("apple"), valueBefore)
+
+	effects := gm.GetSeasonalEffects()
+	modifiers := effects["priceModifiers"].(map[string]float64)
+	assert.Equal(t, market.SeasonalModifier(item.SeasonWinter, item.CategoryFruit), modifiers["fruits"])
+	assert.Equal(t, 1.3, modifiers["fruits"])
+}
+
+func TestGameManager_GetDisplayPrice(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.AdvanceTime(5)
+
+	result := gm.GetDisplayPrice("iron_sword")
+	require.True(t, result["success"].(bool))
+	rawSeries := result["rawSeries"].([]int)
+	displaySeries := result["displaySeries"].([]int)
+	require.NotEmpty(t, rawSeries)
+	assert.Len(t, displaySeries, len(rawSeries))
+	assert.Equal(t, gm.market.GetPrice("iron_sword"), result["rawPrice"])
+	assert.Equal(t, rawSeries[len(rawSeries)-1], result["rawPrice"])
+	assert.Equal(t, displaySeries[len(displaySeries)-1], result["displayPrice"])
+	assert.Equal(t, market.DefaultPriceSmoothing, result["smoothing"])
+
+	// Without smoothing the display price is the raw price
+	require.NoError(t, gm.SetPriceSmoothing(1))
+	result = gm.GetDisplayPrice("iron_sword")
+	assert.Equal(t, result["rawPrice"], result["displayPrice"])
+	assert.Equal(t, result["rawSeries"], result["displaySeries"])
+
+	assert.Error(t, gm.SetPriceSmoothing(0))
+	assert.False(t, gm.GetDisplayPrice("dragon_egg")["success"].(bool))
+}
+
+func TestGameManager_CriticalGoldWarning(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	warnings := make(chan *event.CriticalGoldWarningEvent, 1)
+	gm.eventBus.Subscribe(event.EventNameCriticalGoldWarning, func(e event.Event) error {
+		warnings <- e.(*event.CriticalGoldWarningEvent)
+		return nil
+	})
+
+	startGold := gm.gameState.GetGold()
+	assert.Error(t, gm.SetCriticalGoldWarning(-1, true))
+	require.NoError(t, gm.SetCriticalGoldWarning(startGold-100, true))
+
+	// Spending that stays above the threshold does nothing
+	require.True(t, gm.BuyItem("apple", 1, 10)["success"].(bool))
+	assert.False(t, gm.isPaused)
+
+	require.True(t, gm.BuyItem("iron_sword", 1, 150)["success"].(bool))
+	select {
+	case warning := <-warnings:
+		assert.Equal(t, gm.gameState.GetGold(), warning.Gold)
+		assert.Equal(t, startGold-100, warning.Threshold)
+		assert.True(t, warning.Paused)
+	case <-time.After(time.Second):
+		t.Fatal("expected a critical gold warning")
+	}
+	assert.True(t, gm.isPaused)
+
+	// Without auto-pause the warning still fires but the game keeps running
+	gm.ResumeGame()
+	require.NoError(t, gm.SetCriticalGoldWarning(gm.gameState.GetGold()+1, false))
+	require.True(t, gm.BuyItem("apple", 1, 10)["success"].(bool))
+	select {
+	case warning := <-warnings:
+		assert.False(t, warning.Paused)
+	case <-time.After(time.Second):
+		t.Fatal("expected a critical gold warning")
+	}
+	assert.False(t, gm.isPaused)
+}
+
+func TestGameManager_GetTradeSuggestions(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	// Drive apple prices up over several days
+	gm.market.State.CurrentDemand = market.DemandVeryHigh
+	gm.market.State.CurrentSupply = market.SupplyVeryLow
+	for day := 2; day <= 4; day++ {
+		gm.market.SetDay(day)
+		gm.market.UpdatePrice("apple")
+	}
+	require.Equal(t, market.TrendUp, gm.market.GetPriceHistory("apple").GetTrend())
+
+	result := gm.GetTradeSuggestions()
+	require.True(t, result["success"].(bool))
+	suggestions := result["suggestions"].([]map[string]interface{})
+
+	var buy map[string]interface{}
+	for _, suggestion := range suggestions {
+		if suggestion["action"] == "buy" && suggestion["itemId"] == "apple" {
+			buy = suggestion
+		}
+	}
+	require.NotNil(t, buy, "expected a buy suggestion for the rising apple price")
+	assert.Greater(t, buy["quantity"].(int), 0)
+	assert.Greater(t, buy["expectedGain"].(int), 0)
+	assert.Contains(t, buy["rationale"], "trending up")
+
+	// Suggestions are ranked by expected gain
+	for i := 1; i < len(suggestions); i++ {
+		assert.GreaterOrEqual(t, suggestions[i-1]["expectedGain"].(int), suggestions[i]["expectedGain"].(int))
+	}
+}
+
+func TestGameManager_GetGameSummary(t *testing.T) {
+	gm := newTestGameManager(t)
+	startGold := gm.gameState.GetGold()
+
+	require.True(t, gm.BuyItem("iron_sword", 3, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 3))
+	require.True(t, gm.SellItem("iron_sword", 1, 200)["success"].(bool))
+	require.True(t, gm.SellItem("iron_sword", 1, 50)["success"].(bool))
+	gm.AdvanceTime(4)
+
+	// Going broke ends the game
+	gm.gameState.SetGoldWithReason(0, "test")
+
+	summary := gm.GetGameSummary()
+	require.True(t, summary["success"].(bool))
+	assert.Equal(t, "defeat", summary["outcome"])
+	assert.Equal(t, 0, summary["finalGold"])
+	assert.Equal(t, startGold, summary["startingGold"])
+	assert.Equal(t, startGold, summary["peakGold"])
+	assert.Equal(t, 5, summary["daysPlayed"])
+	assert.Equal(t, 3, summary["totalTransactions"])
+	assert.Equal(t, "Apprentice", summary["rank"])
+	assert.Equal(t, "F", summary["grade"])
+
+	// The remaining sword still counts toward net worth
+	assert.Equal(t, gm.market.GetFairValue("iron_sword"), summary["inventoryValue"])
+	assert.Equal(t, summary["inventoryValue"], summary["netWorth"])
+
+	best := summary["bestTrade"].(map[string]interface{})
+	assert.Greater(t, best["profit"].(int), 0)
+	loss := summary["biggestLoss"].(map[string]interface{})
+	assert.Less(t, loss["profit"].(int), 0)
+	assert.Contains(t, loss["text"], "Sold 1x Iron Sword")
+}
+
+func TestGameManager_GetActiveEffectsTimeline(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetCurrentDay(27)
+	gm.market.SetDay(27)
+
+	gm.market.ApplyEvent(&market.MarketEvent{
+		Type:     market.EventHarvestFestival,
+		Name:     "Harvest Festival",
+		Duration: 5,
+		Effects:  []market.EventEffect{{Type: market.EffectSupplyIncrease, Value: 1}},
+	})
+
+	result := gm.GetActiveEffectsTimeline(10)
+	require.True(t, result["success"].(bool))
+	timeline := result["timeline"].([]map[string]interface{})
+	require.Len(t, timeline, 3)
+
+	// Spring is underway, then the festival, then summer starts on day 31
+	assert.Equal(t, "Spring", timeline[0]["name"])
+	assert.Equal(t, 1, timeline[0]["startDay"])
+	assert.Equal(t, 30, timeline[0]["endDay"])
+
+	festival := timeline[1]
+	assert.Equal(t, "event", festival["type"])
+	assert.Equal(t, 27, festival["startDay"])
+	assert.Equal(t, 31, festival["endDay"])
+	assert.True(t, festival["active"].(bool))
+	assert.Equal(t, 1.0, festival["impacts"].(map[string]float64)["supply"])
+
+	summer := timeline[2]
+	assert.Equal(t, "season", summer["type"])
+	assert.Equal(t, "Summer", summer["name"])
+	assert.Equal(t, 31, summer["startDay"])
+	assert.Equal(t, 60, summer["endDay"])
+	assert.False(t, summer["active"].(bool))
+}
+
+func TestGameManager_GetCategories(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	categories := gm.GetCategories()
+	require.Len(t, categories, len(item.AllCategories()))
+
+	unlocked := func() map[string]bool {
+		status := make(map[string]bool)
+		for _, category := range gm.GetCategories() {
+			assert.NotEmpty(t, category["displayName"])
+			assert.NotEmpty(t, category["icon"])
+			status[category["id"].(string)] = category["unlocked"].(bool)
+		}
+		return status
+	}
+
+	status := unlocked()
+	assert.True(t, status[string(item.CategoryFruit)])
+	assert.True(t, status[string(item.CategoryPotion)])
+	assert.False(t, status[string(item.CategoryWeapon)])
+	assert.False(t, status[string(item.CategoryGem)])
+
+	gm.gameState.SetRank(gamestate.RankExpert)
+	status = unlocked()
+	assert.True(t, status[string(item.CategoryWeapon)])
+	assert.True(t, status[string(item.CategoryAccessory)])
+	assert.True(t, status[string(item.CategoryMagicBook)])
+	assert.False(t, status[string(item.CategoryGem)])
+}
+
+func TestGameManager_AbandonQuest(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	result := gm.AbandonQuest(string(quest.QuestFirstTrade))
+	assert.False(t, result["success"].(bool))
+
+	require.NoError(t, gm.quests.StartQuest(quest.QuestFirstTrade, 1))
+	result = gm.AbandonQuest(string(quest.QuestFirstTrade))
+	require.True(t, result["success"].(bool), result["message"])
+
+	firstTrade, _ := gm.quests.GetQuest(quest.QuestFirstTrade)
+	assert.Equal(t, quest.QuestStatusAvailable, firstTrade.Status)
+	assert.Equal(t, 1, gm.quests.GetStatistics().TotalAbandoned)
+}
+
+func TestGameManager_GetQuestRewardsPreview(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	assert.False(t, gm.GetQuestRewardsPreview("no_such_quest")["success"].(bool))
+
+	// Available quest: unlocks resolve to quest names
+	preview := gm.GetQuestRewardsPreview(string(quest.QuestFirstTrade))
+	require.True(t, preview["success"].(bool))
+	assert.Equal(t, "available", preview["status"])
+	assert.Equal(t, 100, preview["gold"])
+	assert.Equal(t, 50, preview["experience"])
+	unlocks := preview["unlocks"].([]map[string]interface{})
+	require.Len(t, unlocks, 1)
+	assert.Equal(t, string(quest.QuestFirstProfit), unlocks[0]["questId"])
+	assert.Equal(t, "Profitable Trader", unlocks[0]["name"])
+
+	// Reward items resolve to registry names, falling back to the ID
+	diversify, _ := gm.quests.GetQuest(quest.QuestDiversifyPortfolio)
+	diversify.Rewards.Items["iron_sword"] = 2
+	preview = gm.GetQuestRewardsPreview(string(quest.QuestDiversifyPortfolio))
+	assert.Equal(t, "locked", preview["status"])
+	items := preview["items"].([]map[string]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, "investment_guide", items[0]["name"])
+	assert.Equal(t, "Iron Sword", items[1]["name"])
+	assert.Equal(t, 2, items[1]["quantity"])
+
+	// Previews still work once the quest is active
+	require.NoError(t, gm.quests.StartQuest(quest.QuestFirstTrade, 1))
+	assert.Equal(t, "active", gm.GetQuestRewardsPreview(string(quest.QuestFirstTrade))["status"])
+}
+
+func TestGameManager_AutoSellOnDayAdvance(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 2, 150)["success"].(bool))
+	require.True(t, gm.SetAutoSell("iron_sword", 0, 1)["success"].(bool))
+	assert.False(t, gm.SetAutoSell("iron_sword", 0, 0)["success"].(bool))
+
+	goldBefore := gm.gameState.GetGold()
+	gm.AdvanceTime(1)
+
+	assert.Equal(t, 0, gm.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.Greater(t, gm.gameState.GetGold(), goldBefore)
+	assert.Equal(t, gm.ledger.ExpectedBalance(), gm.gameState.GetGold())
+}
+
+func TestGameManager_AutoSellRespectsLiquidity(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.market.SetLiquidity("iron_sword", 2))
+	require.True(t, gm.BuyItem("iron_sword", 6, 150)["success"].(bool))
+	require.True(t, gm.SetAutoSell("iron_sword", 1, 0)["success"].(bool))
+
+	priceBefore := gm.market.GetPrice("iron_sword")
+	gm.mu.Lock()
+	actions := gm.processAutoSellUnsafe()
+	gm.mu.Unlock()
+	require.Len(t, actions, 1)
+	require.Equal(t, 6, actions[0].Quantity)
+
+	// Dumping the whole stack past the day's liquidity pushes the price down
+	// and realizes less than the quoted price
+	assert.Less(t, gm.market.GetPrice("iron_sword"), priceBefore)
+	assert.Zero(t, gm.market.GetRemainingLiquidity("iron_sword"))
+	entries := gm.ledger.Entries()
+	sale := entries[len(entries)-1]
+	fullPrice := float64(priceBefore) * (1 - gm.tradeSpread/2) * 6
+	assert.Less(t, float64(sale.Amount+sale.Tax), fullPrice-1)
+	assert.Equal(t, gm.ledger.ExpectedBalance(), gm.gameState.GetGold())
+}
+
+func TestGameManager_SellingPastLiquidity(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.market.SetLiquidity("iron_sword", 2))
+	require.True(t, gm.BuyItem("iron_sword", 6, 150)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 6))
+
+	within := gm.SellItem("iron_sword", 2, 150)
+	require.True(t, within["success"].(bool))
+	assert.Equal(t, 0, within["excess_units"])
+
+	priceBefore := gm.market.GetPrice("iron_sword")
+	dumped := gm.SellItem("iron_sword", 2, 150)
+	require.True(t, dumped["success"].(bool))
+	assert.Equal(t, 2, dumped["excess_units"])
+	assert.Less(t, dumped["gold_gained"].(int), within["gold_gained"].(int))
+	assert.Less(t, gm.market.GetPrice("iron_sword"), priceBefore)
+}
+
+func TestGameManager_GetCapacityForecast(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetCurrentDay(7)
+	require.NoError(t, gm.inventory.AddToWarehouseByID("apple", 110, 8))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 10))
+
+	// Buying 20 apples a day while selling 5 grows stock by 15 a day
+	for day := 1; day <= 7; day++ {
+		gm.ledger.Record(ledger.Entry{Type: ledger.EntryPurchase, ItemID: "apple", Quantity: 20, Amount: -160, Day: day})
+		gm.ledger.Record(ledger.Entry{Type: ledger.EntrySale, ItemID: "apple", Quantity: 5, Amount: 60, Profit: 20, Day: day})
+	}
+
+	result := gm.GetCapacityForecast(10)
+	require.True(t, result["success"].(bool))
+	assert.InDelta(t, 20.0, result["dailyPurchases"], 0.0001)
+	assert.InDelta(t, 5.0, result["dailySales"], 0.0001)
+
+	// The warehouse starts at 100 of 200 and is full on the seventh day out
+	warehouse := result["warehouse"].(map[string]interface{})
+	assert.Equal(t, 100, warehouse["used"])
+	assert.Equal(t, 14, warehouse["fullOnDay"])
+	shop := result["shop"].(map[string]interface{})
+	assert.Equal(t, 0, shop["fullOnDay"])
+
+	forecast := result["forecast"].([]map[string]interface{})
+	require.Len(t, forecast, 10)
+	assert.Equal(t, 8, forecast[0]["day"])
+	assert.Equal(t, 115.0, forecast[0]["warehouseUsed"])
+	assert.Equal(t, 10.0, forecast[0]["shopUsed"])
+	assert.Equal(t, 200.0, forecast[9]["warehouseUsed"])
+
+	// A known sales velocity keeping pace with purchases holds stock steady
+	// once the shop carries enough to sell
+	gm.inventory.SetSalesVelocity("apple", 20)
+	require.NoError(t, gm.inventory.TransferToShop("apple", 20))
+	result = gm.GetCapacityForecast(10)
+	assert.Equal(t, 0, result["warehouse"].(map[string]interface{})["fullOnDay"])
+
+	assert.False(t, gm.GetCapacityForecast(0)["success"].(bool))
+	assert.False(t, gm.GetCapacityForecast(maxForecastDays + 1)["success"].(bool))
+}
+
+func TestGameManager_GetTradingJournal(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.dayRecords = make(map[int]*dayRecord)
+
+	trades := []ledger.Entry{
+		{Type: ledger.EntryPurchase, ItemID: "apple", Quantity: 20, Amount: -200, Day: 1},
+		{Type: ledger.EntrySale, ItemID: "apple", Quantity: 15, Amount: 225, Profit: 75, Day: 2},
+		{Type: ledger.EntrySpoilage, ItemID: "apple", Quantity: 5, Profit: -50, Day: 4},
+		{Type: ledger.EntryPurchase, ItemID: "iron_sword", Quantity: 2, Amount: -300, Day: 1},
+		{Type: ledger.EntrySale, ItemID: "iron_sword", Quantity: 2, Amount: 400, Profit: 100, Day: 3},
+	}
+	for _, trade := range trades {
+		gm.ledger.Record(trade)
+	}
+	gm.gameState.SetCurrentDay(4)
+
+	// Apples dipped on days 1 and 3 but were only bought on day 1
+	appleItem, exists := gm.market.GetItem("apple")
+	require.True(t, exists)
+	dip := appleItem.BasePrice / 2
+	for day, price := range map[int]int{1: dip, 2: appleItem.BasePrice, 3: dip} {
+		gm.dayRecordUnsafe(day).prices = map[string]int{"apple": price}
+	}
+
+	result := gm.GetTradingJournal()
+	require.True(t, result["success"].(bool))
+	insights := result["insights"].([]map[string]interface{})
+	byKind := make(map[string][]map[string]interface{})
+	for _, insight := range insights {
+		byKind[insight["kind"].(string)] = append(byKind[insight["kind"].(string)], insight)
+	}
+
+	// A quarter of the fruit stocked spoiled before it sold
+	require.Len(t, byKind["spoilage"], 1)
+	spoilage := byKind["spoilage"][0]
+	assert.Equal(t, string(item.CategoryFruit), spoilage["category"])
+	assert.Equal(t, 5, spoilage["unitsSpoiled"])
+	assert.InDelta(t, 25.0, spoilage["spoiledPercent"], 0.0001)
+	assert.Equal(t, 50, spoilage["costLost"])
+	assert.Equal(t, "You tend to sell fruit too late (25% spoiled)", spoilage["message"])
+
+	// Margins by category
+	require.Len(t, byKind["margin"], 2)
+	assert.Equal(t, string(item.CategoryFruit), byKind["margin"][0]["category"])
+	assert.InDelta(t, 100.0/3, byKind["margin"][0]["marginPercent"], 0.0001)
+	assert.Equal(t, "Your weapon trades average 25% margin", byKind["margin"][1]["message"])
+
+	require.Len(t, byKind["missed_dip"], 1)
+	assert.Equal(t, 1, byKind["missed_dip"][0]["count"])
+	assert.Equal(t, []string{"apple"}, byKind["missed_dip"][0]["itemIds"])
+
+	// Occasional spoilage is not called out
+	gm.ledger.Reset(1000)
+	gm.ledger.Record(ledger.Entry{Type: ledger.EntrySale, ItemID: "apple", Quantity: 95, Amount: 950, Profit: 100, Day: 2})
+	gm.ledger.Record(ledger.Entry{Type: ledger.EntrySpoilage, ItemID: "apple", Quantity: 5, Profit: -50, Day: 4})
+	for _, insight := range gm.GetTradingJournal()["insights"].([]map[string]interface{}) {
+		assert.NotEqual(t, "spoilage", insight["kind"])
+	}
+}
+
+func TestGameManager_GetProfitLeaderboard(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	trades := []ledger.Entry{
+		{Type: ledger.EntryPurchase, ItemID: "apple", Quantity: 10, Amount: -100, Day: 1},
+		{Type: ledger.EntrySale, ItemID: "apple", Quantity: 10, Amount: 200, Profit: 100, Day: 1},
+		{Type: ledger.EntryPurchase, ItemID: "iron_sword", Quantity: 2, Amount: -300, Day: 1},
+		{Type: ledger.EntrySale, ItemID: "iron_sword", Quantity: 2, Amount: 400, Profit: 100, Day: 1},
+		{Type: ledger.EntrySale, ItemID: "iron_sword", Quantity: 1, Amount: 200, Profit: 50, Day: 3},
+		{Type: ledger.EntryPurchase, ItemID: "health_potion", Quantity: 4, Amount: -200, Day: 3},
+		{Type: ledger.EntrySale, ItemID: "health_potion", Quantity: 4, Amount: 160, Profit: -40, Day: 3},
+		{Type: ledger.EntryExpense, Amount: -50, Day: 3},
+	}
+	for _, trade := range trades {
+		gm.ledger.Record(trade)
+	}
+	gm.gameState.SetCurrentDay(3)
+
+	result := gm.GetProfitLeaderboard(0)
+	require.True(t, result["success"].(bool))
+	leaderboard := result["leaderboard"].([]map[string]interface{})
+	require.Len(t, leaderboard, 3)
+
+	assert.Equal(t, "iron_sword", leaderboard[0]["itemId"])
+	assert.Equal(t, 150, leaderboard[0]["profit"])
+	assert.Equal(t, 5, leaderboard[0]["unitsTraded"])
+	assert.InDelta(t, 0.25, leaderboard[0]["averageMargin"], 0.0001)
+
+	assert.Equal(t, "apple", leaderboard[1]["itemId"])
+	assert.InDelta(t, 0.5, leaderboard[1]["averageMargin"], 0.0001)
+
+	assert.Equal(t, "health_potion", leaderboard[2]["itemId"])
+	assert.Equal(t, -40, leaderboard[2]["profit"])
+	assert.Equal(t, 3, leaderboard[2]["rank"])
+
+	// A one-day window only counts today's trades
+	leaderboard = gm.GetProfitLeaderboard(1)["leaderboard"].([]map[string]interface{})
+	require.Len(t, leaderboard, 2)
+	assert.Equal(t, "iron_sword", leaderboard[0]["itemId"])
+	assert.Equal(t, 50, leaderboard[0]["profit"])
+	assert.Equal(t, 1, leaderboard[0]["unitsTraded"])
+
+	assert.False(t, gm.GetProfitLeaderboard(-1)["success"].(bool))
+}
+
+func TestGameManager_DailyEvents(t *testing.T) {
+	rollWeek := func(seed int64) []map[string]interface{} {
+		gm := newTestGameManager(t)
+		gm.SetEventSeed(seed)
+		require.NoError(t, gm.eventRoller.SetOdds(events.DailyEventOdds{MarketShock: 0.5, Opportunity: 0.5, Hazard: 0.3}))
+
+		week := make([]map[string]interface{}, 0)
+		for day := 0; day < 7; day++ {
+			gm.AdvanceTime(1)
+			week = append(week, gm.GetDailyEvents()["events"].([]map[string]interface{})...)
+		}
+		return week
+	}
+
+	// A fixed seed replays the same events
+	week := rollWeek(1234)
+	require.NotEmpty(t, week)
+	replay := rollWeek(1234)
+	require.Len(t, replay, len(week))
+	for i := range week {
+		assert.Equal(t, week[i]["kind"], replay[i]["kind"])
+		assert.Equal(t, week[i]["day"], replay[i]["day"])
+		assert.Equal(t, week[i]["itemId"], replay[i]["itemId"])
+		assert.Equal(t, week[i]["magnitude"], replay[i]["magnitude"])
+	}
+}
+
+func TestGameManager_DailyEventEffects(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.SetEventSeed(99)
+	require.True(t, gm.BuyItem("apple", 10, 10)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 10))
+
+	published := make(chan *event.DailyEventRolledEvent, 3)
+	gm.eventBus.Subscribe(event.EventNameDailyEventRolled, func(e event.Event) error {
+		published <- e.(*event.DailyEventRolledEvent)
+		return nil
+	})
+
+	require.NoError(t, gm.eventRoller.SetOdds(events.DailyEventOdds{MarketShock: 1, Opportunity: 1, Hazard: 1}))
+	gm.AdvanceTime(1)
+
+	rolled := gm.GetDailyEvents()["events"].([]map[string]interface{})
+	require.Len(t, rolled, 3)
+
+	shock := rolled[0]
+	assert.Equal(t, string(events.DailyEventMarketShock), shock["kind"])
+	assert.Equal(t, shock["price"], gm.market.GetPrice(shock["itemId"].(string)))
+
+	offer := rolled[1]
+	assert.Equal(t, string(events.DailyEventOpportunity), offer["kind"])
+	assert.Less(t, offer["price"].(int), gm.market.GetPrice(offer["itemId"].(string)))
+
+	theft := rolled[2]
+	assert.Equal(t, string(events.DailyEventHazard), theft["kind"])
+	lost := theft["unitsLost"].(map[string]int)["apple"]
+	assert.Greater(t, lost, 0)
+	assert.Equal(t, 10-lost, gm.inventory.GetShopQuantity("apple"))
+
+	for i := 0; i < 3; i++ {
+		select {
+		case rolledEvent := <-published:
+			assert.Equal(t, 2, rolledEvent.Day)
+		case <-time.After(time.Second):
+			t.Fatal("expected a daily event to be published")
+		}
+	}
+}
+
+func TestGameManager_MarketFollowsGameDay(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetCurrentDay(12)
+
+	// Each new day moves the market to the game's day rather than a day on
+	// from wherever it was
+	gm.AdvanceTime(1)
+	assert.Equal(t, 13, gm.market.State.CurrentDay)
+	assert.Equal(t, gm.gameState.GetCurrentDay(), gm.market.State.CurrentDay)
+}
+
+func TestGameManager_BuyCaravanOffer(t *testing.T) {
+	gm := newTestGameManager(t)
+	assert.False(t, gm.BuyCaravanOffer(1)["success"].(bool), "no caravan yet")
+
+	require.NoError(t, gm.eventRoller.SetOdds(events.DailyEventOdds{Opportunity: 1}))
+	gm.AdvanceTime(1)
+	offer := gm.GetDailyEvents()["events"].([]map[string]interface{})[0]
+	itemID := offer["itemId"].(string)
+	assert.Equal(t, caravanOfferUnits, offer["unitsLeft"])
+
+	// The caravan sells at its asking price, below the market's
+	assert.False(t, gm.BuyCaravanOffer(caravanOfferUnits + 1)["success"].(bool))
+	result := gm.BuyCaravanOffer(3)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, itemID, result["itemId"])
+	assert.Equal(t, caravanOfferUnits-3, result["unitsLeft"])
+	assert.Equal(t, 3, gm.inventory.GetWarehouseQuantity(itemID))
+	assert.Equal(t, offer["price"], gm.inventory.GetPurchasePrice(itemID))
+	assert.Less(t, gm.inventory.GetPurchasePrice(itemID), gm.market.GetPrice(itemID))
+	assert.Equal(t, caravanOfferUnits-3, gm.GetDailyEvents()["events"].([]map[string]interface{})[0]["unitsLeft"])
+	assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+}
+
+func TestGameManager_TheftInsurance(t *testing.T) {
+	simulateTheft := func(insured bool) (goldBefore, goldAfter int, theft map[string]interface{}) {
+		gm := newTestGameManager(t)
+		gm.SetEventSeed(7)
+		require.True(t, gm.BuyItem("apple", 10, 10)["success"].(bool))
+		require.NoError(t, gm.inventory.TransferToShop("apple", 10))
+
+		if insured {
+			result := gm.BuyInsurance(3)
+			require.True(t, result["success"].(bool))
+			assert.Equal(t, 3*insurance.DefaultDailyPremium, result["premium"])
+			assert.False(t, gm.BuyInsurance(3)["success"].(bool))
+		}
+
+		goldBefore = gm.gameState.GetGold()
+		require.NoError(t, gm.eventRoller.SetOdds(events.DailyEventOdds{Hazard: 1}))
+		gm.AdvanceTime(1)
+
+		rolled := gm.GetDailyEvents()["events"].([]map[string]interface{})
+		require.Len(t, rolled, 1)
+		assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+		return goldBefore, gm.gameState.GetGold(), rolled[0]
+	}
+
+	// Uninsured players absorb the whole loss
+	before, after, theft := simulateTheft(false)
+	uninsuredLoss := theft["lossValue"].(int)
+	assert.Greater(t, uninsuredLoss, 0)
+	assert.Equal(t, 0, theft["reimbursed"])
+	assert.Equal(t, before, after)
+
+	// Insured players are paid back the covered share of the same loss
+	before, after, theft = simulateTheft(true)
+	assert.Equal(t, uninsuredLoss, theft["lossValue"])
+	reimbursed := theft["reimbursed"].(int)
+	assert.Equal(t, int(math.Round(float64(uninsuredLoss)*insurance.DefaultCoverage)), reimbursed)
+	assert.Equal(t, before+reimbursed, after)
+}
+
+func TestGameManager_InsuranceStatus(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	status := gm.GetInsuranceStatus()
+	assert.False(t, status["insured"].(bool))
+	assert.False(t, gm.BuyInsurance(0)["success"].(bool))
+
+	gold := gm.gameState.GetGold()
+	require.True(t, gm.BuyInsurance(2)["success"].(bool))
+	assert.Equal(t, gold-2*insurance.DefaultDailyPremium, gm.gameState.GetGold())
+
+	status = gm.GetInsuranceStatus()
+	assert.True(t, status["insured"].(bool))
+	assert.Equal(t, 2, status["daysRemaining"])
+	assert.Equal(t, 2*insurance.DefaultDailyPremium, status["totalPremiums"])
+
+	// The policy belongs to the save it was bought in
+	require.NoError(t, gm.SaveGame(1))
+	require.NoError(t, gm.StartNewGame("Uninsured"))
+	assert.False(t, gm.GetInsuranceStatus()["insured"].(bool))
+	require.NoError(t, gm.SaveGame(2))
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, status, gm.GetInsuranceStatus())
+	require.NoError(t, gm.LoadGame(2))
+	assert.False(t, gm.GetInsuranceStatus()["insured"].(bool))
+	assert.Equal(t, 0, gm.GetInsuranceStatus()["totalPremiums"])
+	require.NoError(t, gm.LoadGame(1))
+
+	gm.AdvanceTime(2)
+	assert.False(t, gm.GetInsuranceStatus()["insured"].(bool))
+}
+
+func TestGameManager_GetDailyReport(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	// A day of mixed activity: purchases with a tariff, a sale, an upgrade
+	// and an insurance premium
+	require.True(t, gm.BuyItem("apple", 10, 10)["success"].(bool))
+	require.True(t, gm.BuyItem("iron_sword", 2, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 10))
+	require.True(t, gm.SellItem("apple", 4, 20)["success"].(bool))
+	require.True(t, gm.UpgradeInventoryCapacity("shop", 5, 50)["success"].(bool))
+	require.True(t, gm.BuyInsurance(1)["success"].(bool))
+
+	report := gm.GetDailyReport(1)
+	require.True(t, report["success"].(bool))
+	assert.True(t, report["inProgress"].(bool))
+	assert.Equal(t, 4, report["unitsSold"])
+	assert.Equal(t, 12, report["unitsBought"])
+	assert.Equal(t, 50+insurance.DefaultDailyPremium, report["expenses"])
+	assert.Greater(t, report["taxesPaid"].(int), 0)
+	assert.Greater(t, report["salesRevenue"].(int), 0)
+	assert.Equal(t, report["closingGold"], gm.gameState.GetGold())
+	assert.Equal(t, report["openingGold"].(int)+report["netProfit"].(int), report["closingGold"])
+	assert.Equal(t,
+		report["salesRevenue"].(int)-report["purchaseCost"].(int)-report["expenses"].(int),
+		report["netProfit"])
+
+	gm.AdvanceTime(1)
+	closed := gm.GetDailyReport(1)
+	assert.False(t, closed["inProgress"].(bool))
+	assert.Equal(t, report["closingGold"], closed["closingGold"])
+
+	today := gm.GetDailyReport(2)
+	assert.Equal(t, closed["closingGold"], today["openingGold"])
+	assert.Equal(t, 0, today["netProfit"])
+	for _, change := range today["priceChanges"].([]map[string]interface{}) {
+		assert.GreaterOrEqual(t, math.Abs(change["change"].(float64)), notablePriceMove)
+	}
+
+	// A doubled price is called out, a steady one is not
+	applePrice := gm.market.GetPrice("apple")
+	gm.dayRecords = make(map[int]*dayRecord)
+	gm.recordPriceMovesUnsafe(map[string]int{"apple": applePrice / 2, "iron_sword": gm.market.GetPrice("iron_sword")})
+	changes := gm.GetDailyReport(2)["priceChanges"].([]map[string]interface{})
+	require.Len(t, changes, 1)
+	assert.Equal(t, "apple", changes[0]["itemId"])
+	assert.Equal(t, applePrice, changes[0]["to"])
+
+	assert.False(t, gm.GetDailyReport(3)["success"].(bool))
+	assert.False(t, gm.GetDailyReport(0)["success"].(bool))
+}
+
+func TestGameManager_FormattedReportFollowsLocale(t *testing.T) {
+	gm := newTestGameManager(t)
+	gold := gm.gameState.GetGold()
+	require.Greater(t, gold, 999)
+
+	english := gm.GetDailyReport(1)["formatted"].(map[string]interface{})
+	assert.Equal(t, newNumberFormatter("en", "gold").Gold(gold), english["closingGold"])
+
+	require.True(t, gm.UpdateSettings("game", map[string]interface{}{"language": "de"})["success"].(bool))
+	german := gm.GetDailyReport(1)["formatted"].(map[string]interface{})
+	assert.NotEqual(t, english["closingGold"], german["closingGold"])
+	assert.Contains(t, german["closingGold"], ".")
+
+	// Raw values are unchanged by the locale
+	assert.Equal(t, gold, gm.GetDailyReport(1)["closingGold"])
+	assert.Equal(t, german["closingGold"], gm.GetGameSummary()["formatted"].(map[string]interface{})["finalGold"])
+
+	assert.False(t, gm.UpdateSettings("game", map[string]interface{}{"language": "klingon"})["success"].(bool))
+}
+
+func TestGameManager_PriceUpdatesReachEventBus(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	published := make(chan *event.PriceUpdatedEvent, 10)
+	gm.eventBus.Subscribe(event.EventNamePriceUpdated, func(e event.Event) error {
+		published <- e.(*event.PriceUpdatedEvent)
+		return nil
+	})
+
+	newPrice := gm.market.ApplyPriceShock("apple", -0.2)
+
+	select {
+	case update := <-published:
+		assert.Equal(t, "apple", update.ItemID)
+		assert.Equal(t, newPrice, update.NewPrice)
+		assert.Equal(t, market.PriceReasonShock, update.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("expected a price update event")
+	}
+}
+
+func TestGameManager_PriceUpdatedEventPerChangedItem(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.eventBridge.ClearEventQueue()
+
+	published := make(chan *event.PriceUpdatedEvent, 10)
+	gm.eventBus.Subscribe(event.EventNamePriceUpdated, func(e event.Event) error {
+		published <- e.(*event.PriceUpdatedEvent)
+		return nil
+	})
+
+	tracked := gm.market.GetTrackedItems()
+	require.Contains(t, tracked, "apple")
+	require.Contains(t, tracked, "iron_sword")
+	require.Contains(t, tracked, "health_potion")
+
+	applePrice := gm.market.GetPrice("apple")
+	gm.market.ApplyPriceShock("apple", 0.2)
+	gm.market.ApplyPriceShock("iron_sword", -0.2)
+	// A zero shock leaves the price where it was and should stay quiet
+	gm.market.ApplyPriceShock("health_potion", 0)
+
+	received := map[string]*event.PriceUpdatedEvent{}
+	counts := map[string]int{}
+	timeout := time.After(300 * time.Millisecond)
+collect:
+	for {
+		select {
+		case update := <-published:
+			received[update.ItemID] = update
+			counts[update.ItemID]++
+		case <-timeout:
+			break collect
+		}
+	}
+
+	assert.Equal(t, map[string]int{"apple": 1, "iron_sword": 1}, counts)
+	apple := received["apple"]
+	require.NotNil(t, apple)
+	assert.Equal(t, applePrice, apple.OldPrice)
+	assert.InDelta(t, 20, apple.Impact, 1)
+	assert.Less(t, received["iron_sword"].Impact, 0.0)
+
+	// The bridge forwards the payload so Godot can animate that ticker
+	forwarded := 0
+	for _, queued := range gm.eventBridge.GetQueuedEvents() {
+		if queued.Name == event.EventNamePriceUpdated && strings.Contains(queued.Data, `"itemId":"apple"`) {
+			assert.Contains(t, queued.Data, `"newPrice"`)
+			assert.Contains(t, queued.Data, `"impact"`)
+			forwarded++
+		}
+	}
+	assert.Equal(t, 1, forwarded)
+}
+
+func TestGameManager_SpoilageIsWrittenOff(t *testing.T) {
+	gm := newTestGameManager(t)
+	published := make(chan *event.ItemSpoiledEvent, 10)
+	gm.eventBus.Subscribe(event.EventNameItemSpoiled, func(e event.Event) error {
+		published <- e.(*event.ItemSpoiledEvent)
+		return nil
+	})
+
+	require.NoError(t, gm.inventory.AddToWarehouseByID("grapes", 4, 15))
+	gold := gm.gameState.GetGold()
+	gm.AdvanceTime(2)
+
+	select {
+	case spoiled := <-published:
+		assert.Equal(t, "grapes", spoiled.ItemID)
+		assert.Equal(t, 4, spoiled.Quantity)
+		assert.Equal(t, locationWarehouse, spoiled.Location)
+		assert.Equal(t, 60, spoiled.GoldLost)
+	case <-time.After(time.Second):
+		t.Fatal("expected an item spoiled event")
+	}
+	assert.Zero(t, gm.inventory.GetWarehouseQuantity("grapes"))
+
+	// The loss is written off in the ledger without moving gold
+	var writeOffs []ledger.Entry
+	for _, entry := range gm.ledger.Entries() {
+		if entry.Type == ledger.EntrySpoilage {
+			writeOffs = append(writeOffs, entry)
+		}
+	}
+	require.Len(t, writeOffs, 1)
+	assert.Equal(t, -60, writeOffs[0].Profit)
+	assert.Equal(t, 3, writeOffs[0].Day)
+	assert.Equal(t, gold, gm.gameState.GetGold())
+	assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+
+	// Recording the loss drains the inventory's spoilage report
+	assert.Empty(t, gm.inventory.GetSpoiledItems())
+}
+
+func TestGameManager_TransferFriction(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("apple", 10, 10)["success"].(bool))
+
+	// A costed transfer deducts gold per unit and lands immediately
+	require.NoError(t, gm.SetTransferFriction(2, 0))
+	gold := gm.gameState.GetGold()
+	result := gm.TransferItem("apple", 4, locationWarehouse, locationShop)
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, 8, result["cost"])
+	assert.Equal(t, gold-8, gm.gameState.GetGold())
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("apple"))
+	assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+
+	// A delayed transfer is in transit until the configured day
+	require.NoError(t, gm.SetTransferFriction(0, 2))
+	result = gm.TransferItem("apple", 3, locationWarehouse, locationShop)
+	require.True(t, result["success"].(bool))
+	assert.True(t, result["inTransit"].(bool))
+	assert.Equal(t, 3, result["arrivalDay"])
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("apple"))
+	assert.Equal(t, 3, gm.inventory.GetWarehouseQuantity("apple"))
+	assert.Len(t, gm.GetInventoryData()["inTransit"], 1)
+
+	gm.AdvanceTime(1)
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("apple"))
+	gm.AdvanceTime(1)
+	assert.Equal(t, 7, gm.inventory.GetShopQuantity("apple"))
+	assert.Empty(t, gm.GetInventoryData()["inTransit"])
+
+	assert.False(t, gm.TransferItem("apple", 1, locationShop, locationShop)["success"].(bool))
+	assert.Error(t, gm.SetTransferFriction(-1, 0))
+}
+
+func TestGameManager_OptimizeInventoryPaysTransferFriction(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 4, 100)["success"].(bool))
+	gm.inventory.SetSalesVelocity("iron_sword", 2)
+	require.NoError(t, gm.SetTransferFriction(3, 1))
+
+	// Placement moves cost and take as long as moving the stock by hand
+	gold := gm.gameState.GetGold()
+	result := gm.OptimizeInventory()
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 4, result["moved"])
+	assert.Equal(t, 12, result["cost"])
+	assert.True(t, result["inTransit"].(bool))
+	assert.Equal(t, gold-12, gm.gameState.GetGold())
+	assert.Zero(t, gm.inventory.GetShopQuantity("iron_sword"))
+	assert.Zero(t, gm.inventory.GetWarehouseQuantity("iron_sword"))
+
+	gm.AdvanceTime(1)
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("iron_sword"))
+	assert.Empty(t, gm.inventory.CheckConsistency())
+	assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+}
+
+func TestGameManager_GetSettingsSchema(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	result := gm.GetSettingsSchema()
+	require.True(t, result["success"].(bool))
+	fields := result["fields"].([]map[string]interface{})
+
+	schema := make(map[string]map[string]interface{})
+	for _, field := range fields {
+		schema[field["key"].(string)] = field
+	}
+
+	rules := settings.NewValidator().Rules()
+	require.Len(t, fields, len(rules))
+	for _, rule := range rules {
+		field, exists := schema[rule.FieldName]
+		require.True(t, exists, rule.FieldName)
+		assert.Equal(t, string(rule.Type), field["type"], rule.FieldName)
+		assert.NotEqual(t, "other", field["category"], rule.FieldName)
+		if rule.MinValue != nil {
+			assert.Equal(t, *rule.MinValue, field["min"], rule.FieldName)
+		}
+		if rule.MaxValue != nil {
+			assert.Equal(t, *rule.MaxValue, field["max"], rule.FieldName)
+		}
+		if len(rule.AllowedValues) > 0 {
+			assert.Equal(t, rule.AllowedValues, field["options"], rule.FieldName)
+		}
+	}
+
+	assert.Equal(t, "audio", schema["masterVolume"]["category"])
+	assert.Equal(t, 1.0, schema["masterVolume"]["default"])
+	assert.True(t, schema["startingGold"]["requiresRestart"].(bool))
+	assert.False(t, schema["masterVolume"]["requiresRestart"].(bool))
+
+	// Every default the schema offers passes validation
+	for key, field := range schema {
+		if value, exists := field["default"]; exists {
+			validation := gm.ValidateSettings(map[string]interface{}{key: value})
+			assert.True(t, validation["valid"].(bool), key)
+		}
+	}
+}
+
+func TestGameManager_CurrencyExchange(t *testing.T) {
+	gm := newTestGameManager(t)
+	gold := gm.gameState.GetGold()
+	require.NoError(t, gm.exchange.SetRate("crowns", 10))
+
+	result := gm.Exchange("gold", "crowns", 500)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 49, result["received"])
+	assert.Equal(t, gold-500, gm.gameState.GetGold())
+	assert.Equal(t, 49, gm.exchange.Balance("crowns"))
+
+	// A stronger crown turns the round trip into a profit
+	require.NoError(t, gm.exchange.SetRate("crowns", 12))
+	result = gm.Exchange("crowns", "gold", 49)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 576, result["received"])
+	assert.Equal(t, gold+76, gm.gameState.GetGold())
+	assert.Equal(t, gm.gameState.GetGold(), gm.ledger.ExpectedBalance())
+
+	assert.False(t, gm.Exchange("crowns", "gold", 1)["success"].(bool))
+	assert.False(t, gm.Exchange("gold", "crowns", gold*10)["success"].(bool))
+	assert.False(t, gm.Exchange("gold", "doubloons", 10)["success"].(bool))
+
+	rate := gm.GetExchangeRate("crowns", "gold")
+	require.True(t, rate["success"].(bool))
+	assert.Equal(t, 12.0, rate["rate"])
+	assert.InDelta(t, 12*(1-exchange.DefaultSpread), rate["effectiveRate"], 1e-9)
+
+	// Holdings are saved with the gold that bought them
+	require.NoError(t, gm.SaveGame(1))
+	require.True(t, gm.Exchange("gold", "crowns", 500)["success"].(bool))
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, 0, gm.exchange.Balance("crowns"))
+	assert.Equal(t, gold+76, gm.gameState.GetGold())
+
+	require.True(t, gm.Exchange("gold", "crowns", 500)["success"].(bool))
+	crowns := gm.exchange.Balance("crowns")
+	require.NoError(t, gm.SaveGame(1))
+	require.NoError(t, gm.StartNewGame("Penniless"))
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, crowns, gm.exchange.Balance("crowns"))
+	assert.Equal(t, 12.0, gm.GetExchangeRate("crowns", "gold")["rate"])
+}
+
+func TestGameManager_GetHeatmap(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	// A synthetic year: apples are cheap early in autumn and dear late in summer
+	for day := 1; day <= gamestate.DaysPerSeason*4; day++ {
+		price := 100
+		switch {
+		case day >= 61 && day <= 70:
+			price = 60
+		case day >= 51 && day <= 60:
+			price = 150
+		}
+		gm.dayRecordUnsafe(day).prices = map[string]int{"apple": price}
+	}
+
+	result := gm.GetHeatmap("apple")
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, gamestate.DaysPerSeason*4, result["daysSampled"])
+	assert.Len(t, result["cells"], 12)
+
+	cheapest := result["cheapest"].(map[string]interface{})
+	assert.Equal(t, "Autumn", cheapest["season"])
+	assert.Equal(t, "early", cheapest["period"])
+	assert.Equal(t, 60.0, cheapest["averagePrice"])
+	assert.Less(t, cheapest["relative"], 1.0)
+
+	dearest := result["dearest"].(map[string]interface{})
+	assert.Equal(t, "Summer", dearest["season"])
+	assert.Equal(t, "late", dearest["period"])
+	assert.Equal(t, 150, dearest["highPrice"])
+
+	assert.False(t, gm.GetHeatmap("unicorn")["success"].(bool))
+}
+
+func TestGameManager_HeatmapRecordsDailyPrices(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.AdvanceTime(2)
+
+	result := gm.GetHeatmap("apple")
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, 3, result["daysSampled"])
+	cells := result["cells"].([]map[string]interface{})
+	assert.Equal(t, 3, cells[0]["samples"])
+	assert.Equal(t, 0, cells[1]["samples"])
+}
+
+func TestGameManager_GetMarketComparison(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.AdvanceTime(7)
+	require.Equal(t, 8, gm.gameState.GetCurrentDay())
+
+	assert.False(t, gm.GetMarketComparison(0)["success"].(bool))
+
+	// Pin a known history a week back: apple was twice today's price,
+	// the iron sword was the same, and nothing else was recorded
+	appleNow := gm.market.GetPrice("apple")
+	swordNow := gm.market.GetPrice("iron_sword")
+	gm.mu.Lock()
+	gm.dayRecords[1].prices = map[string]int{"apple": appleNow * 2, "iron_sword": swordNow}
+	gm.mu.Unlock()
+
+	result := gm.GetMarketComparison(7)
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, 1, result["fromDay"])
+	assert.Equal(t, 8, result["toDay"])
+	assert.Equal(t, 2, result["itemsCompared"])
+
+	items := result["items"].([]map[string]interface{})
+	require.Len(t, items, len(gm.market.GetAllItems()))
+
+	// Biggest mover first
+	assert.Equal(t, "apple", items[0]["itemId"])
+	assert.Equal(t, appleNow*2, items[0]["then"])
+	assert.Equal(t, appleNow, items[0]["now"])
+	assert.InDelta(t, -0.5, items[0]["change"].(float64), 1e-9)
+
+	assert.Equal(t, "iron_sword", items[1]["itemId"])
+	assert.InDelta(t, 0, items[1]["change"].(float64), 1e-9)
+
+	// Items without a recorded price then are listed but not compared
+	for _, entry := range items[2:] {
+		assert.False(t, entry["compared"].(bool))
+		assert.NotContains(t, entry, "change")
+	}
+
+	// Looking back past the start of the game compares nothing
+	result = gm.GetMarketComparison(30)
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, 0, result["itemsCompared"])
+}
+
+func TestGameManager_DifficultyRamp(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	ramped := make(chan *event.DifficultyRampedEvent, 4)
+	gm.eventBus.Subscribe(event.EventNameDifficultyRamped, func(e event.Event) error {
+		ramped <- e.(*event.DifficultyRampedEvent)
+		return nil
+	})
+
+	level := gm.GetDifficultyLevel()
+	assert.Equal(t, 0, level["stage"])
+	assert.Equal(t, "normal", level["baseDifficulty"])
+	assert.Equal(t, "rising", level["nextStageName"])
+	assert.InDelta(t, 1.0, gm.market.GetVolatilityScale(), 1e-9)
+
+	// Volatility and event frequency climb as net worth grows
+	volatility := gm.market.GetVolatilityScale()
+	for _, gold := range []int{6000, 25000, 60000} {
+		gm.gameState.SetGold(gold)
+		gm.AdvanceTime(1)
+
+		assert.Greater(t, gm.market.GetVolatilityScale(), volatility)
+		volatility = gm.market.GetVolatilityScale()
+		assert.InDelta(t, gm.GetDifficultyLevel()["eventFrequency"].(float64), gm.eventRoller.GetFrequency(), 1e-9)
+	}
+	assert.Equal(t, "legendary", gm.GetDifficultyLevel()["stageName"])
+	assert.NotContains(t, gm.GetDifficultyLevel(), "nextStageName")
+
+	// Each climb is announced, though async delivery may reorder them
+	reached := make(map[int]string)
+	for len(reached) < 3 {
+		select {
+		case e := <-ramped:
+			reached[e.Stage] = e.StageName
+		case <-time.After(time.Second):
+			t.Fatalf("expected three difficulty ramped events, got %v", reached)
+		}
+	}
+	assert.Equal(t, map[int]string{1: "rising", 2: "cutthroat", 3: "legendary"}, reached)
+
+	// A bad day does not calm the market again
+	gm.gameState.SetGold(10)
+	gm.AdvanceTime(1)
+	assert.InDelta(t, 2.0, gm.market.GetVolatilityScale(), 1e-9)
+
+	// A custom ramp takes effect straight away; invalid ones are rejected
+	assert.Error(t, gm.SetDifficultyRamp(difficulty.Ramp{}))
+	assert.Error(t, gm.SetDifficultyRamp(difficulty.Ramp{
+		{Name: "calm", Volatility: 1, Events: 1},
+		{Name: "backwards", NetWorth: 0, Volatility: 2, Events: 2},
+	}))
+	require.NoError(t, gm.SetDifficultyRamp(difficulty.Ramp{
+		{Name: "calm", Volatility: 1, Events: 1},
+		{Name: "stormy", NetWorth: 5, Volatility: 3, Events: 2},
+	}))
+	assert.Equal(t, "stormy", gm.GetDifficultyLevel()["stageName"])
+	assert.InDelta(t, 3.0, gm.market.GetVolatilityScale(), 1e-9)
+
+	// A new game starts back at the first stage
+	require.NoError(t, gm.StartNewGameWithConfig("Fresh", &gamestate.GameConfig{InitialGold: 1}))
+	assert.Equal(t, 0, gm.GetDifficultyLevel()["stage"])
+	assert.InDelta(t, 1.0, gm.market.GetVolatilityScale(), 1e-9)
+
+	// Loading takes the stage the saved game had reached
+	require.NoError(t, gm.SaveGame(1))
+	gm.gameState.SetGold(10)
+	gm.AdvanceTime(1)
+	require.Equal(t, 1, gm.GetDifficultyLevel()["stage"])
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, 0, gm.GetDifficultyLevel()["stage"])
+	assert.InDelta(t, 1.0, gm.market.GetVolatilityScale(), 1e-9)
+}
+
+func TestGameManager_ValidateGameState(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("apple", 5, 20)["success"].(bool))
+
+	result := gm.ValidateGameState()
+	require.True(t, result["success"].(bool))
+	assert.True(t, result["valid"].(bool))
+	assert.Empty(t, result["violations"])
+
+	// Stock added straight to the shop skips the tracking entries; bought
+	// stock went to the warehouse, so the shop tracks none
+	apple, exists := gm.market.GetItem("apple")
+	require.True(t, exists)
+	require.NoError(t, gm.inventory.ShopInventory.AddItem(apple, 3))
+	// Gold changed outside the ledger no longer reconciles
+	gm.gameState.SetGold(-10)
+
+	result = gm.ValidateGameState()
+	assert.False(t, result["valid"].(bool))
+	violations := result["violations"].([]map[string]interface{})
+	systems := make(map[string]string)
+	for _, violation := range violations {
+		systems[violation["system"].(string)] = violation["message"].(string)
+	}
+	assert.Equal(t, "shop holds 3 apple but tracks 0", systems["inventory"])
+	assert.Contains(t, systems, "gold")
+	assert.Contains(t, systems, "ledger")
+	assert.NotContains(t, systems, "quests")
+}
+
+func TestGameManager_ApplyCheat(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	// Refused outside debug mode
+	result := gm.ApplyCheat("gold", "5000")
+	assert.False(t, result["success"].(bool))
+	assert.Equal(t, 1000, gm.gameState.GetGold())
+
+	debug := gm.settings.GetSettings()
+	debug.EnableDebugMode = true
+	require.NoError(t, gm.settings.ApplySettings(debug))
+
+	result = gm.ApplyCheat("gold", "5000")
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 5000, gm.gameState.GetGold())
+	assert.True(t, gm.ValidateGameState()["valid"].(bool), "cheated gold is recorded in the ledger")
+
+	require.True(t, gm.ApplyCheat("maxrank")["success"].(bool))
+	assert.Equal(t, gamestate.RankMaster, gm.gameState.GetRank())
+
+	require.True(t, gm.ApplyCheat("unlockall")["success"].(bool))
+	for _, feature := range gm.progression.GetFeatureUnlockSystem().GetAllFeatures() {
+		assert.True(t, gm.progression.GetFeatureUnlockSystem().IsUnlocked(feature.ID), feature.ID)
+	}
+
+	require.True(t, gm.ApplyCheat("skipdays", "3")["success"].(bool))
+	assert.Equal(t, 4, gm.gameState.GetCurrentDay())
+
+	require.True(t, gm.ApplyCheat("spawnitem", "iron_sword", "2")["success"].(bool))
+	assert.Equal(t, 2, gm.inventory.GetWarehouseQuantity("iron_sword"))
+
+	for _, bad := range [][]string{{"gold"}, {"gold", "lots"}, {"skipdays", "0"}, {"spawnitem", "dragon"}, {"fly"}} {
+		assert.False(t, gm.ApplyCheat(bad[0], bad[1:]...)["success"].(bool), bad)
+	}
+}
+
+func TestGameManager_VictoryByReputationAndRank(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.mu.Lock()
+	gm.resetAllSystems(nil)
+	gm.mu.Unlock()
+	victories := make(chan event.Event, 2)
+	gm.eventBus.Subscribe("GameVictory", func(e event.Event) error {
+		victories <- e
+		return nil
+	})
+
+	// Gold alone no longer wins
+	gm.gameState.SetGold(gamestate.VictoryGoldThreshold * 3)
+	gm.checkGameEvents()
+	gm.gameState.SetReputation(gamestate.VictoryRepThreshold)
+	gm.checkGameEvents()
+	assert.False(t, gm.gameEnded)
+
+	// Master rank still leaves the quest objective open
+	gm.gameState.SetRank(gamestate.RankMaster)
+	gm.checkGameEvents()
+	assert.False(t, gm.gameEnded)
+	assert.Equal(t, "in_progress", gm.GetGameSummary()["outcome"])
+
+	// A purchase alone leaves the first quest open
+	require.True(t, gm.BuyItem("iron_sword", 1, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	gm.checkGameEvents()
+	assert.False(t, gm.gameEnded)
+
+	// A profitable sale completes the first quest, meeting the last objective
+	fairValue := float64(gm.market.GetFairValue("iron_sword"))
+	require.True(t, gm.SellItem("iron_sword", 1, fairValue)["success"].(bool))
+	assert.Len(t, gm.quests.GetCompletedQuests(), 1)
+	gm.checkGameEvents()
+	gm.checkGameEvents()
+	assert.True(t, gm.gameEnded)
+	assert.Equal(t, "victory", gm.GetGameSummary()["outcome"])
+
+	select {
+	case <-victories:
+	case <-time.After(time.Second):
+		t.Fatal("expected a victory event")
+	}
+	select {
+	case <-victories:
+		t.Fatal("victory should be announced once")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// A sandbox relaxes the conditions for the next game too
+	require.NoError(t, gm.SetWinConditions(5000, 0, gamestate.RankApprentice, 0))
+	assert.Error(t, gm.SetWinConditions(-5, 0, gamestate.RankApprentice, 0))
+	assert.Error(t, gm.SetWinConditions(5000, 0, gamestate.RankApprentice, -1))
+	gm.mu.Lock()
+	gm.resetAllSystems(nil)
+	gm.mu.Unlock()
+	assert.False(t, gm.gameEnded)
+	assert.Equal(t, 5000, gm.GetWinConditions()["gold"])
+	gm.gameState.SetGold(5000)
+	gm.checkGameEvents()
+	assert.True(t, gm.gameEnded)
+}
+
+func TestGameManager_GetVictoryProgress(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.SetWinConditions(2000, 10, gamestate.RankJourneyman, 1))
+	gm.gameState.SetGold(2500)
+	gm.gameState.SetReputation(20)
+	gm.gameState.SetRank(gamestate.RankApprentice)
+
+	result := gm.GetVictoryProgress()
+	require.True(t, result["success"].(bool))
+	assert.False(t, result["victory"].(bool))
+	objectives := result["objectives"].([]map[string]interface{})
+	require.Len(t, objectives, 4)
+	met := make(map[string]bool, len(objectives))
+	for _, objective := range objectives {
+		met[objective["id"].(string)] = objective["met"].(bool)
+	}
+	assert.Equal(t, map[string]bool{"gold": true, "reputation": true, "rank": false, "quests": false}, met)
+	assert.Equal(t, "Journeyman", objectives[2]["targetRank"])
+	assert.Equal(t, 1, gm.GetWinConditions()["quests"])
+
+	// Victory waits for every objective, then matches the progress report
+	gm.gameState.SetRank(gamestate.RankJourneyman)
+	gm.checkGameEvents()
+	assert.False(t, gm.gameEnded)
+	require.NoError(t, gm.quests.StartQuest(quest.QuestFirstTrade, 1))
+	require.True(t, gm.BuyItem("iron_sword", 1, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	require.True(t, gm.SellItem("iron_sword", 1, float64(gm.market.GetFairValue("iron_sword")))["success"].(bool))
+	assert.True(t, gm.GetVictoryProgress()["victory"].(bool))
+	gm.checkGameEvents()
+	assert.True(t, gm.gameEnded)
+
+	// A scenario with its own goals replaces the usual conditions
+	require.NoError(t, gm.StartNewGameWithScenario("Tester", scenario.StrugglingShop))
+	assert.Equal(t, 2000, gm.gameState.GetWinConditions().Gold)
+	assert.Equal(t, 0, gm.gameState.GetWinConditions().Quests)
+	assert.Len(t, gm.GetVictoryProgress()["objectives"], 3)
+	assert.Equal(t, 2000, gm.GetCurrentScenario()["victory"].(map[string]interface{})["gold"])
+	assert.Equal(t, 2000, gm.GetWinConditions()["gold"])
+}
+
+func TestGameManager_FirstQuestProgressIsSaved(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.mu.Lock()
+	gm.resetAllSystems(nil)
+	gm.mu.Unlock()
+
+	// Every game opens with the first trade under way
+	firstTrade, _ := gm.quests.GetQuest(quest.QuestFirstTrade)
+	require.Equal(t, quest.QuestStatusActive, firstTrade.Status)
+	require.True(t, gm.BuyItem("iron_sword", 1, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	assert.True(t, firstTrade.Objectives[0].Completed)
+	require.NoError(t, gm.SaveGame(1))
+
+	// A new game starts the quest over
+	gm.mu.Lock()
+	gm.resetAllSystems(nil)
+	gm.mu.Unlock()
+	assert.False(t, firstTrade.Objectives[0].Completed)
+
+	// Loading brings back the purchase, so one sale finishes the quest
+	require.NoError(t, gm.LoadGame(1))
+	assert.True(t, firstTrade.Objectives[0].Completed)
+	require.True(t, gm.SellItem("iron_sword", 1, float64(gm.market.GetFairValue("iron_sword")))["success"].(bool))
+	assert.Equal(t, quest.QuestStatusCompleted, firstTrade.Status)
+	assert.Equal(t, 1.0, gm.GetVictoryProgress()["objectives"].([]map[string]interface{})[3]["current"])
+}
+
+func TestGameManager_DefeatByReputation(t *testing.T) {
+	gm := newTestGameManager(t)
+	defeats := make(chan event.Event, 2)
+	gm.eventBus.Subscribe("GameDefeat", func(e event.Event) error {
+		defeats <- e
+		return nil
+	})
+
+	// Broke with stock left to sell is not yet a loss
+	require.NoError(t, gm.inventory.AddToWarehouseByID("apple", 5, 8))
+	gm.gameState.SetGold(0)
+	gm.checkGameEvents()
+	assert.False(t, gm.gameEnded)
+
+	// Ruined reputation loses with gold and stock in hand
+	gm.gameState.SetGold(5000)
+	gm.gameState.SetReputation(gamestate.DefeatRepThreshold)
+	gm.checkGameEvents()
+	gm.checkGameEvents()
+	assert.True(t, gm.gameEnded)
+	assert.Equal(t, "defeat", gm.GetGameSummary()["outcome"])
+
+	select {
+	case <-defeats:
+	case <-time.After(time.Second):
+		t.Fatal("expected a defeat event")
+	}
+	select {
+	case <-defeats:
+		t.Fatal("defeat should be announced once")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestGameManager_BatchAdvanceDays(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.eventRoller.SetOdds(events.DailyEventOdds{Opportunity: 1}))
+
+	// Apples that spoil in three days, potions on their way to the shop and
+	// a sword to sell itself after two days
+	require.NoError(t, gm.inventory.AddToWarehouseByID("apple", 6, 8))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 6))
+	require.NoError(t, gm.inventory.AddToWarehouseByID("health_potion", 4, 20))
+	require.NoError(t, gm.inventory.ShipToShop("health_potion", 2, 3))
+	require.NoError(t, gm.inventory.AddToWarehouseByID("iron_sword", 1, 100))
+	require.NoError(t, gm.inventory.SetAutoSell("iron_sword", 0, 2))
+
+	result := gm.BatchAdvanceDays(5)
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, 5, result["daysAdvanced"])
+	assert.Equal(t, 6, result["day"])
+	digests := result["digests"].([]map[string]interface{})
+	require.Len(t, digests, 5)
+
+	for i, digest := range digests {
+		assert.Equal(t, i+2, digest["day"])
+		// Every day's events were rolled and reported
+		assert.Len(t, digest["events"], 1, "day %d", digest["day"])
+		assert.NotEmpty(t, digest["highlights"])
+	}
+
+	// Day 3: the potions arrive and the sword sells itself
+	day3 := digests[1]
+	shipments := day3["shipments"].([]map[string]interface{})
+	require.Len(t, shipments, 1)
+	assert.Equal(t, "health_potion", shipments[0]["itemId"])
+	assert.Equal(t, locationShop, shipments[0]["to"])
+	autoSold := day3["autoSold"].([]map[string]interface{})
+	require.Len(t, autoSold, 1)
+	assert.Equal(t, "iron_sword", autoSold[0]["itemId"])
+	assert.Greater(t, day3["goldChange"].(int), 0)
+	assert.Equal(t, 2, gm.inventory.GetShopQuantity("health_potion"))
+
+	// Day 4: the apples spoil, once
+	spoiled := digests[2]["spoiled"].([]map[string]interface{})
+	require.Len(t, spoiled, 1)
+	assert.Equal(t, "apple", spoiled[0]["itemId"])
+	assert.Equal(t, 6, spoiled[0]["quantity"])
+	assert.Empty(t, digests[3]["spoiled"])
+	assert.Empty(t, digests[0]["spoiled"])
+
+	// Price moves match the daily reports
+	for _, digest := range digests {
+		report := gm.GetDailyReport(digest["day"].(int))
+		assert.Len(t, digest["priceChanges"], len(report["priceChanges"].([]map[string]interface{})))
+	}
+
+	assert.False(t, gm.BatchAdvanceDays(0)["success"].(bool))
+	assert.False(t, gm.BatchAdvanceDays(maxBatchDays + 1)["success"].(bool))
+}
+
+func TestGameManager_DayCapEndsGame(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	assert.Error(t, gm.StartNewGameWithConfig("Hasty", &gamestate.GameConfig{MaxDays: -1}))
+	require.NoError(t, gm.StartNewGameWithConfig("Timed", &gamestate.GameConfig{InitialGold: 1000, MaxDays: 10}))
+
+	timeUp := make(chan *event.TimeUpEvent, 2)
+	gm.eventBus.Subscribe(event.EventNameTimeUp, func(e event.Event) error {
+		timeUp <- e.(*event.TimeUpEvent)
+		return nil
+	})
+
+	gm.AdvanceTime(8)
+	assert.Equal(t, "in_progress", gm.GetGameSummary()["outcome"])
+
+	// A time.advanced event moves a day on like AdvanceTime, closing the
+	// day's record
+	gm.handleTimeAdvanced()
+	assert.Equal(t, 10, gm.gameState.GetCurrentDay())
+	gm.mu.Lock()
+	assert.Positive(t, gm.dayRecordUnsafe(9).netWorth)
+	gm.mu.Unlock()
+
+	// Time stops on the last day however far it is pushed
+	gm.AdvanceTime(5)
+	assert.Equal(t, 10, gm.gameState.GetCurrentDay())
+	gm.handleTimeAdvanced()
+	assert.Equal(t, 10, gm.gameState.GetCurrentDay())
+
+	select {
+	case ended := <-timeUp:
+		assert.Equal(t, 10, ended.Day)
+		assert.Equal(t, "time_up", ended.Summary["outcome"])
+		assert.Equal(t, 10, ended.Summary["daysPlayed"])
+		assert.Equal(t, 10, ended.Summary["maxDays"])
+	case <-time.After(time.Second):
+		t.Fatal("expected a time up event")
+	}
+	select {
+	case <-timeUp:
+		t.Fatal("time up should fire once")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// A fresh endless game clears the cap
+	require.NoError(t, gm.SaveGame(1))
+	gm.mu.Lock()
+	gm.resetAllSystems(nil)
+	gm.mu.Unlock()
+	gm.AdvanceTime(20)
+	assert.Equal(t, 21, gm.gameState.GetCurrentDay())
+
+	// Saves keep the cap, and loading works out whether time is up
+	require.NoError(t, gm.SaveGame(2))
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, 10, gm.gameState.GetMaxDays())
+	gm.AdvanceTime(3)
+	assert.Equal(t, 10, gm.gameState.GetCurrentDay())
+	require.NoError(t, gm.LoadGame(2))
+	assert.Equal(t, 0, gm.gameState.GetMaxDays())
+	gm.AdvanceTime(3)
+	assert.Equal(t, 24, gm.gameState.GetCurrentDay())
+}
+
+func TestGameManager_AffordabilityLimitedBySpace(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.inventory.SetBaseCapacity(gm.inventory.ShopCapacity, 10))
+	require.True(t, gm.BuyItem("apple", 4, 10)["success"].(bool))
+
+	result := gm.GetAffordability("apple")
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, 6, result["maxQuantity"])
+	assert.Equal(t, "space", result["limitedBy"])
+	assert.Greater(t, result["limits"].(map[string]interface{})["gold"], 6)
+
+	// A stack limit tighter than the space binds instead
+	gm.inventory.SetMaxStack("apple", 5)
+	result = gm.GetAffordability("apple")
+	assert.Equal(t, 1, result["maxQuantity"])
+	assert.Equal(t, "stack", result["limitedBy"])
+}
+
+func TestGameManager_AffordabilityLimitedByGold(t *testing.T) {
+	gm := newTestGameManager(t)
+	price := float64(gm.market.GetPrice("steel_sword"))
+
+	result := gm.GetAffordability("steel_sword")
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, "gold", result["limitedBy"])
+
+	// The most the player can afford can be bought; one more cannot
+	maxQuantity := result["maxQuantity"].(int)
+	require.Greater(t, maxQuantity, 0)
+	assert.LessOrEqual(t, result["totalCost"], gm.gameState.GetGold())
+	assert.False(t, gm.BuyItem("steel_sword", maxQuantity+1, price)["success"].(bool))
+	assert.True(t, gm.BuyItem("steel_sword", maxQuantity, price)["success"].(bool))
+
+	assert.False(t, gm.GetAffordability("unicorn")["success"].(bool))
+}
+
+func TestGameManager_ReputationRules(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 2, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 2))
+
+	// Gouging customers costs the overcharge penalty
+	rules := gm.gameState.GetReputationRules()
+	fairValue := float64(gm.market.GetFairValue("iron_sword"))
+	result := gm.SellItem("iron_sword", 1, fairValue*2)
+	require.True(t, result["success"].(bool))
+	assert.Equal(t, rules[gamestate.ReputationOvercharge], result["reputation_delta"])
+
+	result = gm.SellItem("iron_sword", 1, fairValue)
+	assert.Equal(t, rules[gamestate.ReputationFairSale], result["reputation_delta"])
+
+	// Completing a quest earns the quest_complete rule per point of its reward
+	before := gm.gameState.GetReputation()
+	require.NoError(t, gm.quests.StartQuest(quest.QuestFirstTrade, 1))
+	gm.quests.UpdateObjective(quest.QuestFirstTrade, "buy_item", 1)
+	gm.quests.UpdateObjective(quest.QuestFirstTrade, "sell_item", 1)
+	assert.InDelta(t, before+5*rules[gamestate.ReputationQuestComplete], gm.gameState.GetReputation(), 1e-9)
+
+	// Harder difficulties shrink gains and grow losses
+	gains, losses := reputationScalesForDifficulty("hard")
+	assert.Less(t, gains, 1.0)
+	assert.Greater(t, losses, 1.0)
+
+	// A new game can bring its own table
+	custom := gamestate.ReputationRules{gamestate.ReputationQuestComplete: 2}
+	gm.mu.Lock()
+	gm.resetAllSystems(&gamestate.GameConfig{InitialGold: 1000, ReputationRules: custom})
+	gm.mu.Unlock()
+	assert.Equal(t, custom, gm.gameState.GetReputationRules())
+	gm.mu.Lock()
+	gm.resetAllSystems(nil)
+	gm.mu.Unlock()
+	assert.Equal(t, rules, gm.gameState.GetReputationRules())
+}
+
+func TestGameManager_SaveLoadRestoresInventory(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.inventory.SetBaseCapacity(150, 300))
+	require.NoError(t, gm.inventory.AddToWarehouseByID("apple", 12, 8))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 5))
+	require.NoError(t, gm.inventory.AddToWarehouseByID("iron_sword", 2, 140))
+	bought := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, gm.inventory.SetPurchaseDate("iron_sword", inventory.LocationWarehouse, bought))
+	gm.inventory.ProcessDailyUpdate()
+	gm.inventory.ProcessDailyUpdate()
+	freshness, _ := gm.inventory.GetShopFreshness("apple")
+	require.Less(t, freshness, 1.0)
+	require.NoError(t, gm.inventory.AddToWarehouseByID("steel_sword", 3, 280))
+	require.NoError(t, gm.inventory.ShipToShop("steel_sword", 3, 5))
+	require.NoError(t, gm.SaveGame(0))
+
+	// Load into a fresh manager reading the same saves
+	loaded := newTestGameManager(t)
+	loaded.saveManager = gm.saveManager
+	require.NoError(t, loaded.LoadGame(0))
+
+	assert.Equal(t, 150, loaded.inventory.ShopCapacity)
+	assert.Equal(t, 300, loaded.inventory.WarehouseCapacity)
+	assert.Equal(t, 5, loaded.inventory.GetShopQuantity("apple"))
+	assert.Equal(t, 7, loaded.inventory.GetWarehouseQuantity("apple"))
+	assert.Equal(t, 2, loaded.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 8, loaded.inventory.GetPurchasePrice("apple"))
+	assert.Equal(t, 140, loaded.inventory.GetPurchasePrice("iron_sword"))
+
+	records := loaded.inventory.StockRecords()
+	require.Len(t, records, 3)
+	for _, record := range records {
+		if record.ItemID == "iron_sword" {
+			assert.True(t, bought.Equal(record.PurchaseDate))
+		}
+	}
+	assert.Empty(t, loaded.inventory.CheckConsistency())
+
+	// Perishables keep their age and shipments are still on the way
+	loadedFreshness, _ := loaded.inventory.GetShopFreshness("apple")
+	assert.InDelta(t, freshness, loadedFreshness, 1e-9)
+	inTransit := loaded.inventory.GetInTransit()
+	require.Len(t, inTransit, 1)
+	assert.Equal(t, "steel_sword", inTransit[0].ItemID)
+	assert.Equal(t, 3, inTransit[0].Quantity)
+	assert.Equal(t, 5, inTransit[0].ArrivalDay)
+	loaded.inventory.ReceiveShipments(5)
+	assert.Equal(t, 3, loaded.inventory.GetShopQuantity("steel_sword"))
+	assert.Equal(t, 280, loaded.inventory.GetPurchasePrice("steel_sword"))
+
+	// Saves from before inventory was kept load with an empty inventory
+	require.NoError(t, gm.saveManager.SaveGame(1, gm.gameState, nil, nil, nil))
+	require.NoError(t, loaded.LoadGame(1))
+	assert.True(t, loaded.inventory.IsEmpty())
+	assert.Equal(t, 150, loaded.inventory.ShopCapacity)
+}
+
+func TestGameManager_NamedSaveProfiles(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	gm.gameState.SetGold(1234)
+	gm.gameState.SetCurrentDay(4)
+	require.NoError(t, gm.SaveGameNamed("Iron Run"))
+
+	gm.gameState.SetGold(777)
+	gm.gameState.SetCurrentDay(9)
+	require.NoError(t, gm.SaveGameNamed("Fruit Stand"))
+	require.NoError(t, gm.SaveGame(0))
+
+	require.NoError(t, gm.LoadGameNamed("Iron Run"))
+	assert.Equal(t, 1234, gm.gameState.GetGold())
+	assert.Equal(t, 4, gm.gameState.GetCurrentDay())
+
+	require.NoError(t, gm.LoadGameNamed("Fruit Stand"))
+	assert.Equal(t, 777, gm.gameState.GetGold())
+
+	// A name colliding with another profile's key is refused, while one
+	// reducing to a different key is its own profile
+	require.NoError(t, gm.SaveGameNamed("IRON-run"))
+	assert.ErrorIs(t, gm.SaveGameNamed("iron run!"), persistence.ErrProfileNameTaken)
+	assert.Error(t, gm.LoadGameNamed("Nobody"))
+
+	savesJSON, err := gm.ListNamedSaves()
+	require.NoError(t, err)
+	var saves []persistence.SaveMetadata
+	require.NoError(t, json.Unmarshal([]byte(savesJSON), &saves))
+	require.Len(t, saves, 3)
+	profiles := []string{}
+	for _, save := range saves {
+		assert.Equal(t, persistence.SaveKindProfile, save.Kind)
+		profiles = append(profiles, save.Profile)
+	}
+	sort.Strings(profiles)
+	assert.Equal(t, []string{"Fruit Stand", "IRON-run", "Iron Run"}, profiles)
+}
+
+func TestGameManager_QuickSaveIsSeparateFromManualSlots(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NotNil(t, gm.saveManager)
+
+	gm.gameState.SetGold(1234)
+	gm.gameState.SetCurrentDay(4)
+	require.NoError(t, gm.SaveGame(0))
+
+	gm.gameState.SetGold(777)
+	gm.gameState.SetCurrentDay(9)
+	require.NoError(t, gm.QuickSave())
+
+	// Quick saving again overwrites only the quick slot
+	gm.gameState.SetGold(555)
+	require.NoError(t, gm.QuickSave())
+
+	require.NoError(t, gm.LoadGame(0))
+	assert.Equal(t, 1234, gm.gameState.GetGold())
+	assert.Equal(t, 4, gm.gameState.GetCurrentDay())
+
+	require.NoError(t, gm.QuickLoad())
+	assert.Equal(t, 555, gm.gameState.GetGold())
+	assert.Equal(t, 9, gm.gameState.GetCurrentDay())
+
+	// The load menu lists the quick save after the manual slots
+	slotsJSON, err := gm.GetSaveSlots()
+	require.NoError(t, err)
+	var slots []persistence.SaveSlotInfo
+	require.NoError(t, json.Unmarshal([]byte(slotsJSON), &slots))
+	require.Len(t, slots, persistence.ManualSlotCount+1)
+
+	assert.Equal(t, persistence.SaveKindManual, slots[0].Kind)
+	assert.True(t, slots[0].Exists)
+	assert.False(t, slots[1].Exists)
+
+	quick := slots[len(slots)-1]
+	assert.Equal(t, persistence.QuickSaveSlot, quick.Slot)
+	assert.Equal(t, persistence.SaveKindQuick, quick.Kind)
+	require.NotNil(t, quick.Metadata)
+	assert.Equal(t, 555, quick.Metadata.Gold)
+	assert.Equal(t, 9, quick.Metadata.Day)
+}
+
+func TestGameManager_QualityVariants(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	standard := gm.market.GetPrice("apple")
+	superior := gm.market.GetPrice(item.VariantID("apple", 3))
+	legendary := gm.market.GetPrice(item.VariantID("apple", item.QualityMax))
+	assert.Greater(t, superior, standard)
+	assert.Greater(t, legendary, superior)
+	assert.Equal(t, int(math.Round(float64(standard)*1.5)), superior)
+	assert.Greater(t, gm.market.GetFairValue("apple@q3"), gm.market.GetFairValue("apple"))
+
+	detail := gm.GetItemDetail("apple@q3")
+	require.True(t, detail["success"].(bool))
+	assert.Equal(t, "apple", detail["baseItemId"])
+	assert.Equal(t, 3, detail["quality"])
+	assert.Equal(t, "Superior", detail["qualityName"])
+	assert.Equal(t, "FRUIT", detail["category"])
+	assert.Equal(t, superior, detail["marketPrice"])
+	assert.Len(t, detail["qualityVariants"], item.QualityMax)
+	assert.Equal(t, 1, gm.GetItemDetail("apple")["quality"])
+
+	// Variants are stocked separately from the standard item
+	bought := gm.BuyItem("apple@q3", 4, float64(superior))
+	require.True(t, bought["success"].(bool))
+	assert.Equal(t, 3, bought["quality"])
+	assert.Equal(t, 4, gm.inventory.GetWarehouseQuantity("apple@q3"))
+	assert.Equal(t, 0, gm.inventory.GetWarehouseQuantity("apple"))
+
+	require.NoError(t, gm.inventory.TransferToShop("apple@q3", 4))
+	sold := gm.SellItem("apple@q3", 2, float64(superior))
+	require.True(t, sold["success"].(bool))
+	assert.Equal(t, 3, sold["quality"])
+	assert.Equal(t, 2, gm.inventory.GetShopQuantity("apple@q3"))
+}
+
+func TestGameManager_GetExpectedDailyProfit(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.SetTradeSpread(0))
+	require.NoError(t, gm.taxes.SetTaxRate(item.CategoryFruit, tax.TransactionSale, 0.1))
+	require.NoError(t, gm.taxes.SetTaxRate(item.CategoryWeapon, tax.TransactionSale, 0.1))
+
+	empty := gm.GetExpectedDailyProfit()
+	assert.Equal(t, 0, empty["expectedProfit"])
+	assert.Empty(t, empty["items"])
+
+	require.True(t, gm.BuyItem("apple", 20, 8)["success"].(bool))
+	require.True(t, gm.BuyItem("iron_sword", 5, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 15))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 2))
+
+	// At market price customers buy about the day's liquidity, capped by stock
+	require.NoError(t, gm.market.SetLiquidity("apple", 10))
+	applePrice := float64(gm.market.GetPrice("apple"))
+	swordPrice := float64(gm.market.GetPrice("iron_sword"))
+	revenue := 10*applePrice + 2*swordPrice
+	expected := revenue*0.9 - (10*8 + 2*100)
+
+	projection := gm.GetExpectedDailyProfit()
+	require.True(t, projection["success"].(bool))
+	assert.Equal(t, gm.gameState.GetCurrentDay()+1, projection["day"])
+	assert.InDelta(t, expected, float64(projection["expectedProfit"].(int)), 2)
+
+	breakdown := projection["breakdown"].(map[string]interface{})
+	assert.InDelta(t, revenue, float64(breakdown["revenue"].(int)), 2)
+	assert.Equal(t, 280, breakdown["costOfGoods"])
+	assert.Equal(t, 0, breakdown["spoilage"])
+
+	items := projection["items"].([]map[string]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, "apple", items[0]["itemId"])
+	assert.Equal(t, 10, items[0]["expectedUnits"])
+	assert.Equal(t, 2, items[1]["expectedUnits"])
+
+	// Apples a day from spoiling that won't sell count as a loss
+	gm.inventory.ProcessDailyUpdate()
+	gm.inventory.ProcessDailyUpdate()
+	spoiling := gm.GetExpectedDailyProfit()
+	assert.Equal(t, 10*8, spoiling["breakdown"].(map[string]interface{})["spoilage"])
+	assert.InDelta(t, expected-80, float64(spoiling["expectedProfit"].(int)), 2)
+}
+
+func TestGameManager_GetShopLayoutSuggestions(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	// Both sell quickly, but apples are bought at nearly what they sell for
+	applePrice := gm.market.GetPrice("apple")
+	swordPrice := gm.market.GetPrice("iron_sword")
+	require.True(t, gm.BuyItem("apple", 10, float64(applePrice-1))["success"].(bool))
+	require.True(t, gm.BuyItem("iron_sword", 3, float64(swordPrice/2))["success"].(bool))
+	require.True(t, gm.BuyItem("health_potion", 2, 10)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 5))
+	gm.inventory.SetSalesVelocity("apple", 6)
+	gm.inventory.SetSalesVelocity("iron_sword", 5)
+
+	result := gm.GetShopLayoutSuggestions(1)
+	require.True(t, result["success"].(bool))
+	ranking := result["ranking"].([]map[string]interface{})
+	require.Len(t, ranking, 3)
+
+	assert.Equal(t, "iron_sword", ranking[0]["itemId"])
+	assert.Equal(t, swordPrice-swordPrice/2, ranking[0]["margin"])
+	assert.Equal(t, "shop", ranking[0]["placement"])
+	assert.Equal(t, "move_to_shop", ranking[0]["action"])
+
+	assert.Equal(t, "apple", ranking[1]["itemId"])
+	assert.Equal(t, 1, ranking[1]["margin"])
+	assert.Equal(t, "warehouse", ranking[1]["placement"])
+	assert.Equal(t, "relegate", ranking[1]["action"])
+
+	// Unsold stock never earns a slot
+	assert.Equal(t, "health_potion", ranking[2]["itemId"])
+	assert.Equal(t, "keep", ranking[2]["action"])
+
+	assert.False(t, gm.GetShopLayoutSuggestions(0)["success"].(bool))
+}
+
+func TestGameManager_GetMarketMood(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.SetEventSeed(42)
+
+	start := gm.GetMarketMood()
+	require.True(t, start["success"].(bool))
+	assert.Equal(t, "normal", start["mood"])
+	assert.Equal(t, 0.0, start["index"])
+	assert.Equal(t, market.DefaultMoodStrength, start["strength"])
+
+	gm.mu.Lock()
+	gm.advanceDaysUnsafe(20)
+	gm.mu.Unlock()
+
+	day := gm.gameState.GetCurrentDay()
+	index := market.NewMoodCycle(42).IndexAt(day)
+	mood := gm.GetMarketMood()
+	assert.InDelta(t, index, mood["index"].(float64), 1e-9)
+	assert.Equal(t, string(market.MoodForIndex(index)), mood["mood"])
+	assert.Contains(t, []string{"improving", "worsening", "stable"}, mood["trend"])
+
+	modifiers := mood["modifiers"].(map[string]interface{})
+	assert.InDelta(t, market.MoodModifier(index, market.DefaultMoodStrength, item.CategoryGem), modifiers["GEM"].(float64), 1e-9)
+
+	// Turning the mood off neutralizes it at once
+	require.NoError(t, gm.SetMarketMoodStrength(0))
+	for _, modifier := range gm.GetMarketMood()["modifiers"].(map[string]interface{}) {
+		assert.Equal(t, 1.0, modifier)
+	}
+	assert.Error(t, gm.SetMarketMoodStrength(2))
+}
+
+func TestGameManager_GetPortfolioAllocation(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	cashOnly := gm.GetPortfolioAllocation()
+	require.True(t, cashOnly["success"].(bool))
+	classes := cashOnly["classes"].([]map[string]interface{})
+	assert.Equal(t, 100.0, classes[0]["percent"])
+	assert.Empty(t, cashOnly["concentrated"])
+
+	require.True(t, gm.BuyItem("apple", 10, 5)["success"].(bool))
+	require.True(t, gm.BuyItem("iron_sword", 2, 100)["success"].(bool))
+	require.True(t, gm.Exchange("gold", "crowns", 100)["success"].(bool))
+
+	// Stock on its way to the shop still counts
+	require.NoError(t, gm.SetTransferFriction(0, 2))
+	require.True(t, gm.TransferItem("apple", 4, "warehouse", "shop")["success"].(bool))
+
+	allocation := gm.GetPortfolioAllocation()
+	netWorth := allocation["netWorth"].(int)
+	classes = allocation["classes"].([]map[string]interface{})
+	require.Len(t, classes, 3)
+
+	total, percent := 0, 0.0
+	for _, class := range classes {
+		assert.Greater(t, class["value"].(int), 0, class["class"])
+		total += class["value"].(int)
+		percent += class["percent"].(float64)
+	}
+	assert.Equal(t, netWorth, total)
+	assert.InDelta(t, 100.0, percent, 1e-9)
+
+	assert.Equal(t, gm.gameState.GetGold(), classes[0]["value"])
+	categories := classes[1]["categories"].([]map[string]interface{})
+	require.Len(t, categories, 2)
+	assert.Equal(t, "FRUIT", categories[0]["category"])
+	assert.Equal(t, gm.market.GetFairValue("apple")*10, categories[0]["value"])
+	assert.Equal(t, "WEAPON", categories[1]["category"])
+	currencies := classes[2]["currencies"].([]map[string]interface{})
+	require.Len(t, currencies, 1)
+	assert.Equal(t, "crowns", currencies[0]["currency"])
+
+	gm.mu.RLock()
+	assert.Equal(t, netWorth, gm.netWorthUnsafe())
+	gm.mu.RUnlock()
+
+	// Sinking nearly everything into one category is flagged
+	require.True(t, gm.BuyItem("steel_sword", 2, float64(gm.gameState.GetGold()/3))["success"].(bool))
+	assert.Contains(t, gm.GetPortfolioAllocation()["concentrated"], "WEAPON")
+}
+
+func TestGameManager_DonateItem(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	require.True(t, gm.BuyItem("apple", 10, 8)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 10))
+	gold := gm.gameState.GetGold()
+	reputation := gm.gameState.GetReputation()
+
+	result := gm.DonateItem("apple", 6)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("apple"))
+	assert.Equal(t, gold, gm.gameState.GetGold())
+	assert.Equal(t, 48, result["costWrittenOff"])
+
+	value := result["value"].(int)
+	assert.Equal(t, gm.market.GetFairValue("apple")*6, value)
+	expected := gamestate.DefaultReputationRules()[gamestate.ReputationCharity] * float64(value) / donationValuePerCharity
+	assert.InDelta(t, expected, result["reputationDelta"].(float64), 1e-9)
+	assert.Greater(t, gm.gameState.GetReputation(), reputation)
+
+	entries := gm.ledger.Entries()
+	donation := entries[len(entries)-1]
+	assert.Equal(t, ledger.EntryDonation, donation.Type)
+	assert.Equal(t, 0, donation.Amount)
+	assert.Equal(t, "Donated 6x Fresh Apple", formatLedgerEntry(donation))
+	assert.Equal(t, gm.gameState.GetGold(), gm.ledger.ExpectedBalance())
+
+	assert.False(t, gm.DonateItem("apple", 5)["success"].(bool))
+	assert.False(t, gm.DonateItem("apple", 0)["success"].(bool))
+
+	// Rotten stock is thrown out, leaving nothing to donate
+	for i := 0; i < 3; i++ {
+		gm.inventory.ProcessDailyUpdate()
+	}
+	assert.Equal(t, "Insufficient quantity in shop", gm.DonateItem("apple", 1)["message"])
+}
+
+func TestGameManager_GetReputationHistory(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NotNil(t, gm.saveManager)
+	rules := gm.gameState.GetReputationRules()
+
+	// Record a few days of reputation changes
+	actions := [][]gamestate.ReputationAction{
+		{gamestate.ReputationFairSale, gamestate.ReputationFairSale},
+		{gamestate.ReputationOvercharge},
+		{gamestate.ReputationQuestComplete, gamestate.ReputationCharity},
+	}
+	closing := make([]float64, 0, len(actions))
+	for _, day := range actions {
+		for _, action := range day {
+			gm.gameState.ApplyReputationAction(action, 1)
+		}
+		closing = append(closing, gm.gameState.GetReputation())
+		gm.AdvanceTime(1)
+	}
+
+	result := gm.GetReputationHistory(0)
+	assert.Equal(t, true, result["success"])
+	history := result["history"].([]map[string]interface{})
+	require.Len(t, history, len(actions))
+	for i, sample := range history {
+		assert.Equal(t, i+1, sample["day"])
+		assert.InDelta(t, closing[i], sample["reputation"].(float64), 1e-9)
+	}
+	reasons := history[0]["reasons"].(map[string]float64)
+	assert.InDelta(t, 2*rules[gamestate.ReputationFairSale], reasons[string(gamestate.ReputationFairSale)], 1e-9)
+	reasons = history[1]["reasons"].(map[string]float64)
+	assert.InDelta(t, rules[gamestate.ReputationOvercharge], reasons[string(gamestate.ReputationOvercharge)], 1e-9)
+	reasons = history[2]["reasons"].(map[string]float64)
+	assert.Contains(t, reasons, string(gamestate.ReputationQuestComplete))
+	assert.Contains(t, reasons, string(gamestate.ReputationCharity))
+
+	// The last days only
+	assert.Len(t, gm.GetReputationHistory(2)["history"], 2)
+
+	// The history and today's changes so far survive a save and load
+	gm.gameState.ApplyReputationAction(gamestate.ReputationOvercharge, 1)
+	today := gm.gameState.GetTodaysReputation()
+	require.NoError(t, gm.SaveGame(1))
+	gm.gameState.ResetReputationHistory(nil)
+	require.NoError(t, gm.LoadGame(1))
+	loaded := gm.GetReputationHistory(0)["history"].([]map[string]interface{})
+	assert.Equal(t, history, loaded)
+	loadedToday := gm.gameState.GetTodaysReputation()
+	assert.InDelta(t, today.Change, loadedToday.Change, 1e-9)
+	assert.InDelta(t, today.Reasons[gamestate.ReputationOvercharge], loadedToday.Reasons[gamestate.ReputationOvercharge], 1e-9)
+	assert.NotContains(t, loadedToday.Reasons, gamestate.ReputationOther)
+}
+
+func TestGameManager_GetOnboardingChecklist(t *testing.T) {
+	gm := newTestGameManager(t)
+	completed := make(chan string, 8)
+	gm.eventBus.Subscribe(event.EventNameOnboardingStepCompleted, func(e event.Event) error {
+		select {
+		case completed <- e.(*event.OnboardingStepCompletedEvent).StepID:
+		default: // Later tests' games fire steps too
+		}
+		return nil
+	})
+	expectStep := func(stepID string) {
+		t.Helper()
+		select {
+		case got := <-completed:
+			assert.Equal(t, stepID, got)
+		case <-time.After(time.Second):
+			t.Fatalf("no event for onboarding step %s", stepID)
+		}
+	}
+	done := func() map[string]bool {
+		steps := make(map[string]bool)
+		for _, step := range gm.GetOnboardingChecklist()["steps"].([]map[string]interface{}) {
+			steps[step["id"].(string)] = step["completed"].(bool)
+		}
+		return steps
+	}
+
+	checklist := gm.GetOnboardingChecklist()
+	require.Len(t, checklist["steps"], 4)
+	assert.Equal(t, 0, checklist["completed"])
+	assert.Equal(t, gamestate.OnboardingFirstPurchase, checklist["nextStep"])
+	assert.Equal(t, false, checklist["finished"])
+
+	require.True(t, gm.BuyItem("iron_sword", 3, 150)["success"].(bool))
+	expectStep(gamestate.OnboardingFirstPurchase)
+	assert.True(t, done()[gamestate.OnboardingFirstPurchase])
+	assert.Equal(t, gamestate.OnboardingSetPrice, gm.GetOnboardingChecklist()["nextStep"])
+
+	_, err := gm.pricing.UpdatePrice(&PriceUpdateRequest{ItemID: "iron_sword", NewPrice: 400, Strategy: "manual"})
+	require.NoError(t, err)
+	expectStep(gamestate.OnboardingSetPrice)
+
+	// Selling at a loss does not count
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 3))
+	require.True(t, gm.SellItem("iron_sword", 1, 50)["success"].(bool))
+	assert.False(t, done()[gamestate.OnboardingProfitableSale])
+	require.True(t, gm.SellItem("iron_sword", 1, 400)["success"].(bool))
+	expectStep(gamestate.OnboardingProfitableSale)
+
+	gm.AdvanceTime(1)
+	expectStep(gamestate.OnboardingReachDay2)
+	checklist = gm.GetOnboardingChecklist()
+	assert.Equal(t, 4, checklist["completed"])
+	assert.Equal(t, true, checklist["finished"])
+	assert.Equal(t, "", checklist["nextStep"])
+
+	// Steps are only completed once
+	require.True(t, gm.BuyItem("iron_sword", 1, 150)["success"].(bool))
+	select {
+	case stepID := <-completed:
+		t.Fatalf("onboarding step %s completed twice", stepID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Progress survives a save and load, and a new game starts over
+	require.NoError(t, gm.SaveGame(1))
+	require.NoError(t, gm.StartNewGame("Second Merchant"))
+	assert.Equal(t, 0, gm.GetOnboardingChecklist()["completed"])
+	require.NoError(t, gm.LoadGame(1))
+	checklist = gm.GetOnboardingChecklist()
+	assert.Equal(t, 4, checklist["completed"])
+	assert.Equal(t, 2, checklist["steps"].([]map[string]interface{})[3]["completedDay"])
+}
+
+func TestGameManager_ExportAnalyticsCSV(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 3, 150)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 3))
+	require.True(t, gm.SellItem("iron_sword", 1, 200)["success"].(bool))
+	gm.AdvanceTime(2)
+	require.True(t, gm.SellItem("iron_sword", 1, 200)["success"].(bool))
+
+	result := gm.ExportAnalyticsCSV()
+	require.True(t, result["success"].(bool))
+	read := func(name string) [][]string {
+		records, err := csv.NewReader(strings.NewReader(result[name].(string))).ReadAll()
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+		return records
+	}
+
+	ledgerCSV := read("ledger")
+	assert.Equal(t, []string{"day", "type", "item_id", "currency", "quantity", "amount", "profit", "tax", "timestamp"}, ledgerCSV[0])
+	entries := gm.ledger.Entries()
+	require.Len(t, ledgerCSV, len(entries)+1)
+	assert.Equal(t, []string{"1", "purchase", "iron_sword"}, ledgerCSV[1][:3])
+	assert.Equal(t, strconv.Itoa(entries[len(entries)-1].Amount), ledgerCSV[len(ledgerCSV)-1][5])
+
+	// One row per day, today included
+	netWorthCSV := read("netWorth")
+	assert.Equal(t, []string{"day", "net_worth"}, netWorthCSV[0])
+	require.Len(t, netWorthCSV, 3+1)
+	assert.Equal(t, "3", netWorthCSV[3][0])
+	assert.Equal(t, strconv.Itoa(gm.GetGameSummary()["netWorth"].(int)), netWorthCSV[3][1])
+
+	// Every market item for each day
+	pricesCSV := read("prices")
+	assert.Equal(t, []string{"day", "item_id", "price"}, pricesCSV[0])
+	assert.Len(t, pricesCSV, 3*len(gm.snapshotPricesUnsafe())+1)
+
+	rows := result["rows"].(map[string]int)
+	assert.Equal(t, len(ledgerCSV)-1, rows["ledger"])
+	assert.Equal(t, len(netWorthCSV)-1, rows["netWorth"])
+	assert.Equal(t, len(pricesCSV)-1, rows["prices"])
+}
+
+func TestGameManager_GetComparativePricing(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	for _, itemID := range []string{"apple", "iron_sword", "health_potion"} {
+		require.True(t, gm.BuyItem(itemID, 2, 5)["success"].(bool))
+		require.NoError(t, gm.inventory.TransferToShop(itemID, 2))
+	}
+
+	applePrice := float64(gm.market.GetPrice("apple"))
+	swordPrice := float64(gm.market.GetPrice("iron_sword"))
+	potionPrice := float64(gm.market.GetPrice("health_potion"))
+	gm.pricing.SetCompetitorPrice("apple", applePrice*1.1)
+	gm.pricing.SetCompetitorPrice("iron_sword", swordPrice)
+	gm.pricing.SetCompetitorPrice("health_potion", potionPrice)
+
+	// Apples sit above the market but under competitors; swords are above
+	// both; potions are well below both
+	for itemID, price := range map[string]float64{"apple": applePrice * 1.08, "iron_sword": swordPrice * 1.5, "health_potion": potionPrice * 0.5} {
+		result, err := gm.pricing.UpdatePrice(&PriceUpdateRequest{ItemID: itemID, NewPrice: price, Strategy: "manual"})
+		require.NoError(t, err)
+		require.True(t, result.Success, result.Message)
+	}
+
+	result := gm.GetComparativePricing()
+	require.True(t, result["success"].(bool))
+	items := result["items"].([]map[string]interface{})
+	require.Len(t, items, 3)
+	byID := make(map[string]map[string]interface{}, len(items))
+	for _, entry := range items {
+		byID[entry["itemId"].(string)] = entry
+	}
+
+	sword := byID["iron_sword"]
+	assert.Equal(t, "overpriced", sword["position"])
+	assert.Equal(t, false, sword["competitive"])
+	assert.Equal(t, swordPrice*1.5, sword["yourPrice"])
+	assert.Equal(t, swordPrice, sword["marketPrice"])
+	assert.Equal(t, swordPrice, sword["competitorPrice"])
+	assert.Contains(t, sword["recommendation"], "above the market")
+
+	assert.Equal(t, "competitive", byID["apple"]["position"])
+	assert.Equal(t, true, byID["apple"]["competitive"])
+	assert.Equal(t, "underpriced", byID["health_potion"]["position"])
+	assert.Equal(t, 1, result["overpriced"])
+	assert.Equal(t, 1, result["underpriced"])
+}
+
+func TestGameManager_StartNewGameWithScenario(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	scenarios := gm.GetAvailableScenarios()
+	require.NotEmpty(t, scenarios)
+	ids := make([]string, 0, len(scenarios))
+	for _, s := range scenarios {
+		ids = append(ids, s["id"].(string))
+	}
+	assert.Contains(t, ids, scenario.StrugglingShop)
+	assert.Contains(t, ids, scenario.InheritedFortune)
+
+	assert.ErrorContains(t, gm.StartNewGameWithScenario("Tester", "dragon_hoard"), "unknown scenario")
+	assert.Nil(t, gm.GetCurrentScenario())
+
+	baseRate := gm.taxes.GetTaxRate(item.CategoryWeapon, tax.TransactionSale)
+
+	// The inherited fortune starts rich, with stock and a heavy tax bill
+	require.NoError(t, gm.StartNewGameWithScenario("Heir", scenario.InheritedFortune))
+	assert.Equal(t, 8000, gm.gameState.GetGold())
+	assert.Equal(t, 8000, gm.ledger.OpeningBalance())
+	assert.Equal(t, 20.0, gm.gameState.GetReputation())
+	assert.Equal(t, 3, gm.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.InDelta(t, baseRate*2, gm.taxes.GetTaxRate(item.CategoryWeapon, tax.TransactionSale), 1e-9)
+	assert.Equal(t, scenario.InheritedFortune, gm.GetCurrentScenario()["id"])
+
+	// The next game drops the scenario and its overhead
+	require.NoError(t, gm.SaveGame(1))
+	gm.resetAllSystems(nil)
+	assert.Nil(t, gm.GetCurrentScenario())
+	assert.InDelta(t, baseRate, gm.taxes.GetTaxRate(item.CategoryWeapon, tax.TransactionSale), 1e-9)
+
+	// Loading brings back the saved game's scenario, or none at all
+	require.NoError(t, gm.SaveGame(2))
+	require.NoError(t, gm.LoadGame(1))
+	assert.Equal(t, scenario.InheritedFortune, gm.GetCurrentScenario()["id"])
+	assert.InDelta(t, baseRate*2, gm.taxes.GetTaxRate(item.CategoryWeapon, tax.TransactionSale), 1e-9)
+	require.NoError(t, gm.LoadGame(2))
+	assert.Nil(t, gm.GetCurrentScenario())
+	assert.InDelta(t, baseRate, gm.taxes.GetTaxRate(item.CategoryWeapon, tax.TransactionSale), 1e-9)
+
+	// The struggling shop is poor, disliked and stuck with fruit
+	struggling := newTestGameManager(t)
+	require.NoError(t, struggling.StartNewGameWithScenario("Underdog", scenario.StrugglingShop))
+	assert.Equal(t, 250, struggling.gameState.GetGold())
+	assert.Equal(t, -30.0, struggling.gameState.GetReputation())
+	assert.Equal(t, 15, struggling.inventory.GetWarehouseQuantity("apple"))
+	assert.Zero(t, struggling.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.NotEmpty(t, struggling.GetCurrentScenario()["objectives"])
+
+	// Its fruit has already aged
+	for _, record := range struggling.inventory.StockRecords() {
+		master, _ := item.GetItemRegistry().GetItem(record.ItemID)
+		assert.Equal(t, master.Durability-1, record.Durability, record.ItemID)
+	}
+}
+
+func TestGameManager_SeasonalSpecialistIsJudgedAtTimeUp(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.StartNewGameWithScenario("Grower", scenario.SeasonalSpecialist))
+	assert.Equal(t, true, gm.GetWinConditions()["atTimeUp"])
+
+	// Rich mid-season is not yet a win
+	gm.gameState.SetGold(5000)
+	assert.False(t, gm.GetVictoryProgress()["victory"].(bool))
+	assert.Equal(t, "in_progress", gm.GetGameSummary()["outcome"])
+
+	// The season's end decides it
+	season, err := scenario.Get(scenario.SeasonalSpecialist)
+	require.NoError(t, err)
+	gm.AdvanceTime(season.MaxDays)
+	require.True(t, gm.gameState.IsTimeUp())
+	gm.gameState.SetGold(5000)
+	assert.True(t, gm.GetVictoryProgress()["victory"].(bool))
+	assert.Equal(t, "victory", gm.GetGameSummary()["outcome"])
+}
+
+func TestGameManager_UndoLastDay(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	assert.False(t, gm.UndoLastDay()["success"].(bool))
+
+	// Trade on day 1 and note where the day ends
+	require.True(t, gm.BuyItem("apple", 10, 20)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 4))
+	endOfDayGold := gm.gameState.GetGold()
+	endOfDayPrice := gm.market.GetPrice("iron_sword")
+	endOfDayEntries := len(gm.ledger.Entries())
+
+	// A catastrophic day 2
+	gm.AdvanceTime(1)
+	require.Equal(t, 2, gm.gameState.GetCurrentDay())
+	require.True(t, gm.BuyItem("iron_sword", 1, 500)["success"].(bool))
+	require.True(t, gm.Exchange(exchange.Gold, "crowns", 100)["success"].(bool))
+	require.True(t, gm.BuyInsurance(5)["success"].(bool))
+	require.Less(t, gm.gameState.GetGold(), endOfDayGold)
+
+	result := gm.UndoLastDay()
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 1, result["day"])
+	assert.Equal(t, 2, result["undoneDay"])
+	assert.Equal(t, 1, gm.gameState.GetCurrentDay())
+	assert.Equal(t, endOfDayGold, gm.gameState.GetGold())
+	assert.Equal(t, endOfDayPrice, gm.market.GetPrice("iron_sword"))
+	assert.Equal(t, 0, gm.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 6, gm.inventory.GetWarehouseQuantity("apple"))
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("apple"))
+	assert.Equal(t, 0, gm.exchange.Balance("crowns"))
+	insured := gm.GetInsuranceStatus()
+	assert.Equal(t, false, insured["insured"])
+	assert.Equal(t, 0, insured["totalPremiums"])
+	assert.Len(t, gm.ledger.Entries(), endOfDayEntries)
+	assert.Equal(t, gm.ledger.ExpectedBalance(), gm.gameState.GetGold())
+
+	// Nothing earlier was kept, and only the last few days ever are
+	assert.False(t, gm.UndoLastDay()["success"].(bool))
+	gm.AdvanceTime(maxUndoDays + 2)
+	for i := 0; i < maxUndoDays; i++ {
+		require.True(t, gm.UndoLastDay()["success"].(bool))
+	}
+	assert.Equal(t, 3, gm.gameState.GetCurrentDay())
+	assert.False(t, gm.UndoLastDay()["success"].(bool))
+}
+
+func TestGameManager_GetPriceForecastBand(t *testing.T) {
+	gm := newTestGameManager(t)
+
+	relativeWidth := func(itemID string) float64 {
+		result := gm.GetPriceForecastBand(itemID, 10)
+		require.True(t, result["success"].(bool), result["message"])
+		forecast := result["forecast"].([]map[string]interface{})
+		require.Len(t, forecast, 10)
+		last := forecast[len(forecast)-1]
+		assert.Equal(t, 11, last["day"])
+		return (last["upper"].(float64) - last["lower"].(float64)) / last["center"].(float64)
+	}
+
+	// Volatile potions swing further than stable swords
+	potion := relativeWidth("health_potion")
+	sword := relativeWidth("iron_sword")
+	assert.Greater(t, potion, sword)
+	assert.Greater(t, sword, 0.0)
+
+	// A more volatile market widens the band; calm narrows it
+	require.NoError(t, gm.market.SetVolatilityScale(3))
+	assert.Greater(t, relativeWidth("health_potion"), potion)
+	require.NoError(t, gm.market.SetVolatilityScale(0.5))
+	require.NoError(t, gm.SetMarketMoodStrength(0))
+	assert.Less(t, relativeWidth("health_potion"), potion)
+
+	assert.False(t, gm.GetPriceForecastBand("unknown", 5)["success"].(bool))
+	assert.False(t, gm.GetPriceForecastBand("apple", 0)["success"].(bool))
+}
+
+func TestGameManager_Bundles(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.taxes.SetEnabled(false)
+	require.NoError(t, gm.inventory.AddToShopByID("iron_sword", 3, 80))
+	require.NoError(t, gm.inventory.AddToShopByID("health_potion", 5, 20))
+
+	kit := map[string]int{"iron_sword": 1, "health_potion": 2}
+	assert.False(t, gm.CreateBundle(kit, "Kit", 0)["success"].(bool))
+	assert.False(t, gm.CreateBundle(map[string]int{"dragon_egg": 1, "apple": 1}, "Odd", 200)["success"].(bool))
+	assert.False(t, gm.CreateBundle(map[string]int{"iron_sword": 4, "health_potion": 1}, "Big Kit", 500)["success"].(bool))
+	assert.Empty(t, gm.GetBundles(), "a bundle that could not be assembled is not kept")
+
+	// Creating a bundle reserves one set of components out of the shop
+	result := gm.CreateBundle(kit, "Adventurer's Kit", 180)
+	require.True(t, result["success"].(bool), result["message"])
+	info := result["bundle"].(map[string]interface{})
+	bundleID := info["id"].(string)
+	assert.Equal(t, 1, info["assembled"])
+	assert.Equal(t, 120, info["unitCost"])
+	assert.InDelta(t, 0.5, info["markup"].(float64), 1e-9)
+	assert.Equal(t, 2, gm.inventory.GetShopQuantity("iron_sword"))
+	assert.Equal(t, 3, gm.inventory.GetShopQuantity("health_potion"))
+
+	result = gm.AssembleBundle(bundleID, 1)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.False(t, gm.AssembleBundle(bundleID, 1)["success"].(bool), "only one potion left")
+	assert.Equal(t, 1, gm.inventory.GetShopQuantity("health_potion"))
+
+	// Reserved components cannot be sold on their own
+	assert.False(t, gm.SellItem("health_potion", 2, 30)["success"].(bool))
+
+	// Assembled bundles survive a save and load with their components
+	require.NoError(t, gm.SaveGame(1))
+	require.NoError(t, gm.LoadGame(1))
+	bundles := gm.GetBundles()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, 2, bundles[0]["assembled"])
+	assert.Equal(t, 120, bundles[0]["unitCost"])
+
+	// Selling a bundle credits its price, less the spread, and consumes its
+	// components
+	gold := gm.gameState.GetGold()
+	result = gm.SellBundle(bundleID, 1)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 180, result["revenue"])
+	gained := result["gold_gained"].(int)
+	assert.Greater(t, gained, 0)
+	assert.Less(t, gained, 180)
+	assert.Equal(t, gained-120, result["profit"])
+	assert.Equal(t, gold+gained, gm.gameState.GetGold())
+	assert.Equal(t, 1, gm.inventory.GetShopQuantity("iron_sword"))
+	assert.Equal(t, 1, gm.inventory.GetShopQuantity("health_potion"))
+	assert.False(t, gm.SellBundle(bundleID, 2)["success"].(bool))
+	assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+
+	// Taking the last one apart returns its components at cost
+	assert.False(t, gm.RemoveBundle(bundleID)["success"].(bool))
+	result = gm.DisassembleBundle(bundleID, 1)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 2, gm.inventory.GetShopQuantity("iron_sword"))
+	assert.Equal(t, 3, gm.inventory.GetShopQuantity("health_potion"))
+	assert.Equal(t, 80, gm.inventory.GetPurchasePrice("iron_sword"))
+
+	bundles = gm.GetBundles()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, 0, bundles[0]["assembled"])
+	assert.True(t, gm.RemoveBundle(bundleID)["success"].(bool))
+	assert.Empty(t, gm.GetBundles())
+
+	// A bundle sells for no more than its components are worth plus a
+	// premium, and asking far more than that costs reputation
+	require.NoError(t, gm.inventory.AddToShopByID("apple", 2, 10))
+	result = gm.CreateBundle(map[string]int{"apple": 1, "health_potion": 1}, "Picnic", 50000)
+	require.True(t, result["success"].(bool), result["message"])
+	reputation := gm.gameState.GetReputation()
+	result = gm.SellBundle(result["bundle"].(map[string]interface{})["id"].(string), 1)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, result["priceCap"], result["revenue"])
+	assert.LessOrEqual(t, result["gold_gained"].(int), result["priceCap"].(int))
+	assert.Less(t, gm.gameState.GetReputation(), reputation)
+}
+
+func TestGameManager_DisassembleBundleKeepsCostBasis(t *testing.T) {
+	gm := newTestGameManager(t)
+	kit, err := gm.bundles.Create("Potion Pack", map[string]int{"health_potion": 2}, 60, 1)
+	require.NoError(t, err)
+	require.NoError(t, gm.bundles.Assemble(kit.ID, 1, map[string]int{"health_potion": 10}))
+	require.NoError(t, gm.bundles.Assemble(kit.ID, 2, map[string]int{"health_potion": 11}))
+	costOf := func() int {
+		record := gm.bundles.Record()
+		return record.Bundles[0].ComponentCost["health_potion"]
+	}
+
+	// A take-apart with no room leaves the bundles as they were
+	require.NoError(t, gm.inventory.SetBaseCapacity(1, 1))
+	assert.False(t, gm.DisassembleBundle(kit.ID, 2)["success"].(bool))
+	assert.Equal(t, 64, costOf())
+
+	// Two of three bundles hold 42 gold of potions, which do not split
+	// evenly over four units
+	require.NoError(t, gm.inventory.SetBaseCapacity(100, 100))
+	result := gm.DisassembleBundle(kit.ID, 2)
+	require.True(t, result["success"].(bool), result["message"])
+	assert.Equal(t, 22, costOf())
+	assert.Equal(t, 4, gm.inventory.GetShopQuantity("health_potion"))
+	assert.Equal(t, 11, gm.inventory.GetPurchasePrice("health_potion"))
+}
+
+func TestGameManager_Branches(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetGold(10000)
+	require.NoError(t, gm.SetTransferFriction(1, 0))
+
+	assert.False(t, gm.OpenBranch("Palace Shop", "atlantis", "")["success"].(bool))
+	result := gm.OpenBranch("Harbor Arms", "harbor", "weapon")
+	require.True(t, result["success"].(bool), result["message"])
+	branchID := result["branch"].(map[string]interface{})["id"].(string)
+	assert.Equal(t, 6000, gm.gameState.GetGold())
+
+	branches := gm.GetBranches()
+	require.Len(t, branches, 2)
+	assert.Equal(t, mainBranchID, branches[0]["id"])
+	assert.Equal(t, branchID, branches[1]["id"])
+	assert.Equal(t, "WEAPON", branches[1]["specialty"])
+
+	// Each branch keeps its own stock
+	require.True(t, gm.BuyItem("iron_sword", 5, 150)["success"].(bool))
+	require.True(t, gm.BuyItem("apple", 10, 10)["success"].(bool))
+	netWorth := gm.netWorthUnsafe()
+	transfer := gm.TransferBetweenBranches("iron_sword", 2, mainBranchID, branchID)
+	require.True(t, transfer["success"].(bool), transfer["message"])
+	assert.Equal(t, 2, transfer["cost"])
+	assert.Equal(t, netWorth-2, gm.netWorthUnsafe())
+	assert.False(t, gm.TransferBetweenBranches("iron_sword", 1, mainBranchID, "branch-9")["success"].(bool))
+
+	assert.Equal(t, 3, gm.inventory.GetWarehouseQuantity("iron_sword"))
+	assert.Equal(t, 10, gm.inventory.GetWarehouseQuantity("apple"))
+	branchStock := gm.GetBranchInventory(branchID)
+	require.True(t, branchStock["success"].(bool))
+	warehouse := branchStock["warehouse"].([]map[string]interface{})
+	require.Len(t, warehouse, 1)
+	assert.Equal(t, "iron_sword", warehouse[0]["itemId"])
+	assert.Equal(t, 2, warehouse[0]["quantity"])
+
+	// Branch sales use local prices and leave the main shop alone
+	require.True(t, gm.StockBranchShop(branchID, "iron_sword", 2)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+	sale := gm.SellFromBranch(branchID, "iron_sword", 1)
+	require.True(t, sale["success"].(bool), sale["message"])
+	assert.InDelta(t, float64(gm.market.GetPrice("iron_sword"))*branch.SpecialtyBonus, sale["localPrice"], 1e-9)
+	assert.Equal(t, 1, gm.inventory.GetShopQuantity("iron_sword"))
+	branchShop := gm.GetBranchInventory(branchID)["shop"].([]map[string]interface{})
+	require.Len(t, branchShop, 1)
+	assert.Equal(t, 1, branchShop[0]["quantity"])
+	assert.False(t, gm.SellFromBranch(branchID, "iron_sword", 5)["success"].(bool))
+
+	// Branch sales draw on the same market liquidity as the main shop
+	remaining := gm.market.GetRemainingLiquidity("iron_sword")
+	sale = gm.SellFromBranch(branchID, "iron_sword", 1)
+	require.True(t, sale["success"].(bool), sale["message"])
+	assert.Equal(t, remaining-1, gm.market.GetRemainingLiquidity("iron_sword"))
+	assert.Equal(t, 1.0, sale["liquidityFactor"])
+
+	// Branches and their stock survive a save and load
+	transfer = gm.TransferBetweenBranches("apple", 4, mainBranchID, branchID)
+	require.True(t, transfer["success"].(bool), transfer["message"])
+	require.NoError(t, gm.SaveGame(1))
+	require.NoError(t, gm.LoadGame(1))
+	branches = gm.GetBranches()
+	require.Len(t, branches, 2)
+	assert.Equal(t, "Harbor Arms", branches[1]["name"])
+	assert.Equal(t, "WEAPON", branches[1]["specialty"])
+	loaded, err := gm.branches.Get(branchID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, loaded.Inventory.GetWarehouseQuantity("apple"))
+
+	// Perishable branch stock ages and spoils like the main shop's
+	gm.advanceDaysUnsafe(5)
+	assert.Zero(t, loaded.Inventory.GetWarehouseQuantity("apple"))
+
+	// A new game closes every branch
+	gm.resetAllSystems(nil)
+	assert.Len(t, gm.GetBranches(), 1)
+}
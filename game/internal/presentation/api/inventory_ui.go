@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,12 +11,7 @@ import (
 	"github.com/yourusername/merchant-tails/game/internal/domain/item"
 )
 
-// Fruit item constants
 const (
-	itemApple  = "apple"
-	itemOrange = "orange"
-	itemBanana = "banana"
-
 	// Location constants
 	locationShop      = "shop"
 	locationWarehouse = "warehouse"
@@ -77,6 +73,15 @@ type InventoryStats struct {
 	TotalValue           float64 `json:"total_value"`
 	PerishableItems      int     `json:"perishable_items"`
 	ExpiringItems        int     `json:"expiring_items"` // Items expiring in next 3 days
+
+	Categories map[item.Category]*CategoryStats `json:"categories"`
+}
+
+// CategoryStats aggregates the stock held in one item category
+type CategoryStats struct {
+	Count           int     `json:"count"`
+	Value           float64 `json:"value"`
+	PerishableCount int     `json:"perishable_count"`
 }
 
 // OptimizationSuggestion represents a suggested inventory optimization
@@ -97,6 +102,7 @@ type InventoryFilter struct {
 	MinQuantity int    `json:"min_quantity"`
 	MaxQuantity int    `json:"max_quantity"`
 	Perishable  *bool  `json:"perishable,omitempty"`
+	NameQuery   string `json:"name_query"` // Case-insensitive substring of the display name
 	SortBy      string `json:"sort_by"`    // "name", "quantity", "value", "velocity", "age"
 	SortOrder   string `json:"sort_order"` // "asc" or "desc"
 }
@@ -180,38 +186,24 @@ func (iui *InventoryUIManager) TransferItem(request *InventoryTransferRequest) (
 		}, nil
 	}
 
-	// Execute transfer based on direction
-	var err error
-	switch {
-	case request.FromLocation == locationShop && request.ToLocation == locationWarehouse:
-		err = iui.inventory.TransferToWarehouse(request.ItemID, request.Quantity)
-	case request.FromLocation == locationWarehouse && request.ToLocation == locationShop:
-		err = iui.inventory.TransferToShop(request.ItemID, request.Quantity)
-	default:
+	validDirection := (request.FromLocation == locationShop && request.ToLocation == locationWarehouse) ||
+		(request.FromLocation == locationWarehouse && request.ToLocation == locationShop)
+	if !validDirection {
 		return &InventoryTransferResult{
 			Success: false,
 			Message: "Invalid transfer locations",
 		}, nil
 	}
 
-	if err != nil {
-		return &InventoryTransferResult{
-			Success:      false,
-			ItemID:       request.ItemID,
-			Quantity:     request.Quantity,
-			FromLocation: request.FromLocation,
-			ToLocation:   request.ToLocation,
-			Message:      err.Error(),
-		}, nil
-	}
-
+	// Transfer through the game manager so transfer costs and delays apply
+	result := iui.gameManager.TransferItem(request.ItemID, request.Quantity, request.FromLocation, request.ToLocation)
 	return &InventoryTransferResult{
-		Success:      true,
+		Success:      result["success"].(bool),
 		ItemID:       request.ItemID,
 		Quantity:     request.Quantity,
 		FromLocation: request.FromLocation,
 		ToLocation:   request.ToLocation,
-		Message:      "Transfer successful",
+		Message:      result["message"].(string),
 	}, nil
 }
 
@@ -241,53 +233,47 @@ func (iui *InventoryUIManager) GetInventoryStats() (*InventoryStats, error) {
 	iui.mu.RLock()
 	defer iui.mu.RUnlock()
 
-	// Calculate shop usage
-	shopUsed := 0
+	// Calculate space usage by item footprint
+	shopUsed := iui.inventory.GetShopSpaceUsed()
+	warehouseUsed := iui.inventory.GetWarehouseSpaceUsed()
 	shopItems := iui.inventory.ShopInventory.GetAll()
-	for _, quantity := range shopItems {
-		shopUsed += quantity
-	}
-
-	// Calculate warehouse usage
-	warehouseUsed := 0
 	warehouseItems := iui.inventory.WarehouseInventory.GetAll()
-	for _, quantity := range warehouseItems {
-		warehouseUsed += quantity
-	}
 
 	// Calculate total value
 	totalValue := 0.0
 	totalItems := 0
 	perishableItems := 0
 	expiringItems := 0
-
-	// Count items and calculate values
-	for itemID, quantity := range shopItems {
-		totalItems += quantity
-		price := float64(iui.gameManager.market.GetPrice(itemID))
-		totalValue += price * float64(quantity)
-
-		// Check if perishable
-		if iui.isPerishable(itemID) {
-			perishableItems++
-			if iui.isExpiringSoon(itemID, 3) {
-				expiringItems++
+	categories := make(map[item.Category]*CategoryStats)
+
+	// Count items and calculate values, overall and per category
+	countStock := func(stock map[string]int) {
+		for itemID, quantity := range stock {
+			category := iui.getItemCategory(itemID)
+			catStats, exists := categories[category]
+			if !exists {
+				catStats = &CategoryStats{}
+				categories[category] = catStats
 			}
-		}
-	}
 
-	for itemID, quantity := range warehouseItems {
-		totalItems += quantity
-		price := float64(iui.gameManager.market.GetPrice(itemID))
-		totalValue += price * float64(quantity)
-
-		if iui.isPerishable(itemID) {
-			perishableItems++
-			if iui.isExpiringSoon(itemID, 3) {
-				expiringItems++
+			value := float64(iui.gameManager.market.GetPrice(itemID)) * float64(quantity)
+			totalItems += quantity
+			totalValue += value
+			catStats.Count += quantity
+			catStats.Value += value
+
+			// Check if perishable
+			if iui.isPerishable(itemID) {
+				perishableItems++
+				catStats.PerishableCount++
+				if iui.isExpiringSoon(itemID, 3) {
+					expiringItems++
+				}
 			}
 		}
 	}
+	countStock(shopItems)
+	countStock(warehouseItems)
 
 	stats := &InventoryStats{
 		ShopCapacity:         iui.inventory.ShopCapacity,
@@ -300,6 +286,7 @@ func (iui *InventoryUIManager) GetInventoryStats() (*InventoryStats, error) {
 		TotalValue:           totalValue,
 		PerishableItems:      perishableItems,
 		ExpiringItems:        expiringItems,
+		Categories:           categories,
 	}
 
 	return stats, nil
@@ -349,8 +336,8 @@ func (iui *InventoryUIManager) GetOptimizationSuggestions() ([]*OptimizationSugg
 		if velocity > 1.0 && quantity > 0 {
 			// Check if shop has room
 			shopSpace := iui.inventory.ShopCapacity - iui.getShopUsage()
-			if shopSpace > 0 {
-				moveQty := min(quantity, shopSpace)
+			moveQty := min(quantity, shopSpace/iui.getItemFootprint(itemID))
+			if moveQty > 0 {
 				suggestions = append(suggestions, &OptimizationSuggestion{
 					Type:     "move_to_shop",
 					ItemID:   itemID,
@@ -450,7 +437,7 @@ func (iui *InventoryUIManager) getShopItems() []*InventoryUIItem {
 			DaysInStock:   iui.getDaysInStock(),
 			Durability:    iui.getItemDurability(itemID),
 			SalesVelocity: iui.getSalesVelocity(itemID),
-			SpaceUsed:     quantity,
+			SpaceUsed:     quantity * iui.getItemFootprint(itemID),
 			Icon:          fmt.Sprintf("res://assets/items/%s.png", itemID),
 		}
 		items = append(items, item)
@@ -479,7 +466,7 @@ func (iui *InventoryUIManager) getWarehouseItems() []*InventoryUIItem {
 			DaysInStock:   iui.getDaysInStock(),
 			Durability:    iui.getItemDurability(itemID),
 			SalesVelocity: iui.getSalesVelocity(itemID),
-			SpaceUsed:     quantity,
+			SpaceUsed:     quantity * iui.getItemFootprint(itemID),
 			Icon:          fmt.Sprintf("res://assets/items/%s.png", itemID),
 		}
 		items = append(items, item)
@@ -497,6 +484,11 @@ func (iui *InventoryUIManager) matchesFilter(item *InventoryUIItem, filter *Inve
 		return false
 	}
 
+	if filter.NameQuery != "" &&
+		!strings.Contains(strings.ToLower(item.Name), strings.ToLower(strings.TrimSpace(filter.NameQuery))) {
+		return false
+	}
+
 	if filter.Location != "" && filter.Location != categoryAll && item.Location != filter.Location {
 		return false
 	}
@@ -585,8 +577,7 @@ func (iui *InventoryUIManager) getAverageDailySales(data *SalesData) float64 {
 }
 
 func (iui *InventoryUIManager) isPerishable(itemID string) bool {
-	// Check if item is in fruit category or has durability
-	return itemID == itemApple || itemID == itemOrange || itemID == itemBanana
+	return isRegistryPerishable(itemID)
 }
 
 func (iui *InventoryUIManager) isExpiringSoon(itemID string, days int) bool {
@@ -604,11 +595,11 @@ func (iui *InventoryUIManager) isExpiringSoon(itemID string, days int) bool {
 }
 
 func (iui *InventoryUIManager) getShopUsage() int {
-	total := 0
-	for _, quantity := range iui.inventory.ShopInventory.GetAll() {
-		total += quantity
-	}
-	return total
+	return iui.inventory.GetShopSpaceUsed()
+}
+
+func (iui *InventoryUIManager) getItemFootprint(itemID string) int {
+	return (&item.Item{ID: itemID, Category: iui.getItemCategory(itemID)}).GetFootprint()
 }
 
 func (iui *InventoryUIManager) getTotalStock(itemID string) int {
@@ -618,40 +609,24 @@ func (iui *InventoryUIManager) getTotalStock(itemID string) int {
 }
 
 func (iui *InventoryUIManager) getPurchasePrice(itemID string) float64 {
-	// Simplified - in production would track actual purchase prices
+	// Use what the player actually paid when the item is in stock
+	if cost, ok := iui.inventory.GetAveragePurchasePrice(itemID); ok {
+		return float64(cost)
+	}
+	// Otherwise estimate from the market price
 	return float64(iui.gameManager.market.GetPrice(itemID)) * 0.8
 }
 
 func (iui *InventoryUIManager) getItemName(itemID string) string {
-	// Map item IDs to display names
-	names := map[string]string{
-		"apple":               "Apple",
-		"sword_iron":          "Iron Sword",
-		"potion_health":       "Health Potion",
-		"gem_diamond":         "Diamond",
-		"spellbook_rare":      "Rare Spellbook",
-		"accessory_gold_ring": "Gold Ring",
-	}
-
-	if name, exists := names[itemID]; exists {
-		return name
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists {
+		return master.Name
 	}
 	return itemID
 }
 
 func (iui *InventoryUIManager) getItemCategory(itemID string) item.Category {
-	// Map item IDs to categories
-	categories := map[string]item.Category{
-		"apple":               item.CategoryFruit,
-		"sword_iron":          item.CategoryWeapon,
-		"potion_health":       item.CategoryPotion,
-		"gem_diamond":         item.CategoryGem,
-		"spellbook_rare":      item.CategoryMagicBook,
-		"accessory_gold_ring": item.CategoryAccessory,
-	}
-
-	if cat, exists := categories[itemID]; exists {
-		return cat
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists {
+		return master.Category
 	}
 	return item.CategoryFruit
 }
@@ -662,8 +637,8 @@ func (iui *InventoryUIManager) getDaysInStock() int {
 }
 
 func (iui *InventoryUIManager) getItemDurability(itemID string) int {
-	if iui.isPerishable(itemID) {
-		return 7 // 7 days for fruits
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists && iui.isPerishable(itemID) {
+		return master.Durability
 	}
 	return -1 // Non-perishable
 }
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+func TestInventoryUIManager_NameQuery(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 2, 100)["success"].(bool))
+	require.True(t, gm.BuyItem("steel_sword", 1, 200)["success"].(bool))
+	require.True(t, gm.BuyItem("apple", 5, 10)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+
+	iui := NewInventoryUIManager(gm)
+
+	items, err := iui.GetInventoryItems(&InventoryFilter{NameQuery: "SWO"})
+	require.NoError(t, err)
+	require.Len(t, items, 3) // Iron Sword in both locations plus Steel Sword
+	for _, uiItem := range items {
+		assert.Contains(t, uiItem.Name, "Sword")
+	}
+
+	// Text search combines with the other filters
+	items, err = iui.GetInventoryItems(&InventoryFilter{NameQuery: "iron", Location: locationShop})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "iron_sword", items[0].ItemID)
+
+	items, err = iui.GetInventoryItems(&InventoryFilter{NameQuery: "dragon"})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestInventoryUIManager_CategoryStats(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 2, 100)["success"].(bool))
+	require.True(t, gm.BuyItem("steel_sword", 1, 200)["success"].(bool))
+	require.True(t, gm.BuyItem("apple", 5, 10)["success"].(bool))
+	require.True(t, gm.BuyItem("health_potion", 3, 50)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("apple", 2))
+
+	stats, err := NewInventoryUIManager(gm).GetInventoryStats()
+	require.NoError(t, err)
+	require.Len(t, stats.Categories, 3)
+
+	weapons := stats.Categories[item.CategoryWeapon]
+	require.NotNil(t, weapons)
+	assert.Equal(t, 3, weapons.Count)
+	assert.Equal(t, 5, stats.Categories[item.CategoryFruit].Count)
+	assert.Equal(t, 2, stats.Categories[item.CategoryFruit].PerishableCount)
+
+	// Category rows add up to the overall totals
+	count, value, perishable := 0, 0.0, 0
+	for _, catStats := range stats.Categories {
+		count += catStats.Count
+		value += catStats.Value
+		perishable += catStats.PerishableCount
+	}
+	assert.Equal(t, stats.TotalItems, count)
+	assert.InDelta(t, stats.TotalValue, value, 0.001)
+	assert.Equal(t, stats.PerishableItems, perishable)
+}
+
+func TestInventoryUIManager_RealPurchasePrice(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.NoError(t, gm.inventory.AddToWarehouseByID("apple", 10, 8))
+	require.NoError(t, gm.inventory.AddToWarehouseByID("apple", 30, 12))
+
+	iui := NewInventoryUIManager(gm)
+	items, err := iui.GetInventoryItems(&InventoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 11.0, items[0].PurchasePrice)
+
+	// Items never bought fall back to the market estimate
+	assert.Equal(t, float64(gm.market.GetPrice("orange"))*0.8, iui.getPurchasePrice("orange"))
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// numberFormat holds how a language groups digits and writes decimals
+type numberFormat struct {
+	group        string // Separator between groups of three digits
+	decimal      string // Decimal separator
+	percentSpace bool   // Whether a space goes before the percent sign
+}
+
+// numberFormats maps each supported language to its number format
+var numberFormats = map[string]numberFormat{
+	"en": {group: ",", decimal: "."},
+	"ja": {group: ",", decimal: "."},
+	"zh": {group: ",", decimal: "."},
+	"ko": {group: ",", decimal: "."},
+	"de": {group: ".", decimal: ",", percentSpace: true},
+	"es": {group: ".", decimal: ",", percentSpace: true},
+	"fr": {group: " ", decimal: ",", percentSpace: true},
+}
+
+// currencySymbols maps currency settings to a short suffix; other currencies
+// are written out after the amount
+var currencySymbols = map[string]string{
+	"gold": "g",
+}
+
+// numberFormatter formats gold amounts and percentages for one locale, so
+// summary endpoints can return display strings alongside raw values
+type numberFormatter struct {
+	format   numberFormat
+	currency string
+}
+
+// newNumberFormatter creates a formatter for a language and currency,
+// falling back to English formatting for unknown languages
+func newNumberFormatter(language, currency string) numberFormatter {
+	format, exists := numberFormats[language]
+	if !exists {
+		format = numberFormats["en"]
+	}
+	if currency == "" {
+		currency = "gold"
+	}
+	return numberFormatter{format: format, currency: currency}
+}
+
+// Number formats a whole number with digit grouping, e.g. "1,234,567"
+func (f numberFormatter) Number(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(f.format.group)
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String()
+}
+
+// Gold formats a gold amount with the currency, e.g. "1,234g"
+func (f numberFormatter) Gold(amount int) string {
+	if symbol, exists := currencySymbols[f.currency]; exists {
+		return f.Number(amount) + symbol
+	}
+	return f.Number(amount) + " " + f.currency
+}
+
+// Percent formats a fraction as a percentage to one decimal place, e.g.
+// 0.125 as "12.5%"
+func (f numberFormatter) Percent(fraction float64) string {
+	tenths := int(math.Round(fraction * 1000))
+	sign := ""
+	if tenths < 0 {
+		sign, tenths = "-", -tenths
+	}
+
+	formatted := fmt.Sprintf("%s%s%s%d", sign, f.Number(tenths/10), f.format.decimal, tenths%10)
+	if f.format.percentSpace {
+		return formatted + " %"
+	}
+	return formatted + "%"
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberFormatter(t *testing.T) {
+	english := newNumberFormatter("en", "gold")
+	german := newNumberFormatter("de", "gold")
+
+	assert.Equal(t, "1,234,567g", english.Gold(1234567))
+	assert.Equal(t, "1.234.567g", german.Gold(1234567))
+	assert.Equal(t, "-1,000g", english.Gold(-1000))
+	assert.Equal(t, "999g", english.Gold(999))
+
+	assert.Equal(t, "12.5%", english.Percent(0.125))
+	assert.Equal(t, "12,5 %", german.Percent(0.125))
+	assert.Equal(t, "-3.0%", english.Percent(-0.03))
+
+	// Unknown languages fall back to English; other currencies are spelled out
+	assert.Equal(t, "1,500 crowns", newNumberFormatter("xx", "crowns").Gold(1500))
+}
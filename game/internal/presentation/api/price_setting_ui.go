@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/yourusername/merchant-tails/game/internal/domain/gamestate"
 	"github.com/yourusername/merchant-tails/game/internal/domain/item"
 	"github.com/yourusername/merchant-tails/game/internal/domain/market"
 )
@@ -20,6 +21,9 @@ const (
 	demandVeryLow  = "very_low"
 )
 
+// defaultMinMarkup is the default minimum markup over purchase price, in percent
+const defaultMinMarkup = 5.0
+
 // PriceSettingItem represents an item for price setting
 type PriceSettingItem struct {
 	ItemID           string        `json:"item_id"`
@@ -29,6 +33,7 @@ type PriceSettingItem struct {
 	PurchasePrice    float64       `json:"purchase_price"`
 	CurrentPrice     float64       `json:"current_price"`
 	MarketPrice      float64       `json:"market_price"`
+	FairValue        float64       `json:"fair_value"`
 	CompetitorPrice  float64       `json:"competitor_price"`
 	RecommendedPrice float64       `json:"recommended_price"`
 	MinPrice         float64       `json:"min_price"`
@@ -40,6 +45,23 @@ type PriceSettingItem struct {
 	Icon             string        `json:"icon"`
 }
 
+// PriceSettingQuery represents filtering, sorting, and paging options for price setting items
+type PriceSettingQuery struct {
+	Category  string `json:"category"`
+	SortBy    string `json:"sort_by"`    // "name", "margin", "quantity", "demand"
+	SortOrder string `json:"sort_order"` // "asc" or "desc"
+	Offset    int    `json:"offset"`
+	Limit     int    `json:"limit"` // 0 returns all remaining items
+}
+
+// PriceSettingPage represents one page of price setting items
+type PriceSettingPage struct {
+	Items  []*PriceSettingItem `json:"items"`
+	Total  int                 `json:"total"` // Matching items across all pages
+	Offset int                 `json:"offset"`
+	Limit  int                 `json:"limit"`
+}
+
 // PriceUpdateRequest represents a request to update item price
 type PriceUpdateRequest struct {
 	ItemID   string  `json:"item_id"`
@@ -107,6 +129,7 @@ type PriceSettingUIManager struct {
 	strategies       map[string]*PricingStrategy
 	rules            []*PriceRule
 	competitorPrices map[string]float64
+	minMarkup        float64 // Minimum markup over purchase price in percent; negative allows clearance
 	mu               sync.RWMutex
 }
 
@@ -129,9 +152,33 @@ func NewPriceSettingUIManager(gameManager *GameManager) *PriceSettingUIManager {
 		strategies:       createDefaultStrategies(),
 		rules:            createDefaultRules(),
 		competitorPrices: make(map[string]float64),
+		minMarkup:        defaultMinMarkup,
 	}
 }
 
+// Reset clears all player-set prices, history, and analytics and restores default strategies and rules
+func (psu *PriceSettingUIManager) Reset() {
+	psu.mu.Lock()
+	defer psu.mu.Unlock()
+
+	psu.itemPrices = make(map[string]float64)
+	psu.priceHistory = make(map[string][]PricePoint)
+	psu.analytics = make(map[string]*PriceAnalytics)
+	psu.strategies = createDefaultStrategies()
+	psu.rules = createDefaultRules()
+	psu.competitorPrices = make(map[string]float64)
+}
+
+// GetPriceHistory returns a copy of the recorded price changes for an item
+func (psu *PriceSettingUIManager) GetPriceHistory(itemID string) []PricePoint {
+	psu.mu.RLock()
+	defer psu.mu.RUnlock()
+
+	history := make([]PricePoint, len(psu.priceHistory[itemID]))
+	copy(history, psu.priceHistory[itemID])
+	return history
+}
+
 // createDefaultStrategies creates default pricing strategies
 func createDefaultStrategies() map[string]*PricingStrategy {
 	strategies := make(map[string]*PricingStrategy)
@@ -225,11 +272,20 @@ func createDefaultRules() []*PriceRule {
 	}
 }
 
-// GetPriceSettingItems returns all items available for price setting
-func (psu *PriceSettingUIManager) GetPriceSettingItems(filter string) ([]*PriceSettingItem, error) {
+// GetPriceSettingItems returns a sorted page of items available for price setting.
+// A nil query returns every item sorted by name.
+func (psu *PriceSettingUIManager) GetPriceSettingItems(query *PriceSettingQuery) (*PriceSettingPage, error) {
 	psu.mu.RLock()
 	defer psu.mu.RUnlock()
 
+	if query == nil {
+		query = &PriceSettingQuery{}
+	}
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, fmt.Errorf("offset and limit must not be negative")
+	}
+	filter := query.Category
+
 	items := make([]*PriceSettingItem, 0)
 
 	// Get shop inventory items
@@ -251,15 +307,16 @@ func (psu *PriceSettingUIManager) GetPriceSettingItems(filter string) ([]*PriceS
 		// Get various prices
 		currentPrice := psu.getCurrentPrice(itemID)
 		marketPrice := float64(psu.market.GetPrice(itemID))
+		fairValue := float64(psu.market.GetFairValue(itemID))
 		competitorPrice := psu.getCompetitorPrice(itemID)
 		purchasePrice := psu.getPurchasePrice(itemID)
 
-		// Calculate recommended price
-		recommendedPrice := psu.calculateRecommendedPrice(marketPrice, competitorPrice, purchasePrice)
+		// Recommend from fair value so random market swings don't skew it
+		recommendedPrice := psu.calculateRecommendedPrice(fairValue, competitorPrice, purchasePrice)
 
 		// Calculate price bounds
-		minPrice := purchasePrice * 1.05 // At least 5% markup
-		maxPrice := marketPrice * 2.0    // Max 2x market price
+		minPrice := psu.minPrice(purchasePrice)
+		maxPrice := marketPrice * 2.0 // Max 2x market price
 
 		// Calculate profit margin
 		profitMargin := 0.0
@@ -270,11 +327,8 @@ func (psu *PriceSettingUIManager) GetPriceSettingItems(filter string) ([]*PriceS
 		// Get demand level
 		demandLevel := psu.getDemandLevel()
 
-		// Calculate elasticity
-		elasticity := psu.calculateElasticity(itemID)
-
 		// Estimate sales at current price
-		expectedSales := psu.estimateSales(itemID, currentPrice, elasticity)
+		expectedSales := psu.market.SimulateDemand(itemID, currentPrice)
 
 		item := &PriceSettingItem{
 			ItemID:           itemID,
@@ -284,13 +338,14 @@ func (psu *PriceSettingUIManager) GetPriceSettingItems(filter string) ([]*PriceS
 			PurchasePrice:    purchasePrice,
 			CurrentPrice:     currentPrice,
 			MarketPrice:      marketPrice,
+			FairValue:        fairValue,
 			CompetitorPrice:  competitorPrice,
 			RecommendedPrice: recommendedPrice,
 			MinPrice:         minPrice,
 			MaxPrice:         maxPrice,
 			ProfitMargin:     profitMargin,
 			DemandLevel:      demandLevel,
-			Elasticity:       elasticity,
+			Elasticity:       psu.calculateElasticity(itemID),
 			ExpectedSales:    expectedSales,
 			Icon:             fmt.Sprintf("res://assets/items/%s.png", itemID),
 		}
@@ -298,16 +353,73 @@ func (psu *PriceSettingUIManager) GetPriceSettingItems(filter string) ([]*PriceS
 		items = append(items, item)
 	}
 
-	// Sort by name by default
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Name < items[j].Name
-	})
+	sortPriceSettingItems(items, query.SortBy, query.SortOrder)
+
+	total := len(items)
+	start := query.Offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if query.Limit > 0 && start+query.Limit < total {
+		end = start + query.Limit
+	}
 
-	return items, nil
+	return &PriceSettingPage{
+		Items:  items[start:end],
+		Total:  total,
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}, nil
+}
+
+// demandRank orders demand levels from lowest to highest for sorting
+var demandRank = map[string]int{
+	demandVeryLow:  0,
+	demandLow:      1,
+	demandNormal:   2,
+	demandHigh:     3,
+	demandVeryHigh: 4,
+}
+
+// sortPriceSettingItems sorts items by the given key, breaking ties by name
+func sortPriceSettingItems(items []*PriceSettingItem, sortBy, sortOrder string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if sortOrder == "desc" {
+			a, b = b, a
+		}
+
+		switch sortBy {
+		case "margin":
+			if a.ProfitMargin != b.ProfitMargin {
+				return a.ProfitMargin < b.ProfitMargin
+			}
+		case "quantity":
+			if a.Quantity != b.Quantity {
+				return a.Quantity < b.Quantity
+			}
+		case "demand":
+			if demandRank[a.DemandLevel] != demandRank[b.DemandLevel] {
+				return demandRank[a.DemandLevel] < demandRank[b.DemandLevel]
+			}
+		}
+		return a.Name < b.Name
+	})
 }
 
 // UpdatePrice updates the price of a single item
 func (psu *PriceSettingUIManager) UpdatePrice(request *PriceUpdateRequest) (*PriceUpdateResult, error) {
+	result, err := psu.updatePrice(request)
+	if err == nil && result.Success {
+		// After psu.mu is released, as the game manager takes it under gm.mu
+		psu.gameManager.completeOnboardingStep(gamestate.OnboardingSetPrice)
+	}
+	return result, err
+}
+
+// updatePrice validates and applies a price update
+func (psu *PriceSettingUIManager) updatePrice(request *PriceUpdateRequest) (*PriceUpdateResult, error) {
 	psu.mu.Lock()
 	defer psu.mu.Unlock()
 
@@ -330,10 +442,11 @@ func (psu *PriceSettingUIManager) UpdatePrice(request *PriceUpdateRequest) (*Pri
 
 	// Validate price bounds
 	purchasePrice := psu.getPurchasePrice(request.ItemID)
-	if finalPrice < purchasePrice {
+	if minPrice := psu.minPrice(purchasePrice); finalPrice < minPrice {
 		return &PriceUpdateResult{
 			Success: false,
-			Message: fmt.Sprintf("Price cannot be below purchase price (%.2f)", purchasePrice),
+			Message: fmt.Sprintf("Price %.2f is below the minimum of %.2f (%.1f%% markup over purchase price %.2f)",
+				finalPrice, minPrice, psu.minMarkup, purchasePrice),
 		}, nil
 	}
 
@@ -344,8 +457,7 @@ func (psu *PriceSettingUIManager) UpdatePrice(request *PriceUpdateRequest) (*Pri
 	psu.recordPriceChange(request.ItemID, finalPrice)
 
 	// Calculate expected outcomes
-	elasticity := psu.calculateElasticity(request.ItemID)
-	expectedSales := psu.estimateSales(request.ItemID, finalPrice, elasticity)
+	expectedSales := psu.market.SimulateDemand(request.ItemID, finalPrice)
 	expectedRevenue := finalPrice * float64(expectedSales)
 	expectedProfit := (finalPrice - purchasePrice) * float64(expectedSales)
 
@@ -523,8 +635,84 @@ func (psu *PriceSettingUIManager) ApplyRules() ([]*PriceUpdateResult, error) {
 	return results, nil
 }
 
+// GetCurrentPrice returns the player's set price for an item, defaulting to the market price
+func (psu *PriceSettingUIManager) GetCurrentPrice(itemID string) float64 {
+	psu.mu.RLock()
+	defer psu.mu.RUnlock()
+
+	return psu.getCurrentPrice(itemID)
+}
+
+// GetExpectedSales estimates daily sales of an item at the given price
+func (psu *PriceSettingUIManager) GetExpectedSales(itemID string, price float64) int {
+	psu.mu.RLock()
+	defer psu.mu.RUnlock()
+
+	return psu.market.SimulateDemand(itemID, price)
+}
+
+// PriceComparison sets an item's shop price against the market's and
+// competitors' prices
+type PriceComparison struct {
+	ItemID           string
+	CurrentPrice     float64
+	MarketPrice      float64
+	CompetitorPrice  float64
+	RecommendedPrice float64
+}
+
+// ComparePrices returns an item's shop price alongside the market and
+// competitor prices and the price the pricing screen recommends
+func (psu *PriceSettingUIManager) ComparePrices(itemID string) PriceComparison {
+	psu.mu.RLock()
+	defer psu.mu.RUnlock()
+
+	competitorPrice := psu.getCompetitorPrice(itemID)
+	return PriceComparison{
+		ItemID:          itemID,
+		CurrentPrice:    psu.getCurrentPrice(itemID),
+		MarketPrice:     float64(psu.market.GetPrice(itemID)),
+		CompetitorPrice: competitorPrice,
+		RecommendedPrice: psu.calculateRecommendedPrice(float64(psu.market.GetFairValue(itemID)),
+			competitorPrice, psu.getPurchasePrice(itemID)),
+	}
+}
+
+// SetCompetitorPrice records what competitors charge for an item, replacing
+// the simulated price
+func (psu *PriceSettingUIManager) SetCompetitorPrice(itemID string, price float64) {
+	psu.mu.Lock()
+	defer psu.mu.Unlock()
+	psu.competitorPrices[itemID] = price
+}
+
+// SetMinMarkup sets the minimum markup over purchase price, in percent.
+// Zero allows selling at cost and negative values allow clearance below cost.
+func (psu *PriceSettingUIManager) SetMinMarkup(percent float64) error {
+	if percent <= -100 {
+		return fmt.Errorf("minimum markup must be greater than -100%%, got %.1f%%", percent)
+	}
+
+	psu.mu.Lock()
+	defer psu.mu.Unlock()
+	psu.minMarkup = percent
+	return nil
+}
+
+// GetMinMarkup returns the minimum markup over purchase price, in percent
+func (psu *PriceSettingUIManager) GetMinMarkup() float64 {
+	psu.mu.RLock()
+	defer psu.mu.RUnlock()
+	return psu.minMarkup
+}
+
 // Helper functions
 
+// minPrice returns the lowest allowed price for a purchase price
+func (psu *PriceSettingUIManager) minPrice(purchasePrice float64) float64 {
+	return purchasePrice * (1 + psu.minMarkup/100)
+}
+
 func (psu *PriceSettingUIManager) getCurrentPrice(itemID string) float64 {
 	if price, exists := psu.itemPrices[itemID]; exists {
 		return price
@@ -543,7 +731,13 @@ func (psu *PriceSettingUIManager) getCompetitorPrice(itemID string) float64 {
 }
 
 func (psu *PriceSettingUIManager) getPurchasePrice(itemID string) float64 {
-	// Simplified - in production would track actual purchase prices
+	// Use what the player actually paid when the item is in stock
+	if psu.gameManager.inventory != nil {
+		if cost, ok := psu.gameManager.inventory.GetAveragePurchasePrice(itemID); ok {
+			return float64(cost)
+		}
+	}
+	// Otherwise estimate from the market price
 	return float64(psu.market.GetPrice(itemID)) * 0.7
 }
 
@@ -603,51 +797,7 @@ func (psu *PriceSettingUIManager) getDemandLevel() string {
 }
 
 func (psu *PriceSettingUIManager) calculateElasticity(itemID string) float64 {
-	// Simplified elasticity calculation
-	// Luxury items are more elastic, necessities less elastic
-	category := psu.getItemCategory(itemID)
-
-	switch category {
-	case item.CategoryFruit:
-		return 0.5 // Inelastic (necessity)
-	case item.CategoryPotion:
-		return 0.7 // Somewhat inelastic
-	case item.CategoryWeapon:
-		return 1.0 // Unit elastic
-	case item.CategoryAccessory:
-		return 1.5 // Elastic
-	case item.CategoryGem:
-		return 2.0 // Very elastic (luxury)
-	case item.CategoryMagicBook:
-		return 1.8 // Elastic
-	default:
-		return 1.0
-	}
-}
-
-func (psu *PriceSettingUIManager) estimateSales(itemID string, price, elasticity float64) int {
-	// Estimate sales based on price and elasticity
-	baselineSales := 10
-	marketPrice := float64(psu.market.GetPrice(itemID))
-
-	if marketPrice == 0 {
-		return baselineSales
-	}
-
-	// Calculate price change percentage
-	priceChange := (price - marketPrice) / marketPrice
-
-	// Apply elasticity to estimate quantity change
-	quantityChange := -priceChange * elasticity
-
-	// Calculate expected sales
-	expectedSales := float64(baselineSales) * (1 + quantityChange)
-
-	if expectedSales < 0 {
-		expectedSales = 0
-	}
-
-	return int(expectedSales)
+	return psu.market.GetCategoryElasticity(psu.getItemCategory(itemID))
 }
 
 func (psu *PriceSettingUIManager) recordPriceChange(itemID string, newPrice float64) {
@@ -835,37 +985,19 @@ func (psu *PriceSettingUIManager) applyRule(rule *PriceRule, itemID string) floa
 
 func (psu *PriceSettingUIManager) isExpiringSoon(itemID string) bool {
 	// Check if item is perishable and expiring within 2 days
-	return itemID == "apple" || itemID == "orange" || itemID == "banana"
+	return isRegistryPerishable(itemID) && psu.gameManager.inventory.GetSpoilingSoon(2)[itemID] > 0
 }
 
 func (psu *PriceSettingUIManager) getItemName(itemID string) string {
-	names := map[string]string{
-		"apple":               "Apple",
-		"sword_iron":          "Iron Sword",
-		"potion_health":       "Health Potion",
-		"gem_diamond":         "Diamond",
-		"spellbook_rare":      "Rare Spellbook",
-		"accessory_gold_ring": "Gold Ring",
-	}
-
-	if name, exists := names[itemID]; exists {
-		return name
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists {
+		return master.Name
 	}
 	return itemID
 }
 
 func (psu *PriceSettingUIManager) getItemCategory(itemID string) item.Category {
-	categories := map[string]item.Category{
-		"apple":               item.CategoryFruit,
-		"sword_iron":          item.CategoryWeapon,
-		"potion_health":       item.CategoryPotion,
-		"gem_diamond":         item.CategoryGem,
-		"spellbook_rare":      item.CategoryMagicBook,
-		"accessory_gold_ring": item.CategoryAccessory,
-	}
-
-	if cat, exists := categories[itemID]; exists {
-		return cat
+	if master, exists := item.GetItemRegistry().GetItem(itemID); exists {
+		return master.Category
 	}
 	return item.CategoryFruit
 }
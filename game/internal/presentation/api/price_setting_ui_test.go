@@ -0,0 +1,103 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceSettingUIManager_MinMarkupFloor(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 1, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+
+	require.NoError(t, gm.pricing.SetMinMarkup(20))
+
+	// 110 is above cost but below the 20% floor of 120
+	result, err := gm.pricing.UpdatePrice(&PriceUpdateRequest{ItemID: "iron_sword", NewPrice: 110, Strategy: "manual"})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Message, "120.00")
+
+	result, err = gm.pricing.UpdatePrice(&PriceUpdateRequest{ItemID: "iron_sword", NewPrice: 120, Strategy: "manual"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	page, err := gm.pricing.GetPriceSettingItems(nil)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 120.0, page.Items[0].MinPrice)
+}
+
+func TestPriceSettingUIManager_NegativeMarkupAllowsClearance(t *testing.T) {
+	gm := newTestGameManager(t)
+	require.True(t, gm.BuyItem("iron_sword", 1, 100)["success"].(bool))
+	require.NoError(t, gm.inventory.TransferToShop("iron_sword", 1))
+
+	require.NoError(t, gm.pricing.SetMinMarkup(-50))
+
+	result, err := gm.pricing.UpdatePrice(&PriceUpdateRequest{ItemID: "iron_sword", NewPrice: 60, Strategy: "manual"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	assert.Error(t, gm.pricing.SetMinMarkup(-100))
+	assert.Equal(t, -50.0, gm.pricing.GetMinMarkup())
+}
+
+// stockShop buys items and moves them into the shop
+func stockShop(t *testing.T, gm *GameManager, quantities map[string]int) {
+	t.Helper()
+	for itemID, quantity := range quantities {
+		require.True(t, gm.BuyItem(itemID, quantity, 10)["success"].(bool))
+		require.NoError(t, gm.inventory.TransferToShop(itemID, quantity))
+	}
+}
+
+func TestPriceSettingUIManager_Pagination(t *testing.T) {
+	gm := newTestGameManager(t)
+	stockShop(t, gm, map[string]int{"apple": 3, "health_potion": 1, "iron_sword": 2})
+
+	page, err := gm.pricing.GetPriceSettingItems(&PriceSettingQuery{Offset: 0, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "Fresh Apple", page.Items[0].Name)
+
+	page, err = gm.pricing.GetPriceSettingItems(&PriceSettingQuery{Offset: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	assert.Len(t, page.Items, 1)
+
+	page, err = gm.pricing.GetPriceSettingItems(&PriceSettingQuery{Offset: 5, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	assert.Empty(t, page.Items)
+
+	_, err = gm.pricing.GetPriceSettingItems(&PriceSettingQuery{Offset: -1})
+	assert.Error(t, err)
+}
+
+func TestPriceSettingUIManager_Sorting(t *testing.T) {
+	gm := newTestGameManager(t)
+	stockShop(t, gm, map[string]int{"apple": 3, "health_potion": 1, "iron_sword": 2})
+
+	page, err := gm.pricing.GetPriceSettingItems(&PriceSettingQuery{SortBy: "quantity"})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{page.Items[0].Quantity, page.Items[1].Quantity, page.Items[2].Quantity})
+
+	page, err = gm.pricing.GetPriceSettingItems(&PriceSettingQuery{SortBy: "quantity", SortOrder: "desc"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2, 1}, []int{page.Items[0].Quantity, page.Items[1].Quantity, page.Items[2].Quantity})
+
+	page, err = gm.pricing.GetPriceSettingItems(&PriceSettingQuery{SortBy: "margin", SortOrder: "desc"})
+	require.NoError(t, err)
+	for i := 1; i < len(page.Items); i++ {
+		assert.GreaterOrEqual(t, page.Items[i-1].ProfitMargin, page.Items[i].ProfitMargin)
+	}
+
+	page, err = gm.pricing.GetPriceSettingItems(&PriceSettingQuery{Category: "FRUIT"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+}
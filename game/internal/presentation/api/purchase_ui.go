@@ -3,11 +3,14 @@ package api
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+	"github.com/yourusername/merchant-tails/game/internal/domain/ledger"
 	"github.com/yourusername/merchant-tails/game/internal/domain/market"
+	"github.com/yourusername/merchant-tails/game/internal/domain/tax"
 )
 
 // Risk level constants
@@ -48,14 +51,66 @@ type PurchaseResult struct {
 	Success        bool     `json:"success"`
 	ItemID         string   `json:"item_id"`
 	Quantity       int      `json:"quantity"`
-	UnitPrice      float64  `json:"unit_price"`
-	TotalCost      float64  `json:"total_cost"`
+	BaseUnitPrice  float64  `json:"base_unit_price"` // Supplier's price per unit before the bulk discount
+	BulkDiscount   float64  `json:"bulk_discount"`   // Fraction taken off for the order's size
+	UnitPrice      float64  `json:"unit_price"`      // Per unit before tariff
+	Subtotal       float64  `json:"subtotal"`        // Order cost before tariff
+	Tax            float64  `json:"tax"`             // Purchase tariff charged
+	TaxRate        float64  `json:"tax_rate"`
+	TotalCost      float64  `json:"total_cost"` // Gold paid, tariff included
 	GoldRemaining  float64  `json:"gold_remaining"`
 	InventorySpace int      `json:"inventory_space"`
 	Message        string   `json:"message"`
 	Warnings       []string `json:"warnings"`
 }
 
+// SupplierTier groups suppliers by what they sell; each tier offers its own
+// bulk discounts
+type SupplierTier string
+
+// Supplier tiers
+const (
+	SupplierTierCommon  SupplierTier = "common"  // Produce and potions, sold by the crate
+	SupplierTierCrafted SupplierTier = "crafted" // Weapons and accessories
+	SupplierTierRare    SupplierTier = "rare"    // Gems and magic books
+)
+
+// QuantityBreak is a bulk discount for orders of at least MinQuantity units
+type QuantityBreak struct {
+	MinQuantity int     `json:"min_quantity"`
+	Discount    float64 `json:"discount"` // Fraction off the unit price, 0.1 for 10%
+}
+
+// SupplierTierForCategory returns the tier of supplier selling a category
+func SupplierTierForCategory(category item.Category) SupplierTier {
+	switch category {
+	case item.CategoryWeapon, item.CategoryAccessory:
+		return SupplierTierCrafted
+	case item.CategoryGem, item.CategoryMagicBook:
+		return SupplierTierRare
+	default:
+		return SupplierTierCommon
+	}
+}
+
+// defaultQuantityBreaks returns each supplier tier's standard bulk discounts
+func defaultQuantityBreaks() map[SupplierTier][]QuantityBreak {
+	return map[SupplierTier][]QuantityBreak{
+		SupplierTierCommon: {
+			{MinQuantity: 20, Discount: 0.05},
+			{MinQuantity: 50, Discount: 0.10},
+			{MinQuantity: 100, Discount: 0.15},
+		},
+		SupplierTierCrafted: {
+			{MinQuantity: 5, Discount: 0.05},
+			{MinQuantity: 10, Discount: 0.10},
+		},
+		SupplierTierRare: {
+			{MinQuantity: 3, Discount: 0.05},
+		},
+	}
+}
+
 // BulkPurchaseRequest represents a bulk purchase of multiple items
 type BulkPurchaseRequest struct {
 	Purchases         []PurchaseRequest `json:"purchases"`
@@ -63,6 +118,30 @@ type BulkPurchaseRequest struct {
 	OptimizeForProfit bool              `json:"optimize_for_profit"`
 }
 
+// BulkStopBudget is the stop reason when a bulk purchase's budget ran out
+const BulkStopBudget = "budget"
+
+// BulkPurchaseSkip records an item in a bulk purchase that was not bought
+type BulkPurchaseSkip struct {
+	ItemID string `json:"item_id"`
+	Reason string `json:"reason"`
+}
+
+// BulkPurchaseSummary classifies what happened to each item in a bulk purchase
+type BulkPurchaseSummary struct {
+	TotalSpent      float64            `json:"total_spent"`
+	FullyBought     []string           `json:"fully_bought"`
+	PartiallyBought []string           `json:"partially_bought"` // Quantity cut to what the budget allowed
+	Skipped         []BulkPurchaseSkip `json:"skipped"`
+	StopReason      string             `json:"stop_reason"` // BulkStopBudget when the budget ran out, otherwise empty
+}
+
+// BulkPurchaseResult holds the per-item results of a bulk purchase and its summary
+type BulkPurchaseResult struct {
+	Results []*PurchaseResult   `json:"results"`
+	Summary BulkPurchaseSummary `json:"summary"`
+}
+
 // QuickBuyPreset represents a preset purchase configuration
 type QuickBuyPreset struct {
 	ID          string            `json:"id"`
@@ -79,6 +158,7 @@ type PurchaseUIManager struct {
 	market       *market.Market
 	priceHistory map[string][]float64
 	presets      map[string]*QuickBuyPreset
+	bulkBreaks   map[SupplierTier][]QuantityBreak
 	mu           sync.RWMutex
 }
 
@@ -89,7 +169,49 @@ func NewPurchaseUIManager(gameManager *GameManager) *PurchaseUIManager {
 		market:       gameManager.market,
 		priceHistory: make(map[string][]float64),
 		presets:      createDefaultPresets(),
+		bulkBreaks:   defaultQuantityBreaks(),
+	}
+}
+
+// SetQuantityBreaks replaces a supplier tier's bulk discounts. An empty list
+// turns bulk discounts off for the tier.
+func (pui *PurchaseUIManager) SetQuantityBreaks(tier SupplierTier, breaks []QuantityBreak) error {
+	sorted := append([]QuantityBreak(nil), breaks...)
+	for _, quantityBreak := range sorted {
+		if quantityBreak.MinQuantity < 2 {
+			return fmt.Errorf("quantity break must start at 2 or more units, got %d", quantityBreak.MinQuantity)
+		}
+		if quantityBreak.Discount <= 0 || quantityBreak.Discount >= 1 {
+			return fmt.Errorf("bulk discount must be between 0 and 1, got %.2f", quantityBreak.Discount)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinQuantity < sorted[j].MinQuantity })
+
+	pui.mu.Lock()
+	defer pui.mu.Unlock()
+	pui.bulkBreaks[tier] = sorted
+	return nil
+}
+
+// GetQuantityBreaks returns a supplier tier's bulk discounts, smallest order first
+func (pui *PurchaseUIManager) GetQuantityBreaks(tier SupplierTier) []QuantityBreak {
+	pui.mu.RLock()
+	defer pui.mu.RUnlock()
+	return append([]QuantityBreak(nil), pui.bulkBreaks[tier]...)
+}
+
+// bulkDiscountUnsafe returns the discount for buying quantity units of an
+// item: the largest break the order reaches with the supplier of its base
+// item. Caller must hold pui.mu.
+func (pui *PurchaseUIManager) bulkDiscountUnsafe(itemID string, quantity int) float64 {
+	baseID, _ := item.ParseVariantID(itemID)
+	discount := 0.0
+	for _, quantityBreak := range pui.bulkBreaks[SupplierTierForCategory(getRegistryCategory(baseID))] {
+		if quantity >= quantityBreak.MinQuantity {
+			discount = math.Max(discount, quantityBreak.Discount)
+		}
 	}
+	return discount
 }
 
 // createDefaultPresets creates default quick buy presets
@@ -155,8 +277,9 @@ func (pui *PurchaseUIManager) GetPurchaseOptions(category string, sortBy string)
 			continue
 		}
 
-		// Calculate market data
-		currentPrice := float64(pui.market.GetPrice(marketItem.ID))
+		// Calculate market data; higher quality tiers quote higher prices
+		itemID := item.VariantID(marketItem.ID, marketItem.Quality)
+		currentPrice := float64(pui.market.GetPrice(itemID))
 		priceHistory := pui.getPriceHistory(marketItem.ID)
 		trend := calculateTrend(priceHistory)
 		priceChange := calculatePriceChange(priceHistory)
@@ -171,7 +294,7 @@ func (pui *PurchaseUIManager) GetPurchaseOptions(category string, sortBy string)
 		recommendedQty := calculateRecommendedQuantity(currentPrice, playerGold, riskLevel)
 
 		option := &PurchaseOption{
-			ItemID:          marketItem.ID,
+			ItemID:          itemID,
 			Name:            marketItem.Name,
 			Category:        marketItem.Category,
 			CurrentPrice:    currentPrice,
@@ -196,10 +319,13 @@ func (pui *PurchaseUIManager) GetPurchaseOptions(category string, sortBy string)
 	return options, nil
 }
 
-// ExecutePurchase executes a single purchase
+// ExecutePurchase executes a single purchase. It changes the game's gold,
+// ledger and stock, so it holds the game manager's lock throughout.
 func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*PurchaseResult, error) {
 	pui.mu.Lock()
 	defer pui.mu.Unlock()
+	pui.gameManager.mu.Lock()
+	defer pui.gameManager.mu.Unlock()
 
 	// Validate request
 	if request.Quantity <= 0 {
@@ -209,14 +335,8 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 		}, nil
 	}
 
-	// Get current price
-	currentPrice := float64(pui.market.GetPrice(request.ItemID))
-
-	// Apply negotiation if requested
-	finalPrice := currentPrice
-	if request.NegotiatePrice {
-		finalPrice = pui.negotiatePrice(currentPrice, request.MaxPrice)
-	}
+	quote := pui.quoteUnsafe(request)
+	finalPrice := quote.unitPrice
 
 	// Check if price is acceptable
 	if request.MaxPrice > 0 && finalPrice > request.MaxPrice {
@@ -226,7 +346,7 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 		}, nil
 	}
 
-	totalCost := finalPrice * float64(request.Quantity)
+	totalCost := float64(quote.breakdown.Net)
 
 	// Check if player has enough gold
 	playerGold := float64(pui.gameManager.gameState.GetGold())
@@ -237,16 +357,8 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 		}, nil
 	}
 
-	// Check inventory space
-	inventory := pui.gameManager.inventory
-	// Use shop capacity for available space
-	currentShopItems := 0
-	if inventory.ShopInventory != nil {
-		for _, q := range inventory.ShopInventory.GetAll() {
-			currentShopItems += q
-		}
-	}
-	availableSpace := inventory.ShopCapacity - currentShopItems
+	// Deliveries go to the warehouse, so check its space
+	availableSpace := pui.gameManager.inventory.GetWarehouseRoom(request.ItemID)
 	if request.Quantity > availableSpace {
 		return &PurchaseResult{
 			Success: false,
@@ -254,8 +366,7 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 		}, nil
 	}
 
-	// Execute the purchase directly
-	pui.gameManager.gameState.SetGold(int(playerGold - totalCost))
+	// Add to inventory before charging so a rejected delivery costs nothing
 	err := pui.gameManager.inventory.AddToWarehouseByID(request.ItemID, request.Quantity, int(finalPrice))
 	if err != nil {
 		return &PurchaseResult{
@@ -263,6 +374,15 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 			Message: err.Error(),
 		}, nil
 	}
+	pui.gameManager.taxes.Apply(getRegistryCategory(request.ItemID), tax.TransactionPurchase, quote.subtotal)
+	pui.gameManager.gameState.SetGoldWithReason(int(playerGold)-quote.breakdown.Net, "purchase:"+request.ItemID)
+	pui.gameManager.recordTransaction(ledger.Entry{
+		Type:     ledger.EntryPurchase,
+		ItemID:   request.ItemID,
+		Quantity: request.Quantity,
+		Amount:   -quote.breakdown.Net,
+		Tax:      quote.breakdown.Tax,
+	})
 
 	// Update price history
 	pui.updatePriceHistory(request.ItemID, finalPrice)
@@ -274,7 +394,12 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 		Success:        true,
 		ItemID:         request.ItemID,
 		Quantity:       request.Quantity,
+		BaseUnitPrice:  quote.baseUnitPrice,
+		BulkDiscount:   quote.discount,
 		UnitPrice:      finalPrice,
+		Subtotal:       float64(quote.subtotal),
+		Tax:            float64(quote.breakdown.Tax),
+		TaxRate:        quote.breakdown.Rate,
 		TotalCost:      totalCost,
 		GoldRemaining:  float64(pui.gameManager.gameState.GetGold()),
 		InventorySpace: availableSpace - request.Quantity,
@@ -283,9 +408,73 @@ func (pui *PurchaseUIManager) ExecutePurchase(request *PurchaseRequest) (*Purcha
 	}, nil
 }
 
-// ExecuteBulkPurchase executes multiple purchases
-func (pui *PurchaseUIManager) ExecuteBulkPurchase(request *BulkPurchaseRequest) ([]*PurchaseResult, error) {
-	results := make([]*PurchaseResult, 0, len(request.Purchases))
+// purchaseQuote is what an order costs from the supplier
+type purchaseQuote struct {
+	baseUnitPrice float64 // Supplier's price per unit before the bulk discount
+	discount      float64
+	unitPrice     float64
+	subtotal      int // Before tariff
+	breakdown     tax.TaxBreakdown
+}
+
+// quoteUnsafe prices an order as BuyItem does: the market price, negotiated
+// if asked, plus the supplier's half of the trade spread. The bulk discount
+// comes off that, but never takes the unit price below what customers pay
+// for the item, so an order cannot be resold at once for a profit. The
+// tariff is added last. Caller must hold pui.mu and the game manager's lock.
+func (pui *PurchaseUIManager) quoteUnsafe(request *PurchaseRequest) purchaseQuote {
+	spread := pui.gameManager.tradeSpread
+	marketPrice := float64(pui.market.GetPrice(request.ItemID))
+	price := marketPrice
+	if request.NegotiatePrice {
+		price = pui.negotiatePrice(price, request.MaxPrice)
+	}
+	supplierPrice := price * (1 + spread/2)
+
+	// Larger orders earn the supplier's bulk discount
+	discount := pui.bulkDiscountUnsafe(request.ItemID, request.Quantity)
+	unitPrice := math.Max(supplierPrice*(1-discount), marketPrice*(1-spread/2))
+	subtotal := int(unitPrice * float64(request.Quantity))
+	return purchaseQuote{
+		baseUnitPrice: supplierPrice,
+		discount:      discount,
+		unitPrice:     unitPrice,
+		subtotal:      subtotal,
+		breakdown:     pui.gameManager.taxes.Calculate(getRegistryCategory(request.ItemID), tax.TransactionPurchase, subtotal),
+	}
+}
+
+// affordableQuantity returns the most of an order, up to its quantity,
+// whose full cost fits the budget
+func (pui *PurchaseUIManager) affordableQuantity(request PurchaseRequest, budget float64) int {
+	pui.mu.RLock()
+	defer pui.mu.RUnlock()
+	pui.gameManager.mu.RLock()
+	defer pui.gameManager.mu.RUnlock()
+
+	// Bulk discounts make cost uneven in quantity, so count down
+	for ; request.Quantity > 0; request.Quantity-- {
+		if float64(pui.quoteUnsafe(&request).breakdown.Net) <= budget {
+			break
+		}
+	}
+	return request.Quantity
+}
+
+// ExecuteBulkPurchase executes multiple purchases. With a total budget, an
+// item the remaining budget cannot fully cover is bought in the largest
+// quantity it can, and items it cannot cover at all are skipped; the summary
+// says which items were fully bought, partially bought or skipped, and why.
+func (pui *PurchaseUIManager) ExecuteBulkPurchase(request *BulkPurchaseRequest) (*BulkPurchaseResult, error) {
+	bulk := &BulkPurchaseResult{
+		Results: make([]*PurchaseResult, 0, len(request.Purchases)),
+		Summary: BulkPurchaseSummary{
+			FullyBought:     make([]string, 0),
+			PartiallyBought: make([]string, 0),
+			Skipped:         make([]BulkPurchaseSkip, 0),
+		},
+	}
+	summary := &bulk.Summary
 
 	// Optimize purchase order if requested
 	purchases := request.Purchases
@@ -293,34 +482,46 @@ func (pui *PurchaseUIManager) ExecuteBulkPurchase(request *BulkPurchaseRequest)
 		purchases = pui.optimizePurchaseOrder(purchases)
 	}
 
-	totalSpent := 0.0
 	for _, purchase := range purchases {
-		// Check budget constraint
-		if request.TotalBudget > 0 && totalSpent >= request.TotalBudget {
-			break
-		}
-
-		// Adjust max price based on remaining budget
-		if request.TotalBudget > 0 {
-			remainingBudget := request.TotalBudget - totalSpent
-			maxPossiblePrice := remainingBudget / float64(purchase.Quantity)
-			if purchase.MaxPrice == 0 || purchase.MaxPrice > maxPossiblePrice {
-				purchase.MaxPrice = maxPossiblePrice
+		requested := purchase.Quantity
+
+		// Cut the quantity to what the remaining budget covers
+		if request.TotalBudget > 0 && requested > 0 {
+			remainingBudget := request.TotalBudget - summary.TotalSpent
+			purchase.Quantity = pui.affordableQuantity(purchase, remainingBudget)
+			if purchase.Quantity <= 0 {
+				reason := fmt.Sprintf("Budget exhausted: %.2f remaining", remainingBudget)
+				bulk.Results = append(bulk.Results, &PurchaseResult{
+					Success: false,
+					ItemID:  purchase.ItemID,
+					Message: reason,
+				})
+				summary.Skipped = append(summary.Skipped, BulkPurchaseSkip{ItemID: purchase.ItemID, Reason: reason})
+				summary.StopReason = BulkStopBudget
+				continue
 			}
 		}
 
 		result, err := pui.ExecutePurchase(&purchase)
 		if err != nil {
-			return results, err
+			return bulk, err
 		}
-
-		results = append(results, result)
-		if result.Success {
-			totalSpent += result.TotalCost
+		bulk.Results = append(bulk.Results, result)
+
+		switch {
+		case !result.Success:
+			summary.Skipped = append(summary.Skipped, BulkPurchaseSkip{ItemID: purchase.ItemID, Reason: result.Message})
+		case result.Quantity < requested:
+			summary.TotalSpent += result.TotalCost
+			summary.PartiallyBought = append(summary.PartiallyBought, purchase.ItemID)
+			summary.StopReason = BulkStopBudget
+		default:
+			summary.TotalSpent += result.TotalCost
+			summary.FullyBought = append(summary.FullyBought, purchase.ItemID)
 		}
 	}
 
-	return results, nil
+	return bulk, nil
 }
 
 // GetQuickBuyPresets returns available quick buy presets
@@ -344,7 +545,7 @@ func (pui *PurchaseUIManager) GetQuickBuyPresets() []*QuickBuyPreset {
 }
 
 // ExecuteQuickBuy executes a quick buy preset
-func (pui *PurchaseUIManager) ExecuteQuickBuy(presetID string) ([]*PurchaseResult, error) {
+func (pui *PurchaseUIManager) ExecuteQuickBuy(presetID string) (*BulkPurchaseResult, error) {
 	pui.mu.RLock()
 	preset, exists := pui.presets[presetID]
 	pui.mu.RUnlock()
@@ -430,8 +631,8 @@ func (pui *PurchaseUIManager) generatePurchaseWarnings(itemID string, price floa
 		warnings = append(warnings, "High quantity of volatile item - increased risk")
 	}
 
-	// Check if item is perishable (fruits)
-	if itemID == "apple" || itemID == "orange" || itemID == "banana" {
+	// Check if item is perishable
+	if isRegistryPerishable(itemID) {
 		warnings = append(warnings, "Perishable item - sell quickly to avoid losses")
 	}
 
@@ -619,29 +820,11 @@ func (pui *PurchaseUIManager) getAvailableMarketItems() []*MarketItem {
 
 // getSupplyLevel returns the supply level for an item
 func (pui *PurchaseUIManager) getSupplyLevel() string {
-	// Simplified supply level calculation
-	// In production, this would be calculated from actual market data
 	state := pui.market.State
 	if state == nil {
 		return "normal"
 	}
-
-	// Use market state to determine supply level
-	supplyLevel := state.CurrentSupply
-
-	// Convert to supply level string
-	switch supplyLevel {
-	case market.SupplyVeryLow:
-		return "scarce"
-	case market.SupplyLow:
-		return "low"
-	case market.SupplyHigh:
-		return "high"
-	case market.SupplyVeryHigh:
-		return "abundant"
-	default:
-		return "normal"
-	}
+	return supplyLevelName(state.CurrentSupply)
 }
 
 // MarketItem represents an item available in the market
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/merchant-tails/game/internal/domain/item"
+)
+
+func TestPurchaseUIManager_BulkPurchaseBudgetSummary(t *testing.T) {
+	gm := newTestGameManager(t)
+	pui := NewPurchaseUIManager(gm)
+
+	// Orders cost what BuyItem charges: spread and tariff included
+	gm.mu.Lock()
+	_, apples := gm.purchaseCostUnsafe("apple", 10, float64(gm.market.GetPrice("apple")))
+	_, sword := gm.purchaseCostUnsafe("iron_sword", 1, float64(gm.market.GetPrice("iron_sword")))
+	_, steel := gm.purchaseCostUnsafe("steel_sword", 1, float64(gm.market.GetPrice("steel_sword")))
+	gm.mu.Unlock()
+	require.Greater(t, steel.Net, sword.Net/2)
+
+	// Enough for every apple and one and a half swords
+	budget := float64(apples.Net) + float64(sword.Net)*1.5
+	bulk, err := pui.ExecuteBulkPurchase(&BulkPurchaseRequest{
+		Purchases: []PurchaseRequest{
+			{ItemID: "apple", Quantity: 10},
+			{ItemID: "iron_sword", Quantity: 3},
+			{ItemID: "steel_sword", Quantity: 1},
+		},
+		TotalBudget: budget,
+	})
+	require.NoError(t, err)
+	require.Len(t, bulk.Results, 3)
+
+	summary := bulk.Summary
+	assert.Equal(t, []string{"apple"}, summary.FullyBought)
+	assert.Equal(t, []string{"iron_sword"}, summary.PartiallyBought)
+	require.Len(t, summary.Skipped, 1)
+	assert.Equal(t, "steel_sword", summary.Skipped[0].ItemID)
+	assert.Contains(t, summary.Skipped[0].Reason, "Budget exhausted")
+	assert.Equal(t, BulkStopBudget, summary.StopReason)
+
+	assert.Equal(t, 1, bulk.Results[1].Quantity)
+	assert.Equal(t, float64(apples.Net+sword.Net), summary.TotalSpent)
+	assert.Equal(t, gm.ledger.ExpectedBalance(), gm.gameState.GetGold())
+	assert.LessOrEqual(t, summary.TotalSpent, budget)
+}
+
+func TestPurchaseUIManager_BulkPurchaseWithinBudget(t *testing.T) {
+	gm := newTestGameManager(t)
+	pui := NewPurchaseUIManager(gm)
+
+	bulk, err := pui.ExecuteBulkPurchase(&BulkPurchaseRequest{
+		Purchases: []PurchaseRequest{
+			{ItemID: "apple", Quantity: 2},
+			{ItemID: "apple", Quantity: 0},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"apple"}, bulk.Summary.FullyBought)
+	assert.Empty(t, bulk.Summary.PartiallyBought)
+	require.Len(t, bulk.Summary.Skipped, 1)
+	assert.Equal(t, "Invalid quantity", bulk.Summary.Skipped[0].Reason)
+	assert.Empty(t, bulk.Summary.StopReason)
+}
+
+func TestPurchaseUIManager_BulkDiscount(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetGold(5000)
+	require.NoError(t, gm.SetTradeSpread(0.3)) // Wide enough that no discount reaches the customer price
+	pui := NewPurchaseUIManager(gm)
+
+	small, err := pui.ExecutePurchase(&PurchaseRequest{ItemID: "apple", Quantity: 5})
+	require.NoError(t, err)
+	require.True(t, small.Success, small.Message)
+	assert.Zero(t, small.BulkDiscount)
+	assert.Equal(t, small.BaseUnitPrice, small.UnitPrice)
+
+	// Fifty apples reach the common supplier's 10% break
+	large, err := pui.ExecutePurchase(&PurchaseRequest{ItemID: "apple", Quantity: 50})
+	require.NoError(t, err)
+	require.True(t, large.Success, large.Message)
+	assert.Equal(t, 0.10, large.BulkDiscount)
+	assert.Equal(t, small.BaseUnitPrice, large.BaseUnitPrice)
+	assert.Less(t, large.UnitPrice, small.UnitPrice)
+	assert.InDelta(t, large.BaseUnitPrice*0.9, large.UnitPrice, 1e-9)
+	assert.Equal(t, large.Subtotal+large.Tax, large.TotalCost)
+
+	// Breaks are configurable per tier
+	assert.Equal(t, SupplierTierCrafted, SupplierTierForCategory(item.CategoryWeapon))
+	require.NoError(t, pui.SetQuantityBreaks(SupplierTierCommon, []QuantityBreak{
+		{MinQuantity: 10, Discount: 0.25},
+		{MinQuantity: 3, Discount: 0.2},
+	}))
+	assert.Equal(t, 3, pui.GetQuantityBreaks(SupplierTierCommon)[0].MinQuantity)
+	configured, err := pui.ExecutePurchase(&PurchaseRequest{ItemID: "apple", Quantity: 4})
+	require.NoError(t, err)
+	require.True(t, configured.Success, configured.Message)
+	assert.Equal(t, 0.2, configured.BulkDiscount)
+
+	require.NoError(t, pui.SetQuantityBreaks(SupplierTierCommon, nil))
+	assert.Empty(t, pui.GetQuantityBreaks(SupplierTierCommon))
+	assert.Error(t, pui.SetQuantityBreaks(SupplierTierRare, []QuantityBreak{{MinQuantity: 5, Discount: 1}}))
+	assert.Error(t, pui.SetQuantityBreaks(SupplierTierRare, []QuantityBreak{{MinQuantity: 1, Discount: 0.1}}))
+}
+
+func TestPurchaseUIManager_ChargesTariffs(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetGold(5000)
+	gm.ledger.Reset(5000)
+	pui := NewPurchaseUIManager(gm)
+
+	// One sword costs the same here as through BuyItem
+	bought := gm.BuyItem("iron_sword", 1, float64(gm.market.GetPrice("iron_sword")))
+	require.True(t, bought["success"].(bool))
+	breakdown := bought["breakdown"].(map[string]interface{})
+
+	result, err := pui.ExecutePurchase(&PurchaseRequest{ItemID: "iron_sword", Quantity: 1})
+	require.NoError(t, err)
+	require.True(t, result.Success, result.Message)
+	assert.Equal(t, float64(breakdown["net"].(int)), result.TotalCost)
+	assert.Equal(t, float64(breakdown["tax"].(int)), result.Tax)
+	assert.Positive(t, result.Tax)
+
+	// The tariff is deducted, counted and written to the ledger
+	entries := gm.ledger.Entries()
+	assert.Equal(t, int(result.Tax), entries[len(entries)-1].Tax)
+	assert.Equal(t, 2*int(result.Tax), gm.taxes.GetStatistics()["tariffs"])
+	assert.Equal(t, gm.ledger.ExpectedBalance(), gm.gameState.GetGold())
+	assert.Equal(t, float64(gm.gameState.GetGold()), result.GoldRemaining)
+}
+
+func TestPurchaseUIManager_PurchasesShareTheGameLock(t *testing.T) {
+	gm := newTestGameManager(t)
+	gm.gameState.SetGold(100000)
+	gm.ledger.Reset(100000)
+	gm.criticalGoldThreshold = 50 // Every purchase checks the low gold warning
+	pui := NewPurchaseUIManager(gm)
+
+	// Purchases here and through the game manager keep gold and the ledger
+	// in step
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = pui.ExecutePurchase(&PurchaseRequest{ItemID: "apple", Quantity: 2})
+		}()
+		go func() {
+			defer wg.Done()
+			gm.BuyItem("orange", 2, 12)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 20, gm.inventory.GetWarehouseQuantity("apple"))
+	assert.Equal(t, 20, gm.inventory.GetWarehouseQuantity("orange"))
+	assert.True(t, gm.AuditFinancials()["consistent"].(bool))
+}
@@ -85,8 +85,11 @@ extern double get_player_gold();
 extern void set_player_gold(double gold);
 extern int get_current_day();
 extern void advance_day();
-extern int save_game();
-extern int load_game();
+extern int save_game(char* profile);
+extern int load_game(char* profile);
+extern char* list_save_profiles_json();
+extern int quick_save();
+extern int quick_load();
 extern int buy_item(char* itemID, int quantity);
 extern int sell_item(char* itemID, int quantity);
 extern double get_market_price(char* itemID);
@@ -138,6 +138,45 @@ func start_new_game(player_name: String) -> bool:
 	
 	return false
 
+func start_new_game_with_scenario(player_name: String, scenario_id: String) -> bool:
+	if not is_connected or not game_manager:
+		# Mock mode
+		current_state = _get_mock_initial_state()
+		game_state_updated.emit(current_state)
+		return true
+	
+	if game_manager.has_method("start_new_game_with_scenario"):
+		return game_manager.start_new_game_with_scenario(player_name, scenario_id)
+	
+	return false
+
+func get_available_scenarios() -> Array:
+	if is_connected and game_manager and game_manager.has_method("get_available_scenarios"):
+		var result_json = game_manager.get_available_scenarios()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return [
+		{"id": "standard", "name": "A Fresh Start", "initialGold": 1000, "objectives": []}
+	]
+
+func undo_last_day() -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("undo_last_day"):
+		var result_json = game_manager.undo_last_day()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": false,
+		"message": "There is no earlier day to return to"
+	}
+
 func pause_game() -> void:
 	if is_connected and game_manager and game_manager.has_method("pause_game"):
 		game_manager.pause_game()
@@ -182,6 +221,199 @@ func sell_item(item_id: String, quantity: int, price: float) -> Dictionary:
 		"gold_gained": int(price * quantity)
 	}
 
+func donate_item(item_id: String, quantity: int) -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("donate_item"):
+		var result_json = game_manager.donate_item(item_id, quantity)
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"message": "Donated %dx %s" % [quantity, item_id],
+		"reputationDelta": 0.5
+	}
+
+func open_branch(branch_name: String, location: String, specialty: String) -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("open_branch"):
+		var result_json = game_manager.open_branch(branch_name, location, specialty)
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"message": "Opened %s" % branch_name,
+		"branch": {"id": "branch-1", "name": branch_name, "location": location, "specialty": specialty}
+	}
+
+func get_branches() -> Array:
+	if is_connected and game_manager and game_manager.has_method("get_branches"):
+		var result_json = game_manager.get_branches()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return [
+		{"id": "main", "name": "Main Shop", "location": "main", "priceModifier": 1.0}
+	]
+
+func get_reputation_history(days: int) -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("get_reputation_history"):
+		var result_json = game_manager.get_reputation_history(days)
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"history": [],
+		"today": {"day": 1, "reputation": 0.0, "change": 0.0, "reasons": {}}
+	}
+
+func get_onboarding_checklist() -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("get_onboarding_checklist"):
+		var result_json = game_manager.get_onboarding_checklist()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"steps": [
+			{"id": "first_purchase", "title": "Buy your first stock", "description": "Purchase an item from the market to sell in your shop.", "completed": false},
+			{"id": "set_price", "title": "Set a price", "description": "Choose what your shop charges for an item.", "completed": false},
+			{"id": "profitable_sale", "title": "Make a profitable sale", "description": "Sell an item for more than you paid for it.", "completed": false},
+			{"id": "reach_day_2", "title": "Open on day 2", "description": "End the day and open your shop the next morning.", "completed": false}
+		],
+		"completed": 0,
+		"total": 4,
+		"finished": false,
+		"nextStep": "first_purchase"
+	}
+
+func get_display_price(item_id: String) -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("get_display_price"):
+		var result_json = game_manager.get_display_price(item_id)
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"itemId": item_id,
+		"rawPrice": 100,
+		"displayPrice": 100,
+		"smoothing": 0.3,
+		"rawSeries": [100],
+		"displaySeries": [100]
+	}
+
+func export_analytics_csv() -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("export_analytics_csv"):
+		var result_json = game_manager.export_analytics_csv()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"ledger": "day,type,item_id,currency,quantity,amount,profit,tax,timestamp\n",
+		"netWorth": "day,net_worth\n1,1000\n",
+		"prices": "day,item_id,price\n",
+		"rows": {"ledger": 0, "netWorth": 1, "prices": 0}
+	}
+
+func get_victory_progress() -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("get_victory_progress"):
+		var result_json = game_manager.get_victory_progress()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"objectives": [
+			{"id": "gold", "current": 1000, "target": 50000, "met": false},
+			{"id": "reputation", "current": 0, "target": 75, "met": false},
+			{"id": "rank", "current": 0, "target": 3, "met": false, "currentRank": "Apprentice", "targetRank": "Master"},
+			{"id": "quests", "current": 0, "target": 1, "met": false}
+		],
+		"victory": false
+	}
+
+func get_trading_journal() -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("get_trading_journal"):
+		var result_json = game_manager.get_trading_journal()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"insights": []
+	}
+
+func get_capacity_forecast(days: int) -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("get_capacity_forecast"):
+		var result_json = game_manager.get_capacity_forecast(days)
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"shop": {"capacity": 100, "used": 0, "fullOnDay": 0},
+		"warehouse": {"capacity": 200, "used": 0, "fullOnDay": 0},
+		"forecast": []
+	}
+
+func batch_advance_days(days: int) -> Dictionary:
+	if is_connected and game_manager and game_manager.has_method("batch_advance_days"):
+		var result_json = game_manager.batch_advance_days(days)
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return {
+		"success": true,
+		"daysAdvanced": 0,
+		"digests": []
+	}
+
+func get_bundles() -> Array:
+	if is_connected and game_manager and game_manager.has_method("get_bundles"):
+		var result_json = game_manager.get_bundles()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return []
+
 func move_item_to_shop(item_id: String, quantity: int) -> bool:
 	if is_connected and game_manager and game_manager.has_method("move_item_to_shop"):
 		return game_manager.move_item_to_shop(item_id, quantity)
@@ -198,20 +430,47 @@ func update_item_price(item_id: String, new_price: float) -> void:
 	if is_connected and game_manager and game_manager.has_method("update_item_price"):
 		game_manager.update_item_price(item_id, new_price)
 
-func save_game(slot: int) -> bool:
+func save_profile(profile: String) -> bool:
 	if is_connected and game_manager and game_manager.has_method("save_game"):
-		return game_manager.save_game(slot)
+		return game_manager.save_game(profile)
 	
 	# Mock save
-	print("Mock: Saving game to slot ", slot)
+	print("Mock: Saving game to profile ", profile)
 	return true
 
-func load_game(slot: int) -> bool:
+func load_profile(profile: String) -> bool:
 	if is_connected and game_manager and game_manager.has_method("load_game"):
-		return game_manager.load_game(slot)
+		return game_manager.load_game(profile)
 	
 	# Mock load
-	print("Mock: Loading game from slot ", slot)
+	print("Mock: Loading game from profile ", profile)
+	return true
+
+func list_save_profiles() -> Array:
+	if is_connected and game_manager and game_manager.has_method("list_save_profiles_json"):
+		var result_json = game_manager.list_save_profiles_json()
+		var json = JSON.new()
+		var parse_result = json.parse(result_json)
+		if parse_result == OK:
+			return json.data
+	
+	# Mock response
+	return []
+
+func quick_save() -> bool:
+	if is_connected and game_manager and game_manager.has_method("quick_save"):
+		return game_manager.quick_save()
+	
+	# Mock quick save
+	print("Mock: Quick saving")
+	return true
+
+func quick_load() -> bool:
+	if is_connected and game_manager and game_manager.has_method("quick_load"):
+		return game_manager.quick_load()
+	
+	# Mock quick load
+	print("Mock: Quick loading")
 	return true
 
 func get_save_slots() -> Array:
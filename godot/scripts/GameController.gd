@@ -83,32 +83,45 @@ func resume_game() -> void:
 	if ui_manager and ui_manager.is_panel_open("PauseMenu"):
 		ui_manager.close_current_panel()
 
-func save_game(slot: int) -> void:
-	var success = game_bridge.save_game(slot)
+func save_game(profile: String) -> void:
+	var success = game_bridge.save_profile(profile)
 
 	if success and game_hud:
-		game_hud.show_notification("Game saved to slot " + str(slot), "success")
+		game_hud.show_notification("Game saved to profile " + profile, "success")
 	elif game_hud:
 		game_hud.show_notification("Failed to save game", "error")
 
-func load_game(slot: int) -> void:
-	var success = game_bridge.load_game(slot)
+func load_game(profile: String) -> void:
+	if game_bridge.load_profile(profile):
+		_on_game_loaded("Game loaded from profile " + profile)
 
-	if success:
-		is_game_active = true
+func quick_save() -> void:
+	var success = game_bridge.quick_save()
 
-		# Close menu panels
-		if ui_manager:
-			ui_manager.close_all_panels()
+	if success and game_hud:
+		game_hud.show_notification("Quick saved", "success")
+	elif game_hud:
+		game_hud.show_notification("Failed to quick save", "error")
 
-		# Show game HUD
-		if game_hud:
-			game_hud.visible = true
-			game_hud.show_notification("Game loaded from slot " + str(slot), "success")
+func quick_load() -> void:
+	if game_bridge.quick_load():
+		_on_game_loaded("Quick save loaded")
 
-		# Show shop view
-		if ui_manager:
-			ui_manager.open_panel("ShopView")
+func _on_game_loaded(message: String) -> void:
+	is_game_active = true
+
+	# Close menu panels
+	if ui_manager:
+		ui_manager.close_all_panels()
+
+	# Show game HUD
+	if game_hud:
+		game_hud.visible = true
+		game_hud.show_notification(message, "success")
+
+	# Show shop view
+	if ui_manager:
+		ui_manager.open_panel("ShopView")
 
 func quit_to_menu() -> void:
 	is_game_active = false
@@ -248,8 +261,8 @@ func _input(event: InputEvent) -> void:
 
 	# Quick save
 	if event.is_action_pressed("quick_save"):
-		save_game(0)  # Quick save to slot 0
+		quick_save()
 
 	# Quick load
 	if event.is_action_pressed("quick_load"):
-		load_game(0)  # Quick load from slot 0
+		quick_load()